@@ -0,0 +1,43 @@
+terraform {
+  required_providers {
+    msgraph = {
+      source = "Microsoft/msgraph"
+    }
+  }
+}
+
+provider "msgraph" {
+}
+
+variable "owner_id" {
+  type = string
+}
+
+variable "member_ids" {
+  type = list(string)
+}
+
+// resolve a directory object whose type is not known, e.g. an owner which is a user or a service principal
+data "msgraph_directory_object" "owner" {
+  object_id = var.owner_id
+  response_export_values = {
+    display_name = "displayName"
+  }
+}
+
+output "owner_is_user" {
+  value = data.msgraph_directory_object.owner.odata_type == "#microsoft.graph.user"
+}
+
+// resolve many directory objects with one request, the duplicated IDs are only read once
+data "msgraph_directory_object" "members" {
+  object_ids = var.member_ids
+  types      = ["user", "group"]
+  response_export_values = {
+    display_names = "value[].displayName"
+  }
+}
+
+output "member_groups" {
+  value = [for id, type in data.msgraph_directory_object.members.odata_types : id if type == "#microsoft.graph.group"]
+}
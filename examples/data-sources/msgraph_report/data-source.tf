@@ -0,0 +1,36 @@
+terraform {
+  required_providers {
+    msgraph = {
+      source = "Microsoft/msgraph"
+    }
+  }
+}
+
+provider "msgraph" {
+}
+
+// the sign-in activities of the service principals are returned as JSON
+data "msgraph_report" "service_principal_sign_in_activities" {
+  url         = "reports/servicePrincipalSignInActivities"
+  api_version = "beta"
+  response_export_values = {
+    activities = "value[].{appId: appId, lastSignInDateTime: lastSignInActivity.lastSignInDateTime}"
+  }
+}
+
+output "service_principal_sign_in_activities" {
+  value = data.msgraph_report.service_principal_sign_in_activities.output.activities
+}
+
+// the usage reports are returned as CSV, the rows are parsed into objects keyed by the column names
+data "msgraph_report" "active_users" {
+  url    = "reports/getOffice365ActiveUserDetail(period='D7')"
+  format = "csv"
+  response_export_values = {
+    users = "value[].\"User Principal Name\""
+  }
+}
+
+output "active_users" {
+  value = data.msgraph_report.active_users.output.users
+}
@@ -41,7 +41,7 @@ data "msgraph_resource_action" "check_membership" {
   }
 }
 
-# Example 3: Get group members with query parameters
+# Example 3: Get group members with query parameters, all pages are read and aggregated into value
 data "msgraph_resource_action" "group_members" {
   resource_url = "groups/{group-id}"
   action       = "members"
@@ -59,7 +59,6 @@ data "msgraph_resource_action" "group_members" {
   response_export_values = {
     members    = "value"
     member_ids = "value[].id"
-    next_link  = "@odata.nextLink"
   }
 }
 
@@ -79,6 +78,33 @@ data "msgraph_resource_action" "app_service_principal" {
   }
 }
 
+# Example 5: Call a function with parameters in the URL path
+data "msgraph_resource_action" "reminders" {
+  resource_url = "users/john@example.com"
+  action       = "reminderView(StartDateTime='2024-01-01T00:00:00',EndDateTime='2024-01-07T00:00:00')"
+  method       = "GET"
+
+  response_export_values = {
+    reminders = "value[].{subject: eventSubject, time: reminderFireTime.dateTime}"
+  }
+}
+
+# Example 6: Call a function with the parameters specified as an object, they're quoted and escaped
+data "msgraph_resource_action" "reminders_with_parameters" {
+  resource_url = "users/john@example.com"
+  action       = "reminderView"
+  method       = "GET"
+
+  function_parameters = {
+    StartDateTime = "2024-01-01T00:00:00"
+    EndDateTime   = "2024-01-07T00:00:00"
+  }
+
+  response_export_values = {
+    reminders = "value"
+  }
+}
+
 # Output the results
 output "user_groups" {
   value = data.msgraph_resource_action.user_member_groups.output.groups
@@ -95,3 +121,7 @@ output "group_members" {
 output "service_principal_id" {
   value = data.msgraph_resource_action.app_service_principal.output.sp_id
 }
+
+output "reminders" {
+  value = data.msgraph_resource_action.reminders.output.reminders
+}
@@ -0,0 +1,32 @@
+terraform {
+  required_providers {
+    msgraph = {
+      source = "Microsoft/msgraph"
+    }
+  }
+}
+
+provider "msgraph" {
+}
+
+data "msgraph_resource_list" "security_groups" {
+  url = "groups"
+  headers = {
+    ConsistencyLevel = "eventual"
+  }
+  query_parameters = {
+    "$filter" = ["securityEnabled eq true"]
+    "$select" = ["id", "displayName"]
+    "$count"  = ["true"]
+  }
+  max_results = 500
+}
+
+output "security_group_ids" {
+  value = [for group in data.msgraph_resource_list.security_groups.output : group.id]
+}
+
+output "security_group_count" {
+  // it will output the total number of security groups, even if it's more than max_results
+  value = data.msgraph_resource_list.security_groups.total_count
+}
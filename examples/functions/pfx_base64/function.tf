@@ -0,0 +1,10 @@
+locals {
+  // The encoded certificate can be passed to the `client_certificate` provider attribute,
+  // e.g. via the `ARM_CLIENT_CERTIFICATE` environment variable.
+  client_certificate = provider::msgraph::pfx_base64("${path.module}/client.pfx")
+}
+
+output "client_certificate" {
+  value     = local.client_certificate
+  sensitive = true
+}
@@ -0,0 +1,43 @@
+terraform {
+  required_providers {
+    msgraph = {
+      source = "microsoft/msgraph"
+    }
+  }
+}
+
+provider "msgraph" {
+}
+
+resource "msgraph_resource" "application" {
+  url = "applications"
+  body = {
+    displayName = "My Application"
+  }
+  response_export_values = {
+    app_id = "appId"
+  }
+}
+
+resource "msgraph_resource" "extensionProperty" {
+  # url = "applications/{id}/extensionProperties"
+  url = "applications/${msgraph_resource.application.id}/extensionProperties"
+  body = {
+    name          = "jobGroup"
+    dataType      = "String"
+    targetObjects = ["User"]
+  }
+  response_export_values = {
+    # the name of the attribute on the directory objects, e.g. extension_b7d8e648520f41d3b9d0fdeb0c4ba8e3_jobGroup
+    attribute_name = "name"
+  }
+}
+
+// the extension attribute is set on a user like any other property, the extension attributes which are not
+// configured in body are not reported as changes
+resource "msgraph_update_resource" "user" {
+  url = "users/00000000-0000-0000-0000-000000000000"
+  body = {
+    (msgraph_resource.extensionProperty.output.attribute_name) = "A"
+  }
+}
@@ -0,0 +1,38 @@
+terraform {
+  required_providers {
+    msgraph = {
+      source = "microsoft/msgraph"
+    }
+  }
+}
+
+provider "msgraph" {
+}
+
+locals {
+  # The credentials are keyed by their name, which can't be changed after they're created,
+  # so renaming a credential replaces it.
+  federated_identity_credentials = {
+    "github-main" = "repo:contoso/infrastructure:ref:refs/heads/main"
+    "github-prod" = "repo:contoso/infrastructure:environment:production"
+  }
+}
+
+resource "msgraph_resource" "application" {
+  url = "applications"
+  body = {
+    displayName = "My Application"
+  }
+}
+
+resource "msgraph_resource" "federatedIdentityCredential" {
+  for_each = local.federated_identity_credentials
+
+  url = "applications/${msgraph_resource.application.id}/federatedIdentityCredentials"
+  body = {
+    name      = each.key
+    audiences = ["api://AzureADTokenExchange"]
+    issuer    = "https://token.actions.githubusercontent.com"
+    subject   = each.value
+  }
+}
@@ -0,0 +1,46 @@
+terraform {
+  required_providers {
+    msgraph = {
+      source = "microsoft/msgraph"
+    }
+  }
+}
+
+provider "msgraph" {
+}
+
+locals {
+  // the template ID of the built-in "User Administrator" role
+  userAdministratorRoleTemplateId = "fe930be7-5e62-47db-91af-98c3a49a38b1"
+}
+
+resource "msgraph_resource" "administrativeUnit" {
+  url = "directory/administrativeUnits"
+  body = {
+    displayName = "Seattle District Technical Schools"
+  }
+}
+
+// the role must be activated in the tenant, only the activated directory roles are listed
+data "msgraph_resource" "userAdministrator" {
+  url = "directoryRoles"
+  query_parameters = {
+    "$filter" = ["roleTemplateId eq '${local.userAdministratorRoleTemplateId}'"]
+  }
+  response_export_values = {
+    id = "value[0].id"
+  }
+}
+
+resource "msgraph_resource" "scopedRoleMember" {
+  # url = "directory/administrativeUnits/{administrativeUnit-id}/scopedRoleMembers"
+  url = "directory/administrativeUnits/${msgraph_resource.administrativeUnit.id}/scopedRoleMembers"
+  body = {
+    // the ID of the activated directory role, not the ID of its template
+    roleId = data.msgraph_resource.userAdministrator.output.id
+    roleMemberInfo = {
+      id = "00000000-0000-0000-0000-000000000000"
+    }
+  }
+  // changing the role or the member replaces the scoped role membership
+}
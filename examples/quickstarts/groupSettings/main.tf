@@ -0,0 +1,28 @@
+terraform {
+  required_providers {
+    msgraph = {
+      source = "microsoft/msgraph"
+    }
+  }
+}
+
+provider "msgraph" {
+}
+
+# The values of the Group.Unified settings template which are not specified use their default values.
+resource "msgraph_resource" "groupSetting" {
+  url = "groupSettings"
+  body = {
+    templateId = "62375ab9-6b52-47ed-826b-58e47e0e304b"
+    values = [
+      {
+        name  = "AllowGuestsToAccessGroups"
+        value = "false"
+      },
+      {
+        name  = "EnableGroupCreation"
+        value = "true"
+      }
+    ]
+  }
+}
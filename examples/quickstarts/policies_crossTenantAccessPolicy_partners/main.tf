@@ -0,0 +1,39 @@
+terraform {
+  required_providers {
+    msgraph = {
+      source = "microsoft/msgraph"
+    }
+  }
+}
+
+provider "msgraph" {
+}
+
+variable "partner_tenant_id" {
+  type        = string
+  description = "The tenant ID of the partner organization."
+}
+
+resource "msgraph_resource" "crossTenantAccessPartner" {
+  url          = "policies/crossTenantAccessPolicy/partners"
+  id_attribute = "tenantId"
+  body = {
+    tenantId = var.partner_tenant_id
+    inboundTrust = {
+      isMfaAccepted                       = true
+      isCompliantDeviceAccepted           = false
+      isHybridAzureADJoinedDeviceAccepted = false
+    }
+    b2bCollaborationOutbound = {
+      usersAndGroups = {
+        accessType = "allowed"
+        targets = [
+          {
+            target     = "AllUsers"
+            targetType = "user"
+          }
+        ]
+      }
+    }
+  }
+}
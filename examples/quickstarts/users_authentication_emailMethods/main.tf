@@ -0,0 +1,18 @@
+terraform {
+  required_providers {
+    msgraph = {
+      source = "microsoft/msgraph"
+    }
+  }
+}
+
+provider "msgraph" {
+}
+
+resource "msgraph_resource" "emailMethod" {
+  # url = "users/{user-id}/authentication/emailMethods"
+  url = "users/00000000-0000-0000-0000-000000000000/authentication/emailMethods"
+  body = {
+    emailAddress = "kim@contoso.com"
+  }
+}
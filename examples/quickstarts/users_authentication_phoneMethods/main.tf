@@ -0,0 +1,22 @@
+terraform {
+  required_providers {
+    msgraph = {
+      source = "microsoft/msgraph"
+    }
+  }
+}
+
+provider "msgraph" {
+}
+
+resource "msgraph_resource" "phoneMethod" {
+  # url = "users/{user-id}/authentication/phoneMethods"
+  url = "users/00000000-0000-0000-0000-000000000000/authentication/phoneMethods"
+  body = {
+    // the phone number is returned in the "+{country code} {subscriber number}" format, the formatting characters
+    // of the configured number are not reported as changes
+    phoneNumber = "+1 (206) 555-5555"
+    // changing the phone type replaces the phone method
+    phoneType = "mobile"
+  }
+}
@@ -0,0 +1,28 @@
+terraform {
+  required_providers {
+    msgraph = {
+      source = "Microsoft/msgraph"
+    }
+  }
+}
+
+provider "msgraph" {}
+
+resource "msgraph_resource" "application" {
+  url = "applications"
+  body = {
+    displayName = "My Application"
+  }
+}
+
+// Add a client secret to the application, it's removed when the resource is destroyed.
+resource "msgraph_password_credential" "secret" {
+  resource_url  = msgraph_resource.application.resource_url
+  display_name  = "terraform"
+  end_date_time = "2030-01-01T00:00:00Z"
+}
+
+output "client_secret" {
+  value     = msgraph_password_credential.secret.secret_text
+  sensitive = true
+}
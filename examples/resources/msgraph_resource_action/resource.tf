@@ -102,6 +102,17 @@ resource "msgraph_resource_action" "custom_user_action" {
   }
 }
 
+# Example 4: Revoke the sign-in sessions of a user again whenever the rotation value changes
+resource "msgraph_resource_action" "revoke_sessions" {
+  resource_url = "users/john@example.com"
+  action       = "revokeSignInSessions"
+  method       = "POST"
+
+  triggers = {
+    rotation = "2024-06"
+  }
+}
+
 # Output the results
 output "welcome_email_sent" {
   value = msgraph_resource_action.send_welcome_email.output
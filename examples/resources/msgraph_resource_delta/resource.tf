@@ -0,0 +1,25 @@
+terraform {
+  required_providers {
+    msgraph = {
+      source = "Microsoft/msgraph"
+    }
+  }
+}
+
+provider "msgraph" {}
+
+// Track the changes of the users, each refresh only reads the changes since the previous one.
+resource "msgraph_resource_delta" "users" {
+  url = "users/delta"
+  query_parameters = {
+    "$select" = ["displayName", "userPrincipalName"]
+  }
+}
+
+output "added_users" {
+  value = [for user in msgraph_resource_delta.users.added : user.userPrincipalName]
+}
+
+output "removed_user_ids" {
+  value = msgraph_resource_delta.users.removed
+}
@@ -0,0 +1,5 @@
+# MSGraph update resource can be imported using the URL of the resource to update, e.g.
+terraform import msgraph_update_resource.application applications/00000000-0000-0000-0000-000000000000
+
+# The API version can be specified with the api-version query parameter, e.g.
+terraform import msgraph_update_resource.application applications/00000000-0000-0000-0000-000000000000?api-version=beta
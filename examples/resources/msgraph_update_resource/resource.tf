@@ -30,4 +30,10 @@ resource "msgraph_update_resource" "application_update" {
   body = {
     displayName = "Demo App Updated"
   }
-}
\ No newline at end of file
+}
+# Raw content, e.g. a profile photo, can be uploaded with raw_body_base64 and content_type.
+resource "msgraph_update_resource" "application_logo" {
+  url             = "applications/${azuread_application.application.object_id}/logo"
+  raw_body_base64 = filebase64("${path.module}/logo.png")
+  content_type    = "image/png"
+}
@@ -180,6 +180,14 @@ For tests that authenticate with Azure by using a Service Principal with Certifi
 	}
 }
 
+// PreCheckDelegated skips the test unless the acceptance tests authenticate as a signed-in user, e.g. with the Azure CLI
+// logged in with a user account. The APIs addressed under `/me` are only available with delegated credentials.
+func PreCheckDelegated(t *testing.T) {
+	if v := os.Getenv("ARM_TEST_DELEGATED"); v == "" {
+		t.Skip("ARM_TEST_DELEGATED must be set for the acceptance tests which require delegated credentials, e.g. the Azure CLI logged in with a user account")
+	}
+}
+
 // CheckDestroyedFunc returns a TestCheckFunc which validates the resource no longer exists
 func CheckDestroyedFunc(client *clients.Client, testResource TestResource, resourceType, resourceName string) func(state *terraform.State) error {
 	return func(state *terraform.State) error {
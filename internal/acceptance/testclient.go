@@ -97,6 +97,12 @@ func BuildTestClient() (*clients.Client, error) {
 		if v := os.Getenv("ARM_OIDC_AZURE_SERVICE_CONNECTION_ID"); v != "" {
 			model.OIDCAzureServiceConnectionID = types.StringValue(v)
 		}
+		if v := os.Getenv("ARM_CLIENT_ASSERTION"); v != "" {
+			model.ClientAssertion = types.StringValue(v)
+		}
+		if v := os.Getenv("ARM_CLIENT_ASSERTION_FILE_PATH"); v != "" {
+			model.ClientAssertionFilePath = types.StringValue(v)
+		}
 		if v := os.Getenv("ARM_USE_OIDC"); v != "" {
 			model.UseOIDC = types.BoolValue(v == "true")
 		} else {
@@ -107,9 +113,21 @@ func BuildTestClient() (*clients.Client, error) {
 		} else {
 			model.UseCLI = types.BoolValue(true)
 		}
+		if v := os.Getenv("ARM_USE_AZD_CLI"); v != "" {
+			model.UseAzdCLI = types.BoolValue(v == "true")
+		}
+		if v := os.Getenv("ARM_USE_ENVIRONMENT_CREDENTIAL"); v != "" {
+			model.UseEnvironmentCredential = types.BoolValue(v == "true")
+		}
+
+		auxiliaryTenantIds, err := provider.ParseAuxiliaryTenantIds(os.Getenv("ARM_AUXILIARY_TENANT_IDS"))
+		if err != nil {
+			return nil, err
+		}
 
 		option := azidentity.DefaultAzureCredentialOptions{
-			TenantID: model.TenantID.ValueString(),
+			TenantID:                   model.TenantID.ValueString(),
+			AdditionallyAllowedTenants: auxiliaryTenantIds,
 		}
 		cred, err := provider.BuildChainedTokenCredential(model, option)
 		if err != nil {
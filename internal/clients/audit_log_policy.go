@@ -0,0 +1,157 @@
+package clients
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"log"
+	"net/http"
+	"os"
+	"sync"
+	"time"
+
+	"github.com/Azure/azure-sdk-for-go/sdk/azcore/policy"
+	"github.com/microsoft/terraform-provider-msgraph/internal/utils"
+)
+
+// DefaultAuditLogRedactedKeys are the names of the properties whose values are masked in the audit log by default, in
+// addition to the properties named like credentials which are always masked.
+var DefaultAuditLogRedactedKeys = []string{
+	"password",
+	"secretText",
+	"secret",
+	"clientSecret",
+	"key",
+	"privateKey",
+	"token",
+	"accessToken",
+	"refreshToken",
+}
+
+type auditLogPolicy struct {
+	path         string
+	redactedKeys []string
+	mu           sync.Mutex
+}
+
+type auditRecord struct {
+	Timestamp    string `json:"timestamp"`
+	Method       string `json:"method"`
+	Url          string `json:"url"`
+	StatusCode   int    `json:"statusCode,omitempty"`
+	RequestId    string `json:"requestId,omitempty"`
+	Body         string `json:"body,omitempty"`
+	ResponseBody string `json:"responseBody,omitempty"`
+	Error        string `json:"error,omitempty"`
+}
+
+// NewAuditLogPolicy returns a policy which appends a JSON line to the file at the path for each attempt of the
+// mutating requests, i.e. the ones which aren't `GET` or `HEAD`. The values of the properties of the request and
+// response bodies named like credentials, e.g. `newPassword` or `clientSecret`, named like the redacted keys, compared
+// case-insensitively, and at the redacted paths of the context are masked.
+func NewAuditLogPolicy(path string, redactedKeys []string) (policy.Policy, error) {
+	// #nosec G304
+	f, err := os.OpenFile(path, os.O_APPEND|os.O_CREATE|os.O_WRONLY, 0o600)
+	if err != nil {
+		return nil, fmt.Errorf("opening the audit log %q: %v", path, err)
+	}
+	if err := f.Close(); err != nil {
+		return nil, fmt.Errorf("closing the audit log %q: %v", path, err)
+	}
+	return &auditLogPolicy{
+		path:         path,
+		redactedKeys: redactedKeys,
+	}, nil
+}
+
+func (p *auditLogPolicy) Do(req *policy.Request) (*http.Response, error) {
+	rawRequest := req.Raw()
+	if rawRequest.Method == http.MethodGet || rawRequest.Method == http.MethodHead {
+		return req.Next()
+	}
+
+	record := auditRecord{
+		Method: rawRequest.Method,
+		Url:    rawRequest.URL.String(),
+		Body:   p.requestBody(req),
+	}
+	response, err := req.Next()
+	record.Timestamp = time.Now().UTC().Format(time.RFC3339Nano)
+	if err != nil {
+		record.Error = err.Error()
+	} else {
+		record.StatusCode = response.StatusCode
+		record.RequestId = response.Header.Get("request-id")
+		record.ResponseBody = p.responseBody(req.Raw().Context(), response)
+	}
+	p.write(record)
+	return response, err
+}
+
+// requestBody returns the redacted JSON body of the request, or the size of the body when it isn't JSON.
+func (p *auditLogPolicy) requestBody(req *policy.Request) string {
+	if req.Raw().Body == nil {
+		return ""
+	}
+	data, err := io.ReadAll(req.Raw().Body)
+	if rewindErr := req.RewindBody(); rewindErr != nil {
+		log.Printf("[ERROR] Failed to rewind request body: %v", rewindErr)
+	}
+	if err != nil || len(data) == 0 {
+		return ""
+	}
+	return p.redactBody(req.Raw().Context(), data)
+}
+
+// responseBody returns the redacted JSON body of the response like requestBody, the body is restored to be read again.
+func (p *auditLogPolicy) responseBody(ctx context.Context, response *http.Response) string {
+	if response.Body == nil || response.Body == http.NoBody {
+		return ""
+	}
+	data, err := io.ReadAll(response.Body)
+	if closeErr := response.Body.Close(); closeErr != nil {
+		log.Printf("[ERROR] Failed to close response body: %v", closeErr)
+	}
+	response.Body = io.NopCloser(bytes.NewReader(data))
+	if err != nil || len(data) == 0 {
+		return ""
+	}
+	return p.redactBody(ctx, data)
+}
+
+func (p *auditLogPolicy) redactBody(ctx context.Context, data []byte) string {
+	var value interface{}
+	if err := json.Unmarshal(data, &value); err != nil {
+		return fmt.Sprintf("(%d bytes)", len(data))
+	}
+	value = utils.RedactPaths(value, redactedPathsFromContext(ctx))
+	value = utils.RedactKeys(utils.RedactSensitiveProperties(value), p.redactedKeys)
+	redacted, err := json.Marshal(value)
+	if err != nil {
+		return fmt.Sprintf("(%d bytes)", len(data))
+	}
+	return string(redacted)
+}
+
+func (p *auditLogPolicy) write(record auditRecord) {
+	data, err := json.Marshal(record)
+	if err != nil {
+		log.Printf("[ERROR] Failed to marshal audit record: %v", err)
+		return
+	}
+
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	// #nosec G304
+	f, err := os.OpenFile(p.path, os.O_APPEND|os.O_CREATE|os.O_WRONLY, 0o600)
+	if err != nil {
+		log.Printf("[ERROR] Failed to open the audit log %q: %v", p.path, err)
+		return
+	}
+	defer f.Close()
+	if _, err := f.Write(append(data, '\n')); err != nil {
+		log.Printf("[ERROR] Failed to write the audit log %q: %v", p.path, err)
+	}
+}
@@ -0,0 +1,182 @@
+package clients
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/Azure/azure-sdk-for-go/sdk/azcore/policy"
+	"github.com/Azure/azure-sdk-for-go/sdk/azcore/runtime"
+	"github.com/Azure/azure-sdk-for-go/sdk/azcore/streaming"
+)
+
+func TestAuditLogPolicy(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "audit.jsonl")
+	auditLog, err := NewAuditLogPolicy(path, DefaultAuditLogRedactedKeys)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	transport := &operationTransport{
+		responses: map[string][]operationResponse{
+			"POST https://graph.microsoft.com/v1.0/applications/1/addPassword": {
+				{statusCode: http.StatusServiceUnavailable, header: http.Header{"Request-Id": {"attempt-1"}}},
+				{statusCode: http.StatusOK, header: http.Header{"Request-Id": {"attempt-2"}}, body: `{"secretText":"c2VjcmV0"}`},
+			},
+			"GET https://graph.microsoft.com/v1.0/applications/1": {{statusCode: http.StatusOK, body: `{"id":"1"}`}},
+		},
+	}
+	pl := runtime.NewPipeline("test", "v0.0.1", runtime.PipelineOptions{}, &policy.ClientOptions{
+		Transport:        transport,
+		PerRetryPolicies: []policy.Policy{auditLog},
+		Retry:            policy.RetryOptions{MaxRetries: 1, RetryDelay: time.Millisecond},
+	})
+
+	req, err := runtime.NewRequest(context.Background(), http.MethodPost, "https://graph.microsoft.com/v1.0/applications/1/addPassword")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := req.SetBody(streaming.NopCloser(strings.NewReader(`{"passwordCredential":{"displayName":"ci","secretText":"aGVsbG8="}}`)), "application/json"); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := pl.Do(req); err != nil {
+		t.Fatal(err)
+	}
+	req, err = runtime.NewRequest(context.Background(), http.MethodGet, "https://graph.microsoft.com/v1.0/applications/1")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := pl.Do(req); err != nil {
+		t.Fatal(err)
+	}
+
+	data, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	lines := strings.Split(strings.TrimSpace(string(data)), "\n")
+	if len(lines) != 2 {
+		t.Fatalf("expected a record per attempt of the mutating request, got %d: %s", len(lines), string(data))
+	}
+	for i, expectedRequestId := range []string{"attempt-1", "attempt-2"} {
+		var record auditRecord
+		if err := json.Unmarshal([]byte(lines[i]), &record); err != nil {
+			t.Fatal(err)
+		}
+		if record.Method != http.MethodPost || record.RequestId != expectedRequestId || record.Timestamp == "" {
+			t.Fatalf("unexpected record %d: %s", i, lines[i])
+		}
+		// The properties named like credentials are masked with their nested properties.
+		if record.Body != `{"passwordCredential":"(redacted)"}` {
+			t.Fatalf("expected the credential to be redacted, got %s", record.Body)
+		}
+	}
+	if !strings.Contains(lines[1], `"statusCode":200`) {
+		t.Fatalf("expected the status code in the record, got %s", lines[1])
+	}
+}
+
+func TestAuditLogPolicy_SensitiveProperties(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "audit.jsonl")
+	// The properties named like credentials are masked even if they're not in the redacted keys.
+	auditLog, err := NewAuditLogPolicy(path, []string{"displayName"})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	transport := &operationTransport{
+		responses: map[string][]operationResponse{
+			"POST https://graph.microsoft.com/v1.0/me/changePassword": {{statusCode: http.StatusNoContent}},
+		},
+	}
+	pl := runtime.NewPipeline("test", "v0.0.1", runtime.PipelineOptions{}, &policy.ClientOptions{
+		Transport:        transport,
+		PerRetryPolicies: []policy.Policy{auditLog},
+	})
+
+	req, err := runtime.NewRequest(context.Background(), http.MethodPost, "https://graph.microsoft.com/v1.0/me/changePassword")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := req.SetBody(streaming.NopCloser(strings.NewReader(`{"currentPassword":"xWwvJ]6NMw+bWH-d","newPassword":"0eM85N54wFxWwvJ]","displayName":"ci"}`)), "application/json"); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := pl.Do(req); err != nil {
+		t.Fatal(err)
+	}
+
+	data, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var record auditRecord
+	if err := json.Unmarshal(data, &record); err != nil {
+		t.Fatal(err)
+	}
+	if record.Body != `{"currentPassword":"(redacted)","displayName":"(redacted)","newPassword":"(redacted)"}` {
+		t.Fatalf("expected the passwords to be redacted, got %s", record.Body)
+	}
+}
+
+func TestAuditLogPolicy_ResponseBody(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "audit.jsonl")
+	auditLog, err := NewAuditLogPolicy(path, DefaultAuditLogRedactedKeys)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	transport := &operationTransport{
+		responses: map[string][]operationResponse{
+			"POST https://graph.microsoft.com/v1.0/applications/1/addPassword": {
+				{statusCode: http.StatusOK, body: `{"displayName":"ci","secretText":"c2VjcmV0"}`},
+			},
+		},
+	}
+	pl := runtime.NewPipeline("test", "v0.0.1", runtime.PipelineOptions{}, &policy.ClientOptions{
+		Transport:        transport,
+		PerRetryPolicies: []policy.Policy{auditLog},
+	})
+
+	req, err := runtime.NewRequest(context.Background(), http.MethodPost, "https://graph.microsoft.com/v1.0/applications/1/addPassword")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := req.SetBody(streaming.NopCloser(strings.NewReader(`{"passwordCredential":{"displayName":"ci"}}`)), "application/json"); err != nil {
+		t.Fatal(err)
+	}
+	response, err := pl.Do(req)
+	if err != nil {
+		t.Fatal(err)
+	}
+	// The response body can still be read by the caller.
+	payload, err := runtime.Payload(response)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if string(payload) != `{"displayName":"ci","secretText":"c2VjcmV0"}` {
+		t.Fatalf("expected the response body to be unchanged, got %s", string(payload))
+	}
+
+	data, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var record auditRecord
+	if err := json.Unmarshal(data, &record); err != nil {
+		t.Fatal(err)
+	}
+	if record.ResponseBody != `{"displayName":"ci","secretText":"(redacted)"}` {
+		t.Fatalf("expected the secret of the response to be redacted, got %s", record.ResponseBody)
+	}
+}
+
+func TestNewAuditLogPolicy_InvalidPath(t *testing.T) {
+	if _, err := NewAuditLogPolicy(filepath.Join(t.TempDir(), "missing", "audit.jsonl"), nil); err == nil {
+		t.Fatal("expected an error, got nil")
+	}
+}
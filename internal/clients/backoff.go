@@ -0,0 +1,148 @@
+package clients
+
+import (
+	"context"
+	"math"
+	"math/rand"
+	"net/http"
+	"sync"
+	"time"
+
+	"github.com/microsoft/terraform-provider-msgraph/internal/retry"
+)
+
+const (
+	DefaultBackoffInterval            = 1 * time.Second
+	DefaultBackoffMultiplier          = 2.0
+	DefaultBackoffRandomizationFactor = 0.25
+)
+
+// backoffRetryDelay is the delay of the SDK for the retry options which wait for a custom backoff in ShouldRetry.
+const backoffRetryDelay = time.Nanosecond
+
+// Backoff is an exponential backoff with jitter. The delay before the n-th retry is Interval * Multiplier^(n-1),
+// capped by MaxInterval, and randomized by +/- RandomizationFactor of its value.
+type Backoff struct {
+	Interval            time.Duration
+	MaxInterval         time.Duration
+	Multiplier          float64
+	RandomizationFactor float64
+}
+
+// NewBackoff creates a Backoff based on the provided retry.Value. It returns nil if none of the backoff options
+// are specified, in which case the default backoff of the SDK is used.
+func NewBackoff(rtry retry.Value) *Backoff {
+	if rtry.IsNull() || rtry.IsUnknown() {
+		return nil
+	}
+	if isNullOrUnknown(rtry.IntervalSeconds) && isNullOrUnknown(rtry.MaxIntervalSeconds) &&
+		isNullOrUnknown(rtry.Multiplier) && isNullOrUnknown(rtry.RandomizationFactor) {
+		return nil
+	}
+
+	backoff := &Backoff{
+		Interval:            DefaultBackoffInterval,
+		Multiplier:          DefaultBackoffMultiplier,
+		RandomizationFactor: DefaultBackoffRandomizationFactor,
+	}
+	if !isNullOrUnknown(rtry.IntervalSeconds) {
+		backoff.Interval = time.Duration(rtry.IntervalSeconds.ValueInt64()) * time.Second
+	}
+	if !isNullOrUnknown(rtry.MaxIntervalSeconds) {
+		backoff.MaxInterval = time.Duration(rtry.MaxIntervalSeconds.ValueInt64()) * time.Second
+	}
+	if !isNullOrUnknown(rtry.Multiplier) {
+		backoff.Multiplier = rtry.Multiplier.ValueFloat64()
+	}
+	if !isNullOrUnknown(rtry.RandomizationFactor) {
+		backoff.RandomizationFactor = rtry.RandomizationFactor.ValueFloat64()
+	}
+	return backoff
+}
+
+// Delay returns the randomized delay before the given retry, starting at 1.
+func (b Backoff) Delay(retry int) time.Duration {
+	return b.randomize(b.baseDelay(retry), rand.Float64())
+}
+
+// baseDelay returns the delay before the given retry without jitter.
+func (b Backoff) baseDelay(retry int) time.Duration {
+	if retry < 1 {
+		retry = 1
+	}
+	delay := float64(b.Interval) * math.Pow(b.Multiplier, float64(retry-1))
+	if b.MaxInterval > 0 && delay > float64(b.MaxInterval) {
+		delay = float64(b.MaxInterval)
+	}
+	// avoid overflowing time.Duration when there's no maximum interval
+	if delay >= float64(math.MaxInt64) {
+		return time.Duration(math.MaxInt64)
+	}
+	return time.Duration(delay)
+}
+
+// randomize spreads the delay to [delay * (1 - RandomizationFactor), delay * (1 + RandomizationFactor)], random is in [0, 1).
+func (b Backoff) randomize(delay time.Duration, random float64) time.Duration {
+	if b.RandomizationFactor <= 0 {
+		return delay
+	}
+	delta := b.RandomizationFactor * float64(delay)
+	randomized := float64(delay) - delta + random*2*delta
+	if randomized >= float64(math.MaxInt64) {
+		return time.Duration(math.MaxInt64)
+	}
+	return time.Duration(randomized)
+}
+
+// backoffWaiter keeps track of the consecutive retries of a request and waits for the backoff delay before each of them.
+// The SDK retry policy can't be configured with a custom backoff, so the wait is done before the ShouldRetry callback returns,
+// and the SDK's own delay is disabled.
+type backoffWaiter struct {
+	backoff Backoff
+	mu      sync.Mutex
+	retries int
+}
+
+// reset is called when a request won't be retried, so the next request starts with the initial interval.
+func (w *backoffWaiter) reset() {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	w.retries = 0
+}
+
+// next returns the delay before the next retry.
+func (w *backoffWaiter) next() time.Duration {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	w.retries++
+	return w.backoff.Delay(w.retries)
+}
+
+// wait waits for the delay before the next retry, it returns false if the deadline of the request would be exceeded.
+func (w *backoffWaiter) wait(resp *http.Response) bool {
+	delay := w.next()
+	ctx := context.Background()
+	if resp != nil && resp.Request != nil {
+		ctx = resp.Request.Context()
+	}
+	if deadline, ok := ctx.Deadline(); ok && delay > time.Until(deadline) {
+		return false
+	}
+	timer := time.NewTimer(delay)
+	defer timer.Stop()
+	select {
+	case <-timer.C:
+		return true
+	case <-ctx.Done():
+		return false
+	}
+}
+
+type nullOrUnknown interface {
+	IsNull() bool
+	IsUnknown() bool
+}
+
+func isNullOrUnknown(v nullOrUnknown) bool {
+	return v.IsNull() || v.IsUnknown()
+}
@@ -0,0 +1,206 @@
+package clients
+
+import (
+	"context"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/Azure/azure-sdk-for-go/sdk/azcore/policy"
+	"github.com/Azure/azure-sdk-for-go/sdk/azcore/runtime"
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/microsoft/terraform-provider-msgraph/internal/retry"
+)
+
+func newBackoffRetryValue(intervalSeconds, maxIntervalSeconds types.Int64, multiplier, randomizationFactor types.Float64) retry.Value {
+	return retry.NewRetryValueMust(retry.Value{}.AttributeTypes(context.Background()), map[string]attr.Value{
+		"error_message_regex":  types.ListNull(types.StringType),
+		"status_codes":         types.ListValueMust(types.Int64Type, []attr.Value{types.Int64Value(409)}),
+		"interval_seconds":     intervalSeconds,
+		"max_interval_seconds": maxIntervalSeconds,
+		"multiplier":           multiplier,
+		"randomization_factor": randomizationFactor,
+		"idempotent_only":      types.BoolNull(),
+	})
+}
+
+func TestNewBackoff(t *testing.T) {
+	testcases := []struct {
+		name     string
+		retry    retry.Value
+		expected *Backoff
+	}{
+		{
+			name:     "null retry",
+			retry:    retry.NewValueNull(),
+			expected: nil,
+		},
+		{
+			name:     "no backoff options",
+			retry:    newBackoffRetryValue(types.Int64Null(), types.Int64Null(), types.Float64Null(), types.Float64Null()),
+			expected: nil,
+		},
+		{
+			name:  "defaults for the unspecified options",
+			retry: newBackoffRetryValue(types.Int64Null(), types.Int64Value(30), types.Float64Null(), types.Float64Null()),
+			expected: &Backoff{
+				Interval:            DefaultBackoffInterval,
+				MaxInterval:         30 * time.Second,
+				Multiplier:          DefaultBackoffMultiplier,
+				RandomizationFactor: DefaultBackoffRandomizationFactor,
+			},
+		},
+		{
+			name:  "all options",
+			retry: newBackoffRetryValue(types.Int64Value(5), types.Int64Value(60), types.Float64Value(1.5), types.Float64Value(0)),
+			expected: &Backoff{
+				Interval:            5 * time.Second,
+				MaxInterval:         time.Minute,
+				Multiplier:          1.5,
+				RandomizationFactor: 0,
+			},
+		},
+	}
+
+	for _, tc := range testcases {
+		t.Run(tc.name, func(t *testing.T) {
+			actual := NewBackoff(tc.retry)
+			if tc.expected == nil || actual == nil {
+				if tc.expected != actual {
+					t.Fatalf("expected %v, got %v", tc.expected, actual)
+				}
+				return
+			}
+			if *actual != *tc.expected {
+				t.Fatalf("expected %+v, got %+v", *tc.expected, *actual)
+			}
+		})
+	}
+}
+
+func TestBackoff_BaseDelay(t *testing.T) {
+	backoff := Backoff{
+		Interval:    time.Second,
+		MaxInterval: 10 * time.Second,
+		Multiplier:  3,
+	}
+	testcases := []struct {
+		retry    int
+		expected time.Duration
+	}{
+		{retry: 1, expected: time.Second},
+		{retry: 2, expected: 3 * time.Second},
+		{retry: 3, expected: 9 * time.Second},
+		{retry: 4, expected: 10 * time.Second},
+		{retry: 100, expected: 10 * time.Second},
+	}
+
+	for _, tc := range testcases {
+		if actual := backoff.baseDelay(tc.retry); actual != tc.expected {
+			t.Fatalf("retry %d: expected %s, got %s", tc.retry, tc.expected, actual)
+		}
+	}
+
+	backoff.MaxInterval = 0
+	if actual := backoff.baseDelay(1000); actual <= 0 {
+		t.Fatalf("expected a positive delay without maximum interval, got %s", actual)
+	}
+}
+
+func TestBackoff_Randomize(t *testing.T) {
+	backoff := Backoff{RandomizationFactor: 0.5}
+	testcases := []struct {
+		random   float64
+		expected time.Duration
+	}{
+		{random: 0, expected: 5 * time.Second},
+		{random: 0.5, expected: 10 * time.Second},
+		{random: 0.75, expected: 12500 * time.Millisecond},
+	}
+
+	for _, tc := range testcases {
+		if actual := backoff.randomize(10*time.Second, tc.random); actual != tc.expected {
+			t.Fatalf("random %v: expected %s, got %s", tc.random, tc.expected, actual)
+		}
+	}
+
+	backoff.RandomizationFactor = 0
+	if actual := backoff.randomize(10*time.Second, 0.9); actual != 10*time.Second {
+		t.Fatalf("expected no jitter, got %s", actual)
+	}
+}
+
+func TestNewRetryOptions_Backoff(t *testing.T) {
+	options := NewRetryOptions(newBackoffRetryValue(types.Int64Value(1), types.Int64Null(), types.Float64Null(), types.Float64Value(0)), http.MethodGet)
+	if options.RetryDelay != backoffRetryDelay || options.MaxRetryDelay != backoffRetryDelay {
+		t.Fatalf("expected the SDK retry delay to be minimal, got %s and %s", options.RetryDelay, options.MaxRetryDelay)
+	}
+
+	newResponse := func(statusCode int, timeout time.Duration) *http.Response {
+		req, err := http.NewRequest(http.MethodGet, "https://graph.microsoft.com/v1.0/groups", nil)
+		if err != nil {
+			t.Fatal(err)
+		}
+		ctx, cancel := context.WithTimeout(context.Background(), timeout)
+		t.Cleanup(cancel)
+		return &http.Response{StatusCode: statusCode, Header: http.Header{}, Request: req.WithContext(ctx)}
+	}
+
+	start := time.Now()
+	if !options.ShouldRetry(newResponse(http.StatusConflict, time.Minute), nil) {
+		t.Fatalf("expected the request to be retried")
+	}
+	if elapsed := time.Since(start); elapsed < time.Second {
+		t.Fatalf("expected to wait for the backoff delay, waited %s", elapsed)
+	}
+
+	// the second retry would wait for 2 seconds, which exceeds the deadline
+	if options.ShouldRetry(newResponse(http.StatusConflict, 500*time.Millisecond), nil) {
+		t.Fatalf("expected the request not to be retried when the backoff delay exceeds the deadline")
+	}
+
+	if options.ShouldRetry(newResponse(http.StatusOK, time.Minute), nil) {
+		t.Fatalf("expected a successful request not to be retried")
+	}
+}
+
+func TestNewRetryOptions_BackoffPipeline(t *testing.T) {
+	const groupsUrl = "https://graph.microsoft.com/v1.0/groups"
+	transport := &operationTransport{
+		responses: map[string][]operationResponse{
+			"GET " + groupsUrl: {
+				{statusCode: http.StatusConflict},
+				{statusCode: http.StatusTooManyRequests, header: http.Header{"Retry-After": {"1"}}},
+				{statusCode: http.StatusOK, body: `{"value":[]}`},
+			},
+		},
+	}
+	options := NewRetryOptions(newBackoffRetryValue(types.Int64Value(1), types.Int64Null(), types.Float64Null(), types.Float64Value(0)), http.MethodGet)
+	pl := runtime.NewPipeline("test", "v0.0.1", runtime.PipelineOptions{}, &policy.ClientOptions{
+		Transport: transport,
+		Retry:     *options,
+	})
+
+	ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+	defer cancel()
+	req, err := runtime.NewRequest(ctx, http.MethodGet, groupsUrl)
+	if err != nil {
+		t.Fatal(err)
+	}
+	start := time.Now()
+	resp, err := pl.Do(req)
+	if err != nil {
+		t.Fatalf("expected the request to succeed after the retries, got %v", err)
+	}
+	if resp.StatusCode != http.StatusOK {
+		t.Fatalf("expected status code %d, got %d", http.StatusOK, resp.StatusCode)
+	}
+	if len(transport.requests) != 3 {
+		t.Fatalf("expected 3 requests, got %d", len(transport.requests))
+	}
+	// the backoff delay and the Retry-After delay are 1 second each
+	if elapsed := time.Since(start); elapsed < 2*time.Second || elapsed > 10*time.Second {
+		t.Fatalf("expected to wait for the backoff and Retry-After delays only, waited %s", elapsed)
+	}
+}
@@ -3,6 +3,7 @@ package clients
 import (
 	"context"
 	"log"
+	"regexp"
 	"time"
 
 	"github.com/Azure/azure-sdk-for-go/sdk/azcore"
@@ -27,6 +28,23 @@ type Option struct {
 	CloudCfg                    cloud.Configuration
 	CustomCorrelationRequestID  string
 	TenantId                    string
+	MaxResponseBytes            int64
+	TokenAcquisitionTimeout     time.Duration
+	DefaultApiVersion           string
+	DefaultReadTimeout          time.Duration
+	// RequestTimeout bounds each try of a request, independently of the timeout of the operation.
+	RequestTimeout time.Duration
+	EnableMetrics  bool
+	// Transport sends the requests, e.g. through a proxy. Defaults to the HTTP client of azcore.
+	Transport policy.Transporter
+	// AuditLogPath is the file the mutating requests are appended to, the audit log is disabled when it's empty.
+	AuditLogPath string
+	// AuditLogRedactedKeys are the names of the properties masked in the audit log.
+	AuditLogRedactedKeys []string
+	// ProtectedUrlPatterns are the patterns of the URLs of the objects which must not be deleted.
+	ProtectedUrlPatterns []*regexp.Regexp
+	// AllowProtectedDeletion overrides ProtectedUrlPatterns, so the protected objects can be deleted.
+	AllowProtectedDeletion bool
 }
 
 func (client *Client) Build(ctx context.Context, o *Option) error {
@@ -47,7 +65,19 @@ func (client *Client) Build(ctx context.Context, o *Option) error {
 		perCallPolicies = append(perCallPolicies, withCorrelationRequestID(id))
 	}
 	perRetryPolicies := make([]policy.Policy, 0)
+	perRetryPolicies = append(perRetryPolicies, tryTimeoutPolicy{})
 	perRetryPolicies = append(perRetryPolicies, NewLiveTrafficLogPolicy())
+	if o.EnableMetrics {
+		perCallPolicies = append(perCallPolicies, metricsPolicy{})
+		perRetryPolicies = append(perRetryPolicies, attemptsPolicy{})
+	}
+	if o.AuditLogPath != "" {
+		auditLogPolicy, err := NewAuditLogPolicy(o.AuditLogPath, o.AuditLogRedactedKeys)
+		if err != nil {
+			return err
+		}
+		perRetryPolicies = append(perRetryPolicies, auditLogPolicy)
+	}
 
 	allowedHeaders := []string{
 		"Access-Control-Allow-Methods",
@@ -86,7 +116,7 @@ func (client *Client) Build(ctx context.Context, o *Option) error {
 		"$format",
 	}
 
-	msgraphClient, err := NewMSGraphClient(o.Cred, &policy.ClientOptions{
+	msgraphClient, err := NewMSGraphClient(NewTokenCredentialWithTimeout(o.Cred, o.TokenAcquisitionTimeout), &policy.ClientOptions{
 		Logging: policy.LogOptions{
 			IncludeBody:        false,
 			AllowedHeaders:     allowedHeaders,
@@ -94,11 +124,20 @@ func (client *Client) Build(ctx context.Context, o *Option) error {
 		},
 		PerCallPolicies:  perCallPolicies,
 		PerRetryPolicies: perRetryPolicies,
+		Retry: policy.RetryOptions{
+			TryTimeout: o.RequestTimeout,
+		},
+		Transport: NewResponseSizeLimitTransport(o.Transport, o.MaxResponseBytes),
 	})
 	if err != nil {
 		return err
 	}
 
+	msgraphClient.defaultApiVersion = o.DefaultApiVersion
+	msgraphClient.defaultReadTimeout = o.DefaultReadTimeout
+	msgraphClient.requestTimeout = o.RequestTimeout
+	msgraphClient.protectedUrlPatterns = o.ProtectedUrlPatterns
+	msgraphClient.allowProtectedDeletion = o.AllowProtectedDeletion
 	client.MSGraphClient = msgraphClient
 
 	return nil
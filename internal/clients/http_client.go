@@ -0,0 +1,67 @@
+package clients
+
+import (
+	"crypto/x509"
+	"fmt"
+	"net/http"
+	"net/url"
+	"os"
+
+	"golang.org/x/net/http/httpproxy"
+)
+
+// HTTPClientOptions configures the HTTP client used to call Microsoft Graph and to acquire the access tokens.
+type HTTPClientOptions struct {
+	// HTTPProxy is the proxy of the HTTP requests, e.g. `http://proxy.contoso.com:8080`.
+	HTTPProxy string
+	// HTTPSProxy is the proxy of the HTTPS requests.
+	HTTPSProxy string
+	// NoProxy is a comma separated list of the hosts, domains and IP ranges which are not proxied.
+	NoProxy string
+	// CABundlePath is the path to a PEM file of the certificate authorities which are trusted in addition to the
+	// ones of the system, e.g. the one of a TLS-inspecting proxy.
+	CABundlePath string
+}
+
+// NewHTTPClient returns an HTTP client with the same settings as the default one of azcore, which uses the proxies
+// and trusts the certificate authorities of the options.
+func NewHTTPClient(o HTTPClientOptions) (*http.Client, error) {
+	client := newDefaultHTTPClient()
+	transport := client.Transport.(*http.Transport)
+
+	proxyConfig := httpproxy.Config{
+		HTTPProxy:  o.HTTPProxy,
+		HTTPSProxy: o.HTTPSProxy,
+		NoProxy:    o.NoProxy,
+	}
+	proxyFunc := proxyConfig.ProxyFunc()
+	transport.Proxy = func(req *http.Request) (*url.URL, error) {
+		return proxyFunc(req.URL)
+	}
+
+	if o.CABundlePath != "" {
+		pool, err := loadCABundle(o.CABundlePath)
+		if err != nil {
+			return nil, err
+		}
+		transport.TLSClientConfig.RootCAs = pool
+	}
+	return client, nil
+}
+
+// loadCABundle returns the certificate pool of the system with the certificates of the PEM file appended.
+func loadCABundle(path string) (*x509.CertPool, error) {
+	// #nosec G304
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("reading the CA bundle %q: %v", path, err)
+	}
+	pool, err := x509.SystemCertPool()
+	if err != nil || pool == nil {
+		pool = x509.NewCertPool()
+	}
+	if !pool.AppendCertsFromPEM(data) {
+		return nil, fmt.Errorf("the CA bundle %q doesn't contain any PEM encoded certificate", path)
+	}
+	return pool, nil
+}
@@ -0,0 +1,111 @@
+package clients
+
+import (
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/pem"
+	"math/big"
+	"net/http"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+func TestNewHTTPClient_Proxy(t *testing.T) {
+	client, err := NewHTTPClient(HTTPClientOptions{
+		HTTPProxy:  "http://http-proxy.contoso.com:8080",
+		HTTPSProxy: "http://https-proxy.contoso.com:8080",
+		NoProxy:    ".internal.contoso.com",
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	proxy := client.Transport.(*http.Transport).Proxy
+
+	testcases := []struct {
+		url      string
+		expected string
+	}{
+		{url: "https://graph.microsoft.com/v1.0/me", expected: "http://https-proxy.contoso.com:8080"},
+		{url: "http://example.com", expected: "http://http-proxy.contoso.com:8080"},
+		{url: "https://api.internal.contoso.com", expected: ""},
+	}
+	for _, tc := range testcases {
+		t.Run(tc.url, func(t *testing.T) {
+			req, err := http.NewRequest(http.MethodGet, tc.url, nil)
+			if err != nil {
+				t.Fatal(err)
+			}
+			actual, err := proxy(req)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if actual == nil && tc.expected != "" || actual != nil && actual.String() != tc.expected {
+				t.Fatalf("expected the proxy %q, got %v", tc.expected, actual)
+			}
+		})
+	}
+}
+
+func TestNewHTTPClient_CABundle(t *testing.T) {
+	dir := t.TempDir()
+	validPath := filepath.Join(dir, "ca.pem")
+	if err := os.WriteFile(validPath, selfSignedCertificatePEM(t), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	invalidPath := filepath.Join(dir, "invalid.pem")
+	if err := os.WriteFile(invalidPath, []byte("not a certificate"), 0o600); err != nil {
+		t.Fatal(err)
+	}
+
+	testcases := []struct {
+		name    string
+		path    string
+		wantErr bool
+	}{
+		{name: "valid bundle", path: validPath},
+		{name: "invalid bundle", path: invalidPath, wantErr: true},
+		{name: "missing bundle", path: filepath.Join(dir, "missing.pem"), wantErr: true},
+	}
+	for _, tc := range testcases {
+		t.Run(tc.name, func(t *testing.T) {
+			client, err := NewHTTPClient(HTTPClientOptions{CABundlePath: tc.path})
+			if tc.wantErr {
+				if err == nil {
+					t.Fatal("expected an error, got nil")
+				}
+				return
+			}
+			if err != nil {
+				t.Fatal(err)
+			}
+			if client.Transport.(*http.Transport).TLSClientConfig.RootCAs == nil {
+				t.Fatal("expected the root CAs to be set")
+			}
+		})
+	}
+}
+
+func selfSignedCertificatePEM(t *testing.T) []byte {
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+	template := &x509.Certificate{
+		SerialNumber:          big.NewInt(1),
+		Subject:               pkix.Name{CommonName: "Contoso Proxy CA"},
+		NotBefore:             time.Now(),
+		NotAfter:              time.Now().Add(time.Hour),
+		IsCA:                  true,
+		BasicConstraintsValid: true,
+	}
+	der, err := x509.CreateCertificate(rand.Reader, template, template, &key.PublicKey, key)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: der})
+}
@@ -45,11 +45,12 @@ func NewLiveTrafficLogPolicy() policy.Policy {
 
 func (p *liveTrafficLogPolicy) Do(req *policy.Request) (*http.Response, error) {
 	rawRequest := req.Raw()
+	redactedPaths := redactedPathsFromContext(rawRequest.Context())
 	liveReq := liveRequest{
 		Headers: p.header(rawRequest.Header),
 		Method:  rawRequest.Method,
 		Url:     rawRequest.URL.String(),
-		Body:    p.requestBodyString(req),
+		Body:    redactBody(p.requestBodyString(req), redactedPaths),
 	}
 	if err := req.RewindBody(); err != nil {
 		return nil, err
@@ -59,7 +60,7 @@ func (p *liveTrafficLogPolicy) Do(req *policy.Request) (*http.Response, error) {
 	if err == nil {
 		liveResp.Headers = p.header(response.Header)
 		liveResp.StatusCode = response.StatusCode
-		liveResp.Body = p.responseBodyString(response)
+		liveResp.Body = redactBody(p.responseBodyString(response), redactedPaths)
 	} else {
 		liveResp.Body = err.Error()
 	}
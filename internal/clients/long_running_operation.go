@@ -0,0 +1,110 @@
+package clients
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/Azure/azure-sdk-for-go/sdk/azcore/runtime"
+)
+
+// DefaultOperationPollingInterval is the delay between the status requests of a long-running operation
+// when the response doesn't have a Retry-After header.
+var DefaultOperationPollingInterval = 10 * time.Second
+
+const (
+	operationStatusSucceeded = "succeeded"
+	operationStatusFailed    = "failed"
+)
+
+// isLongRunningOperation returns true if the response is the 202 Accepted response of an asynchronous operation,
+// e.g. the creation of a team, whose status is polled at the URL of the Location header.
+func isLongRunningOperation(resp *http.Response) bool {
+	return resp.StatusCode == http.StatusAccepted && resp.Header.Get("Location") != ""
+}
+
+// waitForOperation polls the operation at the Location header of the response until it has succeeded or failed.
+// When it has succeeded, the created resource is read from the Content-Location header of the response or the
+// `targetResourceLocation` of the operation, and returned. If neither is available, an error is returned, as the
+// operation isn't the created resource.
+func (client *MSGraphClient) waitForOperation(ctx context.Context, resp *http.Response, apiVersion string, options RequestOptions) (interface{}, error) {
+	operationUrl := client.resolveLocation(resp.Header.Get("Location"), apiVersion)
+	resourceLocation := resp.Header.Get("Content-Location")
+	delay := operationPollingInterval(resp)
+	for {
+		timer := time.NewTimer(delay)
+		select {
+		case <-ctx.Done():
+			timer.Stop()
+			return nil, fmt.Errorf("waiting for the operation %s: %w", operationUrl, ctx.Err())
+		case <-timer.C:
+		}
+
+		operation, operationResp, err := client.getOperation(ctx, operationUrl, options)
+		if err != nil {
+			return nil, err
+		}
+		operationMap, _ := operation.(map[string]interface{})
+		status, _ := operationMap["status"].(string)
+		switch strings.ToLower(status) {
+		case operationStatusSucceeded:
+			if resourceLocation == "" {
+				resourceLocation, _ = operationMap["targetResourceLocation"].(string)
+			}
+			if resourceLocation == "" {
+				return nil, fmt.Errorf("the operation %s has succeeded, but the location of the created resource is unknown", operationUrl)
+			}
+			req, err := runtime.NewRequest(ctx, http.MethodGet, client.resolveLocation(resourceLocation, apiVersion))
+			if err != nil {
+				return nil, err
+			}
+			return client.fetchPage(req, options)
+		case operationStatusFailed:
+			return nil, fmt.Errorf("the operation %s has failed: %v", operationUrl, operationMap["error"])
+		}
+		delay = operationPollingInterval(operationResp)
+	}
+}
+
+// getOperation reads the status of an operation.
+func (client *MSGraphClient) getOperation(ctx context.Context, operationUrl string, options RequestOptions) (interface{}, *http.Response, error) {
+	req, err := runtime.NewRequest(ctx, http.MethodGet, operationUrl)
+	if err != nil {
+		return nil, nil, err
+	}
+	req.Raw().Header.Set("Accept", "application/json")
+	for key, value := range options.Headers {
+		req.Raw().Header.Set(key, value)
+	}
+	resp, err := client.pl.Do(req)
+	if err != nil {
+		return nil, nil, err
+	}
+	if !runtime.HasStatusCode(resp, http.StatusOK) {
+		return nil, nil, runtime.NewResponseError(resp)
+	}
+	var responseBody interface{}
+	if err := runtime.UnmarshalAsJSON(resp, &responseBody); err != nil {
+		return nil, nil, err
+	}
+	return responseBody, resp, nil
+}
+
+// resolveLocation returns the absolute URL of a Location header, which is relative to the API version for some
+// operations, e.g. `/teams('{id}')/operations('{id}')`.
+func (client *MSGraphClient) resolveLocation(location string, apiVersion string) string {
+	if strings.HasPrefix(location, "https://") || strings.HasPrefix(location, "http://") {
+		return location
+	}
+	return runtime.JoinPaths(client.host, apiVersion, location)
+}
+
+// operationPollingInterval returns the delay of the Retry-After header, or the default polling interval.
+func operationPollingInterval(resp *http.Response) time.Duration {
+	if delay := RetryAfter(resp); delay > 0 {
+		return delay
+	}
+	return DefaultOperationPollingInterval
+}
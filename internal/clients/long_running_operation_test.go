@@ -0,0 +1,204 @@
+package clients
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/Azure/azure-sdk-for-go/sdk/azcore/policy"
+	"github.com/Azure/azure-sdk-for-go/sdk/azcore/runtime"
+)
+
+type operationResponse struct {
+	statusCode int
+	header     http.Header
+	body       string
+}
+
+// operationTransport replies to the requests with the responses of their method and URL, the responses are
+// returned in order and the last one is repeated.
+type operationTransport struct {
+	responses map[string][]operationResponse
+	requests  []string
+}
+
+func (t *operationTransport) Do(req *http.Request) (*http.Response, error) {
+	key := req.Method + " " + req.URL.String()
+	t.requests = append(t.requests, key)
+	responses := t.responses[key]
+	resp := operationResponse{statusCode: http.StatusNotFound, body: `{"error":{"code":"NotFound"}}`}
+	if len(responses) != 0 {
+		resp = responses[0]
+	}
+	if len(responses) > 1 {
+		t.responses[key] = responses[1:]
+	}
+	header := resp.header.Clone()
+	if header == nil {
+		header = http.Header{}
+	}
+	return &http.Response{StatusCode: resp.statusCode, Header: header, Body: io.NopCloser(strings.NewReader(resp.body)), Request: req}, nil
+}
+
+func newOperationTestClient(transport *operationTransport) *MSGraphClient {
+	return &MSGraphClient{
+		host: "https://graph.microsoft.com",
+		pl: runtime.NewPipeline("test", "v0.0.1", runtime.PipelineOptions{}, &policy.ClientOptions{
+			Transport: transport,
+			Retry:     policy.RetryOptions{MaxRetries: -1},
+		}),
+	}
+}
+
+func TestCreate_LongRunningOperation(t *testing.T) {
+	interval := DefaultOperationPollingInterval
+	DefaultOperationPollingInterval = time.Millisecond
+	t.Cleanup(func() { DefaultOperationPollingInterval = interval })
+
+	const (
+		teamsUrl     = "https://graph.microsoft.com/v1.0/teams"
+		operationUrl = "https://graph.microsoft.com/v1.0/teams('00000000-0000-0000-0000-000000000001')/operations('00000000-0000-0000-0000-000000000002')"
+		teamUrl      = "https://graph.microsoft.com/v1.0/teams('00000000-0000-0000-0000-000000000001')"
+	)
+	accepted := http.Header{}
+	accepted.Set("Location", "/teams('00000000-0000-0000-0000-000000000001')/operations('00000000-0000-0000-0000-000000000002')")
+	accepted.Set("Content-Location", "/teams('00000000-0000-0000-0000-000000000001')")
+
+	testcases := []struct {
+		name                   string
+		operations             []operationResponse
+		withoutContentLocation bool
+		expectError            bool
+		expectedId             string
+	}{
+		{
+			name: "succeeded",
+			operations: []operationResponse{
+				{statusCode: http.StatusOK, body: `{"status":"notStarted"}`},
+				{statusCode: http.StatusOK, body: `{"status":"inProgress"}`},
+				{statusCode: http.StatusOK, body: `{"status":"succeeded","targetResourceId":"00000000-0000-0000-0000-000000000001"}`},
+			},
+			expectedId: "00000000-0000-0000-0000-000000000001",
+		},
+		{
+			name: "succeeded without the location of the resource",
+			operations: []operationResponse{
+				{statusCode: http.StatusOK, body: `{"status":"succeeded","targetResourceId":"00000000-0000-0000-0000-000000000001"}`},
+			},
+			withoutContentLocation: true,
+			expectError:            true,
+		},
+		{
+			name: "failed",
+			operations: []operationResponse{
+				{statusCode: http.StatusOK, body: `{"status":"inProgress"}`},
+				{statusCode: http.StatusOK, body: `{"status":"failed","error":{"code":"Conflict","message":"the group already has a team"}}`},
+			},
+			expectError: true,
+		},
+	}
+
+	for _, tc := range testcases {
+		t.Run(tc.name, func(t *testing.T) {
+			header := accepted.Clone()
+			if tc.withoutContentLocation {
+				header.Del("Content-Location")
+			}
+			transport := &operationTransport{
+				responses: map[string][]operationResponse{
+					"POST " + teamsUrl:    {{statusCode: http.StatusAccepted, header: header}},
+					"GET " + operationUrl: tc.operations,
+					"GET " + teamUrl:      {{statusCode: http.StatusOK, body: `{"id":"00000000-0000-0000-0000-000000000001","displayName":"Example Team"}`}},
+				},
+			}
+			client := newOperationTestClient(transport)
+
+			body, err := client.Create(context.Background(), "teams", "v1.0", map[string]interface{}{"displayName": "Example Team"}, RequestOptions{})
+			if tc.expectError {
+				if err == nil {
+					t.Fatalf("expected an error, got %v", body)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			bodyMap, ok := body.(map[string]interface{})
+			if !ok || bodyMap["id"] != tc.expectedId {
+				t.Fatalf("expected the created team with id %q, got %v, requests: %v", tc.expectedId, body, transport.requests)
+			}
+		})
+	}
+}
+
+func TestCreate_LongRunningOperationTimeout(t *testing.T) {
+	interval := DefaultOperationPollingInterval
+	DefaultOperationPollingInterval = time.Millisecond
+	t.Cleanup(func() { DefaultOperationPollingInterval = interval })
+
+	accepted := http.Header{}
+	accepted.Set("Location", "https://graph.microsoft.com/v1.0/teams('1')/operations('2')")
+	transport := &operationTransport{
+		responses: map[string][]operationResponse{
+			"POST https://graph.microsoft.com/v1.0/teams":                     {{statusCode: http.StatusAccepted, header: accepted}},
+			"GET https://graph.microsoft.com/v1.0/teams('1')/operations('2')": {{statusCode: http.StatusOK, body: `{"status":"inProgress"}`}},
+		},
+	}
+	client := newOperationTestClient(transport)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+	defer cancel()
+	if _, err := client.Create(ctx, "teams", "v1.0", map[string]interface{}{}, RequestOptions{}); err == nil {
+		t.Fatalf("expected an error when the operation doesn't complete before the deadline")
+	}
+}
+
+func TestOperationPollingInterval(t *testing.T) {
+	testcases := []struct {
+		retryAfter string
+		expected   time.Duration
+	}{
+		{retryAfter: "", expected: DefaultOperationPollingInterval},
+		{retryAfter: "5", expected: 5 * time.Second},
+		{retryAfter: "invalid", expected: DefaultOperationPollingInterval},
+	}
+	for _, tc := range testcases {
+		resp := &http.Response{Header: http.Header{}}
+		if tc.retryAfter != "" {
+			resp.Header.Set("Retry-After", tc.retryAfter)
+		}
+		if actual := operationPollingInterval(resp); actual != tc.expected {
+			t.Fatalf("operationPollingInterval(%q) = %s, want %s", tc.retryAfter, actual, tc.expected)
+		}
+	}
+
+	resp := &http.Response{Header: http.Header{"Retry-After": {time.Now().Add(time.Minute).UTC().Format(http.TimeFormat)}}}
+	if actual := operationPollingInterval(resp); actual <= 30*time.Second || actual > time.Minute {
+		t.Fatalf("expected the delay until the HTTP-date, got %s", actual)
+	}
+}
+
+func TestResolveLocation(t *testing.T) {
+	client := &MSGraphClient{host: "https://graph.microsoft.com"}
+	testcases := []struct {
+		location string
+		expected string
+	}{
+		{
+			location: "/teams('1')/operations('2')",
+			expected: "https://graph.microsoft.com/beta/teams('1')/operations('2')",
+		},
+		{
+			location: "https://graph.microsoft.com/v1.0/teams('1')/operations('2')",
+			expected: "https://graph.microsoft.com/v1.0/teams('1')/operations('2')",
+		},
+	}
+	for _, tc := range testcases {
+		if actual := client.resolveLocation(tc.location, "beta"); actual != tc.expected {
+			t.Fatalf("resolveLocation(%q) = %q, want %q", tc.location, actual, tc.expected)
+		}
+	}
+}
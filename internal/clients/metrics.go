@@ -0,0 +1,146 @@
+package clients
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"net/http"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/Azure/azure-sdk-for-go/sdk/azcore/policy"
+)
+
+// CallMetric is the duration, status and number of retries of a Graph call.
+type CallMetric struct {
+	Method     string
+	Path       string
+	StatusCode int
+	Duration   time.Duration
+	Retries    int
+}
+
+// callAttempts counts the attempts of a Graph call, it's shared by the per-call and the per-retry policies.
+type callAttempts struct {
+	count int
+}
+
+// metricsPolicy records the metrics of each Graph call, including its retries, and logs them.
+type metricsPolicy struct{}
+
+func (p metricsPolicy) Do(req *policy.Request) (*http.Response, error) {
+	attempts := &callAttempts{}
+	req.SetOperationValue(attempts)
+
+	start := time.Now()
+	response, err := req.Next()
+	metric := CallMetric{
+		Method:   req.Raw().Method,
+		Path:     req.Raw().URL.Path,
+		Duration: time.Since(start),
+		Retries:  max(attempts.count-1, 0),
+	}
+	if response != nil {
+		metric.StatusCode = response.StatusCode
+	}
+	log.Printf("[INFO] Graph call: %s %s, status %d, duration %s, retries %d", metric.Method, metric.Path, metric.StatusCode, metric.Duration.Round(time.Millisecond), metric.Retries)
+	if collector := operationMetricsFromContext(req.Raw().Context()); collector != nil {
+		collector.add(metric)
+	}
+	return response, err
+}
+
+// attemptsPolicy counts the attempts of the call recorded by metricsPolicy.
+type attemptsPolicy struct{}
+
+func (p attemptsPolicy) Do(req *policy.Request) (*http.Response, error) {
+	var attempts *callAttempts
+	if req.OperationValue(&attempts) && attempts != nil {
+		attempts.count++
+	}
+	return req.Next()
+}
+
+// operationMetrics collects the metrics of the Graph calls of an operation.
+type operationMetrics struct {
+	mutex   sync.Mutex
+	metrics []CallMetric
+}
+
+func (m *operationMetrics) add(metric CallMetric) {
+	m.mutex.Lock()
+	defer m.mutex.Unlock()
+	m.metrics = append(m.metrics, metric)
+}
+
+type operationMetricsContextKey struct{}
+
+// WithOperationMetrics returns a context which collects the metrics of its Graph calls when the metrics are enabled,
+// so they're summarized by LogOperationMetrics at the end of the operation.
+func WithOperationMetrics(ctx context.Context) context.Context {
+	return context.WithValue(ctx, operationMetricsContextKey{}, &operationMetrics{})
+}
+
+func operationMetricsFromContext(ctx context.Context) *operationMetrics {
+	collector, _ := ctx.Value(operationMetricsContextKey{}).(*operationMetrics)
+	return collector
+}
+
+// LogOperationMetrics logs the summary of the Graph calls collected in the context of the operation, grouped by
+// method and path. Nothing is logged when no call was collected, e.g. when the metrics are disabled.
+func LogOperationMetrics(ctx context.Context, operation string) {
+	collector := operationMetricsFromContext(ctx)
+	if collector == nil {
+		return
+	}
+	collector.mutex.Lock()
+	defer collector.mutex.Unlock()
+	if len(collector.metrics) == 0 {
+		return
+	}
+	log.Printf("[INFO] Graph calls of %s: %s", operation, summarizeMetrics(collector.metrics))
+}
+
+// summarizeMetrics returns the number of calls, the total duration and the retries of the metrics, followed by the
+// same totals for each method and path, from the longest to the shortest total duration.
+func summarizeMetrics(metrics []CallMetric) string {
+	type group struct {
+		key      string
+		calls    int
+		duration time.Duration
+		retries  int
+	}
+	total := group{}
+	groups := make(map[string]*group)
+	for _, metric := range metrics {
+		key := fmt.Sprintf("%s %s", metric.Method, metric.Path)
+		g, ok := groups[key]
+		if !ok {
+			g = &group{key: key}
+			groups[key] = g
+		}
+		for _, v := range []*group{&total, g} {
+			v.calls++
+			v.duration += metric.Duration
+			v.retries += metric.Retries
+		}
+	}
+	sorted := make([]*group, 0, len(groups))
+	for _, g := range groups {
+		sorted = append(sorted, g)
+	}
+	sort.Slice(sorted, func(i, j int) bool {
+		if sorted[i].duration != sorted[j].duration {
+			return sorted[i].duration > sorted[j].duration
+		}
+		return sorted[i].key < sorted[j].key
+	})
+
+	parts := make([]string, 0, len(sorted))
+	for _, g := range sorted {
+		parts = append(parts, fmt.Sprintf("%s: %d calls, %s, %d retries", g.key, g.calls, g.duration.Round(time.Millisecond), g.retries))
+	}
+	return fmt.Sprintf("%d calls, %s, %d retries (%s)", total.calls, total.duration.Round(time.Millisecond), total.retries, strings.Join(parts, "; "))
+}
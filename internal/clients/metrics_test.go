@@ -0,0 +1,73 @@
+package clients
+
+import (
+	"context"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/Azure/azure-sdk-for-go/sdk/azcore/policy"
+	"github.com/Azure/azure-sdk-for-go/sdk/azcore/runtime"
+)
+
+type statusSequenceTransport struct {
+	statusCodes []int
+	calls       int
+}
+
+func (t *statusSequenceTransport) Do(req *http.Request) (*http.Response, error) {
+	statusCode := t.statusCodes[min(t.calls, len(t.statusCodes)-1)]
+	t.calls++
+	return &http.Response{StatusCode: statusCode, Header: http.Header{}, Body: http.NoBody, Request: req}, nil
+}
+
+func TestMetricsPolicy(t *testing.T) {
+	transport := &statusSequenceTransport{statusCodes: []int{http.StatusTooManyRequests, http.StatusTooManyRequests, http.StatusOK}}
+	pl := runtime.NewPipeline("test", "v0.0.1", runtime.PipelineOptions{
+		PerCall:  []policy.Policy{metricsPolicy{}},
+		PerRetry: []policy.Policy{attemptsPolicy{}},
+	}, &policy.ClientOptions{
+		Transport: transport,
+		Retry:     policy.RetryOptions{MaxRetries: 3, RetryDelay: time.Millisecond, MaxRetryDelay: time.Millisecond},
+	})
+
+	ctx := WithOperationMetrics(context.Background())
+	for i := 0; i < 2; i++ {
+		req, err := runtime.NewRequest(ctx, http.MethodGet, "https://graph.microsoft.com/v1.0/groups")
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if _, err := pl.Do(req); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+	}
+
+	metrics := operationMetricsFromContext(ctx).metrics
+	if len(metrics) != 2 {
+		t.Fatalf("expected 2 metrics, got %d", len(metrics))
+	}
+	if got := metrics[0]; got.Method != http.MethodGet || got.Path != "/v1.0/groups" || got.StatusCode != http.StatusOK || got.Retries != 2 {
+		t.Fatalf("unexpected first metric: %+v", got)
+	}
+	if got := metrics[1]; got.StatusCode != http.StatusOK || got.Retries != 0 {
+		t.Fatalf("unexpected second metric: %+v", got)
+	}
+}
+
+func TestSummarizeMetrics(t *testing.T) {
+	metrics := []CallMetric{
+		{Method: http.MethodGet, Path: "/v1.0/groups/1", StatusCode: http.StatusOK, Duration: 100 * time.Millisecond},
+		{Method: http.MethodPost, Path: "/v1.0/groups", StatusCode: http.StatusCreated, Duration: 2 * time.Second, Retries: 1},
+		{Method: http.MethodGet, Path: "/v1.0/groups/1", StatusCode: http.StatusOK, Duration: 200 * time.Millisecond, Retries: 2},
+	}
+	want := "3 calls, 2.3s, 3 retries (POST /v1.0/groups: 1 calls, 2s, 1 retries; GET /v1.0/groups/1: 2 calls, 300ms, 2 retries)"
+	if got := summarizeMetrics(metrics); got != want {
+		t.Fatalf("summarizeMetrics() = %q, want %q", got, want)
+	}
+}
+
+func TestLogOperationMetrics_WithoutCollector(t *testing.T) {
+	// no collector in the context, nothing is logged and it doesn't panic
+	LogOperationMetrics(context.Background(), "create")
+	LogOperationMetrics(WithOperationMetrics(context.Background()), "create")
+}
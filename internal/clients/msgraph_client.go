@@ -1,13 +1,20 @@
 package clients
 
 import (
+	"bytes"
 	"context"
 	"encoding/json"
+	"fmt"
 	"net/http"
+	"regexp"
+	"slices"
+	"strings"
+	"time"
 
 	"github.com/Azure/azure-sdk-for-go/sdk/azcore"
 	"github.com/Azure/azure-sdk-for-go/sdk/azcore/policy"
 	"github.com/Azure/azure-sdk-for-go/sdk/azcore/runtime"
+	"github.com/Azure/azure-sdk-for-go/sdk/azcore/streaming"
 )
 
 const (
@@ -16,11 +23,29 @@ const (
 	nextLinkKey   = "@odata.nextLink"
 )
 
+// RawBody is a request body which is sent as is instead of being marshaled as JSON,
+// e.g. the binary content of a profile photo.
+type RawBody struct {
+	Content     []byte
+	ContentType string
+}
+
 type MSGraphClient struct {
 	host string
 	pl   runtime.Pipeline
+
+	defaultApiVersion  string
+	defaultReadTimeout time.Duration
+	// requestTimeout bounds each try of a request, so a hung request fails and is retried, zero means no limit.
+	requestTimeout time.Duration
+
+	protectedUrlPatterns   []*regexp.Regexp
+	allowProtectedDeletion bool
 }
 
+// NewMSGraphClient returns a client whose requests are authorized with the tokens of the credential. The tokens are
+// cached by the bearer token policy of the pipeline until they expire, so they're shared by all the operations of
+// the client instead of being acquired for each request.
 func NewMSGraphClient(credential azcore.TokenCredential, opt *policy.ClientOptions) (*MSGraphClient, error) {
 	pl := runtime.NewPipeline(moduleName, moduleVersion, runtime.PipelineOptions{
 		AllowedHeaders:         nil,
@@ -28,7 +53,7 @@ func NewMSGraphClient(credential azcore.TokenCredential, opt *policy.ClientOptio
 		APIVersion:             runtime.APIVersionOptions{},
 		PerCall:                nil,
 		PerRetry: []policy.Policy{
-			runtime.NewBearerTokenPolicy(credential, []string{"https://graph.microsoft.com/.default"}, nil),
+			newTenantBearerTokenPolicy(credential, []string{"https://graph.microsoft.com/.default"}),
 		},
 		Tracing: runtime.TracingOptions{},
 	}, opt)
@@ -39,23 +64,35 @@ func NewMSGraphClient(credential azcore.TokenCredential, opt *policy.ClientOptio
 }
 
 func (client *MSGraphClient) Read(ctx context.Context, url string, apiVersion string, options RequestOptions) (interface{}, error) {
-	// apply per-request retry options via context
-	if options.RetryOptions != nil {
-		ctx = policy.WithRetryOptions(ctx, *options.RetryOptions)
+	responseBody, err := client.ReadPage(ctx, url, apiVersion, options)
+	if err != nil {
+		return nil, err
+	}
+
+	// if response has nextLink, follow the link and return the final response
+	if responseBodyMap, ok := responseBody.(map[string]interface{}); ok {
+		if nextLink := responseBodyMap["@odata.nextLink"]; nextLink != nil {
+			return client.List(ctx, url, apiVersion, options)
+		}
 	}
+
+	return responseBody, nil
+}
+
+// ReadPage reads a resource without following the @odata.nextLink of the response, so only the first page of a
+// collection is returned, together with its @odata.nextLink.
+func (client *MSGraphClient) ReadPage(ctx context.Context, url string, apiVersion string, options RequestOptions) (interface{}, error) {
+	ctx = client.withRetryOptions(ctx, options)
 	req, err := runtime.NewRequest(ctx, http.MethodGet, runtime.JoinPaths(client.host, apiVersion, url))
 	if err != nil {
 		return nil, err
 	}
-	reqQP := req.Raw().URL.Query()
-	for key, value := range options.QueryParameters {
-		reqQP.Set(key, value)
-	}
-	req.Raw().URL.RawQuery = reqQP.Encode()
+	setQueryParameters(req, options.QueryParameters)
 	req.Raw().Header.Set("Accept", "application/json")
 	for key, value := range options.Headers {
 		req.Raw().Header.Set(key, value)
 	}
+	logRequest(ctx, req, nil)
 	resp, err := client.pl.Do(req)
 	if err != nil {
 		return nil, err
@@ -68,23 +105,63 @@ func (client *MSGraphClient) Read(ctx context.Context, url string, apiVersion st
 	if err := runtime.UnmarshalAsJSON(resp, &responseBody); err != nil {
 		return nil, err
 	}
+	return responseBody, nil
+}
 
-	// if response has nextLink, follow the link and return the final response
-	if responseBodyMap, ok := responseBody.(map[string]interface{}); ok {
-		if nextLink := responseBodyMap["@odata.nextLink"]; nextLink != nil {
-			return client.List(ctx, url, apiVersion, options)
-		}
+// ReadRaw reads the raw content of a resource, e.g. the binary content of a `$value` endpoint.
+func (client *MSGraphClient) ReadRaw(ctx context.Context, url string, apiVersion string, options RequestOptions) ([]byte, error) {
+	ctx = client.withRetryOptions(ctx, options)
+	req, err := runtime.NewRequest(ctx, http.MethodGet, runtime.JoinPaths(client.host, apiVersion, url))
+	if err != nil {
+		return nil, err
 	}
-
-	return responseBody, nil
+	setQueryParameters(req, options.QueryParameters)
+	req.Raw().Header.Set("Accept", "*/*")
+	for key, value := range options.Headers {
+		req.Raw().Header.Set(key, value)
+	}
+	logRequest(ctx, req, nil)
+	resp, err := client.pl.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	if !runtime.HasStatusCode(resp, http.StatusOK) {
+		return nil, runtime.NewResponseError(resp)
+	}
+	return runtime.Payload(resp)
 }
 
+// ListRefIDs returns the IDs of the objects in the collection, following the @odata.nextLink until all pages are read.
 func (client *MSGraphClient) ListRefIDs(ctx context.Context, url string, apiVersion string, options RequestOptions) ([]string, error) {
 	responseBody, err := client.List(ctx, url, apiVersion, options)
 	if err != nil {
 		return nil, err
 	}
+	return refIDsOf(responseBody)
+}
+
+// ContainsRefID returns whether the object with the ID is in the collection. The pages are read until the ID is found,
+// so the remaining pages of a large collection are not read.
+func (client *MSGraphClient) ContainsRefID(ctx context.Context, url string, apiVersion string, id string, options RequestOptions) (bool, error) {
+	pager := client.newPager(url, apiVersion, options)
+	for pager.More() {
+		page, err := pager.NextPage(ctx)
+		if err != nil {
+			return false, err
+		}
+		ids, err := refIDsOf(page)
+		if err != nil {
+			return false, err
+		}
+		if slices.Contains(ids, id) {
+			return true, nil
+		}
+	}
+	return false, nil
+}
 
+// refIDsOf returns the IDs of the objects in the `value` of a collection response.
+func refIDsOf(responseBody interface{}) ([]string, error) {
 	data, err := json.Marshal(responseBody)
 	if err != nil {
 		return nil, err
@@ -107,7 +184,113 @@ func (client *MSGraphClient) ListRefIDs(ctx context.Context, url string, apiVers
 }
 
 func (client *MSGraphClient) List(ctx context.Context, url string, apiVersion string, options RequestOptions) (interface{}, error) {
-	pager := runtime.NewPager(runtime.PagingHandler[interface{}]{
+	return aggregatePages(ctx, client.newPager(url, apiVersion, options))
+}
+
+// ListFromPage follows the @odata.nextLink of a response which has already been read, e.g. the response of a function
+// returning a collection, and returns the response with the items of all pages in `value`.
+// Responses which don't follow the paging guideline are returned as is.
+func (client *MSGraphClient) ListFromPage(ctx context.Context, firstPage interface{}, options RequestOptions) (interface{}, error) {
+	return aggregatePages(ctx, client.newPagerFrom(func(ctx context.Context) (interface{}, error) {
+		return firstPage, nil
+	}, options))
+}
+
+// ListFromLink reads a collection from an absolute link returned by the API, e.g. the @odata.deltaLink of a delta
+// query, follows the @odata.nextLink of the pages, and returns the response with the items of all pages in `value`.
+func (client *MSGraphClient) ListFromLink(ctx context.Context, link string, options RequestOptions) (interface{}, error) {
+	return aggregatePages(ctx, client.newPagerFrom(func(ctx context.Context) (interface{}, error) {
+		req, err := runtime.NewRequest(ctx, http.MethodGet, link)
+		if err != nil {
+			return nil, err
+		}
+		return client.fetchPage(req, options)
+	}, options))
+}
+
+func aggregatePages(ctx context.Context, pager *runtime.Pager[interface{}]) (interface{}, error) {
+	out := make(map[string]interface{})
+	value := make([]interface{}, 0)
+	for pager.More() {
+		page, err := pager.NextPage(ctx)
+		if err != nil {
+			return nil, err
+		}
+
+		if pageMap, ok := page.(map[string]interface{}); ok {
+			if pageValue, ok := pageMap["value"].([]interface{}); ok {
+				value = append(value, pageValue...)
+				// copy all fields except for nextLinkKey and value, e.g. the @odata.deltaLink of the last page of a delta query
+				for key, val := range pageMap {
+					if key != nextLinkKey && key != "value" {
+						out[key] = val
+					}
+				}
+				continue
+			}
+		}
+
+		// if response doesn't follow the paging guideline, return the response as is
+		return page, nil
+	}
+
+	out["value"] = value
+
+	return out, nil
+}
+
+// ListWithLimit follows @odata.nextLink until the collection is exhausted or maxResults items have been read,
+// a maxResults of 0 means no limit. It returns the aggregated items and the @odata.count of the collection,
+// which is only returned by the API when `$count=true` is requested.
+func (client *MSGraphClient) ListWithLimit(ctx context.Context, url string, apiVersion string, maxResults int, options RequestOptions) ([]interface{}, *int64, error) {
+	pager := client.newPager(url, apiVersion, options)
+
+	var count *int64
+	value := make([]interface{}, 0)
+	for pager.More() {
+		page, err := pager.NextPage(ctx)
+		if err != nil {
+			return nil, nil, err
+		}
+
+		pageMap, ok := page.(map[string]interface{})
+		if !ok {
+			return nil, nil, fmt.Errorf("unexpected response of %s: expected an object with a `value` array", url)
+		}
+		if count == nil {
+			if v, ok := pageMap["@odata.count"].(float64); ok {
+				c := int64(v)
+				count = &c
+			}
+		}
+		pageValue, ok := pageMap["value"].([]interface{})
+		if !ok {
+			return nil, nil, fmt.Errorf("unexpected response of %s: expected an object with a `value` array", url)
+		}
+		value = append(value, pageValue...)
+
+		if maxResults > 0 && len(value) >= maxResults {
+			return value[:maxResults], count, nil
+		}
+	}
+
+	return value, count, nil
+}
+
+func (client *MSGraphClient) newPager(url string, apiVersion string, options RequestOptions) *runtime.Pager[interface{}] {
+	return client.newPagerFrom(func(ctx context.Context) (interface{}, error) {
+		req, err := runtime.NewRequest(ctx, http.MethodGet, runtime.JoinPaths(client.host, apiVersion, url))
+		if err != nil {
+			return nil, err
+		}
+		setQueryParameters(req, options.QueryParameters)
+		return client.fetchPage(req, options)
+	}, options)
+}
+
+// newPagerFrom returns a pager which reads the first page with the given function, and follows the @odata.nextLink of the pages.
+func (client *MSGraphClient) newPagerFrom(firstPage func(ctx context.Context) (interface{}, error), options RequestOptions) *runtime.Pager[interface{}] {
+	return runtime.NewPager(runtime.PagingHandler[interface{}]{
 		More: func(current interface{}) bool {
 			if current == nil {
 				return false
@@ -119,109 +302,64 @@ func (client *MSGraphClient) List(ctx context.Context, url string, apiVersion st
 			if currentMap[nextLinkKey] == nil {
 				return false
 			}
-			if nextLink := currentMap[nextLinkKey].(string); nextLink == "" {
+			if nextLink, ok := currentMap[nextLinkKey].(string); !ok || nextLink == "" {
 				return false
 			}
 			return true
 		},
 		Fetcher: func(ctx context.Context, current *interface{}) (interface{}, error) {
-			if options.RetryOptions != nil {
-				ctx = policy.WithRetryOptions(ctx, *options.RetryOptions)
-			}
-			var request *policy.Request
+			ctx = client.withRetryOptions(ctx, options)
 			if current == nil {
-				req, err := runtime.NewRequest(ctx, http.MethodGet, runtime.JoinPaths(client.host, apiVersion, url))
-				if err != nil {
-					return nil, err
-				}
-				reqQP := req.Raw().URL.Query()
-				for key, value := range options.QueryParameters {
-					reqQP.Set(key, value)
-				}
-				req.Raw().URL.RawQuery = reqQP.Encode()
-				for key, value := range options.Headers {
-					req.Raw().Header.Set(key, value)
-				}
-				request = req
-			} else {
-				nextLink := ""
-				if currentMap, ok := (*current).(map[string]interface{}); ok && currentMap[nextLinkKey] != nil {
-					nextLink = currentMap[nextLinkKey].(string)
-				}
-				req, err := runtime.NewRequest(ctx, http.MethodGet, nextLink)
-				if err != nil {
-					return nil, err
-				}
-				request = req
+				return firstPage(ctx)
 			}
-			request.Raw().Header.Set("Accept", "application/json")
-			resp, err := client.pl.Do(request)
-			if err != nil {
-				return nil, err
-			}
-			if !runtime.HasStatusCode(resp, http.StatusOK) {
-				return nil, runtime.NewResponseError(resp)
+			nextLink := ""
+			if currentMap, ok := (*current).(map[string]interface{}); ok && currentMap[nextLinkKey] != nil {
+				nextLink = currentMap[nextLinkKey].(string)
 			}
-			var responseBody interface{}
-			if err := runtime.UnmarshalAsJSON(resp, &responseBody); err != nil {
+			req, err := runtime.NewRequest(ctx, http.MethodGet, nextLink)
+			if err != nil {
 				return nil, err
 			}
-			return responseBody, nil
+			return client.fetchPage(req, options)
 		},
 	})
+}
 
-	out := make(map[string]interface{})
-	value := make([]interface{}, 0)
-	for pager.More() {
-		page, err := pager.NextPage(ctx)
-		if err != nil {
-			return nil, err
-		}
-
-		if pageMap, ok := page.(map[string]interface{}); ok {
-			if pageMap["value"] != nil {
-				if pageValue, ok := pageMap["value"].([]interface{}); ok {
-					value = append(value, pageValue...)
-					continue
-				}
-			}
-			// copy all fields except for nextLinkKey and value
-			for key, val := range pageMap {
-				if key != nextLinkKey && key != "value" {
-					out[key] = val
-				}
-			}
-		}
-
-		// if response doesn't follow the paging guideline, return the response as is
-		return page, nil
+// fetchPage sends the GET request of a page with the headers of the options.
+func (client *MSGraphClient) fetchPage(req *policy.Request, options RequestOptions) (interface{}, error) {
+	for key, value := range options.Headers {
+		req.Raw().Header.Set(key, value)
 	}
-
-	out["value"] = value
-
-	return out, nil
+	req.Raw().Header.Set("Accept", "application/json")
+	resp, err := client.pl.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	if !runtime.HasStatusCode(resp, http.StatusOK) {
+		return nil, runtime.NewResponseError(resp)
+	}
+	var responseBody interface{}
+	if err := runtime.UnmarshalAsJSON(resp, &responseBody); err != nil {
+		return nil, err
+	}
+	return responseBody, nil
 }
 
 func (client *MSGraphClient) Create(ctx context.Context, url string, apiVersion string, body interface{}, options RequestOptions) (interface{}, error) {
-	if options.RetryOptions != nil {
-		ctx = policy.WithRetryOptions(ctx, *options.RetryOptions)
-	}
+	ctx = client.withRetryOptions(ctx, options)
 	req, err := runtime.NewRequest(ctx, http.MethodPost, runtime.JoinPaths(client.host, apiVersion, url))
 	if err != nil {
 		return nil, err
 	}
-	reqQP := req.Raw().URL.Query()
-	for key, value := range options.QueryParameters {
-		reqQP.Set(key, value)
-	}
-	req.Raw().URL.RawQuery = reqQP.Encode()
+	setQueryParameters(req, options.QueryParameters)
 	req.Raw().Header.Set("Accept", "application/json")
 	for key, value := range options.Headers {
 		req.Raw().Header.Set(key, value)
 	}
-	if err := runtime.MarshalAsJSON(req, body); err != nil {
+	if err := setRequestBody(req, body); err != nil {
 		return nil, err
 	}
+	logRequest(ctx, req, body)
 	resp, err := client.pl.Do(req)
 	if err != nil {
 		return nil, err
@@ -230,7 +368,10 @@ func (client *MSGraphClient) Create(ctx context.Context, url string, apiVersion
 		return nil, runtime.NewResponseError(resp)
 	}
 
-	// TODO: Handle long-running operations if needed
+	// Some resources, e.g. teams, are created asynchronously, the response is 202 Accepted with the operation to poll.
+	if isLongRunningOperation(resp) {
+		return client.waitForOperation(ctx, resp, apiVersion, options)
+	}
 
 	var responseBody interface{}
 	if err := runtime.UnmarshalAsJSON(resp, &responseBody); err != nil {
@@ -240,25 +381,20 @@ func (client *MSGraphClient) Create(ctx context.Context, url string, apiVersion
 }
 
 func (client *MSGraphClient) Update(ctx context.Context, url string, apiVersion string, body interface{}, options RequestOptions) (interface{}, error) {
-	if options.RetryOptions != nil {
-		ctx = policy.WithRetryOptions(ctx, *options.RetryOptions)
-	}
+	ctx = client.withRetryOptions(ctx, options)
 	req, err := runtime.NewRequest(ctx, http.MethodPatch, runtime.JoinPaths(client.host, apiVersion, url))
 	if err != nil {
 		return nil, err
 	}
-	reqQP := req.Raw().URL.Query()
-	for key, value := range options.QueryParameters {
-		reqQP.Set(key, value)
-	}
-	req.Raw().URL.RawQuery = reqQP.Encode()
+	setQueryParameters(req, options.QueryParameters)
 	req.Raw().Header.Set("Accept", "application/json")
 	for key, value := range options.Headers {
 		req.Raw().Header.Set(key, value)
 	}
-	if err := runtime.MarshalAsJSON(req, body); err != nil {
+	if err := setRequestBody(req, body); err != nil {
 		return nil, err
 	}
+	logRequest(ctx, req, body)
 	resp, err := client.pl.Do(req)
 	if err != nil {
 		return nil, err
@@ -277,22 +413,17 @@ func (client *MSGraphClient) Update(ctx context.Context, url string, apiVersion
 }
 
 func (client *MSGraphClient) Delete(ctx context.Context, url string, apiVersion string, options RequestOptions) error {
-	if options.RetryOptions != nil {
-		ctx = policy.WithRetryOptions(ctx, *options.RetryOptions)
-	}
+	ctx = client.withRetryOptions(ctx, options)
 	req, err := runtime.NewRequest(ctx, http.MethodDelete, runtime.JoinPaths(client.host, apiVersion, url))
 	if err != nil {
 		return err
 	}
-	reqQP := req.Raw().URL.Query()
-	for key, value := range options.QueryParameters {
-		reqQP.Set(key, value)
-	}
-	req.Raw().URL.RawQuery = reqQP.Encode()
+	setQueryParameters(req, options.QueryParameters)
 	req.Raw().Header.Set("Accept", "application/json")
 	for key, value := range options.Headers {
 		req.Raw().Header.Set(key, value)
 	}
+	logRequest(ctx, req, nil)
 	resp, err := client.pl.Do(req)
 	if err != nil {
 		return err
@@ -307,21 +438,14 @@ func (client *MSGraphClient) Delete(ctx context.Context, url string, apiVersion
 }
 
 func (client *MSGraphClient) Action(ctx context.Context, method string, url string, apiVersion string, body interface{}, options RequestOptions) (interface{}, error) {
-	// apply per-request retry options via context
-	if options.RetryOptions != nil {
-		ctx = policy.WithRetryOptions(ctx, *options.RetryOptions)
-	}
+	ctx = client.withRetryOptions(ctx, options)
 
 	req, err := runtime.NewRequest(ctx, method, runtime.JoinPaths(client.host, apiVersion, url))
 	if err != nil {
 		return nil, err
 	}
 
-	reqQP := req.Raw().URL.Query()
-	for key, value := range options.QueryParameters {
-		reqQP.Set(key, value)
-	}
-	req.Raw().URL.RawQuery = reqQP.Encode()
+	setQueryParameters(req, options.QueryParameters)
 	req.Raw().Header.Set("Accept", "application/json")
 	for key, value := range options.Headers {
 		req.Raw().Header.Set(key, value)
@@ -329,12 +453,12 @@ func (client *MSGraphClient) Action(ctx context.Context, method string, url stri
 
 	// Set request body if provided
 	if body != nil {
-		if err := runtime.MarshalAsJSON(req, body); err != nil {
+		if err := setRequestBody(req, body); err != nil {
 			return nil, err
 		}
-		req.Raw().Header.Set("Content-Type", "application/json")
 	}
 
+	logRequest(ctx, req, body)
 	resp, err := client.pl.Do(req)
 	if err != nil {
 		return nil, err
@@ -358,6 +482,71 @@ func (client *MSGraphClient) Action(ctx context.Context, method string, url stri
 	return responseBody, nil
 }
 
+// withRetryOptions returns the context with the per-request retry options, whose tries are bounded by the request
+// timeout of the client unless they have their own.
+func (client *MSGraphClient) withRetryOptions(ctx context.Context, options RequestOptions) context.Context {
+	if options.RetryOptions == nil {
+		return ctx
+	}
+	retryOptions := *options.RetryOptions
+	if retryOptions.TryTimeout == 0 {
+		retryOptions.TryTimeout = client.requestTimeout
+	}
+	return policy.WithRetryOptions(ctx, retryOptions)
+}
+
 func (client *MSGraphClient) GraphBaseUrl() string {
 	return client.host
 }
+
+// DefaultApiVersion returns the API version used when `api_version` isn't specified, it's `v1.0` unless the
+// provider is configured with another `default_api_version`.
+func (client *MSGraphClient) DefaultApiVersion() string {
+	if client == nil || client.defaultApiVersion == "" {
+		return "v1.0"
+	}
+	return client.defaultApiVersion
+}
+
+// DefaultReadTimeout returns the timeout of the reads whose `timeouts` block doesn't specify `read`, it defaults to
+// 5 minutes.
+func (client *MSGraphClient) DefaultReadTimeout() time.Duration {
+	if client == nil || client.defaultReadTimeout <= 0 {
+		return 5 * time.Minute
+	}
+	return client.defaultReadTimeout
+}
+
+// ProtectedUrlPattern returns the pattern of the provider's `protected_url_patterns` which the URL of an object to
+// delete matches, or an empty string if the URL isn't protected or the protection is overridden. The URL is relative
+// to the API version, e.g. `identity/conditionalAccess/policies/{policy-id}`.
+func (client *MSGraphClient) ProtectedUrlPattern(url string) string {
+	if client == nil || client.allowProtectedDeletion {
+		return ""
+	}
+	url = strings.TrimPrefix(url, "/")
+	for _, pattern := range client.protectedUrlPatterns {
+		if pattern.MatchString(url) {
+			return pattern.String()
+		}
+	}
+	return ""
+}
+
+// setQueryParameters adds the query parameters to the URL of the request. The query string is encoded with the
+// parameters sorted by name, so the same parameters always produce the same URL whatever the map iteration order is.
+func setQueryParameters(req *policy.Request, queryParameters map[string]string) {
+	reqQP := req.Raw().URL.Query()
+	for key, value := range queryParameters {
+		reqQP.Set(key, value)
+	}
+	req.Raw().URL.RawQuery = reqQP.Encode()
+}
+
+// setRequestBody sets the request body, marshaling it as JSON unless it's a RawBody.
+func setRequestBody(req *policy.Request, body interface{}) error {
+	if rawBody, ok := body.(RawBody); ok {
+		return req.SetBody(streaming.NopCloser(bytes.NewReader(rawBody.Content)), rawBody.ContentType)
+	}
+	return runtime.MarshalAsJSON(req, body)
+}
@@ -0,0 +1,443 @@
+package clients
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"reflect"
+	"regexp"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/Azure/azure-sdk-for-go/sdk/azcore"
+	"github.com/Azure/azure-sdk-for-go/sdk/azcore/policy"
+	"github.com/Azure/azure-sdk-for-go/sdk/azcore/runtime"
+)
+
+func TestSetQueryParameters_StableOrder(t *testing.T) {
+	queryParameters := NewQueryParameters(map[string][]string{
+		"$top":     {"10"},
+		"$select":  {"id", "displayName"},
+		"$filter":  {"startswith(displayName,'a')"},
+		"$orderby": {"displayName"},
+		"$expand":  {"owners"},
+		"$count":   {"true"},
+	})
+	expected := "https://graph.microsoft.com/v1.0/applications?%24count=true&%24expand=owners&%24filter=startswith%28displayName%2C%27a%27%29&%24orderby=displayName&%24select=id%2CdisplayName&%24top=10"
+
+	for i := 0; i < 20; i++ {
+		req, err := runtime.NewRequest(context.Background(), http.MethodGet, "https://graph.microsoft.com/v1.0/applications")
+		if err != nil {
+			t.Fatal(err)
+		}
+		setQueryParameters(req, queryParameters)
+		if actual := req.Raw().URL.String(); actual != expected {
+			t.Fatalf("expected %s, got %s", expected, actual)
+		}
+	}
+}
+
+func TestSetQueryParameters_KeepsExistingParameters(t *testing.T) {
+	req, err := runtime.NewRequest(context.Background(), http.MethodGet, "https://graph.microsoft.com/v1.0/applications?$top=5")
+	if err != nil {
+		t.Fatal(err)
+	}
+	setQueryParameters(req, map[string]string{"$select": "id"})
+	expected := "https://graph.microsoft.com/v1.0/applications?%24select=id&%24top=5"
+	if actual := req.Raw().URL.String(); actual != expected {
+		t.Fatalf("expected %s, got %s", expected, actual)
+	}
+}
+
+func TestReadPage(t *testing.T) {
+	pages := func() map[string][]operationResponse {
+		return map[string][]operationResponse{
+			"GET https://graph.microsoft.com/v1.0/users?%24count=true&%24top=1": {
+				{statusCode: http.StatusOK, body: `{"@odata.count":2,"value":[{"id":"1"}],"@odata.nextLink":"https://graph.microsoft.com/v1.0/users?$count=true&$top=1&$skiptoken=abc"}`},
+			},
+			"GET https://graph.microsoft.com/v1.0/users?$count=true&$top=1&$skiptoken=abc": {
+				{statusCode: http.StatusOK, body: `{"@odata.count":2,"value":[{"id":"2"}]}`},
+			},
+		}
+	}
+	options := RequestOptions{
+		QueryParameters: NewQueryParameters(map[string][]string{"$top": {"1"}, "$count": {"true"}}),
+	}
+
+	transport := &operationTransport{responses: pages()}
+	page, err := newOperationTestClient(transport).ReadPage(context.Background(), "users", "v1.0", options)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	expected := `{"@odata.count":2,"@odata.nextLink":"https://graph.microsoft.com/v1.0/users?$count=true&$top=1&$skiptoken=abc","value":[{"id":"1"}]}`
+	if !jsonEqual(page, expected) {
+		t.Fatalf("expected %s, got %v", expected, page)
+	}
+	if len(transport.requests) != 1 {
+		t.Fatalf("expected 1 request, got %v", transport.requests)
+	}
+
+	transport = &operationTransport{responses: pages()}
+	all, err := newOperationTestClient(transport).Read(context.Background(), "users", "v1.0", options)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	expected = `{"@odata.count":2,"value":[{"id":"1"},{"id":"2"}]}`
+	if !jsonEqual(all, expected) {
+		t.Fatalf("expected %s, got %v", expected, all)
+	}
+}
+
+func TestListFromLink(t *testing.T) {
+	const deltaLink = "https://graph.microsoft.com/v1.0/groups/delta?$deltatoken=abc"
+	transport := &operationTransport{responses: map[string][]operationResponse{
+		"GET " + deltaLink: {
+			{statusCode: http.StatusOK, body: `{"value":[{"id":"1"}],"@odata.nextLink":"https://graph.microsoft.com/v1.0/groups/delta?$skiptoken=def"}`},
+		},
+		"GET https://graph.microsoft.com/v1.0/groups/delta?$skiptoken=def": {
+			{statusCode: http.StatusOK, body: `{"value":[{"id":"2","@removed":{"reason":"changed"}}],"@odata.deltaLink":"https://graph.microsoft.com/v1.0/groups/delta?$deltatoken=ghi"}`},
+		},
+	}}
+	actual, err := newOperationTestClient(transport).ListFromLink(context.Background(), deltaLink, RequestOptions{})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	expected := `{"@odata.deltaLink":"https://graph.microsoft.com/v1.0/groups/delta?$deltatoken=ghi","value":[{"id":"1"},{"id":"2","@removed":{"reason":"changed"}}]}`
+	if !jsonEqual(actual, expected) {
+		t.Fatalf("expected %s, got %v", expected, actual)
+	}
+
+	transport = &operationTransport{responses: map[string][]operationResponse{
+		"GET " + deltaLink: {
+			{statusCode: http.StatusGone, body: `{"error":{"code":"SyncStateNotFound"}}`},
+		},
+	}}
+	if _, err := newOperationTestClient(transport).ListFromLink(context.Background(), deltaLink, RequestOptions{}); err == nil {
+		t.Fatalf("expected an error for an expired delta link")
+	}
+}
+
+func TestListRefIDs(t *testing.T) {
+	pages := func() map[string][]operationResponse {
+		return map[string][]operationResponse{
+			"GET https://graph.microsoft.com/v1.0/groups/00000000-0000-0000-0000-000000000001/members": {
+				{statusCode: http.StatusOK, body: `{"value":[{"id":"1"},{"id":"2"}],"@odata.nextLink":"https://graph.microsoft.com/v1.0/groups/00000000-0000-0000-0000-000000000001/members?$skiptoken=abc"}`},
+			},
+			"GET https://graph.microsoft.com/v1.0/groups/00000000-0000-0000-0000-000000000001/members?$skiptoken=abc": {
+				{statusCode: http.StatusOK, body: `{"value":[{"id":"3"}],"@odata.nextLink":"https://graph.microsoft.com/v1.0/groups/00000000-0000-0000-0000-000000000001/members?$skiptoken=def"}`},
+			},
+			"GET https://graph.microsoft.com/v1.0/groups/00000000-0000-0000-0000-000000000001/members?$skiptoken=def": {
+				{statusCode: http.StatusOK, body: `{"value":[{"id":"4"}]}`},
+			},
+		}
+	}
+	const collectionUrl = "groups/00000000-0000-0000-0000-000000000001/members"
+
+	transport := &operationTransport{responses: pages()}
+	ids, err := newOperationTestClient(transport).ListRefIDs(context.Background(), collectionUrl, "v1.0", RequestOptions{})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if expected := []string{"1", "2", "3", "4"}; !reflect.DeepEqual(ids, expected) {
+		t.Fatalf("expected %v, got %v", expected, ids)
+	}
+
+	testcases := []struct {
+		name     string
+		id       string
+		expected bool
+		requests int
+	}{
+		{
+			name:     "on the first page",
+			id:       "2",
+			expected: true,
+			requests: 1,
+		},
+		{
+			name:     "on a later page",
+			id:       "3",
+			expected: true,
+			requests: 2,
+		},
+		{
+			name:     "on the last page",
+			id:       "4",
+			expected: true,
+			requests: 3,
+		},
+		{
+			name:     "not in the collection",
+			id:       "5",
+			expected: false,
+			requests: 3,
+		},
+	}
+	for _, tc := range testcases {
+		t.Run(tc.name, func(t *testing.T) {
+			transport := &operationTransport{responses: pages()}
+			found, err := newOperationTestClient(transport).ContainsRefID(context.Background(), collectionUrl, "v1.0", tc.id, RequestOptions{})
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if found != tc.expected {
+				t.Fatalf("expected %v, got %v", tc.expected, found)
+			}
+			if len(transport.requests) != tc.requests {
+				t.Fatalf("expected %d requests, got %v", tc.requests, transport.requests)
+			}
+		})
+	}
+}
+
+func TestDefaultReadTimeout(t *testing.T) {
+	testcases := []struct {
+		name     string
+		client   *MSGraphClient
+		expected time.Duration
+	}{
+		{
+			name:     "nil client",
+			client:   nil,
+			expected: 5 * time.Minute,
+		},
+		{
+			name:     "not configured",
+			client:   &MSGraphClient{},
+			expected: 5 * time.Minute,
+		},
+		{
+			name:     "configured",
+			client:   &MSGraphClient{defaultReadTimeout: 15 * time.Minute},
+			expected: 15 * time.Minute,
+		},
+	}
+
+	for _, tc := range testcases {
+		t.Run(tc.name, func(t *testing.T) {
+			if actual := tc.client.DefaultReadTimeout(); actual != tc.expected {
+				t.Fatalf("expected %s, got %s", tc.expected, actual)
+			}
+		})
+	}
+}
+
+func TestProtectedUrlPattern(t *testing.T) {
+	patterns := []*regexp.Regexp{
+		regexp.MustCompile(`^identity/conditionalAccess/policies/`),
+		regexp.MustCompile(`(?i)^groups/00000000-0000-0000-0000-000000000001$`),
+	}
+
+	testcases := []struct {
+		name     string
+		client   *MSGraphClient
+		url      string
+		expected string
+	}{
+		{
+			name:     "nil client",
+			client:   nil,
+			url:      "identity/conditionalAccess/policies/00000000-0000-0000-0000-000000000002",
+			expected: "",
+		},
+		{
+			name:     "no patterns",
+			client:   &MSGraphClient{},
+			url:      "identity/conditionalAccess/policies/00000000-0000-0000-0000-000000000002",
+			expected: "",
+		},
+		{
+			name:     "matched",
+			client:   &MSGraphClient{protectedUrlPatterns: patterns},
+			url:      "identity/conditionalAccess/policies/00000000-0000-0000-0000-000000000002",
+			expected: `^identity/conditionalAccess/policies/`,
+		},
+		{
+			name:     "matched with a leading slash",
+			client:   &MSGraphClient{protectedUrlPatterns: patterns},
+			url:      "/GROUPS/00000000-0000-0000-0000-000000000001",
+			expected: `(?i)^groups/00000000-0000-0000-0000-000000000001$`,
+		},
+		{
+			name:     "not matched",
+			client:   &MSGraphClient{protectedUrlPatterns: patterns},
+			url:      "groups/00000000-0000-0000-0000-000000000001/members/00000000-0000-0000-0000-000000000002/$ref",
+			expected: "",
+		},
+		{
+			name:     "overridden",
+			client:   &MSGraphClient{protectedUrlPatterns: patterns, allowProtectedDeletion: true},
+			url:      "identity/conditionalAccess/policies/00000000-0000-0000-0000-000000000002",
+			expected: "",
+		},
+	}
+
+	for _, tc := range testcases {
+		t.Run(tc.name, func(t *testing.T) {
+			if actual := tc.client.ProtectedUrlPattern(tc.url); actual != tc.expected {
+				t.Fatalf("expected %q, got %q", tc.expected, actual)
+			}
+		})
+	}
+}
+
+// countingTokenCredential issues tokens valid for the lifetime and counts how many were issued.
+type countingTokenCredential struct {
+	lifetime time.Duration
+	calls    int
+}
+
+func (c *countingTokenCredential) GetToken(ctx context.Context, options policy.TokenRequestOptions) (azcore.AccessToken, error) {
+	c.calls++
+	return azcore.AccessToken{Token: fmt.Sprintf("token-%d", c.calls), ExpiresOn: time.Now().Add(c.lifetime)}, nil
+}
+
+func TestNewMSGraphClient_TokenCache(t *testing.T) {
+	const reads = 10
+	testcases := []struct {
+		name     string
+		lifetime time.Duration
+		expected int
+	}{
+		{
+			// the token is cached by the bearer token policy and reused by all the requests of the client
+			name:     "valid token",
+			lifetime: time.Hour,
+			expected: 1,
+		},
+		{
+			// the expiry of the token is honored, an expired token is acquired again for the next request
+			name:     "expired token",
+			lifetime: -time.Second,
+			expected: reads,
+		},
+	}
+
+	for _, tc := range testcases {
+		t.Run(tc.name, func(t *testing.T) {
+			credential := &countingTokenCredential{lifetime: tc.lifetime}
+			transport := &authorizationTransport{}
+			client, err := NewMSGraphClient(credential, &policy.ClientOptions{Transport: transport})
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			for i := 0; i < reads; i++ {
+				if _, err := client.Read(context.Background(), "groups/1", "v1.0", DefaultRequestOptions()); err != nil {
+					t.Fatalf("unexpected error: %v", err)
+				}
+			}
+			if credential.calls != tc.expected {
+				t.Fatalf("expected %d token acquisitions for %d reads, got %d", tc.expected, reads, credential.calls)
+			}
+			if len(transport.authorizations) != reads {
+				t.Fatalf("expected %d requests, got %d", reads, len(transport.authorizations))
+			}
+		})
+	}
+}
+
+// slowTransport responds after the delay of each try, unless the request is cancelled before.
+type slowTransport struct {
+	delays []time.Duration
+	tries  int
+}
+
+func (t *slowTransport) Do(req *http.Request) (*http.Response, error) {
+	delay := t.delays[t.tries]
+	t.tries++
+	select {
+	case <-time.After(delay):
+		return &http.Response{StatusCode: http.StatusOK, Header: http.Header{}, Body: io.NopCloser(strings.NewReader(`{"id":"1"}`)), Request: req}, nil
+	case <-req.Context().Done():
+		return nil, req.Context().Err()
+	}
+}
+
+func TestRequestTimeout(t *testing.T) {
+	transport := &slowTransport{delays: []time.Duration{time.Minute, 0}}
+	client := &MSGraphClient{
+		host: "https://graph.microsoft.com",
+		pl: runtime.NewPipeline("test", "v0.0.1", runtime.PipelineOptions{}, &policy.ClientOptions{
+			Transport: transport,
+		}),
+		requestTimeout: 50 * time.Millisecond,
+	}
+	options := RequestOptions{
+		RetryOptions: &policy.RetryOptions{MaxRetries: 2, RetryDelay: time.Millisecond},
+	}
+
+	// the first try is hung, so it's cancelled by the request timeout and the second try gets a fresh deadline
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+	actual, err := client.Read(ctx, "groups/1", "v1.0", options)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if !jsonEqual(actual, `{"id":"1"}`) {
+		t.Fatalf("expected the object, got %v", actual)
+	}
+	if transport.tries != 2 {
+		t.Fatalf("expected 2 tries, got %d", transport.tries)
+	}
+}
+
+func TestRequestTimeout_NonIdempotent(t *testing.T) {
+	testcases := []struct {
+		name          string
+		retryOptions  *policy.RetryOptions
+		expectedTries int
+	}{
+		{
+			name:          "default retry options",
+			retryOptions:  nil,
+			expectedTries: 1,
+		},
+		{
+			name: "custom retry options which retry any error",
+			retryOptions: &policy.RetryOptions{MaxRetries: 2, RetryDelay: time.Millisecond, ShouldRetry: func(*http.Response, error) bool {
+				return true
+			}},
+			expectedTries: 1,
+		},
+	}
+
+	for _, tc := range testcases {
+		t.Run(tc.name, func(t *testing.T) {
+			transport := &slowTransport{delays: []time.Duration{time.Minute, 0}}
+			client := &MSGraphClient{
+				host: "https://graph.microsoft.com",
+				pl: runtime.NewPipeline("test", "v0.0.1", runtime.PipelineOptions{}, &policy.ClientOptions{
+					Transport:        transport,
+					PerRetryPolicies: []policy.Policy{tryTimeoutPolicy{}},
+					Retry:            policy.RetryOptions{TryTimeout: 50 * time.Millisecond, MaxRetries: 2, RetryDelay: time.Millisecond},
+				}),
+				requestTimeout: 50 * time.Millisecond,
+			}
+
+			// the POST might have been processed when its try timed out, so it isn't sent again
+			ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+			defer cancel()
+			_, err := client.Create(ctx, "groups", "v1.0", map[string]interface{}{"displayName": "example"}, RequestOptions{RetryOptions: tc.retryOptions})
+			var timeoutErr *TryTimeoutError
+			if !errors.As(err, &timeoutErr) {
+				t.Fatalf("expected a try timeout error, got %v", err)
+			}
+			if transport.tries != tc.expectedTries {
+				t.Fatalf("expected %d tries, got %d", tc.expectedTries, transport.tries)
+			}
+		})
+	}
+}
+
+// jsonEqual returns whether the value is equal to the JSON.
+func jsonEqual(value interface{}, expected string) bool {
+	var expectedValue interface{}
+	if err := json.Unmarshal([]byte(expected), &expectedValue); err != nil {
+		return false
+	}
+	return reflect.DeepEqual(value, expectedValue)
+}
@@ -1,9 +1,14 @@
 package clients
 
 import (
+	"context"
+	"errors"
 	"log"
 	"math"
+	"net"
 	"net/http"
+	"sort"
+	"strconv"
 	"strings"
 	"time"
 
@@ -21,6 +26,10 @@ var DefaultRetryableStatusCodes = []int{
 	http.StatusGatewayTimeout,      // 504
 }
 
+// DefaultMaxRetryDelay caps the exponential delay of the SDK between the retries. A longer Retry-After delay is
+// still honored until the deadline of the request, it's waited for in ShouldRetry.
+var DefaultMaxRetryDelay = 60 * time.Second
+
 var DefaultRetryableReadAfterCreateStatusCodes = []int{
 	http.StatusNotFound,  // 404
 	http.StatusForbidden, // 403
@@ -69,7 +78,8 @@ func CombineRetryOptions(opts ...*policy.RetryOptions) *policy.RetryOptions {
 		if opt == nil {
 			continue
 		}
-		if retryDelay == 0 || opt.RetryDelay < retryDelay {
+		// The options which apply their own backoff don't change the delay of the other options.
+		if opt.RetryDelay > backoffRetryDelay && (retryDelay == 0 || opt.RetryDelay < retryDelay) {
 			retryDelay = opt.RetryDelay
 		}
 		if opt.MaxRetryDelay > maxRetryDelay {
@@ -107,9 +117,13 @@ func NewRetryOptionsForReadAfterCreate() *policy.RetryOptions {
 	statusCodes = append(statusCodes, DefaultRetryableReadAfterCreateStatusCodes...)
 	return &policy.RetryOptions{
 		// Set a very high max retries to make sure context deadline is respected.
-		MaxRetries:  math.MaxInt16,
-		StatusCodes: statusCodes,
-		ShouldRetry: func(resp *http.Response, err error) bool {
+		MaxRetries:    math.MaxInt16,
+		MaxRetryDelay: DefaultMaxRetryDelay,
+		StatusCodes:   statusCodes,
+		ShouldRetry: withLongRetryAfter(DefaultMaxRetryDelay, func(resp *http.Response, err error) bool {
+			if resp == nil || retryAfterExceedsDeadline(resp) {
+				return false
+			}
 			// We need to test for status codes here too. This covers the case that these options are combined with
 			// retry options from NewRetryOptions, because the ShouldRetry function takes precedence over StatusCodes.
 			for _, code := range statusCodes {
@@ -118,27 +132,52 @@ func NewRetryOptionsForReadAfterCreate() *policy.RetryOptions {
 				}
 			}
 			return false
-		},
+		}),
 	}
 }
 
-// NewRetryOptions creates a RetryOptions based on the provided retry.RetryValue.
-func NewRetryOptions(rtry retry.Value) *policy.RetryOptions {
+// NewRetryOptions creates a RetryOptions based on the provided retry.RetryValue for the requests of the HTTP method.
+// When `idempotent_only` is enabled, the requests of the non-idempotent methods are only retried when it's safe.
+func NewRetryOptions(rtry retry.Value, method string) *policy.RetryOptions {
 	if rtry.IsNull() || rtry.IsUnknown() {
 		return nil
 	}
 
 	log.Printf("[DEBUG] Using custom retry configuration")
-	return &policy.RetryOptions{
+	safeRetriesOnly := rtry.IsIdempotentOnly() && !isIdempotentMethod(method)
+	statusCodes := make([]int, 0)
+	statusCodes = append(statusCodes, DefaultRetryableStatusCodes...)
+	statusCodes = append(statusCodes, rtry.GetStatusCodes()...)
+	options := &policy.RetryOptions{
 		// Set a very high max retries to make sure context deadline is respected.
-		MaxRetries:  math.MaxInt16,
-		StatusCodes: DefaultRetryableStatusCodes,
+		MaxRetries:    math.MaxInt16,
+		MaxRetryDelay: DefaultMaxRetryDelay,
+		StatusCodes:   statusCodes,
 		ShouldRetry: func(resp *http.Response, err error) bool {
-			// We need to test for DefaultRetryableStatusCodes here as using ShouldRetry overrides the use of StatusCodes.
-			for _, code := range DefaultRetryableStatusCodes {
-				if resp.StatusCode == code {
+			if retryAfterExceedsDeadline(resp) {
+				return false
+			}
+			// The try timed out, the deadline of the operation is checked by the SDK before. Only the idempotent
+			// requests are retried, as the others might have been processed.
+			if errors.Is(err, context.DeadlineExceeded) && isIdempotentMethod(method) && !safeRetriesOnly {
+				log.Printf("[DEBUG] Retrying %s request as it timed out", method)
+				return true
+			}
+			if safeRetriesOnly {
+				if isSafeToRetry(resp, err) {
+					log.Printf("[DEBUG] Retrying %s request as it wasn't processed", method)
 					return true
 				}
+				return false
+			}
+			// We need to test for the status codes here as using ShouldRetry overrides the use of StatusCodes.
+			if resp != nil {
+				for _, code := range statusCodes {
+					if resp.StatusCode == code {
+						log.Printf("[DEBUG] Retrying request due to status code %d", code)
+						return true
+					}
+				}
 			}
 
 			// Get the error message to check against regex patterns,
@@ -165,6 +204,139 @@ func NewRetryOptions(rtry retry.Value) *policy.RetryOptions {
 			return false
 		},
 	}
+
+	backoff := NewBackoff(rtry)
+	if backoff == nil {
+		options.ShouldRetry = withLongRetryAfter(options.MaxRetryDelay, options.ShouldRetry)
+		return options
+	}
+	log.Printf("[DEBUG] Using custom backoff: %+v", *backoff)
+	waiter := &backoffWaiter{backoff: *backoff}
+	shouldRetry := options.ShouldRetry
+	// Reduce the delay of the SDK to a minimum, the backoff delay is waited for in ShouldRetry instead.
+	// A zero or negative delay can't be used, as the SDK would either apply its default delay or wait forever.
+	options.RetryDelay = backoffRetryDelay
+	options.MaxRetryDelay = backoffRetryDelay
+	options.ShouldRetry = func(resp *http.Response, err error) bool {
+		if !shouldRetry(resp, err) {
+			waiter.reset()
+			return false
+		}
+		// The Retry-After header still takes precedence over the backoff delay.
+		if RetryAfter(resp) > 0 {
+			return waitForRetryAfter(resp)
+		}
+		if !waiter.wait(resp) {
+			log.Printf("[DEBUG] Not retrying request as the backoff delay exceeds the deadline")
+			waiter.reset()
+			return false
+		}
+		return true
+	}
+	return options
+}
+
+// isIdempotentMethod returns true if sending the request more than once has the same effect as sending it once.
+// PATCH is considered idempotent, as the updates of Microsoft Graph set the values of the properties.
+func isIdempotentMethod(method string) bool {
+	switch strings.ToUpper(method) {
+	case http.MethodGet, http.MethodHead, http.MethodOptions, http.MethodPut, http.MethodPatch, http.MethodDelete:
+		return true
+	}
+	return false
+}
+
+// isSafeToRetry returns true if the request has not been processed, so it can be retried without creating duplicates.
+// It's the case when the request is throttled, or when the connection fails before the request is sent.
+func isSafeToRetry(resp *http.Response, err error) bool {
+	if resp != nil {
+		return resp.StatusCode == http.StatusTooManyRequests
+	}
+	var dnsErr *net.DNSError
+	if errors.As(err, &dnsErr) {
+		return true
+	}
+	var opErr *net.OpError
+	return errors.As(err, &opErr) && opErr.Op == "dial"
+}
+
+// RetryAfter returns the delay requested by the Retry-After header of the response.
+// The header can either be a number of seconds or an HTTP-date, 0 is returned if it's missing or invalid.
+func RetryAfter(resp *http.Response) time.Duration {
+	if resp == nil {
+		return 0
+	}
+	value := strings.TrimSpace(resp.Header.Get("Retry-After"))
+	if value == "" {
+		return 0
+	}
+	if seconds, err := strconv.Atoi(value); err == nil {
+		if seconds <= 0 {
+			return 0
+		}
+		return time.Duration(seconds) * time.Second
+	}
+	if t, err := http.ParseTime(value); err == nil {
+		if delay := time.Until(t); delay > 0 {
+			return delay
+		}
+	}
+	return 0
+}
+
+// withLongRetryAfter wraps the ShouldRetry function of retry options to honor a Retry-After delay which exceeds the
+// MaxRetryDelay of the options, the SDK would otherwise give up on the retry.
+func withLongRetryAfter(maxRetryDelay time.Duration, shouldRetry func(*http.Response, error) bool) func(*http.Response, error) bool {
+	return func(resp *http.Response, err error) bool {
+		if !shouldRetry(resp, err) {
+			return false
+		}
+		if RetryAfter(resp) > maxRetryDelay {
+			return waitForRetryAfter(resp)
+		}
+		return true
+	}
+}
+
+// waitForRetryAfter waits for the Retry-After delay of the response, the header is then removed so that the SDK
+// doesn't wait for it again, nor gives up on the retry because the delay exceeds MaxRetryDelay.
+// It returns false if the context of the request is done before the delay has elapsed.
+func waitForRetryAfter(resp *http.Response) bool {
+	delay := RetryAfter(resp)
+	if delay <= 0 {
+		return true
+	}
+	ctx := context.Background()
+	if resp.Request != nil {
+		ctx = resp.Request.Context()
+	}
+	timer := time.NewTimer(delay)
+	defer timer.Stop()
+	select {
+	case <-timer.C:
+	case <-ctx.Done():
+		return false
+	}
+	resp.Header.Del("Retry-After")
+	return true
+}
+
+// retryAfterExceedsDeadline returns true if waiting for the Retry-After delay would go beyond the deadline of the request,
+// in which case it's better to return the throttling error than to wait for the context to be cancelled.
+func retryAfterExceedsDeadline(resp *http.Response) bool {
+	if resp == nil || resp.Request == nil {
+		return false
+	}
+	deadline, ok := resp.Request.Context().Deadline()
+	if !ok {
+		return false
+	}
+	delay := RetryAfter(resp)
+	if delay > 0 && delay > time.Until(deadline) {
+		log.Printf("[DEBUG] Not retrying request as the Retry-After delay %s exceeds the deadline", delay)
+		return true
+	}
+	return false
 }
 
 func NewQueryParameters(queryParameters map[string][]string) map[string]string {
@@ -179,6 +351,57 @@ func NewQueryParameters(queryParameters map[string][]string) map[string]string {
 	return opts
 }
 
+// QueryString returns the query string of the query parameters with the keys sorted and the values not escaped,
+// e.g. `$select=id,displayName&$top=5`, so it's readable in the plan.
+func QueryString(queryParameters map[string]string) string {
+	keys := make([]string, 0, len(queryParameters))
+	for key := range queryParameters {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	parts := make([]string, 0, len(keys))
+	for _, key := range keys {
+		parts = append(parts, key+"="+queryParameters[key])
+	}
+	return strings.Join(parts, "&")
+}
+
+// NewHeaders returns the headers to be sent with a request, dropping the ones with empty values.
+func NewHeaders(headers map[string]string) map[string]string {
+	opts := make(map[string]string)
+
+	for key, value := range headers {
+		if value != "" {
+			opts[key] = value
+		}
+	}
+
+	return opts
+}
+
+// WithPreference returns a copy of the headers whose `Prefer` header includes the preference, e.g.
+// `return=representation`. The other preferences already in the header are kept, except the ones with the same name,
+// e.g. `return=minimal`, which are replaced.
+func WithPreference(headers map[string]string, preference string) map[string]string {
+	name, _, _ := strings.Cut(preference, "=")
+	res := make(map[string]string, len(headers)+1)
+	preferences := make([]string, 0)
+	for key, value := range headers {
+		if !strings.EqualFold(key, "Prefer") {
+			res[key] = value
+			continue
+		}
+		for _, item := range strings.Split(value, ",") {
+			item = strings.TrimSpace(item)
+			if itemName, _, _ := strings.Cut(item, "="); item != "" && !strings.EqualFold(strings.TrimSpace(itemName), name) {
+				preferences = append(preferences, item)
+			}
+		}
+	}
+	res["Prefer"] = strings.Join(append(preferences, preference), ", ")
+	return res
+}
+
 func DefaultRequestOptions() RequestOptions {
 	return RequestOptions{
 		Headers:         make(map[string]string),
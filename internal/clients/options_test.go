@@ -0,0 +1,536 @@
+package clients
+
+import (
+	"context"
+	"errors"
+	"net"
+	"net/http"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/Azure/azure-sdk-for-go/sdk/azcore/policy"
+	"github.com/Azure/azure-sdk-for-go/sdk/azcore/runtime"
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/microsoft/terraform-provider-msgraph/internal/retry"
+)
+
+func TestNewHeaders(t *testing.T) {
+	testcases := []struct {
+		name     string
+		input    map[string]string
+		expected map[string]string
+	}{
+		{
+			name:     "nil headers",
+			input:    nil,
+			expected: map[string]string{},
+		},
+		{
+			name: "headers with values",
+			input: map[string]string{
+				"ConsistencyLevel": "eventual",
+				"Prefer":           "return=minimal",
+			},
+			expected: map[string]string{
+				"ConsistencyLevel": "eventual",
+				"Prefer":           "return=minimal",
+			},
+		},
+		{
+			name: "headers with empty values are dropped",
+			input: map[string]string{
+				"ConsistencyLevel": "eventual",
+				"Prefer":           "",
+			},
+			expected: map[string]string{
+				"ConsistencyLevel": "eventual",
+			},
+		},
+	}
+
+	for _, tc := range testcases {
+		t.Run(tc.name, func(t *testing.T) {
+			actual := NewHeaders(tc.input)
+			if !reflect.DeepEqual(actual, tc.expected) {
+				t.Fatalf("expected %v, got %v", tc.expected, actual)
+			}
+		})
+	}
+}
+
+func TestWithPreference(t *testing.T) {
+	testcases := []struct {
+		name     string
+		input    map[string]string
+		expected map[string]string
+	}{
+		{
+			name:     "nil headers",
+			input:    nil,
+			expected: map[string]string{"Prefer": "return=representation"},
+		},
+		{
+			name:     "other headers are kept",
+			input:    map[string]string{"ConsistencyLevel": "eventual"},
+			expected: map[string]string{"ConsistencyLevel": "eventual", "Prefer": "return=representation"},
+		},
+		{
+			name:     "other preferences are kept",
+			input:    map[string]string{"prefer": "odata.maxpagesize=10"},
+			expected: map[string]string{"Prefer": "odata.maxpagesize=10, return=representation"},
+		},
+		{
+			name:     "preference with the same name is replaced",
+			input:    map[string]string{"Prefer": "return=minimal, odata.maxpagesize=10"},
+			expected: map[string]string{"Prefer": "odata.maxpagesize=10, return=representation"},
+		},
+		{
+			name:     "preference already set",
+			input:    map[string]string{"Prefer": "return=representation"},
+			expected: map[string]string{"Prefer": "return=representation"},
+		},
+	}
+
+	for _, tc := range testcases {
+		t.Run(tc.name, func(t *testing.T) {
+			actual := WithPreference(tc.input, "return=representation")
+			if !reflect.DeepEqual(actual, tc.expected) {
+				t.Fatalf("expected %v, got %v", tc.expected, actual)
+			}
+		})
+	}
+}
+
+func TestQueryString(t *testing.T) {
+	testcases := []struct {
+		name     string
+		input    map[string]string
+		expected string
+	}{
+		{
+			name:     "no query parameters",
+			input:    nil,
+			expected: "",
+		},
+		{
+			name: "query parameters are sorted and not escaped",
+			input: map[string]string{
+				"$top":    "5",
+				"$select": "id,displayName",
+				"$filter": "startswith(displayName,'a')",
+			},
+			expected: "$filter=startswith(displayName,'a')&$select=id,displayName&$top=5",
+		},
+	}
+
+	for _, tc := range testcases {
+		t.Run(tc.name, func(t *testing.T) {
+			actual := QueryString(tc.input)
+			if actual != tc.expected {
+				t.Fatalf("expected %q, got %q", tc.expected, actual)
+			}
+		})
+	}
+}
+
+func TestNewRetryOptions_StatusCodes(t *testing.T) {
+	newRetryValue := func(errorMessageRegex []string, statusCodes []int64) retry.Value {
+		regexValues := make([]attr.Value, 0)
+		for _, v := range errorMessageRegex {
+			regexValues = append(regexValues, types.StringValue(v))
+		}
+		statusCodeValues := make([]attr.Value, 0)
+		for _, v := range statusCodes {
+			statusCodeValues = append(statusCodeValues, types.Int64Value(v))
+		}
+		regexList := types.ListNull(types.StringType)
+		if errorMessageRegex != nil {
+			regexList = types.ListValueMust(types.StringType, regexValues)
+		}
+		statusCodeList := types.ListNull(types.Int64Type)
+		if statusCodes != nil {
+			statusCodeList = types.ListValueMust(types.Int64Type, statusCodeValues)
+		}
+		return retry.NewRetryValueMust(retry.Value{}.AttributeTypes(context.Background()), map[string]attr.Value{
+			"error_message_regex":  regexList,
+			"status_codes":         statusCodeList,
+			"interval_seconds":     types.Int64Null(),
+			"max_interval_seconds": types.Int64Null(),
+			"multiplier":           types.Float64Null(),
+			"randomization_factor": types.Float64Null(),
+			"idempotent_only":      types.BoolNull(),
+		})
+	}
+
+	testcases := []struct {
+		name       string
+		retry      retry.Value
+		statusCode int
+		expected   bool
+	}{
+		{
+			name:       "configured status code",
+			retry:      newRetryValue(nil, []int64{409}),
+			statusCode: http.StatusConflict,
+			expected:   true,
+		},
+		{
+			name:       "default status code",
+			retry:      newRetryValue(nil, []int64{409}),
+			statusCode: http.StatusTooManyRequests,
+			expected:   true,
+		},
+		{
+			name:       "other status code",
+			retry:      newRetryValue(nil, []int64{409}),
+			statusCode: http.StatusBadRequest,
+			expected:   false,
+		},
+		{
+			name:       "status code when both status codes and regex are configured",
+			retry:      newRetryValue([]string{"never matches"}, []int64{409}),
+			statusCode: http.StatusConflict,
+			expected:   true,
+		},
+	}
+
+	for _, tc := range testcases {
+		t.Run(tc.name, func(t *testing.T) {
+			options := NewRetryOptions(tc.retry, http.MethodGet)
+			req, err := http.NewRequest(http.MethodGet, "https://graph.microsoft.com/v1.0/groups", nil)
+			if err != nil {
+				t.Fatal(err)
+			}
+			resp := &http.Response{
+				StatusCode: tc.statusCode,
+				Request:    req,
+				Body:       http.NoBody,
+			}
+			if actual := options.ShouldRetry(resp, nil); actual != tc.expected {
+				t.Fatalf("expected %v, got %v", tc.expected, actual)
+			}
+		})
+	}
+}
+
+func TestRetryAfter(t *testing.T) {
+	testcases := []struct {
+		name     string
+		header   string
+		expected time.Duration
+		delta    time.Duration
+	}{
+		{
+			name:     "no header",
+			header:   "",
+			expected: 0,
+		},
+		{
+			name:     "delta seconds",
+			header:   "30",
+			expected: 30 * time.Second,
+		},
+		{
+			name:     "negative delta seconds",
+			header:   "-5",
+			expected: 0,
+		},
+		{
+			name:     "http date",
+			header:   time.Now().Add(2 * time.Minute).UTC().Format(http.TimeFormat),
+			expected: 2 * time.Minute,
+			delta:    2 * time.Second,
+		},
+		{
+			name:     "http date in the past",
+			header:   time.Now().Add(-2 * time.Minute).UTC().Format(http.TimeFormat),
+			expected: 0,
+		},
+		{
+			name:     "invalid value",
+			header:   "soon",
+			expected: 0,
+		},
+	}
+
+	for _, tc := range testcases {
+		t.Run(tc.name, func(t *testing.T) {
+			resp := &http.Response{
+				Header: http.Header{},
+			}
+			if tc.header != "" {
+				resp.Header.Set("Retry-After", tc.header)
+			}
+			actual := RetryAfter(resp)
+			if actual < tc.expected-tc.delta || actual > tc.expected+tc.delta {
+				t.Fatalf("expected %v, got %v", tc.expected, actual)
+			}
+		})
+	}
+}
+
+func TestNewRetryOptions_IdempotentOnly(t *testing.T) {
+	newRetryValue := func(idempotentOnly types.Bool) retry.Value {
+		return retry.NewRetryValueMust(retry.Value{}.AttributeTypes(context.Background()), map[string]attr.Value{
+			"error_message_regex":  types.ListValueMust(types.StringType, []attr.Value{types.StringValue("connection reset")}),
+			"status_codes":         types.ListValueMust(types.Int64Type, []attr.Value{types.Int64Value(409)}),
+			"interval_seconds":     types.Int64Null(),
+			"max_interval_seconds": types.Int64Null(),
+			"multiplier":           types.Float64Null(),
+			"randomization_factor": types.Float64Null(),
+			"idempotent_only":      idempotentOnly,
+		})
+	}
+	dialErr := &net.OpError{Op: "dial", Net: "tcp", Err: errors.New("connection refused")}
+	readErr := &net.OpError{Op: "read", Net: "tcp", Err: errors.New("connection reset by peer")}
+
+	testcases := []struct {
+		name       string
+		retry      retry.Value
+		method     string
+		statusCode int
+		err        error
+		expected   bool
+	}{
+		{
+			name:       "post with configured status code",
+			retry:      newRetryValue(types.BoolNull()),
+			method:     http.MethodPost,
+			statusCode: http.StatusConflict,
+			expected:   true,
+		},
+		{
+			name:       "idempotent only post with configured status code",
+			retry:      newRetryValue(types.BoolValue(true)),
+			method:     http.MethodPost,
+			statusCode: http.StatusConflict,
+			expected:   false,
+		},
+		{
+			name:       "idempotent only post with server error",
+			retry:      newRetryValue(types.BoolValue(true)),
+			method:     http.MethodPost,
+			statusCode: http.StatusServiceUnavailable,
+			expected:   false,
+		},
+		{
+			name:       "idempotent only post when throttled",
+			retry:      newRetryValue(types.BoolValue(true)),
+			method:     http.MethodPost,
+			statusCode: http.StatusTooManyRequests,
+			expected:   true,
+		},
+		{
+			name:     "idempotent only post when the connection fails before sending",
+			retry:    newRetryValue(types.BoolValue(true)),
+			method:   http.MethodPost,
+			err:      dialErr,
+			expected: true,
+		},
+		{
+			name:     "idempotent only post when the connection fails after sending",
+			retry:    newRetryValue(types.BoolValue(true)),
+			method:   http.MethodPost,
+			err:      readErr,
+			expected: false,
+		},
+		{
+			name:     "post when the connection fails after sending",
+			retry:    newRetryValue(types.BoolValue(false)),
+			method:   http.MethodPost,
+			err:      readErr,
+			expected: true,
+		},
+		{
+			name:       "idempotent only put with configured status code",
+			retry:      newRetryValue(types.BoolValue(true)),
+			method:     http.MethodPut,
+			statusCode: http.StatusConflict,
+			expected:   true,
+		},
+		{
+			name:     "idempotent only delete when the connection fails after sending",
+			retry:    newRetryValue(types.BoolValue(true)),
+			method:   http.MethodDelete,
+			err:      readErr,
+			expected: true,
+		},
+		{
+			name:     "get when the try times out",
+			retry:    newRetryValue(types.BoolNull()),
+			method:   http.MethodGet,
+			err:      context.DeadlineExceeded,
+			expected: true,
+		},
+		{
+			name:     "post when the try times out",
+			retry:    newRetryValue(types.BoolValue(false)),
+			method:   http.MethodPost,
+			err:      context.DeadlineExceeded,
+			expected: false,
+		},
+		{
+			name:     "idempotent only post when the try times out",
+			retry:    newRetryValue(types.BoolValue(true)),
+			method:   http.MethodPost,
+			err:      context.DeadlineExceeded,
+			expected: false,
+		},
+	}
+
+	for _, tc := range testcases {
+		t.Run(tc.name, func(t *testing.T) {
+			options := NewRetryOptions(tc.retry, tc.method)
+			var resp *http.Response
+			if tc.err == nil {
+				req, err := http.NewRequest(tc.method, "https://graph.microsoft.com/v1.0/groups", nil)
+				if err != nil {
+					t.Fatal(err)
+				}
+				resp = &http.Response{
+					StatusCode: tc.statusCode,
+					Request:    req,
+					Header:     http.Header{},
+					Body:       http.NoBody,
+				}
+			}
+			if actual := options.ShouldRetry(resp, tc.err); actual != tc.expected {
+				t.Fatalf("expected %v, got %v", tc.expected, actual)
+			}
+		})
+	}
+}
+
+func TestNewRetryOptions_RetryAfterDeadline(t *testing.T) {
+	retryValue := retry.NewRetryValueMust(retry.Value{}.AttributeTypes(context.Background()), map[string]attr.Value{
+		"error_message_regex":  types.ListNull(types.StringType),
+		"status_codes":         types.ListValueMust(types.Int64Type, []attr.Value{types.Int64Value(409)}),
+		"interval_seconds":     types.Int64Null(),
+		"max_interval_seconds": types.Int64Null(),
+		"multiplier":           types.Float64Null(),
+		"randomization_factor": types.Float64Null(),
+		"idempotent_only":      types.BoolNull(),
+	})
+
+	testcases := []struct {
+		name       string
+		retryAfter string
+		timeout    time.Duration
+		expected   bool
+	}{
+		{
+			name:       "no deadline",
+			retryAfter: "120",
+			expected:   true,
+		},
+		{
+			name:       "retry after within the deadline",
+			retryAfter: "1",
+			timeout:    time.Minute,
+			expected:   true,
+		},
+		{
+			name:       "retry after beyond the deadline",
+			retryAfter: "120",
+			timeout:    time.Minute,
+			expected:   false,
+		},
+	}
+
+	// the Retry-After delays of the test cases are below the max retry delay, so ShouldRetry doesn't wait for them
+	maxRetryDelay := DefaultMaxRetryDelay
+	DefaultMaxRetryDelay = 5 * time.Minute
+	t.Cleanup(func() { DefaultMaxRetryDelay = maxRetryDelay })
+
+	for _, tc := range testcases {
+		t.Run(tc.name, func(t *testing.T) {
+			ctx := context.Background()
+			if tc.timeout != 0 {
+				var cancel context.CancelFunc
+				ctx, cancel = context.WithTimeout(ctx, tc.timeout)
+				defer cancel()
+			}
+			req, err := http.NewRequestWithContext(ctx, http.MethodGet, "https://graph.microsoft.com/v1.0/groups", nil)
+			if err != nil {
+				t.Fatal(err)
+			}
+			resp := &http.Response{
+				StatusCode: http.StatusTooManyRequests,
+				Header:     http.Header{"Retry-After": []string{tc.retryAfter}},
+				Request:    req,
+				Body:       http.NoBody,
+			}
+			for _, options := range []*policy.RetryOptions{NewRetryOptions(retryValue, http.MethodGet), NewRetryOptionsForReadAfterCreate()} {
+				if actual := options.ShouldRetry(resp, nil); actual != tc.expected {
+					t.Fatalf("expected %v, got %v", tc.expected, actual)
+				}
+				if options.MaxRetryDelay != DefaultMaxRetryDelay {
+					t.Fatalf("expected the max retry delay to be %v, got %v", DefaultMaxRetryDelay, options.MaxRetryDelay)
+				}
+			}
+		})
+	}
+}
+
+func TestCombineRetryOptions_MaxRetryDelay(t *testing.T) {
+	combined := CombineRetryOptions(&policy.RetryOptions{MaxRetryDelay: 30 * time.Second}, NewRetryOptionsForReadAfterCreate())
+	if combined.MaxRetryDelay != DefaultMaxRetryDelay {
+		t.Fatalf("expected the max retry delay to be %v, got %v", DefaultMaxRetryDelay, combined.MaxRetryDelay)
+	}
+
+	combined = CombineRetryOptions(&policy.RetryOptions{MaxRetryDelay: 30 * time.Second}, &policy.RetryOptions{MaxRetryDelay: 10 * time.Second})
+	if combined.MaxRetryDelay != 30*time.Second {
+		t.Fatalf("expected the max retry delay to be 30s, got %v", combined.MaxRetryDelay)
+	}
+}
+
+func TestNewRetryOptions_LongRetryAfter(t *testing.T) {
+	maxRetryDelay := DefaultMaxRetryDelay
+	DefaultMaxRetryDelay = 100 * time.Millisecond
+	t.Cleanup(func() { DefaultMaxRetryDelay = maxRetryDelay })
+
+	const groupsUrl = "https://graph.microsoft.com/v1.0/groups"
+	for _, options := range []*policy.RetryOptions{NewRetryOptions(retry.NewRetryValueMust(retry.Value{}.AttributeTypes(context.Background()), map[string]attr.Value{
+		"error_message_regex":  types.ListNull(types.StringType),
+		"status_codes":         types.ListNull(types.Int64Type),
+		"interval_seconds":     types.Int64Null(),
+		"max_interval_seconds": types.Int64Null(),
+		"multiplier":           types.Float64Null(),
+		"randomization_factor": types.Float64Null(),
+		"idempotent_only":      types.BoolNull(),
+	}), http.MethodGet), NewRetryOptionsForReadAfterCreate()} {
+		transport := &operationTransport{
+			responses: map[string][]operationResponse{
+				"GET " + groupsUrl: {
+					{statusCode: http.StatusTooManyRequests, header: http.Header{"Retry-After": {"1"}}},
+					{statusCode: http.StatusOK, body: `{"value":[]}`},
+				},
+			},
+		}
+		pl := runtime.NewPipeline("test", "v0.0.1", runtime.PipelineOptions{}, &policy.ClientOptions{
+			Transport: transport,
+			Retry:     *options,
+		})
+
+		ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+		req, err := runtime.NewRequest(ctx, http.MethodGet, groupsUrl)
+		if err != nil {
+			cancel()
+			t.Fatal(err)
+		}
+		start := time.Now()
+		resp, err := pl.Do(req)
+		cancel()
+		if err != nil {
+			t.Fatalf("expected the request to succeed after the retry, got %v", err)
+		}
+		if resp.StatusCode != http.StatusOK {
+			t.Fatalf("expected status code %d, got %d", http.StatusOK, resp.StatusCode)
+		}
+		if elapsed := time.Since(start); elapsed < time.Second {
+			t.Fatalf("expected to wait for the Retry-After delay, waited %s", elapsed)
+		}
+	}
+}
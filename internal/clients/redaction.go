@@ -0,0 +1,40 @@
+package clients
+
+import (
+	"context"
+	"encoding/json"
+
+	"github.com/microsoft/terraform-provider-msgraph/internal/utils"
+)
+
+type redactedPathsContextKey struct{}
+
+// WithRedactedPaths returns a context whose requests have the values at the paths of their JSON request and response
+// bodies masked in the logs, e.g. `keyCredentials.key`. The requests themselves are not changed.
+func WithRedactedPaths(ctx context.Context, paths []string) context.Context {
+	if len(paths) == 0 {
+		return ctx
+	}
+	return context.WithValue(ctx, redactedPathsContextKey{}, paths)
+}
+
+func redactedPathsFromContext(ctx context.Context) []string {
+	paths, _ := ctx.Value(redactedPathsContextKey{}).([]string)
+	return paths
+}
+
+// redactBody returns the JSON body with the values at the paths masked, the body is returned as is when it isn't JSON.
+func redactBody(body string, paths []string) string {
+	if len(paths) == 0 || body == "" {
+		return body
+	}
+	var value interface{}
+	if err := json.Unmarshal([]byte(body), &value); err != nil {
+		return body
+	}
+	data, err := json.Marshal(utils.RedactPaths(value, paths))
+	if err != nil {
+		return body
+	}
+	return string(data)
+}
@@ -0,0 +1,85 @@
+package clients
+
+import (
+	"bytes"
+	"context"
+	"log"
+	"net/http"
+	"os"
+	"strings"
+	"testing"
+
+	"github.com/Azure/azure-sdk-for-go/sdk/azcore/policy"
+	"github.com/Azure/azure-sdk-for-go/sdk/azcore/runtime"
+	"github.com/Azure/azure-sdk-for-go/sdk/azcore/streaming"
+)
+
+func TestRedactBody(t *testing.T) {
+	testcases := []struct {
+		name     string
+		body     string
+		paths    []string
+		expected string
+	}{
+		{
+			name:     "json body",
+			body:     `{"displayName":"example","logo":"aGVsbG8="}`,
+			paths:    []string{"logo"},
+			expected: `{"displayName":"example","logo":"(redacted)"}`,
+		},
+		{
+			name:     "no paths",
+			body:     `{"logo":"aGVsbG8="}`,
+			expected: `{"logo":"aGVsbG8="}`,
+		},
+		{
+			name:     "not json",
+			body:     `aGVsbG8=`,
+			paths:    []string{"logo"},
+			expected: `aGVsbG8=`,
+		},
+	}
+
+	for _, tc := range testcases {
+		t.Run(tc.name, func(t *testing.T) {
+			if actual := redactBody(tc.body, tc.paths); actual != tc.expected {
+				t.Fatalf("expected %s, got %s", tc.expected, actual)
+			}
+		})
+	}
+}
+
+func TestLiveTrafficLogPolicy_RedactedPaths(t *testing.T) {
+	var output bytes.Buffer
+	log.SetOutput(&output)
+	t.Cleanup(func() { log.SetOutput(os.Stderr) })
+
+	transport := &operationTransport{
+		responses: map[string][]operationResponse{
+			"POST https://graph.microsoft.com/v1.0/applications": {{statusCode: http.StatusCreated, body: `{"id":"1","logo":"c2VjcmV0"}`}},
+		},
+	}
+	pl := runtime.NewPipeline("test", "v0.0.1", runtime.PipelineOptions{}, &policy.ClientOptions{
+		Transport:        transport,
+		PerRetryPolicies: []policy.Policy{NewLiveTrafficLogPolicy()},
+	})
+
+	ctx := WithRedactedPaths(context.Background(), []string{"logo"})
+	req, err := runtime.NewRequest(ctx, http.MethodPost, "https://graph.microsoft.com/v1.0/applications")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := req.SetBody(streaming.NopCloser(strings.NewReader(`{"displayName":"example","logo":"aGVsbG8="}`)), "application/json"); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := pl.Do(req); err != nil {
+		t.Fatal(err)
+	}
+
+	if strings.Contains(output.String(), "aGVsbG8=") || strings.Contains(output.String(), "c2VjcmV0") {
+		t.Fatalf("expected the logo to be redacted in the logs, got %s", output.String())
+	}
+	if !strings.Contains(output.String(), "(redacted)") {
+		t.Fatalf("expected the placeholder in the logs, got %s", output.String())
+	}
+}
@@ -0,0 +1,48 @@
+package clients
+
+import (
+	"context"
+	"encoding/json"
+
+	"github.com/Azure/azure-sdk-for-go/sdk/azcore/policy"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+	"github.com/microsoft/terraform-provider-msgraph/internal/utils"
+)
+
+// logRequest logs the method, the resolved URL and the size of the body of the request before it's sent, so
+// `TF_LOG=DEBUG` shows what's sent to Microsoft Graph. The credentials and the redacted paths in the body are masked.
+func logRequest(ctx context.Context, req *policy.Request, body interface{}) {
+	tflog.Debug(ctx, "Sending Microsoft Graph request", requestLogFields(ctx, req, body))
+}
+
+func requestLogFields(ctx context.Context, req *policy.Request, body interface{}) map[string]interface{} {
+	fields := map[string]interface{}{
+		"method": req.Raw().Method,
+		"url":    req.Raw().URL.String(),
+	}
+	switch v := body.(type) {
+	case nil:
+		return fields
+	case RawBody:
+		fields["body_size"] = len(v.Content)
+		return fields
+	}
+
+	data, err := json.Marshal(body)
+	if err != nil {
+		return fields
+	}
+	// Round trip the body so it's redacted the same way whatever type it's passed as.
+	var value interface{}
+	if err := json.Unmarshal(data, &value); err != nil {
+		return fields
+	}
+	value = utils.RedactSensitiveProperties(utils.RedactPaths(value, redactedPathsFromContext(ctx)))
+	redacted, err := json.Marshal(value)
+	if err != nil {
+		return fields
+	}
+	fields["body_size"] = len(redacted)
+	fields["body"] = string(redacted)
+	return fields
+}
@@ -0,0 +1,76 @@
+package clients
+
+import (
+	"context"
+	"net/http"
+	"reflect"
+	"testing"
+
+	"github.com/Azure/azure-sdk-for-go/sdk/azcore/runtime"
+)
+
+func TestRequestLogFields(t *testing.T) {
+	testcases := []struct {
+		name     string
+		ctx      context.Context
+		body     interface{}
+		expected map[string]interface{}
+	}{
+		{
+			name: "no body",
+			ctx:  context.Background(),
+			expected: map[string]interface{}{
+				"method": http.MethodPost,
+				"url":    "https://graph.microsoft.com/beta/applications?$select=id",
+			},
+		},
+		{
+			name: "credentials are redacted",
+			ctx:  context.Background(),
+			body: map[string]interface{}{
+				"displayName":        "example",
+				"passwordCredential": map[string]interface{}{"displayName": "example"},
+			},
+			expected: map[string]interface{}{
+				"method":    http.MethodPost,
+				"url":       "https://graph.microsoft.com/beta/applications?$select=id",
+				"body_size": 59,
+				"body":      `{"displayName":"example","passwordCredential":"(redacted)"}`,
+			},
+		},
+		{
+			name: "redacted paths",
+			ctx:  WithRedactedPaths(context.Background(), []string{"logo"}),
+			body: map[string]interface{}{"logo": "aGVsbG8="},
+			expected: map[string]interface{}{
+				"method":    http.MethodPost,
+				"url":       "https://graph.microsoft.com/beta/applications?$select=id",
+				"body_size": 21,
+				"body":      `{"logo":"(redacted)"}`,
+			},
+		},
+		{
+			name: "raw body",
+			ctx:  context.Background(),
+			body: RawBody{Content: []byte{0x89, 0x50, 0x4e, 0x47}, ContentType: "image/png"},
+			expected: map[string]interface{}{
+				"method":    http.MethodPost,
+				"url":       "https://graph.microsoft.com/beta/applications?$select=id",
+				"body_size": 4,
+			},
+		},
+	}
+
+	for _, tc := range testcases {
+		t.Run(tc.name, func(t *testing.T) {
+			req, err := runtime.NewRequest(tc.ctx, http.MethodPost, "https://graph.microsoft.com/beta/applications?$select=id")
+			if err != nil {
+				t.Fatal(err)
+			}
+			actual := requestLogFields(tc.ctx, req, tc.body)
+			if !reflect.DeepEqual(actual, tc.expected) {
+				t.Fatalf("expected %v, got %v", tc.expected, actual)
+			}
+		})
+	}
+}
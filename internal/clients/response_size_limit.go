@@ -0,0 +1,120 @@
+package clients
+
+import (
+	"crypto/tls"
+	"fmt"
+	"io"
+	"net"
+	"net/http"
+	"time"
+
+	"github.com/Azure/azure-sdk-for-go/sdk/azcore/policy"
+)
+
+// DefaultMaxResponseBytes is the default maximum size of a single response body, it's high enough to not affect normal use.
+const DefaultMaxResponseBytes int64 = 100 * 1024 * 1024
+
+// ResponseTooLargeError is returned when the body of a response exceeds the maximum allowed size.
+type ResponseTooLargeError struct {
+	Limit int64
+	Url   string
+}
+
+func (e *ResponseTooLargeError) Error() string {
+	return fmt.Sprintf("the response of %s exceeds the maximum size of %d bytes, please narrow down the request, e.g. with `$select` or `$top`, or increase the `max_response_bytes` provider attribute", e.Url, e.Limit)
+}
+
+// NonRetriable marks the error as not retriable, as the response would be as large when retried.
+func (e *ResponseTooLargeError) NonRetriable() {}
+
+// responseSizeLimitTransport is a transport which aborts reading a response when its body exceeds the limit,
+// so large responses are not fully loaded into memory.
+type responseSizeLimitTransport struct {
+	next  policy.Transporter
+	limit int64
+}
+
+// NewResponseSizeLimitTransport returns a transport which limits the size of the response bodies.
+// If next is nil, an HTTP client with the same settings as the default one of azcore is used.
+func NewResponseSizeLimitTransport(next policy.Transporter, limit int64) policy.Transporter {
+	if next == nil {
+		next = newDefaultHTTPClient()
+	}
+	if limit <= 0 {
+		limit = DefaultMaxResponseBytes
+	}
+	return &responseSizeLimitTransport{
+		next:  next,
+		limit: limit,
+	}
+}
+
+func (t *responseSizeLimitTransport) Do(req *http.Request) (*http.Response, error) {
+	resp, err := t.next.Do(req)
+	if err != nil || resp == nil || resp.Body == nil {
+		return resp, err
+	}
+	tooLargeErr := &ResponseTooLargeError{
+		Limit: t.limit,
+		Url:   req.URL.Redacted(),
+	}
+	if resp.ContentLength > t.limit {
+		_ = resp.Body.Close()
+		return nil, tooLargeErr
+	}
+	resp.Body = &limitedBody{
+		body:      resp.Body,
+		remaining: t.limit,
+		err:       tooLargeErr,
+	}
+	return resp, nil
+}
+
+// limitedBody returns an error once more than the allowed number of bytes have been read.
+type limitedBody struct {
+	body      io.ReadCloser
+	remaining int64
+	err       error
+}
+
+func (b *limitedBody) Read(p []byte) (int, error) {
+	if b.remaining < 0 {
+		return 0, b.err
+	}
+	// read one more byte than allowed to detect that the limit is exceeded
+	if int64(len(p)) > b.remaining+1 {
+		p = p[:b.remaining+1]
+	}
+	n, err := b.body.Read(p)
+	b.remaining -= int64(n)
+	if b.remaining < 0 {
+		return 0, b.err
+	}
+	return n, err
+}
+
+func (b *limitedBody) Close() error {
+	return b.body.Close()
+}
+
+func newDefaultHTTPClient() *http.Client {
+	return &http.Client{
+		Transport: &http.Transport{
+			Proxy: http.ProxyFromEnvironment,
+			DialContext: (&net.Dialer{
+				Timeout:   30 * time.Second,
+				KeepAlive: 30 * time.Second,
+			}).DialContext,
+			ForceAttemptHTTP2:     true,
+			MaxIdleConns:          100,
+			MaxIdleConnsPerHost:   10,
+			IdleConnTimeout:       90 * time.Second,
+			TLSHandshakeTimeout:   10 * time.Second,
+			ExpectContinueTimeout: 1 * time.Second,
+			TLSClientConfig: &tls.Config{
+				MinVersion:    tls.VersionTLS12,
+				Renegotiation: tls.RenegotiateFreelyAsClient,
+			},
+		},
+	}
+}
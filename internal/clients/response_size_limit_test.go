@@ -0,0 +1,96 @@
+package clients
+
+import (
+	"errors"
+	"io"
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/Azure/azure-sdk-for-go/sdk/azcore/policy"
+)
+
+type fakeTransport struct {
+	body          string
+	contentLength int64
+}
+
+func (t fakeTransport) Do(req *http.Request) (*http.Response, error) {
+	return &http.Response{
+		StatusCode:    http.StatusOK,
+		Body:          io.NopCloser(strings.NewReader(t.body)),
+		ContentLength: t.contentLength,
+		Request:       req,
+	}, nil
+}
+
+var _ policy.Transporter = fakeTransport{}
+
+func TestResponseSizeLimitTransport(t *testing.T) {
+	testcases := []struct {
+		name          string
+		body          string
+		contentLength int64
+		limit         int64
+		expectError   bool
+	}{
+		{
+			name:          "body within the limit",
+			body:          `{"id":"1"}`,
+			contentLength: 10,
+			limit:         10,
+			expectError:   false,
+		},
+		{
+			name:          "content length exceeds the limit",
+			body:          `{"id":"1"}`,
+			contentLength: 10,
+			limit:         5,
+			expectError:   true,
+		},
+		{
+			name:          "body without content length within the limit",
+			body:          `{"id":"1"}`,
+			contentLength: -1,
+			limit:         10,
+			expectError:   false,
+		},
+		{
+			name:          "body without content length exceeds the limit",
+			body:          `{"id":"1"}`,
+			contentLength: -1,
+			limit:         5,
+			expectError:   true,
+		},
+	}
+
+	for _, tc := range testcases {
+		t.Run(tc.name, func(t *testing.T) {
+			transport := NewResponseSizeLimitTransport(fakeTransport{body: tc.body, contentLength: tc.contentLength}, tc.limit)
+			req, err := http.NewRequest(http.MethodGet, "https://graph.microsoft.com/v1.0/groups", nil)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			resp, err := transport.Do(req)
+			var body []byte
+			if err == nil {
+				body, err = io.ReadAll(resp.Body)
+			}
+
+			var tooLargeErr *ResponseTooLargeError
+			if tc.expectError {
+				if !errors.As(err, &tooLargeErr) {
+					t.Fatalf("expected a ResponseTooLargeError, got %v", err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if string(body) != tc.body {
+				t.Fatalf("expected body %q, got %q", tc.body, string(body))
+			}
+		})
+	}
+}
@@ -0,0 +1,77 @@
+package clients
+
+import (
+	"context"
+	"net/http"
+	"sync"
+
+	"github.com/Azure/azure-sdk-for-go/sdk/azcore"
+	"github.com/Azure/azure-sdk-for-go/sdk/azcore/policy"
+	"github.com/Azure/azure-sdk-for-go/sdk/azcore/runtime"
+)
+
+type tenantIdContextKey struct{}
+
+// WithTenantId returns a context whose requests are authorized with a token issued by the tenant, instead of the
+// tenant configured in the provider. The tenant must be allowed by the `AdditionallyAllowedTenants` of the credential.
+func WithTenantId(ctx context.Context, tenantId string) context.Context {
+	if tenantId == "" {
+		return ctx
+	}
+	return context.WithValue(ctx, tenantIdContextKey{}, tenantId)
+}
+
+func tenantIdFromContext(ctx context.Context) string {
+	tenantId, _ := ctx.Value(tenantIdContextKey{}).(string)
+	return tenantId
+}
+
+// tenantBearerTokenPolicy authorizes the requests with the tokens of the tenant of their context. A bearer token
+// policy is kept for each tenant, so the tokens are cached per tenant.
+type tenantBearerTokenPolicy struct {
+	credential    azcore.TokenCredential
+	scopes        []string
+	defaultPolicy policy.Policy
+
+	mu       sync.Mutex
+	policies map[string]policy.Policy
+}
+
+func newTenantBearerTokenPolicy(credential azcore.TokenCredential, scopes []string) *tenantBearerTokenPolicy {
+	return &tenantBearerTokenPolicy{
+		credential:    credential,
+		scopes:        scopes,
+		defaultPolicy: runtime.NewBearerTokenPolicy(credential, scopes, nil),
+		policies:      make(map[string]policy.Policy),
+	}
+}
+
+func (p *tenantBearerTokenPolicy) Do(req *policy.Request) (*http.Response, error) {
+	tenantId := tenantIdFromContext(req.Raw().Context())
+	if tenantId == "" {
+		return p.defaultPolicy.Do(req)
+	}
+	return p.policyOf(tenantId).Do(req)
+}
+
+func (p *tenantBearerTokenPolicy) policyOf(tenantId string) policy.Policy {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	if pl, ok := p.policies[tenantId]; ok {
+		return pl
+	}
+	pl := runtime.NewBearerTokenPolicy(&tenantCredential{credential: p.credential, tenantId: tenantId}, p.scopes, nil)
+	p.policies[tenantId] = pl
+	return pl
+}
+
+// tenantCredential requests the tokens of the credential from the tenant.
+type tenantCredential struct {
+	credential azcore.TokenCredential
+	tenantId   string
+}
+
+func (c *tenantCredential) GetToken(ctx context.Context, options policy.TokenRequestOptions) (azcore.AccessToken, error) {
+	options.TenantID = c.tenantId
+	return c.credential.GetToken(ctx, options)
+}
@@ -0,0 +1,79 @@
+package clients
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/Azure/azure-sdk-for-go/sdk/azcore"
+	"github.com/Azure/azure-sdk-for-go/sdk/azcore/policy"
+	"github.com/Azure/azure-sdk-for-go/sdk/azcore/runtime"
+)
+
+// tenantTokenCredential issues a token named after the tenant it's requested from.
+type tenantTokenCredential struct {
+	calls int
+}
+
+func (c *tenantTokenCredential) GetToken(ctx context.Context, options policy.TokenRequestOptions) (azcore.AccessToken, error) {
+	c.calls++
+	tenantId := options.TenantID
+	if tenantId == "" {
+		tenantId = "default"
+	}
+	return azcore.AccessToken{Token: "token-" + tenantId, ExpiresOn: time.Now().Add(time.Hour)}, nil
+}
+
+// authorizationTransport records the authorization headers of the requests.
+type authorizationTransport struct {
+	authorizations []string
+}
+
+func (t *authorizationTransport) Do(req *http.Request) (*http.Response, error) {
+	t.authorizations = append(t.authorizations, req.Header.Get("Authorization"))
+	return &http.Response{StatusCode: http.StatusOK, Header: http.Header{}, Body: io.NopCloser(strings.NewReader(`{}`)), Request: req}, nil
+}
+
+func TestTenantBearerTokenPolicy(t *testing.T) {
+	credential := &tenantTokenCredential{}
+	transport := &authorizationTransport{}
+	pl := runtime.NewPipeline("test", "v0.0.1", runtime.PipelineOptions{
+		PerRetry: []policy.Policy{newTenantBearerTokenPolicy(credential, []string{"https://graph.microsoft.com/.default"})},
+	}, &policy.ClientOptions{
+		Transport: transport,
+	})
+
+	contexts := []context.Context{
+		context.Background(),
+		WithTenantId(context.Background(), "00000000-0000-0000-0000-000000000001"),
+		WithTenantId(context.Background(), "00000000-0000-0000-0000-000000000001"),
+		WithTenantId(context.Background(), ""),
+	}
+	for _, ctx := range contexts {
+		req, err := runtime.NewRequest(ctx, http.MethodGet, "https://graph.microsoft.com/v1.0/me")
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		resp, err := pl.Do(req)
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		_ = resp.Body.Close()
+	}
+
+	expected := []string{
+		"Bearer token-default",
+		"Bearer token-00000000-0000-0000-0000-000000000001",
+		"Bearer token-00000000-0000-0000-0000-000000000001",
+		"Bearer token-default",
+	}
+	if strings.Join(transport.authorizations, ",") != strings.Join(expected, ",") {
+		t.Fatalf("expected authorizations %v, got %v", expected, transport.authorizations)
+	}
+	if credential.calls != 2 {
+		t.Fatalf("expected the tokens to be cached per tenant with 2 token requests, got %d", credential.calls)
+	}
+}
@@ -0,0 +1,41 @@
+package clients
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/Azure/azure-sdk-for-go/sdk/azcore"
+	"github.com/Azure/azure-sdk-for-go/sdk/azcore/policy"
+)
+
+// tokenCredentialWithTimeout bounds the time spent on acquiring a token, so a slow or unreachable
+// identity provider fails fast instead of consuming the timeout of the whole operation.
+type tokenCredentialWithTimeout struct {
+	cred    azcore.TokenCredential
+	timeout time.Duration
+}
+
+// NewTokenCredentialWithTimeout returns a credential whose GetToken calls are cancelled after the timeout.
+// The credential is returned as is if the timeout is not positive.
+func NewTokenCredentialWithTimeout(cred azcore.TokenCredential, timeout time.Duration) azcore.TokenCredential {
+	if cred == nil || timeout <= 0 {
+		return cred
+	}
+	return &tokenCredentialWithTimeout{
+		cred:    cred,
+		timeout: timeout,
+	}
+}
+
+func (c *tokenCredentialWithTimeout) GetToken(ctx context.Context, options policy.TokenRequestOptions) (azcore.AccessToken, error) {
+	ctx, cancel := context.WithTimeout(ctx, c.timeout)
+	defer cancel()
+
+	token, err := c.cred.GetToken(ctx, options)
+	if err != nil && errors.Is(ctx.Err(), context.DeadlineExceeded) {
+		return token, fmt.Errorf("failed to acquire a token within the token acquisition timeout of %s, please check the connectivity to the identity provider or increase `token_acquisition_timeout`: %w", c.timeout, err)
+	}
+	return token, err
+}
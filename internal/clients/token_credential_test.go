@@ -0,0 +1,71 @@
+package clients
+
+import (
+	"context"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/Azure/azure-sdk-for-go/sdk/azcore"
+	"github.com/Azure/azure-sdk-for-go/sdk/azcore/policy"
+)
+
+type fakeTokenCredential struct {
+	delay time.Duration
+}
+
+func (c fakeTokenCredential) GetToken(ctx context.Context, options policy.TokenRequestOptions) (azcore.AccessToken, error) {
+	select {
+	case <-time.After(c.delay):
+		return azcore.AccessToken{Token: "token"}, nil
+	case <-ctx.Done():
+		return azcore.AccessToken{}, ctx.Err()
+	}
+}
+
+func TestNewTokenCredentialWithTimeout(t *testing.T) {
+	testcases := []struct {
+		name        string
+		delay       time.Duration
+		timeout     time.Duration
+		expectError bool
+	}{
+		{
+			name:        "token acquired within the timeout",
+			delay:       0,
+			timeout:     time.Second,
+			expectError: false,
+		},
+		{
+			name:        "token acquisition exceeds the timeout",
+			delay:       time.Second,
+			timeout:     10 * time.Millisecond,
+			expectError: true,
+		},
+		{
+			name:        "no timeout",
+			delay:       10 * time.Millisecond,
+			timeout:     0,
+			expectError: false,
+		},
+	}
+
+	for _, tc := range testcases {
+		t.Run(tc.name, func(t *testing.T) {
+			cred := NewTokenCredentialWithTimeout(fakeTokenCredential{delay: tc.delay}, tc.timeout)
+			token, err := cred.GetToken(context.Background(), policy.TokenRequestOptions{})
+			if tc.expectError {
+				if err == nil || !strings.Contains(err.Error(), "token acquisition timeout") {
+					t.Fatalf("expected a token acquisition timeout error, got %v", err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if token.Token != "token" {
+				t.Fatalf("expected token %q, got %q", "token", token.Token)
+			}
+		})
+	}
+}
@@ -0,0 +1,46 @@
+package clients
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+
+	"github.com/Azure/azure-sdk-for-go/sdk/azcore/policy"
+)
+
+// TryTimeoutError is returned when a try of a non-idempotent request, e.g. a POST creating an object, times out.
+// The request might have been processed, so it's not retried to avoid creating duplicates.
+type TryTimeoutError struct {
+	Method string
+	Url    string
+	Err    error
+}
+
+func (e *TryTimeoutError) Error() string {
+	return fmt.Sprintf("the %s request to %s timed out, it's not retried as it might have been processed: %v", e.Method, e.Url, e.Err)
+}
+
+func (e *TryTimeoutError) Unwrap() error {
+	return e.Err
+}
+
+// NonRetriable marks the error as not retriable, as sending the request again might create a duplicate.
+func (e *TryTimeoutError) NonRetriable() {}
+
+// tryTimeoutPolicy stops the retries of the non-idempotent requests whose try timed out, whether the retries are
+// decided by the default retry options of the SDK or by a custom ShouldRetry.
+type tryTimeoutPolicy struct{}
+
+func (tryTimeoutPolicy) Do(req *policy.Request) (*http.Response, error) {
+	resp, err := req.Next()
+	if err == nil || isIdempotentMethod(req.Raw().Method) {
+		return resp, err
+	}
+	if errors.Is(err, context.DeadlineExceeded) || errors.Is(req.Raw().Context().Err(), context.DeadlineExceeded) {
+		return resp, &TryTimeoutError{Method: req.Raw().Method, Url: req.Raw().URL.String(), Err: err}
+	}
+	return resp, err
+}
+
+var _ policy.Policy = tryTimeoutPolicy{}
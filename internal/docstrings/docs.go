@@ -3,7 +3,7 @@ package docstrings
 import "fmt"
 
 func ApiVersion() string {
-	return "The API version of the data source. The allowed values are `v1.0` and `beta`. Defaults to `v1.0`."
+	return "The API version of the data source. The allowed values are `v1.0`, `beta` and other versions in the `vX.Y` format, so the versions released in the future can be used without upgrading the provider. Only `v1.0` is supported for production use, the APIs in `beta` and the versions which aren't released may change or be removed without notice. Defaults to the `default_api_version` of the provider, which defaults to `v1.0`."
 }
 
 func Url(kind string) string {
@@ -74,14 +74,60 @@ func ResponseExportValues() string {
 	}
 	%[1]s%[1]s%[1]s
 
+The full JMESPath syntax is supported, including list projections, filters, pipes and functions, e.g. %[1]svalue[].displayName%[1]s, %[1]svalue[?accountEnabled].id%[1]s or %[1]slength(value)%[1]s. The result is set under its key even when it's a list. The items of all pages of a collection are in %[1]svalue%[1]s.
+
 To learn more about JMESPath, visit [JMESPath](https://jmespath.org/).
 `, "`")
 }
 
+func SensitiveOutputPathPatterns() string {
+	return "A list of regular expressions matched against the paths of the values in `output`, e.g. `(?i)(secretText|password|token)$`. The path of a value is the dot separated list of property names leading to it, starting with the key of `response_export_values`, e.g. `app.passwordCredentials.secretText`, and the items of arrays share the path of the array. The matched values are moved from `output` to `sensitive_output`, so a secret isn't exposed when it's exported by accident without being marked as sensitive."
+}
+
+func SensitiveOutput() string {
+	return "The sensitive HCL object containing the values of `output` whose paths match `sensitive_output_path_patterns`, at the same paths as in `output`. It's null if `sensitive_output_path_patterns` isn't specified."
+}
+
+func OutputFormat() string {
+	return "The format of `output`. The allowed values are `typed` and `json_string`. With `typed`, `output` is an object whose types are inferred from the JSON values of the response, e.g. numeric IDs become numbers. With `json_string`, `output` is a single JSON-encoded string which can be decoded with `jsondecode`, so the values keep the types of the response, which is useful when they're passed to other providers expecting strings. Defaults to `typed`."
+}
+
+func IgnoreCasing() string {
+	return "Whether ignore the casing of string values in `body` to suppress plan-diff, e.g. when GUIDs, user principal names or domain names are returned with a different casing than configured. Defaults to `false`."
+}
+
 func ResourceID() string {
 	return "The ID of the resource. Normally, it is in the format of UUID."
 }
 
 func IgnoreMissingProperty() string {
-	return "Whether ignore not returned properties like credentials in `body` to suppress plan-diff. Defaults to `true`. It's recommend to enable this option when some sensitive properties are not returned in response body, instead of setting them in `lifecycle.ignore_changes` because it will make the sensitive fields unable to update."
+	return "Whether ignore not returned properties like credentials in `body` to suppress plan-diff. Defaults to `true`. It's recommend to enable this option when some sensitive properties are not returned in response body, instead of setting them in `lifecycle.ignore_changes` because it will make the sensitive fields unable to update. Items of arrays keyed by `name` which are not configured, e.g. the default values of group settings, are ignored too."
+}
+
+func AcceptableErrorCodes(operations string) string {
+	return fmt.Sprintf("A list of error responses which are treated as success when returned by %s, e.g. a `404` when the object is already gone or a `400` with the Graph error code returned when the object is already in the desired state. Each item supports `status_code` and the optional `error_code`, which is the `error.code` of the Graph error response and matches any error code if not specified. **Use with care**: a matched error is silently ignored, so an actual failure with the same status code and error code would go unnoticed. Use the most specific `error_code` possible.", operations)
+}
+
+func CreateRetry() string {
+	return "Configures the retries of the whole create on transient errors, e.g. the `Request_MultipleObjectsWithSameKeyValue` or replication errors returned when creating service principals, which succeed when the object is created again. It supports `error_codes`, `max_attempts` and `interval_seconds`. Unlike `retry`, which retries the HTTP requests on throttling and the configured status codes and error messages, the create request is sent again only when it fails with one of the Graph error codes, and the created object is then waited for and read as usual. The retries always stop at the create timeout."
+}
+
+func Consistency() string {
+	return "Configures how the existence of the resource is checked after it's created, updated or deleted. Microsoft Graph is eventually consistent, so the provider checks the resource until 3 consecutive checks observe the change. It supports `poll_interval_seconds` and `max_attempts`. The checks always stop at the timeout of the operation."
+}
+
+func WriteOnlyBody() string {
+	return "An object of secret properties which are merged into `body` when the object is created, and when `write_only_body_version` is changed, e.g. the `passwordCredentials` of an application or the `passwordProfile` of a user. They're never reconciled with the response, so they don't appear in `body` or `output`, and they're masked in the logs. It's a write-only attribute, which requires Terraform 1.11 or later: the value is neither stored in the plan nor in the state, so ephemeral values can be used. As its changes can't be detected, change `write_only_body_version` to send the new values in the update request."
+}
+
+func RedactPlanPaths() string {
+	return "A list of paths of `body` whose values are masked as `(redacted)` wherever the provider renders them, e.g. `logo` or `keyCredentials.key`, without changing the request sent to Microsoft Graph. The paths are separated by dots, and the items of the arrays along the path are all masked. The values are masked in the diagnostics of the resource, e.g. an error of the API which echoes the value, and in the request and response bodies written to the logs, including the audit log of the provider. This keeps large or noisy values, e.g. base64 blobs, out of what the provider renders. The values configured in `body` are shown in the plan as written in the configuration, which a provider can't change, so wrap them with the `sensitive` function to hide them from the plan as well."
+}
+
+func TenantId(kind string) string {
+	return fmt.Sprintf("The ID of the tenant whose directory is managed by this %[1]s, instead of the tenant configured in the provider. The requests of this %[1]s are authorized with a token issued by the tenant, so the managed directory objects of several tenants can be configured with one provider. The tenant must be allowed in `auxiliary_tenant_ids` of the provider, and the application used to authenticate must be a multi-tenant application, i.e. its `signInAudience` is `AzureADMultipleOrgs`, whose service principal exists in the tenant with the consent granted by an administrator of the tenant, e.g. by visiting `https://login.microsoftonline.com/{tenant-id}/adminconsent?client_id={client-id}`. The Azure CLI and other developer credentials must be signed in to the tenant. Defaults to the tenant configured in the provider.", kind)
+}
+
+func AutoODataType() string {
+	return "Whether to add the `@odata.type` to the request body when it's omitted and can be inferred for a curated set of polymorphic endpoints: the named locations of conditional access, i.e. `#microsoft.graph.ipNamedLocation` with `ipRanges` and `#microsoft.graph.countryNamedLocation` with `countriesAndRegions`, the authentication method configurations of the authentication methods policy, e.g. `#microsoft.graph.fido2AuthenticationMethodConfiguration` for `Fido2`, and the authentication events flows. The `@odata.type` configured in `body` always takes precedence. Defaults to `false`."
 }
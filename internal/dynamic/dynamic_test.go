@@ -684,3 +684,66 @@ func TestFromJSONImplied(t *testing.T) {
 		})
 	}
 }
+
+func TestSemanticallyEqual(t *testing.T) {
+	cases := []struct {
+		name   string
+		a      string
+		b      string
+		expect bool
+	}{
+		{
+			name:   "same",
+			a:      `{"displayName": "a", "count": 1}`,
+			b:      `{"displayName": "a", "count": 1}`,
+			expect: true,
+		},
+		{
+			name:   "reordered keys",
+			a:      `{"displayName": "a", "nested": {"x": true, "y": "b"}}`,
+			b:      `{"nested": {"y": "b", "x": true}, "displayName": "a"}`,
+			expect: true,
+		},
+		{
+			name:   "number formatting",
+			a:      `{"count": 1, "ratio": 0.5}`,
+			b:      `{"count": 1.0, "ratio": 5e-1}`,
+			expect: true,
+		},
+		{
+			name:   "whitespace",
+			a:      `{"list": [1, 2, 3]}`,
+			b:      "{\n\t\"list\": [\n\t\t1,\n\t\t2,\n\t\t3\n\t]\n}",
+			expect: true,
+		},
+		{
+			name:   "different value",
+			a:      `{"count": 1}`,
+			b:      `{"count": 2}`,
+			expect: false,
+		},
+		{
+			name:   "different list order",
+			a:      `{"list": [1, 2]}`,
+			b:      `{"list": [2, 1]}`,
+			expect: false,
+		},
+		{
+			name:   "missing key",
+			a:      `{"displayName": "a", "count": 1}`,
+			b:      `{"displayName": "a"}`,
+			expect: false,
+		},
+	}
+
+	for _, tt := range cases {
+		t.Run(tt.name, func(t *testing.T) {
+			a, err := FromJSONImplied([]byte(tt.a))
+			require.NoError(t, err)
+			b, err := FromJSONImplied([]byte(tt.b))
+			require.NoError(t, err)
+			require.Equal(t, tt.expect, SemanticallyEqual(a, b))
+			require.Equal(t, tt.expect, SemanticallyEqual(b, a))
+		})
+	}
+}
@@ -0,0 +1,14 @@
+package locks
+
+// mutexKV is the instance of MutexKV shared by all the resources of the provider.
+var mutexKV = NewMutexKV()
+
+// ByName locks the name, the writes of the resources sharing the name are serialized.
+func ByName(name string) {
+	mutexKV.Lock(name)
+}
+
+// UnlockByName unlocks the name locked by ByName.
+func UnlockByName(name string) {
+	mutexKV.Unlock(name)
+}
@@ -0,0 +1,48 @@
+package locks
+
+import (
+	"log"
+	"strings"
+	"sync"
+)
+
+// MutexKV is a simple key/value store for arbitrary mutexes. It can be used to serialize changes across arbitrary
+// collaborators that share knowledge of the keys they must serialize on.
+type MutexKV struct {
+	lock  sync.Mutex
+	store map[string]*sync.Mutex
+}
+
+// Lock locks the mutex for the given key. The keys are case-insensitive, as the URLs of Microsoft Graph are.
+func (m *MutexKV) Lock(key string) {
+	log.Printf("[DEBUG] Locking %q", key)
+	m.get(key).Lock()
+	log.Printf("[DEBUG] Locked %q", key)
+}
+
+// Unlock unlocks the mutex for the given key. Caller must have called Lock for the same key first.
+func (m *MutexKV) Unlock(key string) {
+	log.Printf("[DEBUG] Unlocking %q", key)
+	m.get(key).Unlock()
+	log.Printf("[DEBUG] Unlocked %q", key)
+}
+
+// get returns a mutex for the given key, no guarantee of its lock status.
+func (m *MutexKV) get(key string) *sync.Mutex {
+	key = strings.ToLower(key)
+	m.lock.Lock()
+	defer m.lock.Unlock()
+	mutex, ok := m.store[key]
+	if !ok {
+		mutex = &sync.Mutex{}
+		m.store[key] = mutex
+	}
+	return mutex
+}
+
+// NewMutexKV returns a properly initialized MutexKV.
+func NewMutexKV() *MutexKV {
+	return &MutexKV{
+		store: make(map[string]*sync.Mutex),
+	}
+}
@@ -0,0 +1,47 @@
+package myvalidator
+
+import (
+	"context"
+	"fmt"
+	"regexp"
+
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+)
+
+var apiVersionRegex = regexp.MustCompile(`^(beta|v[0-9]+\.[0-9]+)$`)
+
+// IsApiVersion returns whether the value is a valid API version of Microsoft Graph, i.e. `beta` or a `vX.Y` version
+// like `v1.0`.
+func IsApiVersion(value string) bool {
+	return apiVersionRegex.MatchString(value)
+}
+
+type apiVersion struct{}
+
+func (v apiVersion) Description(ctx context.Context) string {
+	return "validates that the string is `beta` or a version in the `vX.Y` format, e.g. `v1.0`"
+}
+
+func (v apiVersion) MarkdownDescription(ctx context.Context) string {
+	return "validates that the string is `beta` or a version in the `vX.Y` format, e.g. `v1.0`"
+}
+
+func (apiVersion) ValidateString(ctx context.Context, req validator.StringRequest, resp *validator.StringResponse) {
+	str := req.ConfigValue
+
+	if str.IsUnknown() || str.IsNull() {
+		return
+	}
+
+	if !IsApiVersion(str.ValueString()) {
+		resp.Diagnostics.AddAttributeError(
+			req.Path,
+			"Invalid API version",
+			fmt.Sprintf("The API version must be `v1.0`, `beta` or another version in the `vX.Y` format, got %q", str.ValueString()),
+		)
+	}
+}
+
+func ApiVersion() validator.String {
+	return apiVersion{}
+}
@@ -0,0 +1,83 @@
+package myvalidator
+
+import (
+	"context"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types/basetypes"
+)
+
+func TestApiVersion_ValidateString(t *testing.T) {
+	testcases := []struct {
+		name        string
+		value       basetypes.StringValue
+		expectError bool
+	}{
+		{
+			name:        "v1.0",
+			value:       basetypes.NewStringValue("v1.0"),
+			expectError: false,
+		},
+		{
+			name:        "beta",
+			value:       basetypes.NewStringValue("beta"),
+			expectError: false,
+		},
+		{
+			name:        "future version",
+			value:       basetypes.NewStringValue("v2.1"),
+			expectError: false,
+		},
+		{
+			name:        "missing minor version",
+			value:       basetypes.NewStringValue("v2"),
+			expectError: true,
+		},
+		{
+			name:        "path",
+			value:       basetypes.NewStringValue("v1.0/users"),
+			expectError: true,
+		},
+		{
+			name:        "upper case",
+			value:       basetypes.NewStringValue("Beta"),
+			expectError: true,
+		},
+		{
+			name:        "empty",
+			value:       basetypes.NewStringValue(""),
+			expectError: true,
+		},
+		{
+			name:        "null value",
+			value:       basetypes.NewStringNull(),
+			expectError: false,
+		},
+		{
+			name:        "unknown value",
+			value:       basetypes.NewStringUnknown(),
+			expectError: false,
+		},
+	}
+
+	for _, tc := range testcases {
+		t.Run(tc.name, func(t *testing.T) {
+			req := validator.StringRequest{
+				ConfigValue: tc.value,
+				Path:        path.Empty(),
+			}
+			resp := &validator.StringResponse{
+				Diagnostics: diag.Diagnostics{},
+			}
+
+			ApiVersion().ValidateString(context.Background(), req, resp)
+
+			if resp.Diagnostics.HasError() != tc.expectError {
+				t.Errorf("expected error %v, got: %v", tc.expectError, resp.Diagnostics)
+			}
+		})
+	}
+}
@@ -0,0 +1,60 @@
+package myvalidator
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+)
+
+// Ensure interface compliance
+var _ validator.String = relativeURL{}
+
+// RelativeURL returns a validator that ensures a URL is a Microsoft Graph path relative to the API version,
+// e.g. `applications` or `groups/{id}/members/$ref`. Absolute URLs are rejected, and a leading slash is warned about.
+func RelativeURL() validator.String { return relativeURL{} }
+
+type relativeURL struct{}
+
+func (v relativeURL) Description(ctx context.Context) string {
+	return "Must be a Microsoft Graph path relative to the API version (e.g. applications or groups/{id}/members/$ref)."
+}
+
+func (v relativeURL) MarkdownDescription(ctx context.Context) string {
+	return v.Description(ctx)
+}
+
+func (v relativeURL) ValidateString(ctx context.Context, req validator.StringRequest, resp *validator.StringResponse) {
+	if req.ConfigValue.IsNull() || req.ConfigValue.IsUnknown() {
+		return
+	}
+
+	val := req.ConfigValue.ValueString()
+
+	if strings.HasPrefix(val, "http://") || strings.HasPrefix(val, "https://") {
+		resp.Diagnostics.Append(diag.NewAttributeErrorDiagnostic(
+			req.Path,
+			"Invalid URL",
+			fmt.Sprintf("URL must be relative to the API version (exclude https://graph.microsoft.com/v1.0), got %q. Provide only the path, e.g. 'applications' or 'groups/{id}/members/$ref', and set the API version with `api_version`.", val),
+		))
+		return
+	}
+
+	if strings.HasPrefix(val, "/") {
+		resp.Diagnostics.Append(diag.NewAttributeWarningDiagnostic(
+			req.Path,
+			"Leading slash in URL",
+			fmt.Sprintf("URL should not start with a leading slash, got %q. The path is relative to the API version, e.g. 'applications' or 'groups/{id}/members/$ref'. Remove the leading '/'.", val),
+		))
+	}
+
+	if strings.Contains(strings.TrimPrefix(val, "/"), "//") {
+		resp.Diagnostics.Append(diag.NewAttributeErrorDiagnostic(
+			req.Path,
+			"Invalid URL",
+			fmt.Sprintf("URL contains empty path segment (double '//'): %q.", val),
+		))
+	}
+}
@@ -0,0 +1,60 @@
+package myvalidator
+
+import (
+	"context"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types/basetypes"
+)
+
+func TestRelativeURL_ValidateString(t *testing.T) {
+	v := relativeURL{}
+
+	cases := []struct {
+		name      string
+		value     string
+		wantError bool
+		wantWarn  bool
+	}{
+		{"valid_collection", "applications", false, false},
+		{"valid_ref", "groups/123/members/$ref", false, false},
+		{"valid_function", "reports/getOffice365ActiveUserDetail(period='D7')", false, false},
+		{"leading_slash", "/applications", false, true},
+		{"absolute_url", "https://graph.microsoft.com/v1.0/applications", true, false},
+		{"absolute_http_url", "http://graph.microsoft.com/v1.0/applications", true, false},
+		{"double_slash", "groups//members", true, false},
+	}
+
+	for _, tc := range cases {
+		c := tc
+		t.Run(c.name, func(t *testing.T) {
+			req := validator.StringRequest{
+				ConfigValue: basetypes.NewStringValue(c.value),
+				Path:        path.Empty(),
+			}
+			resp := &validator.StringResponse{Diagnostics: diag.Diagnostics{}}
+			v.ValidateString(context.Background(), req, resp)
+
+			hasErr := resp.Diagnostics.HasError()
+			if hasErr != c.wantError {
+				t.Fatalf("error expectation mismatch: got error=%v want=%v diagnostics=%v", hasErr, c.wantError, resp.Diagnostics)
+			}
+
+			foundWarn := resp.Diagnostics.WarningsCount() > 0
+			if foundWarn != c.wantWarn {
+				t.Fatalf("warning expectation mismatch: got warn=%v want=%v diagnostics=%v", foundWarn, c.wantWarn, resp.Diagnostics)
+			}
+		})
+	}
+
+	t.Run("null", func(t *testing.T) {
+		resp := &validator.StringResponse{}
+		v.ValidateString(context.Background(), validator.StringRequest{ConfigValue: basetypes.NewStringNull(), Path: path.Empty()}, resp)
+		if len(resp.Diagnostics) != 0 {
+			t.Fatalf("expected no diagnostics for null, got %v", resp.Diagnostics)
+		}
+	})
+}
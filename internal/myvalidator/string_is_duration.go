@@ -0,0 +1,48 @@
+package myvalidator
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+)
+
+type stringIsDuration struct{}
+
+func (v stringIsDuration) Description(ctx context.Context) string {
+	return "validates that the string is a positive duration, e.g. `30s` or `2m`"
+}
+
+func (v stringIsDuration) MarkdownDescription(ctx context.Context) string {
+	return "validates that the string is a positive duration, e.g. `30s` or `2m`"
+}
+
+func (stringIsDuration) ValidateString(ctx context.Context, req validator.StringRequest, resp *validator.StringResponse) {
+	str := req.ConfigValue
+
+	if str.IsUnknown() || str.IsNull() {
+		return
+	}
+
+	duration, err := time.ParseDuration(str.ValueString())
+	if err != nil {
+		resp.Diagnostics.AddAttributeError(
+			req.Path,
+			"Invalid duration",
+			err.Error(),
+		)
+		return
+	}
+	if duration <= 0 {
+		resp.Diagnostics.AddAttributeError(
+			req.Path,
+			"Invalid duration",
+			fmt.Sprintf("The duration must be positive, got %s", str.ValueString()),
+		)
+	}
+}
+
+func StringIsDuration() validator.String {
+	return stringIsDuration{}
+}
@@ -0,0 +1,63 @@
+package myvalidator
+
+import (
+	"context"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types/basetypes"
+)
+
+func TestStringIsDuration_ValidateString(t *testing.T) {
+	testcases := []struct {
+		name        string
+		value       basetypes.StringValue
+		expectError bool
+	}{
+		{
+			name:        "valid duration",
+			value:       basetypes.NewStringValue("2m30s"),
+			expectError: false,
+		},
+		{
+			name:        "invalid duration",
+			value:       basetypes.NewStringValue("2 minutes"),
+			expectError: true,
+		},
+		{
+			name:        "zero duration",
+			value:       basetypes.NewStringValue("0s"),
+			expectError: true,
+		},
+		{
+			name:        "negative duration",
+			value:       basetypes.NewStringValue("-1m"),
+			expectError: true,
+		},
+		{
+			name:        "null value",
+			value:       basetypes.NewStringNull(),
+			expectError: false,
+		},
+	}
+
+	for _, tc := range testcases {
+		t.Run(tc.name, func(t *testing.T) {
+			req := validator.StringRequest{
+				ConfigValue: tc.value,
+				Path:        path.Empty(),
+			}
+			resp := &validator.StringResponse{
+				Diagnostics: diag.Diagnostics{},
+			}
+
+			StringIsDuration().ValidateString(context.Background(), req, resp)
+
+			if resp.Diagnostics.HasError() != tc.expectError {
+				t.Errorf("expected error %v, got: %v", tc.expectError, resp.Diagnostics)
+			}
+		})
+	}
+}
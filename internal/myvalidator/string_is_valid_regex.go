@@ -2,6 +2,7 @@ package myvalidator
 
 import (
 	"context"
+	"fmt"
 	"regexp"
 
 	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
@@ -29,7 +30,7 @@ func (stringIsValidRegex) ValidateString(ctx context.Context, req validator.Stri
 		resp.Diagnostics.AddAttributeError(
 			req.Path,
 			"Invalid regular expression",
-			err.Error(),
+			fmt.Sprintf("The pattern %q is not a valid Go regular expression: %s. See https://pkg.go.dev/regexp/syntax for the supported syntax, note that the backslashes must be escaped in HCL strings, e.g. \"\\\\d+\".", strval, err.Error()),
 		)
 	}
 }
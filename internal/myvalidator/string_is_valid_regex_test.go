@@ -2,6 +2,7 @@ package myvalidator
 
 import (
 	"context"
+	"strings"
 	"testing"
 
 	"github.com/hashicorp/terraform-plugin-framework/diag"
@@ -41,7 +42,48 @@ func TestStringIsValidRegex_ValidateString(t *testing.T) {
 		v.ValidateString(context.Background(), req, resp)
 
 		if !resp.Diagnostics.HasError() {
-			t.Errorf("Expected errors, but got none")
+			t.Fatalf("Expected errors, but got none")
+		}
+		if detail := resp.Diagnostics.Errors()[0].Detail(); !strings.Contains(detail, `"[a-zA-Z+$"`) {
+			t.Errorf("Expected the error to contain the pattern, but got: %s", detail)
+		}
+		if !resp.Diagnostics.Errors()[0].(diag.DiagnosticWithPath).Path().Equal(req.Path) {
+			t.Errorf("Expected the error to point at %s", req.Path)
+		}
+	})
+
+	t.Run("invalid regex in a list", func(t *testing.T) {
+		req := validator.StringRequest{
+			ConfigValue: basetypes.NewStringValue(`(?P<name>\d+`),
+			Path:        path.Root("retry").AtName("error_message_regex").AtListIndex(1),
+		}
+		resp := &validator.StringResponse{
+			Diagnostics: diag.Diagnostics{},
+		}
+
+		v.ValidateString(context.Background(), req, resp)
+
+		if !resp.Diagnostics.HasError() {
+			t.Fatalf("Expected errors, but got none")
+		}
+		if actual := resp.Diagnostics.Errors()[0].(diag.DiagnosticWithPath).Path().String(); actual != "retry.error_message_regex[1]" {
+			t.Errorf("Expected the error to point at retry.error_message_regex[1], but got: %s", actual)
+		}
+	})
+
+	t.Run("unknown value", func(t *testing.T) {
+		req := validator.StringRequest{
+			ConfigValue: basetypes.NewStringUnknown(),
+			Path:        path.Empty(),
+		}
+		resp := &validator.StringResponse{
+			Diagnostics: diag.Diagnostics{},
+		}
+
+		v.ValidateString(context.Background(), req, resp)
+
+		if resp.Diagnostics.HasError() {
+			t.Errorf("Expected no errors, but got: %v", resp.Diagnostics)
 		}
 	})
 }
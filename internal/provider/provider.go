@@ -6,13 +6,20 @@ import (
 	"fmt"
 	"log"
 	"os"
+	"regexp"
+	"strconv"
 	"strings"
+	"time"
 
 	"github.com/Azure/azure-sdk-for-go/sdk/azcore"
 	"github.com/Azure/azure-sdk-for-go/sdk/azcore/cloud"
 	"github.com/Azure/azure-sdk-for-go/sdk/azidentity"
+	"github.com/hashicorp/go-uuid"
+	"github.com/hashicorp/terraform-plugin-framework-validators/int64validator"
+	"github.com/hashicorp/terraform-plugin-framework-validators/listvalidator"
 	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
 	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/function"
 	"github.com/hashicorp/terraform-plugin-framework/provider"
 	"github.com/hashicorp/terraform-plugin-framework/provider/schema"
 	"github.com/hashicorp/terraform-plugin-framework/resource"
@@ -21,10 +28,12 @@ import (
 	"github.com/microsoft/terraform-provider-msgraph/internal/clients"
 	"github.com/microsoft/terraform-provider-msgraph/internal/myvalidator"
 	"github.com/microsoft/terraform-provider-msgraph/internal/services"
+	"github.com/microsoft/terraform-provider-msgraph/internal/services/functions"
 	"github.com/microsoft/terraform-provider-msgraph/version"
 )
 
 var _ provider.Provider = &MSGraphProvider{}
+var _ provider.ProviderWithFunctions = &MSGraphProvider{}
 
 type MSGraphProvider struct{}
 
@@ -37,6 +46,8 @@ type MSGraphProviderModel struct {
 	ClientCertificatePassword    types.String `tfsdk:"client_certificate_password"`
 	ClientSecret                 types.String `tfsdk:"client_secret"`
 	ClientSecretFilePath         types.String `tfsdk:"client_secret_file_path"`
+	ClientAssertion              types.String `tfsdk:"client_assertion"`
+	ClientAssertionFilePath      types.String `tfsdk:"client_assertion_file_path"`
 	OIDCRequestToken             types.String `tfsdk:"oidc_request_token"`
 	OIDCRequestURL               types.String `tfsdk:"oidc_request_url"`
 	OIDCToken                    types.String `tfsdk:"oidc_token"`
@@ -45,12 +56,31 @@ type MSGraphProviderModel struct {
 	UseOIDC                      types.Bool   `tfsdk:"use_oidc"`
 	UseCLI                       types.Bool   `tfsdk:"use_cli"`
 	UsePowerShell                types.Bool   `tfsdk:"use_powershell"`
+	UseAzdCLI                    types.Bool   `tfsdk:"use_azd_cli"`
+	UseEnvironmentCredential     types.Bool   `tfsdk:"use_environment_credential"`
+	UseInteractiveBrowser        types.Bool   `tfsdk:"use_interactive_browser"`
+	RedirectURL                  types.String `tfsdk:"redirect_url"`
 	UseMSI                       types.Bool   `tfsdk:"use_msi"`
 	UseAKSWorkloadIdentity       types.Bool   `tfsdk:"use_aks_workload_identity"`
 	PartnerID                    types.String `tfsdk:"partner_id"`
 	CustomCorrelationRequestID   types.String `tfsdk:"custom_correlation_request_id"`
 	DisableCorrelationRequestID  types.Bool   `tfsdk:"disable_correlation_request_id"`
 	DisableTerraformPartnerID    types.Bool   `tfsdk:"disable_terraform_partner_id"`
+	AppendUserAgent              types.String `tfsdk:"append_user_agent"`
+	MaxResponseBytes             types.Int64  `tfsdk:"max_response_bytes"`
+	TokenAcquisitionTimeout      types.String `tfsdk:"token_acquisition_timeout"`
+	RequestTimeoutSeconds        types.Int64  `tfsdk:"request_timeout_seconds"`
+	DefaultApiVersion            types.String `tfsdk:"default_api_version"`
+	DefaultReadTimeout           types.String `tfsdk:"default_read_timeout"`
+	EnableMetrics                types.Bool   `tfsdk:"enable_metrics"`
+	HTTPProxy                    types.String `tfsdk:"http_proxy"`
+	HTTPSProxy                   types.String `tfsdk:"https_proxy"`
+	NoProxy                      types.String `tfsdk:"no_proxy"`
+	CABundlePath                 types.String `tfsdk:"ca_bundle_path"`
+	AuditLogPath                 types.String `tfsdk:"audit_log_path"`
+	AuditLogRedactedKeys         types.List   `tfsdk:"audit_log_redacted_keys"`
+	ProtectedUrlPatterns         types.List   `tfsdk:"protected_url_patterns"`
+	AuxiliaryTenantIDs           types.List   `tfsdk:"auxiliary_tenant_ids"`
 }
 
 func New() func() provider.Provider {
@@ -111,6 +141,30 @@ func (model MSGraphProviderModel) GetClientSecret() (*string, error) {
 	return &clientSecret, nil
 }
 
+// GetClientAssertion returns the client assertion, the file is read every time so the assertions which are rotated
+// in it by the workload identity provider are picked up.
+func (model MSGraphProviderModel) GetClientAssertion() (string, error) {
+	clientAssertion := strings.TrimSpace(model.ClientAssertion.ValueString())
+
+	if path := model.ClientAssertionFilePath.ValueString(); path != "" {
+		// #nosec G304
+		fileAssertionRaw, err := os.ReadFile(path)
+		if err != nil {
+			return "", fmt.Errorf("reading Client Assertion from file %q: %v", path, err)
+		}
+
+		fileAssertion := strings.TrimSpace(string(fileAssertionRaw))
+
+		if clientAssertion != "" && clientAssertion != fileAssertion {
+			return "", fmt.Errorf("mismatch between supplied Client Assertion and supplied Client Assertion file contents - please either remove one or ensure they match")
+		}
+
+		clientAssertion = fileAssertion
+	}
+
+	return clientAssertion, nil
+}
+
 func (model MSGraphProviderModel) GetOIDCTokenFilePath() string {
 	if !model.OIDCTokenFilePath.IsNull() && model.OIDCTokenFilePath.ValueString() != "" {
 		return model.OIDCTokenFilePath.ValueString()
@@ -146,6 +200,15 @@ func (p *MSGraphProvider) Schema(ctx context.Context, req provider.SchemaRequest
 				MarkdownDescription: "The Tenant ID should be used. This can also be sourced from the `ARM_TENANT_ID` Environment Variable.",
 			},
 
+			"auxiliary_tenant_ids": schema.ListAttribute{
+				ElementType:         types.StringType,
+				Optional:            true,
+				MarkdownDescription: "The IDs of the tenants, other than `tenant_id`, which the credential is allowed to acquire tokens for, e.g. the tenants managed with the `tenant_id` of the resources. Use `*` to allow any tenant. This can also be sourced from the `ARM_AUXILIARY_TENANT_IDS` environment variable, whose IDs are separated by semicolons.",
+				Validators: []validator.List{
+					listvalidator.ValueStringsAre(stringvalidator.Any(myvalidator.StringIsUUID(), stringvalidator.OneOf("*"))),
+				},
+			},
+
 			// Client Certificate specific fields
 			"client_certificate_path": schema.StringAttribute{
 				Optional:            true,
@@ -173,6 +236,18 @@ func (p *MSGraphProvider) Schema(ctx context.Context, req provider.SchemaRequest
 				MarkdownDescription: "The path to a file containing the Client Secret which should be used. For use When authenticating as a Service Principal using a Client Secret. This can also be sourced from the `ARM_CLIENT_SECRET_FILE_PATH` Environment Variable.",
 			},
 
+			// Client Assertion specific fields
+			"client_assertion": schema.StringAttribute{
+				Optional:            true,
+				Sensitive:           true,
+				MarkdownDescription: "A JWT signed by a workload identity provider, e.g. the ID token of a GitLab or Bitbucket pipeline, which is exchanged for an access token of the Service Principal trusting it with a federated identity credential. This can also be sourced from the `ARM_CLIENT_ASSERTION` Environment Variable.",
+			},
+
+			"client_assertion_file_path": schema.StringAttribute{
+				Optional:            true,
+				MarkdownDescription: "The path to a file containing the JWT used as `client_assertion`. The file is read every time an access token is requested, so the JWT can be rotated. This can also be sourced from the `ARM_CLIENT_ASSERTION_FILE_PATH` Environment Variable.",
+			},
+
 			// OIDC specific fields
 			"oidc_request_token": schema.StringAttribute{
 				Optional:            true,
@@ -216,6 +291,29 @@ func (p *MSGraphProvider) Schema(ctx context.Context, req provider.SchemaRequest
 				MarkdownDescription: "Should Azure PowerShell be used for authentication? This can also be sourced from the `ARM_USE_POWERSHELL` environment variable. Defaults to `false`.",
 			},
 
+			// Azure Developer CLI specific fields
+			"use_azd_cli": schema.BoolAttribute{
+				Optional:            true,
+				MarkdownDescription: "Should Azure Developer CLI (`azd`) be used for authentication? This can also be sourced from the `ARM_USE_AZD_CLI` environment variable. Defaults to `false`.",
+			},
+
+			// Environment credential specific fields
+			"use_environment_credential": schema.BoolAttribute{
+				Optional:            true,
+				MarkdownDescription: "Should the credential configured with the `AZURE_TENANT_ID`, `AZURE_CLIENT_ID`, `AZURE_CLIENT_SECRET`, `AZURE_CLIENT_CERTIFICATE_PATH`, `AZURE_USERNAME` and other `AZURE_*` environment variables be used for authentication? This can also be sourced from the `ARM_USE_ENVIRONMENT_CREDENTIAL` environment variable. Defaults to `false`.",
+			},
+
+			// Interactive browser specific fields
+			"use_interactive_browser": schema.BoolAttribute{
+				Optional:            true,
+				MarkdownDescription: "Should a browser be opened to sign in interactively, as a last resort when no other credential can be used? This can also be sourced from the `ARM_USE_INTERACTIVE_BROWSER` environment variable. Defaults to `false`. It's intended for local development, don't enable it in automation as it waits for the sign in.",
+			},
+
+			"redirect_url": schema.StringAttribute{
+				Optional:            true,
+				MarkdownDescription: "The redirect URL of the application used to sign in with `use_interactive_browser`. This can also be sourced from the `ARM_REDIRECT_URL` environment variable. When set, the application specified by `client_id` is used to sign in and the URL must match one of its redirect URIs, otherwise the Azure development sign on application is used with `http://localhost`.",
+			},
+
 			// Managed Service Identity specific fields
 			"use_msi": schema.BoolAttribute{
 				Optional:            true,
@@ -250,6 +348,96 @@ func (p *MSGraphProvider) Schema(ctx context.Context, req provider.SchemaRequest
 				Optional:            true,
 				MarkdownDescription: "Disable sending the Terraform Partner ID if a custom `partner_id` isn't specified, which allows Microsoft to better understand the usage of Terraform. The Partner ID does not give HashiCorp any direct access to usage information. This can also be sourced from the `ARM_DISABLE_TERRAFORM_PARTNER_ID` environment variable. Defaults to `false`.",
 			},
+
+			"append_user_agent": schema.StringAttribute{
+				Optional:            true,
+				MarkdownDescription: "A value which is appended to the `User-Agent` header of the requests, e.g. an identifier of the team, so the requests can be correlated in your own logs. It's independent of `partner_id` and `disable_terraform_partner_id`. This can also be sourced from the `ARM_APPEND_USER_AGENT` environment variable.",
+			},
+
+			"max_response_bytes": schema.Int64Attribute{
+				Optional: true,
+				Validators: []validator.Int64{
+					int64validator.AtLeast(1),
+				},
+				MarkdownDescription: "The maximum size in bytes of a single response from the Microsoft Graph API. Reading a larger response is aborted with an error, which protects against exhausting the memory, e.g. when expanding the members of a large group. Defaults to `104857600` (100 MiB).",
+			},
+
+			"token_acquisition_timeout": schema.StringAttribute{
+				Optional: true,
+				Validators: []validator.String{
+					myvalidator.StringIsDuration(),
+				},
+				MarkdownDescription: "The maximum time to wait for acquiring an access token, e.g. `2m`, separately from the timeouts of the operations. This allows failing fast with a clear error when the identity provider is slow or unreachable. This can also be sourced from the `ARM_TOKEN_ACQUISITION_TIMEOUT` environment variable. If not specified, the token acquisition is only bounded by the timeout of the operation.",
+			},
+
+			"request_timeout_seconds": schema.Int64Attribute{
+				Optional: true,
+				Validators: []validator.Int64{
+					int64validator.AtLeast(1),
+				},
+				MarkdownDescription: "The maximum time in seconds to wait for a single HTTP request to Microsoft Graph, independently of the `timeouts` of the operations, which include the retries and the waits for the objects to be replicated. A request which doesn't complete in time is cancelled and retried, and each retry has its own deadline. The `POST` requests, e.g. the ones creating objects or adding credentials, are not retried after they time out, as they might have been processed. This can also be sourced from the `ARM_MSGRAPH_REQUEST_TIMEOUT_SECONDS` environment variable. If not specified, the requests are only bounded by the timeouts of the operations.",
+			},
+
+			"default_api_version": schema.StringAttribute{
+				Optional: true,
+				Validators: []validator.String{
+					myvalidator.ApiVersion(),
+				},
+				MarkdownDescription: "The API version of Microsoft Graph used by the resources and data sources which don't specify `api_version`. The allowed values are `v1.0`, `beta` and other versions in the `vX.Y` format. This can also be sourced from the `ARM_MSGRAPH_API_VERSION` environment variable. Defaults to `v1.0`.",
+			},
+
+			"default_read_timeout": schema.StringAttribute{
+				Optional: true,
+				Validators: []validator.String{
+					myvalidator.StringIsDuration(),
+				},
+				MarkdownDescription: "The read timeout of the resources and data sources whose `timeouts` block doesn't specify `read`, e.g. `15m`. It covers waiting for the eventual consistency and reading the object, so it can be raised for high-latency or throttled environments without configuring the `timeouts` block of every resource. This can also be sourced from the `ARM_MSGRAPH_DEFAULT_READ_TIMEOUT` environment variable. Defaults to `5m`.",
+			},
+
+			"enable_metrics": schema.BoolAttribute{
+				Optional:            true,
+				MarkdownDescription: "Whether to log the method, path, status, duration and number of retries of each call to Microsoft Graph, and a summary of the calls grouped by method and path at the end of each operation of the resources, at the `INFO` level, e.g. with `TF_LOG=INFO`. This helps to find the endpoints which dominate the apply time, and whether throttling retries are the bottleneck. This can also be sourced from the `ARM_MSGRAPH_ENABLE_METRICS` environment variable. Defaults to `false`.",
+			},
+
+			"http_proxy": schema.StringAttribute{
+				Optional:            true,
+				MarkdownDescription: "The URL of the proxy used for the HTTP requests, e.g. `http://proxy.contoso.com:8080`. This can also be sourced from the `HTTP_PROXY` or `http_proxy` environment variables.",
+			},
+
+			"https_proxy": schema.StringAttribute{
+				Optional:            true,
+				MarkdownDescription: "The URL of the proxy used for the HTTPS requests, i.e. the requests to Microsoft Graph and the identity provider, e.g. `http://proxy.contoso.com:8080`. This can also be sourced from the `HTTPS_PROXY` or `https_proxy` environment variables.",
+			},
+
+			"no_proxy": schema.StringAttribute{
+				Optional:            true,
+				MarkdownDescription: "A comma separated list of the hosts, domains and IP ranges which are not proxied, e.g. `localhost,.contoso.com,10.0.0.0/8`. This can also be sourced from the `NO_PROXY` or `no_proxy` environment variables.",
+			},
+
+			"ca_bundle_path": schema.StringAttribute{
+				Optional:            true,
+				MarkdownDescription: "The path to a PEM file of the certificate authorities which are trusted in addition to the ones of the system, e.g. the one of a TLS-inspecting proxy. This can also be sourced from the `ARM_CA_BUNDLE_PATH` environment variable.",
+			},
+
+			"audit_log_path": schema.StringAttribute{
+				Optional:            true,
+				MarkdownDescription: "The path to a file which a JSON line is appended to for each attempt of the mutating requests sent to Microsoft Graph, i.e. the ones which aren't `GET` or `HEAD`, including the retries. The line contains the `timestamp`, `method`, `url`, `statusCode`, `requestId`, the redacted request `body` and the redacted `responseBody`. This can also be sourced from the `ARM_MSGRAPH_AUDIT_LOG_PATH` environment variable.",
+			},
+
+			"audit_log_redacted_keys": schema.ListAttribute{
+				ElementType:         types.StringType,
+				Optional:            true,
+				MarkdownDescription: "The names of the properties of the request and response bodies whose values are masked as `(redacted)` in the audit log at any depth, compared case-insensitively. The properties named like credentials, i.e. whose names contain `password`, `secret` or `token`, e.g. `newPassword` or `clientSecret`, are always masked regardless of this list. The paths of `redact_plan_paths` of the resources are masked too. Defaults to `[\"password\", \"secretText\", \"secret\", \"clientSecret\", \"key\", \"privateKey\", \"token\", \"accessToken\", \"refreshToken\"]`.",
+			},
+
+			"protected_url_patterns": schema.ListAttribute{
+				ElementType: types.StringType,
+				Optional:    true,
+				Validators: []validator.List{
+					listvalidator.ValueStringsAre(myvalidator.StringIsValidRegex()),
+				},
+				MarkdownDescription: "A list of regular expressions of the URLs of the objects which must not be deleted by `msgraph_resource`, e.g. `[\"^identity/conditionalAccess/policies/\"]`. The URL of the deleted object, relative to the API version and without a leading slash, e.g. `identity/conditionalAccess/policies/{policy-id}` or `groups/{group-id}/members/{member-id}/$ref`, is matched against the patterns, and the deletion fails with an error if any of them matches. This is a safety net in addition to the `prevent_destroy` lifecycle argument of Terraform. The protection can be overridden by setting the `ARM_MSGRAPH_ALLOW_PROTECTED_DELETION` environment variable to `true`.",
+			},
 		},
 	}
 }
@@ -324,6 +512,18 @@ func (p *MSGraphProvider) Configure(ctx context.Context, req provider.ConfigureR
 		}
 	}
 
+	if model.ClientAssertion.IsNull() {
+		if v := os.Getenv("ARM_CLIENT_ASSERTION"); v != "" {
+			model.ClientAssertion = types.StringValue(v)
+		}
+	}
+
+	if model.ClientAssertionFilePath.IsNull() {
+		if v := os.Getenv("ARM_CLIENT_ASSERTION_FILE_PATH"); v != "" {
+			model.ClientAssertionFilePath = types.StringValue(v)
+		}
+	}
+
 	if model.OIDCRequestToken.IsNull() {
 		if v := os.Getenv("ARM_OIDC_REQUEST_TOKEN"); v != "" {
 			model.OIDCRequestToken = types.StringValue(v)
@@ -382,6 +582,36 @@ func (p *MSGraphProvider) Configure(ctx context.Context, req provider.ConfigureR
 		}
 	}
 
+	if model.UseAzdCLI.IsNull() {
+		if v := os.Getenv("ARM_USE_AZD_CLI"); v != "" {
+			model.UseAzdCLI = types.BoolValue(v == "true")
+		} else {
+			model.UseAzdCLI = types.BoolValue(false)
+		}
+	}
+
+	if model.UseEnvironmentCredential.IsNull() {
+		if v := os.Getenv("ARM_USE_ENVIRONMENT_CREDENTIAL"); v != "" {
+			model.UseEnvironmentCredential = types.BoolValue(v == "true")
+		} else {
+			model.UseEnvironmentCredential = types.BoolValue(false)
+		}
+	}
+
+	if model.UseInteractiveBrowser.IsNull() {
+		if v := os.Getenv("ARM_USE_INTERACTIVE_BROWSER"); v != "" {
+			model.UseInteractiveBrowser = types.BoolValue(v == "true")
+		} else {
+			model.UseInteractiveBrowser = types.BoolValue(false)
+		}
+	}
+
+	if model.RedirectURL.IsNull() {
+		if v := os.Getenv("ARM_REDIRECT_URL"); v != "" {
+			model.RedirectURL = types.StringValue(v)
+		}
+	}
+
 	if model.UseMSI.IsNull() {
 		if v := os.Getenv("ARM_USE_MSI"); v != "" {
 			model.UseMSI = types.BoolValue(v == "true")
@@ -418,8 +648,157 @@ func (p *MSGraphProvider) Configure(ctx context.Context, req provider.ConfigureR
 		}
 	}
 
+	if model.AppendUserAgent.IsNull() {
+		if v := os.Getenv("ARM_APPEND_USER_AGENT"); v != "" {
+			model.AppendUserAgent = types.StringValue(v)
+		}
+	}
+
+	if model.EnableMetrics.IsNull() {
+		if v := os.Getenv("ARM_MSGRAPH_ENABLE_METRICS"); v != "" {
+			model.EnableMetrics = types.BoolValue(v == "true")
+		} else {
+			model.EnableMetrics = types.BoolValue(false)
+		}
+	}
+
+	if model.TokenAcquisitionTimeout.IsNull() {
+		if v := os.Getenv("ARM_TOKEN_ACQUISITION_TIMEOUT"); v != "" {
+			model.TokenAcquisitionTimeout = types.StringValue(v)
+		}
+	}
+
+	var tokenAcquisitionTimeout time.Duration
+	if v := model.TokenAcquisitionTimeout.ValueString(); v != "" {
+		timeout, err := time.ParseDuration(v)
+		if err != nil || timeout <= 0 {
+			resp.Diagnostics.AddError("Invalid token acquisition timeout", fmt.Sprintf("The token acquisition timeout must be a positive duration, e.g. `2m`, got %q", v))
+			return
+		}
+		tokenAcquisitionTimeout = timeout
+	}
+
+	if model.RequestTimeoutSeconds.IsNull() {
+		if v := os.Getenv("ARM_MSGRAPH_REQUEST_TIMEOUT_SECONDS"); v != "" {
+			seconds, err := strconv.ParseInt(v, 10, 64)
+			if err != nil || seconds <= 0 {
+				resp.Diagnostics.AddError("Invalid request timeout", fmt.Sprintf("The request timeout must be a positive number of seconds, got %q from the ARM_MSGRAPH_REQUEST_TIMEOUT_SECONDS environment variable", v))
+				return
+			}
+			model.RequestTimeoutSeconds = types.Int64Value(seconds)
+		}
+	}
+
+	if model.DefaultReadTimeout.IsNull() {
+		if v := os.Getenv("ARM_MSGRAPH_DEFAULT_READ_TIMEOUT"); v != "" {
+			model.DefaultReadTimeout = types.StringValue(v)
+		}
+	}
+
+	var defaultReadTimeout time.Duration
+	if v := model.DefaultReadTimeout.ValueString(); v != "" {
+		timeout, err := time.ParseDuration(v)
+		if err != nil || timeout <= 0 {
+			resp.Diagnostics.AddError("Invalid default read timeout", fmt.Sprintf("The default read timeout must be a positive duration, e.g. `15m`, got %q", v))
+			return
+		}
+		defaultReadTimeout = timeout
+	}
+
+	if model.DefaultApiVersion.IsNull() {
+		if v := os.Getenv("ARM_MSGRAPH_API_VERSION"); v != "" {
+			model.DefaultApiVersion = types.StringValue(v)
+		}
+	}
+
+	if v := model.DefaultApiVersion.ValueString(); v != "" && !myvalidator.IsApiVersion(v) {
+		resp.Diagnostics.AddError("Invalid default API version", fmt.Sprintf("The default API version must be `v1.0`, `beta` or another version in the `vX.Y` format, got %q", v))
+		return
+	}
+
+	if model.HTTPProxy.IsNull() {
+		model.HTTPProxy = types.StringValue(getEnvAny("HTTP_PROXY", "http_proxy"))
+	}
+
+	if model.HTTPSProxy.IsNull() {
+		model.HTTPSProxy = types.StringValue(getEnvAny("HTTPS_PROXY", "https_proxy"))
+	}
+
+	if model.NoProxy.IsNull() {
+		model.NoProxy = types.StringValue(getEnvAny("NO_PROXY", "no_proxy"))
+	}
+
+	if model.CABundlePath.IsNull() {
+		if v := os.Getenv("ARM_CA_BUNDLE_PATH"); v != "" {
+			model.CABundlePath = types.StringValue(v)
+		}
+	}
+
+	if model.AuditLogPath.IsNull() {
+		if v := os.Getenv("ARM_MSGRAPH_AUDIT_LOG_PATH"); v != "" {
+			model.AuditLogPath = types.StringValue(v)
+		}
+	}
+
+	auditLogRedactedKeys := clients.DefaultAuditLogRedactedKeys
+	if !model.AuditLogRedactedKeys.IsNull() {
+		auditLogRedactedKeys = make([]string, 0)
+		if resp.Diagnostics.Append(model.AuditLogRedactedKeys.ElementsAs(ctx, &auditLogRedactedKeys, false)...); resp.Diagnostics.HasError() {
+			return
+		}
+	}
+
+	patterns := make([]string, 0)
+	if !model.ProtectedUrlPatterns.IsNull() {
+		if resp.Diagnostics.Append(model.ProtectedUrlPatterns.ElementsAs(ctx, &patterns, false)...); resp.Diagnostics.HasError() {
+			return
+		}
+	}
+	protectedUrlPatterns := make([]*regexp.Regexp, 0, len(patterns))
+	for _, pattern := range patterns {
+		protectedUrlPattern, err := regexp.Compile(pattern)
+		if err != nil {
+			resp.Diagnostics.AddError("Invalid protected URL pattern", fmt.Sprintf("The pattern %q is not a valid regular expression: %s", pattern, err.Error()))
+			return
+		}
+		protectedUrlPatterns = append(protectedUrlPatterns, protectedUrlPattern)
+	}
+
+	// The same HTTP client is used to call Microsoft Graph and to acquire the access tokens, so both go through the proxy.
+	httpClient, err := clients.NewHTTPClient(clients.HTTPClientOptions{
+		HTTPProxy:    model.HTTPProxy.ValueString(),
+		HTTPSProxy:   model.HTTPSProxy.ValueString(),
+		NoProxy:      model.NoProxy.ValueString(),
+		CABundlePath: model.CABundlePath.ValueString(),
+	})
+	if err != nil {
+		resp.Diagnostics.AddError("Invalid HTTP client configuration", err.Error())
+		return
+	}
+
+	auxiliaryTenantIds := make([]string, 0)
+	if model.AuxiliaryTenantIDs.IsNull() {
+		if v := os.Getenv("ARM_AUXILIARY_TENANT_IDS"); v != "" {
+			if auxiliaryTenantIds, err = ParseAuxiliaryTenantIds(v); err != nil {
+				resp.Diagnostics.AddError("Invalid `ARM_AUXILIARY_TENANT_IDS` value", err.Error())
+				return
+			}
+		}
+	} else if resp.Diagnostics.Append(model.AuxiliaryTenantIDs.ElementsAs(ctx, &auxiliaryTenantIds, false)...); resp.Diagnostics.HasError() {
+		return
+	}
+
 	option := azidentity.DefaultAzureCredentialOptions{
-		TenantID: model.TenantID.ValueString(),
+		TenantID:                   model.TenantID.ValueString(),
+		AdditionallyAllowedTenants: auxiliaryTenantIds,
+	}
+	option.ClientOptions.Transport = httpClient
+
+	// The conflicting methods used to be silently accepted, so they're reported as a warning for now, and will be
+	// reported as an error in a future release.
+	if attributes := conflictingAuthenticationAttributes(model); len(attributes) > 1 {
+		resp.Diagnostics.AddWarning("Conflicting authentication methods",
+			fmt.Sprintf("%s are specified, but only one of them can be used to authenticate, so the first credential of the chain which can be initialized is used. Please remove all but one of them from the provider configuration or unset the corresponding `ARM_*` environment variables, as this will be an error in a future release.", strings.Join(attributes, ", ")))
 	}
 
 	cred, err := BuildChainedTokenCredential(model, option)
@@ -430,11 +809,22 @@ func (p *MSGraphProvider) Configure(ctx context.Context, req provider.ConfigureR
 
 	copt := &clients.Option{
 		Cred:                        cred,
-		ApplicationUserAgent:        buildUserAgent(req.TerraformVersion, model.PartnerID.ValueString(), model.DisableTerraformPartnerID.ValueBool()),
+		ApplicationUserAgent:        buildUserAgent(req.TerraformVersion, model.PartnerID.ValueString(), model.DisableTerraformPartnerID.ValueBool(), model.AppendUserAgent.ValueString()),
 		DisableCorrelationRequestID: model.DisableCorrelationRequestID.ValueBool(),
 		CustomCorrelationRequestID:  model.CustomCorrelationRequestID.ValueString(),
 		CloudCfg:                    cloud.Configuration{},
 		TenantId:                    model.TenantID.ValueString(),
+		MaxResponseBytes:            model.MaxResponseBytes.ValueInt64(),
+		TokenAcquisitionTimeout:     tokenAcquisitionTimeout,
+		RequestTimeout:              time.Duration(model.RequestTimeoutSeconds.ValueInt64()) * time.Second,
+		DefaultApiVersion:           model.DefaultApiVersion.ValueString(),
+		DefaultReadTimeout:          defaultReadTimeout,
+		EnableMetrics:               model.EnableMetrics.ValueBool(),
+		Transport:                   httpClient,
+		AuditLogPath:                model.AuditLogPath.ValueString(),
+		AuditLogRedactedKeys:        auditLogRedactedKeys,
+		ProtectedUrlPatterns:        protectedUrlPatterns,
+		AllowProtectedDeletion:      os.Getenv("ARM_MSGRAPH_ALLOW_PROTECTED_DELETION") == "true",
 	}
 	client := &clients.Client{}
 	if err = client.Build(ctx, copt); err != nil {
@@ -452,6 +842,8 @@ func (p *MSGraphProvider) Resources(ctx context.Context) []func() resource.Resou
 		services.NewMSGraphResourceAction,
 		services.NewMSGraphUpdateResource,
 		services.NewMSGraphResourceCollection,
+		services.NewMSGraphResourceDelta,
+		services.NewMSGraphPasswordCredential,
 	}
 }
 
@@ -459,10 +851,19 @@ func (p *MSGraphProvider) DataSources(ctx context.Context) []func() datasource.D
 	return []func() datasource.DataSource{
 		services.NewMSGraphDataSource,
 		services.NewMSGraphResourceActionDataSource,
+		services.NewMSGraphResourceListDataSource,
+		services.NewMSGraphReportDataSource,
+		services.NewMSGraphDirectoryObjectDataSource,
+	}
+}
+
+func (p *MSGraphProvider) Functions(ctx context.Context) []func() function.Function {
+	return []func() function.Function{
+		functions.NewPfxBase64Function,
 	}
 }
 
-func buildUserAgent(terraformVersion string, partnerID string, disableTerraformPartnerID bool) string {
+func buildUserAgent(terraformVersion string, partnerID string, disableTerraformPartnerID bool, appendUserAgent string) string {
 	if terraformVersion == "" {
 		// Terraform 0.12 introduced this field to the protocol
 		// We can therefore assume that if it's missing it's 0.10 or 0.11
@@ -488,9 +889,66 @@ func buildUserAgent(terraformVersion string, partnerID string, disableTerraformP
 	if partnerID != "" {
 		userAgent = fmt.Sprintf("%s pid-%s", userAgent, partnerID)
 	}
+
+	// the custom suffix is appended regardless of the partner ID
+	if appendUserAgent = strings.TrimSpace(appendUserAgent); appendUserAgent != "" {
+		userAgent = fmt.Sprintf("%s %s", userAgent, appendUserAgent)
+	}
 	return userAgent
 }
 
+// getEnvAny returns the value of the first environment variable which is set, e.g. `HTTP_PROXY` or `http_proxy`.
+func getEnvAny(names ...string) string {
+	for _, name := range names {
+		if v := os.Getenv(name); v != "" {
+			return v
+		}
+	}
+	return ""
+}
+
+// ParseAuxiliaryTenantIds parses the tenant IDs separated by semicolons, each of them must be a UUID or `*`.
+func ParseAuxiliaryTenantIds(input string) ([]string, error) {
+	tenantIds := make([]string, 0)
+	for _, v := range strings.Split(input, ";") {
+		v = strings.TrimSpace(v)
+		if v == "" {
+			continue
+		}
+		if _, err := uuid.ParseUUID(v); err != nil && v != "*" {
+			return nil, fmt.Errorf("the auxiliary tenant ID %q must be a UUID or `*`", v)
+		}
+		tenantIds = append(tenantIds, v)
+	}
+	return tenantIds, nil
+}
+
+// conflictingAuthenticationAttributes returns the attributes of the explicitly configured authentication methods, i.e.
+// the client secret, client certificate, client assertion, OIDC and managed identity, one per method. The methods
+// which are tried by default, e.g. the Azure CLI, don't conflict with them.
+func conflictingAuthenticationAttributes(model MSGraphProviderModel) []string {
+	methods := [][]struct {
+		name string
+		set  bool
+	}{
+		{{"client_secret", model.ClientSecret.ValueString() != ""}, {"client_secret_file_path", model.ClientSecretFilePath.ValueString() != ""}},
+		{{"client_certificate", model.ClientCertificate.ValueString() != ""}, {"client_certificate_path", model.ClientCertificatePath.ValueString() != ""}},
+		{{"client_assertion", model.ClientAssertion.ValueString() != ""}, {"client_assertion_file_path", model.ClientAssertionFilePath.ValueString() != ""}},
+		{{"use_oidc", model.UseOIDC.ValueBool()}, {"use_aks_workload_identity", model.UseAKSWorkloadIdentity.ValueBool()}},
+		{{"use_msi", model.UseMSI.ValueBool()}},
+	}
+	attributes := make([]string, 0)
+	for _, method := range methods {
+		for _, attribute := range method {
+			if attribute.set {
+				attributes = append(attributes, fmt.Sprintf("`%s`", attribute.name))
+				break
+			}
+		}
+	}
+	return attributes
+}
+
 func BuildChainedTokenCredential(model MSGraphProviderModel, options azidentity.DefaultAzureCredentialOptions) (*azidentity.ChainedTokenCredential, error) {
 	log.Printf("[DEBUG] building chained token credential")
 	var creds []azcore.TokenCredential
@@ -511,6 +969,12 @@ func BuildChainedTokenCredential(model MSGraphProviderModel, options azidentity.
 		}
 	}
 
+	if cred, err := buildClientAssertionCredential(model, options); err == nil {
+		creds = append(creds, cred)
+	} else {
+		log.Printf("[DEBUG] failed to initialize client assertion credential: %v", err)
+	}
+
 	if cred, err := buildClientSecretCredential(model, options); err == nil {
 		creds = append(creds, cred)
 	} else {
@@ -523,6 +987,15 @@ func BuildChainedTokenCredential(model MSGraphProviderModel, options azidentity.
 		log.Printf("[DEBUG] failed to initialize client certificate credential: %v", err)
 	}
 
+	if model.UseEnvironmentCredential.ValueBool() {
+		log.Printf("[DEBUG] environment credential enabled")
+		if cred, err := buildEnvironmentCredential(options); err == nil {
+			creds = append(creds, cred)
+		} else {
+			log.Printf("[DEBUG] failed to initialize environment credential: %v", err)
+		}
+	}
+
 	if model.UseMSI.ValueBool() {
 		log.Printf("[DEBUG] msi credential enabled")
 		if cred, err := buildManagedIdentityCredential(model, options); err == nil {
@@ -550,6 +1023,25 @@ func BuildChainedTokenCredential(model MSGraphProviderModel, options azidentity.
 		}
 	}
 
+	if model.UseAzdCLI.ValueBool() {
+		log.Printf("[DEBUG] azd cli credential enabled")
+		if cred, err := buildAzureDeveloperCLICredential(options); err == nil {
+			creds = append(creds, cred)
+		} else {
+			log.Printf("[DEBUG] failed to initialize azd cli credential: %v", err)
+		}
+	}
+
+	// The interactive browser credential is the last one, so it's only prompted when no other credential succeeds.
+	if model.UseInteractiveBrowser.ValueBool() {
+		log.Printf("[DEBUG] interactive browser credential enabled")
+		if cred, err := buildInteractiveBrowserCredential(model, options); err == nil {
+			creds = append(creds, cred)
+		} else {
+			log.Printf("[DEBUG] failed to initialize interactive browser credential: %v", err)
+		}
+	}
+
 	if len(creds) == 0 {
 		return nil, fmt.Errorf("no credentials were successfully initialized")
 	}
@@ -557,6 +1049,26 @@ func BuildChainedTokenCredential(model MSGraphProviderModel, options azidentity.
 	return azidentity.NewChainedTokenCredential(creds, nil)
 }
 
+func buildClientAssertionCredential(model MSGraphProviderModel, options azidentity.DefaultAzureCredentialOptions) (azcore.TokenCredential, error) {
+	log.Printf("[DEBUG] building client assertion credential")
+	if model.ClientAssertion.ValueString() == "" && model.ClientAssertionFilePath.ValueString() == "" {
+		return nil, fmt.Errorf("missing required client assertion configuration")
+	}
+	clientID, err := model.GetClientId()
+	if err != nil {
+		return nil, err
+	}
+	getAssertion := func(ctx context.Context) (string, error) {
+		return model.GetClientAssertion()
+	}
+	o := &azidentity.ClientAssertionCredentialOptions{
+		AdditionallyAllowedTenants: options.AdditionallyAllowedTenants,
+		ClientOptions:              options.ClientOptions,
+		DisableInstanceDiscovery:   options.DisableInstanceDiscovery,
+	}
+	return azidentity.NewClientAssertionCredential(options.TenantID, *clientID, getAssertion, o)
+}
+
 func buildClientSecretCredential(model MSGraphProviderModel, options azidentity.DefaultAzureCredentialOptions) (azcore.TokenCredential, error) {
 	log.Printf("[DEBUG] building client secret credential")
 	clientID, err := model.GetClientId()
@@ -630,7 +1142,8 @@ func buildOidcCredential(model MSGraphProviderModel, options azidentity.DefaultA
 	}
 	o := &OidcCredentialOptions{
 		ClientOptions: azcore.ClientOptions{
-			Cloud: options.Cloud,
+			Cloud:     options.Cloud,
+			Transport: options.Transport,
 		},
 		AdditionallyAllowedTenants: options.AdditionallyAllowedTenants,
 		TenantID:                   options.TenantID,
@@ -674,6 +1187,47 @@ func buildAzurePowerShellCredential(options azidentity.DefaultAzureCredentialOpt
 	return azidentity.NewAzurePowerShellCredential(o)
 }
 
+func buildAzureDeveloperCLICredential(options azidentity.DefaultAzureCredentialOptions) (azcore.TokenCredential, error) {
+	log.Printf("[DEBUG] building azure developer cli credential")
+	o := &azidentity.AzureDeveloperCLICredentialOptions{
+		AdditionallyAllowedTenants: options.AdditionallyAllowedTenants,
+		TenantID:                   options.TenantID,
+	}
+	return azidentity.NewAzureDeveloperCLICredential(o)
+}
+
+func buildEnvironmentCredential(options azidentity.DefaultAzureCredentialOptions) (azcore.TokenCredential, error) {
+	log.Printf("[DEBUG] building environment credential")
+	o := &azidentity.EnvironmentCredentialOptions{
+		ClientOptions:            options.ClientOptions,
+		DisableInstanceDiscovery: options.DisableInstanceDiscovery,
+	}
+	return azidentity.NewEnvironmentCredential(o)
+}
+
+func buildInteractiveBrowserCredential(model MSGraphProviderModel, options azidentity.DefaultAzureCredentialOptions) (azcore.TokenCredential, error) {
+	log.Printf("[DEBUG] building interactive browser credential")
+	o := &azidentity.InteractiveBrowserCredentialOptions{
+		ClientOptions:              options.ClientOptions,
+		AdditionallyAllowedTenants: options.AdditionallyAllowedTenants,
+		DisableInstanceDiscovery:   options.DisableInstanceDiscovery,
+		TenantID:                   options.TenantID,
+	}
+	// The redirect URL must be registered in the application, so it's only used with the configured client ID.
+	if redirectURL := model.RedirectURL.ValueString(); redirectURL != "" {
+		clientId, err := model.GetClientId()
+		if err != nil {
+			return nil, err
+		}
+		if *clientId == "" {
+			return nil, fmt.Errorf("client_id is required when redirect_url is specified")
+		}
+		o.ClientID = *clientId
+		o.RedirectURL = redirectURL
+	}
+	return azidentity.NewInteractiveBrowserCredential(o)
+}
+
 func buildAzurePipelinesCredential(model MSGraphProviderModel, options azidentity.DefaultAzureCredentialOptions) (azcore.TokenCredential, error) {
 	log.Printf("[DEBUG] building azure pipeline credential")
 	o := &azidentity.AzurePipelinesCredentialOptions{
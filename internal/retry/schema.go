@@ -3,7 +3,10 @@ package retry
 import (
 	"context"
 
+	"github.com/hashicorp/terraform-plugin-framework-validators/float64validator"
+	"github.com/hashicorp/terraform-plugin-framework-validators/int64validator"
 	"github.com/hashicorp/terraform-plugin-framework-validators/listvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/path"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
 	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
 	"github.com/hashicorp/terraform-plugin-framework/types"
@@ -15,15 +18,70 @@ func Schema(ctx context.Context) schema.Attribute {
 		Attributes: map[string]schema.Attribute{
 			"error_message_regex": schema.ListAttribute{
 				ElementType:         types.StringType,
-				Required:            true,
+				Optional:            true,
 				Description:         "A list of regular expressions to match against error messages. If any of the regular expressions match, the request will be retried.",
 				MarkdownDescription: "A list of regular expressions to match against error messages. If any of the regular expressions match, the request will be retried.",
 				Validators: []validator.List{
 					listvalidator.ValueStringsAre(myvalidator.StringIsValidRegex()),
 					listvalidator.UniqueValues(),
 					listvalidator.SizeAtLeast(1),
+					listvalidator.AtLeastOneOf(
+						path.MatchRelative().AtParent().AtName("status_codes"),
+						path.MatchRelative().AtParent().AtName("interval_seconds"),
+						path.MatchRelative().AtParent().AtName("max_interval_seconds"),
+						path.MatchRelative().AtParent().AtName("multiplier"),
+						path.MatchRelative().AtParent().AtName("randomization_factor"),
+					),
 				},
 			},
+			"status_codes": schema.ListAttribute{
+				ElementType:         types.Int64Type,
+				Optional:            true,
+				Description:         "A list of HTTP status codes. If the response has any of the status codes, the request will be retried. If both `error_message_regex` and `status_codes` are specified, the request will be retried when either of them matches.",
+				MarkdownDescription: "A list of HTTP status codes, e.g. `[429, 502, 503, 504]`. If the response has any of the status codes, the request will be retried. If both `error_message_regex` and `status_codes` are specified, the request will be retried when either of them matches.",
+				Validators: []validator.List{
+					listvalidator.ValueInt64sAre(int64validator.Between(100, 599)),
+					listvalidator.UniqueValues(),
+					listvalidator.SizeAtLeast(1),
+				},
+			},
+			"interval_seconds": schema.Int64Attribute{
+				Optional:            true,
+				Description:         "The delay in seconds before the first retry. The backoff options are only used when at least one of them is specified, otherwise the default backoff is used, which starts at 0.8 seconds and roughly doubles the delay on each retry. Defaults to `1` when other backoff options are specified.",
+				MarkdownDescription: "The delay in seconds before the first retry. The backoff options are only used when at least one of them is specified, otherwise the default backoff is used, which starts at 0.8 seconds and roughly doubles the delay on each retry. Defaults to `1` when other backoff options are specified.",
+				Validators: []validator.Int64{
+					int64validator.AtLeast(1),
+				},
+			},
+			"max_interval_seconds": schema.Int64Attribute{
+				Optional:            true,
+				Description:         "The maximum delay in seconds between two retries. Defaults to no limit, the request is retried until the timeout is reached.",
+				MarkdownDescription: "The maximum delay in seconds between two retries. Defaults to no limit, the request is retried until the timeout is reached.",
+				Validators: []validator.Int64{
+					int64validator.AtLeast(1),
+				},
+			},
+			"multiplier": schema.Float64Attribute{
+				Optional:            true,
+				Description:         "The factor by which the delay is multiplied after each retry. Defaults to `2` when other backoff options are specified.",
+				MarkdownDescription: "The factor by which the delay is multiplied after each retry. Defaults to `2` when other backoff options are specified.",
+				Validators: []validator.Float64{
+					float64validator.AtLeast(1),
+				},
+			},
+			"randomization_factor": schema.Float64Attribute{
+				Optional:            true,
+				Description:         "The jitter applied to each delay, e.g. `0.25` randomizes the delay between 75% and 125% of its value, so concurrent requests don't retry in lockstep. Must be between `0` and `1`. Defaults to `0.25` when other backoff options are specified.",
+				MarkdownDescription: "The jitter applied to each delay, e.g. `0.25` randomizes the delay between 75% and 125% of its value, so concurrent requests don't retry in lockstep. Must be between `0` and `1`. Defaults to `0.25` when other backoff options are specified.",
+				Validators: []validator.Float64{
+					float64validator.Between(0, 1),
+				},
+			},
+			"idempotent_only": schema.BoolAttribute{
+				Optional:            true,
+				Description:         "Whether the non-idempotent requests, i.e. POST, are only retried when it's safe to do so, which avoids creating duplicates. When enabled, a POST request is only retried when it's throttled with a 429 status code or when the connection fails before the request is sent, the other status codes and error messages only retry the idempotent requests, i.e. GET, PUT, PATCH and DELETE. Defaults to `false`.",
+				MarkdownDescription: "Whether the non-idempotent requests, i.e. `POST`, are only retried when it's safe to do so, which avoids creating duplicates. When enabled, a `POST` request is only retried when it's throttled with a `429` status code or when the connection fails before the request is sent, the other status codes and error messages only retry the idempotent requests, i.e. `GET`, `PUT`, `PATCH` and `DELETE`. Defaults to `false`.",
+			},
 		},
 		CustomType: Type{
 			ObjectType: types.ObjectType{
@@ -32,6 +90,6 @@ func Schema(ctx context.Context) schema.Attribute {
 		},
 		Optional:            true,
 		Description:         "The retry object supports the following attributes:",
-		MarkdownDescription: "The retry object supports the following attributes:",
+		MarkdownDescription: "The retry object supports the following attributes. The `Retry-After` header of throttled responses is honored, the request is not retried if the requested delay exceeds the timeout.",
 	}
 }
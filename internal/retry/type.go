@@ -52,12 +52,126 @@ func (t Type) ValueFromObject(ctx context.Context, in basetypes.ObjectValue) (ba
 			fmt.Sprintf(`error_message_regex expected to be basetypes.ListValue, was: %T`, errorMessageRegexAttribute))
 	}
 
+	statusCodesAttribute, ok := attributes["status_codes"]
+
+	if !ok {
+		diags.AddError(
+			"Attribute Missing",
+			`status_codes is missing from object`)
+
+		return nil, diags
+	}
+
+	statusCodesVal, ok := statusCodesAttribute.(basetypes.ListValue)
+
+	if !ok {
+		diags.AddError(
+			"Attribute Wrong Type",
+			fmt.Sprintf(`status_codes expected to be basetypes.ListValue, was: %T`, statusCodesAttribute))
+	}
+
+	intervalSecondsAttribute, ok := attributes["interval_seconds"]
+
+	if !ok {
+		diags.AddError(
+			"Attribute Missing",
+			`interval_seconds is missing from object`)
+
+		return nil, diags
+	}
+
+	intervalSecondsVal, ok := intervalSecondsAttribute.(basetypes.Int64Value)
+
+	if !ok {
+		diags.AddError(
+			"Attribute Wrong Type",
+			fmt.Sprintf(`interval_seconds expected to be basetypes.Int64Value, was: %T`, intervalSecondsAttribute))
+	}
+
+	maxIntervalSecondsAttribute, ok := attributes["max_interval_seconds"]
+
+	if !ok {
+		diags.AddError(
+			"Attribute Missing",
+			`max_interval_seconds is missing from object`)
+
+		return nil, diags
+	}
+
+	maxIntervalSecondsVal, ok := maxIntervalSecondsAttribute.(basetypes.Int64Value)
+
+	if !ok {
+		diags.AddError(
+			"Attribute Wrong Type",
+			fmt.Sprintf(`max_interval_seconds expected to be basetypes.Int64Value, was: %T`, maxIntervalSecondsAttribute))
+	}
+
+	multiplierAttribute, ok := attributes["multiplier"]
+
+	if !ok {
+		diags.AddError(
+			"Attribute Missing",
+			`multiplier is missing from object`)
+
+		return nil, diags
+	}
+
+	multiplierVal, ok := multiplierAttribute.(basetypes.Float64Value)
+
+	if !ok {
+		diags.AddError(
+			"Attribute Wrong Type",
+			fmt.Sprintf(`multiplier expected to be basetypes.Float64Value, was: %T`, multiplierAttribute))
+	}
+
+	randomizationFactorAttribute, ok := attributes["randomization_factor"]
+
+	if !ok {
+		diags.AddError(
+			"Attribute Missing",
+			`randomization_factor is missing from object`)
+
+		return nil, diags
+	}
+
+	randomizationFactorVal, ok := randomizationFactorAttribute.(basetypes.Float64Value)
+
+	if !ok {
+		diags.AddError(
+			"Attribute Wrong Type",
+			fmt.Sprintf(`randomization_factor expected to be basetypes.Float64Value, was: %T`, randomizationFactorAttribute))
+	}
+
+	idempotentOnlyAttribute, ok := attributes["idempotent_only"]
+
+	if !ok {
+		diags.AddError(
+			"Attribute Missing",
+			`idempotent_only is missing from object`)
+
+		return nil, diags
+	}
+
+	idempotentOnlyVal, ok := idempotentOnlyAttribute.(basetypes.BoolValue)
+
+	if !ok {
+		diags.AddError(
+			"Attribute Wrong Type",
+			fmt.Sprintf(`idempotent_only expected to be basetypes.BoolValue, was: %T`, idempotentOnlyAttribute))
+	}
+
 	if diags.HasError() {
 		return nil, diags
 	}
 
 	return Value{
-		ErrorMessageRegex: errorMessageRegexVal,
-		state:             attr.ValueStateKnown,
+		ErrorMessageRegex:   errorMessageRegexVal,
+		StatusCodes:         statusCodesVal,
+		IntervalSeconds:     intervalSecondsVal,
+		MaxIntervalSeconds:  maxIntervalSecondsVal,
+		Multiplier:          multiplierVal,
+		RandomizationFactor: randomizationFactorVal,
+		IdempotentOnly:      idempotentOnlyVal,
+		state:               attr.ValueStateKnown,
 	}, diags
 }
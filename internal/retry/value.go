@@ -96,13 +96,127 @@ func NewValue(attributeTypes map[string]attr.Type, attributes map[string]attr.Va
 			fmt.Sprintf(`error_message_regex expected to be basetypes.ListValue, was: %T`, errorMessageRegexAttribute))
 	}
 
+	statusCodesAttribute, ok := attributes["status_codes"]
+
+	if !ok {
+		diags.AddError(
+			"Attribute Missing",
+			`status_codes is missing from object`)
+
+		return NewValueUnknown(), diags
+	}
+
+	statusCodesVal, ok := statusCodesAttribute.(basetypes.ListValue)
+
+	if !ok {
+		diags.AddError(
+			"Attribute Wrong Type",
+			fmt.Sprintf(`status_codes expected to be basetypes.ListValue, was: %T`, statusCodesAttribute))
+	}
+
+	intervalSecondsAttribute, ok := attributes["interval_seconds"]
+
+	if !ok {
+		diags.AddError(
+			"Attribute Missing",
+			`interval_seconds is missing from object`)
+
+		return NewValueUnknown(), diags
+	}
+
+	intervalSecondsVal, ok := intervalSecondsAttribute.(basetypes.Int64Value)
+
+	if !ok {
+		diags.AddError(
+			"Attribute Wrong Type",
+			fmt.Sprintf(`interval_seconds expected to be basetypes.Int64Value, was: %T`, intervalSecondsAttribute))
+	}
+
+	maxIntervalSecondsAttribute, ok := attributes["max_interval_seconds"]
+
+	if !ok {
+		diags.AddError(
+			"Attribute Missing",
+			`max_interval_seconds is missing from object`)
+
+		return NewValueUnknown(), diags
+	}
+
+	maxIntervalSecondsVal, ok := maxIntervalSecondsAttribute.(basetypes.Int64Value)
+
+	if !ok {
+		diags.AddError(
+			"Attribute Wrong Type",
+			fmt.Sprintf(`max_interval_seconds expected to be basetypes.Int64Value, was: %T`, maxIntervalSecondsAttribute))
+	}
+
+	multiplierAttribute, ok := attributes["multiplier"]
+
+	if !ok {
+		diags.AddError(
+			"Attribute Missing",
+			`multiplier is missing from object`)
+
+		return NewValueUnknown(), diags
+	}
+
+	multiplierVal, ok := multiplierAttribute.(basetypes.Float64Value)
+
+	if !ok {
+		diags.AddError(
+			"Attribute Wrong Type",
+			fmt.Sprintf(`multiplier expected to be basetypes.Float64Value, was: %T`, multiplierAttribute))
+	}
+
+	randomizationFactorAttribute, ok := attributes["randomization_factor"]
+
+	if !ok {
+		diags.AddError(
+			"Attribute Missing",
+			`randomization_factor is missing from object`)
+
+		return NewValueUnknown(), diags
+	}
+
+	randomizationFactorVal, ok := randomizationFactorAttribute.(basetypes.Float64Value)
+
+	if !ok {
+		diags.AddError(
+			"Attribute Wrong Type",
+			fmt.Sprintf(`randomization_factor expected to be basetypes.Float64Value, was: %T`, randomizationFactorAttribute))
+	}
+
+	idempotentOnlyAttribute, ok := attributes["idempotent_only"]
+
+	if !ok {
+		diags.AddError(
+			"Attribute Missing",
+			`idempotent_only is missing from object`)
+
+		return NewValueUnknown(), diags
+	}
+
+	idempotentOnlyVal, ok := idempotentOnlyAttribute.(basetypes.BoolValue)
+
+	if !ok {
+		diags.AddError(
+			"Attribute Wrong Type",
+			fmt.Sprintf(`idempotent_only expected to be basetypes.BoolValue, was: %T`, idempotentOnlyAttribute))
+	}
+
 	if diags.HasError() {
 		return NewValueUnknown(), diags
 	}
 
 	return Value{
-		ErrorMessageRegex: errorMessageRegexVal,
-		state:             attr.ValueStateKnown,
+		ErrorMessageRegex:   errorMessageRegexVal,
+		StatusCodes:         statusCodesVal,
+		IntervalSeconds:     intervalSecondsVal,
+		MaxIntervalSeconds:  maxIntervalSecondsVal,
+		Multiplier:          multiplierVal,
+		RandomizationFactor: randomizationFactorVal,
+		IdempotentOnly:      idempotentOnlyVal,
+		state:               attr.ValueStateKnown,
 	}, diags
 }
 
@@ -172,12 +286,18 @@ func (t Type) ValueType(ctx context.Context) attr.Value {
 var _ basetypes.ObjectValuable = Value{}
 
 type Value struct {
-	ErrorMessageRegex basetypes.ListValue `tfsdk:"error_message_regex"`
-	state             attr.ValueState
+	ErrorMessageRegex   basetypes.ListValue    `tfsdk:"error_message_regex"`
+	StatusCodes         basetypes.ListValue    `tfsdk:"status_codes"`
+	IntervalSeconds     basetypes.Int64Value   `tfsdk:"interval_seconds"`
+	MaxIntervalSeconds  basetypes.Int64Value   `tfsdk:"max_interval_seconds"`
+	Multiplier          basetypes.Float64Value `tfsdk:"multiplier"`
+	RandomizationFactor basetypes.Float64Value `tfsdk:"randomization_factor"`
+	IdempotentOnly      basetypes.BoolValue    `tfsdk:"idempotent_only"`
+	state               attr.ValueState
 }
 
 func (v Value) ToTerraformValue(ctx context.Context) (tftypes.Value, error) {
-	attrTypes := make(map[string]tftypes.Type, 5)
+	attrTypes := make(map[string]tftypes.Type, 7)
 
 	var val tftypes.Value
 	var err error
@@ -185,12 +305,20 @@ func (v Value) ToTerraformValue(ctx context.Context) (tftypes.Value, error) {
 	attrTypes["error_message_regex"] = basetypes.ListType{
 		ElemType: types.StringType,
 	}.TerraformType(ctx)
+	attrTypes["status_codes"] = basetypes.ListType{
+		ElemType: types.Int64Type,
+	}.TerraformType(ctx)
+	attrTypes["interval_seconds"] = basetypes.Int64Type{}.TerraformType(ctx)
+	attrTypes["max_interval_seconds"] = basetypes.Int64Type{}.TerraformType(ctx)
+	attrTypes["multiplier"] = basetypes.Float64Type{}.TerraformType(ctx)
+	attrTypes["randomization_factor"] = basetypes.Float64Type{}.TerraformType(ctx)
+	attrTypes["idempotent_only"] = basetypes.BoolType{}.TerraformType(ctx)
 
 	objectType := tftypes.Object{AttributeTypes: attrTypes}
 
 	switch v.state {
 	case attr.ValueStateKnown:
-		vals := make(map[string]tftypes.Value, 5)
+		vals := make(map[string]tftypes.Value, 7)
 
 		val, err = v.ErrorMessageRegex.ToTerraformValue(ctx)
 		if err != nil {
@@ -199,6 +327,48 @@ func (v Value) ToTerraformValue(ctx context.Context) (tftypes.Value, error) {
 
 		vals["error_message_regex"] = val
 
+		val, err = v.StatusCodes.ToTerraformValue(ctx)
+		if err != nil {
+			return tftypes.NewValue(objectType, tftypes.UnknownValue), err
+		}
+
+		vals["status_codes"] = val
+
+		val, err = v.IntervalSeconds.ToTerraformValue(ctx)
+		if err != nil {
+			return tftypes.NewValue(objectType, tftypes.UnknownValue), err
+		}
+
+		vals["interval_seconds"] = val
+
+		val, err = v.MaxIntervalSeconds.ToTerraformValue(ctx)
+		if err != nil {
+			return tftypes.NewValue(objectType, tftypes.UnknownValue), err
+		}
+
+		vals["max_interval_seconds"] = val
+
+		val, err = v.Multiplier.ToTerraformValue(ctx)
+		if err != nil {
+			return tftypes.NewValue(objectType, tftypes.UnknownValue), err
+		}
+
+		vals["multiplier"] = val
+
+		val, err = v.RandomizationFactor.ToTerraformValue(ctx)
+		if err != nil {
+			return tftypes.NewValue(objectType, tftypes.UnknownValue), err
+		}
+
+		vals["randomization_factor"] = val
+
+		val, err = v.IdempotentOnly.ToTerraformValue(ctx)
+		if err != nil {
+			return tftypes.NewValue(objectType, tftypes.UnknownValue), err
+		}
+
+		vals["idempotent_only"] = val
+
 		if err := tftypes.ValidateValue(objectType, vals); err != nil {
 			return tftypes.NewValue(objectType, tftypes.UnknownValue), err
 		}
@@ -240,18 +410,34 @@ func (v Value) ToObjectValue(ctx context.Context) (basetypes.ObjectValue, diag.D
 		diags.Append(d...)
 	}
 
-	if diags.HasError() {
-		return types.ObjectUnknown(map[string]attr.Type{
-			"error_message_regex": basetypes.ListType{
-				ElemType: types.StringType,
-			},
-		}), diags
+	var statusCodesVal basetypes.ListValue
+	switch {
+	case v.StatusCodes.IsUnknown():
+		statusCodesVal = types.ListUnknown(types.Int64Type)
+	case v.StatusCodes.IsNull():
+		statusCodesVal = types.ListNull(types.Int64Type)
+	default:
+		var d diag.Diagnostics
+		statusCodesVal, d = types.ListValue(types.Int64Type, v.StatusCodes.Elements())
+		diags.Append(d...)
 	}
 
 	attributeTypes := map[string]attr.Type{
 		"error_message_regex": basetypes.ListType{
 			ElemType: types.StringType,
 		},
+		"status_codes": basetypes.ListType{
+			ElemType: types.Int64Type,
+		},
+		"interval_seconds":     basetypes.Int64Type{},
+		"max_interval_seconds": basetypes.Int64Type{},
+		"multiplier":           basetypes.Float64Type{},
+		"randomization_factor": basetypes.Float64Type{},
+		"idempotent_only":      basetypes.BoolType{},
+	}
+
+	if diags.HasError() {
+		return types.ObjectUnknown(attributeTypes), diags
 	}
 
 	if v.IsNull() {
@@ -265,7 +451,13 @@ func (v Value) ToObjectValue(ctx context.Context) (basetypes.ObjectValue, diag.D
 	objVal, diags := types.ObjectValue(
 		attributeTypes,
 		map[string]attr.Value{
-			"error_message_regex": errorMessageRegexVal,
+			"error_message_regex":  errorMessageRegexVal,
+			"status_codes":         statusCodesVal,
+			"interval_seconds":     v.IntervalSeconds,
+			"max_interval_seconds": v.MaxIntervalSeconds,
+			"multiplier":           v.Multiplier,
+			"randomization_factor": v.RandomizationFactor,
+			"idempotent_only":      v.IdempotentOnly,
 		})
 
 	return objVal, diags
@@ -290,6 +482,30 @@ func (v Value) Equal(o attr.Value) bool {
 		return false
 	}
 
+	if !v.StatusCodes.Equal(other.StatusCodes) {
+		return false
+	}
+
+	if !v.IntervalSeconds.Equal(other.IntervalSeconds) {
+		return false
+	}
+
+	if !v.MaxIntervalSeconds.Equal(other.MaxIntervalSeconds) {
+		return false
+	}
+
+	if !v.Multiplier.Equal(other.Multiplier) {
+		return false
+	}
+
+	if !v.RandomizationFactor.Equal(other.RandomizationFactor) {
+		return false
+	}
+
+	if !v.IdempotentOnly.Equal(other.IdempotentOnly) {
+		return false
+	}
+
 	return true
 }
 
@@ -306,6 +522,14 @@ func (v Value) AttributeTypes(ctx context.Context) map[string]attr.Type {
 		"error_message_regex": basetypes.ListType{
 			ElemType: types.StringType,
 		},
+		"status_codes": basetypes.ListType{
+			ElemType: types.Int64Type,
+		},
+		"interval_seconds":     basetypes.Int64Type{},
+		"max_interval_seconds": basetypes.Int64Type{},
+		"multiplier":           basetypes.Float64Type{},
+		"randomization_factor": basetypes.Float64Type{},
+		"idempotent_only":      basetypes.BoolType{},
 	}
 }
 
@@ -316,6 +540,9 @@ func (v Value) GetErrorMessages() []string {
 	if v.IsUnknown() {
 		return nil
 	}
+	if v.ErrorMessageRegex.IsNull() || v.ErrorMessageRegex.IsUnknown() {
+		return nil
+	}
 	res := make([]string, len(v.ErrorMessageRegex.Elements()))
 	for i, elem := range v.ErrorMessageRegex.Elements() {
 		res[i] = elem.(types.String).ValueString()
@@ -328,9 +555,34 @@ func (v Value) GetErrorMessagesRegex() []regexp.Regexp {
 	if msgs == nil {
 		return nil
 	}
-	res := make([]regexp.Regexp, len(msgs))
-	for i, msg := range msgs {
-		res[i] = *regexp.MustCompile(msg)
+	res := make([]regexp.Regexp, 0, len(msgs))
+	for _, msg := range msgs {
+		// The patterns are validated at plan time, an invalid one can't match any error message.
+		if r, err := regexp.Compile(msg); err == nil {
+			res = append(res, *r)
+		}
+	}
+	return res
+}
+
+// IsIdempotentOnly returns true if the non-idempotent requests are only retried when it's safe to do so.
+func (v Value) IsIdempotentOnly() bool {
+	if v.IsNull() || v.IsUnknown() {
+		return false
+	}
+	return v.IdempotentOnly.ValueBool()
+}
+
+func (v Value) GetStatusCodes() []int {
+	if v.IsNull() || v.IsUnknown() {
+		return nil
+	}
+	if v.StatusCodes.IsNull() || v.StatusCodes.IsUnknown() {
+		return nil
+	}
+	res := make([]int, len(v.StatusCodes.Elements()))
+	for i, elem := range v.StatusCodes.Elements() {
+		res[i] = int(elem.(types.Int64).ValueInt64())
 	}
 	return res
 }
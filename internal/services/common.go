@@ -4,13 +4,71 @@ import (
 	"context"
 	"encoding/json"
 	"fmt"
+	"time"
 
+	"github.com/hashicorp/terraform-plugin-framework-validators/int64validator"
+	"github.com/hashicorp/terraform-plugin-framework-validators/listvalidator"
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
 	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
 	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-framework/types/basetypes"
 	"github.com/hashicorp/terraform-plugin-log/tflog"
+	"github.com/microsoft/terraform-provider-msgraph/internal/clients"
+	"github.com/microsoft/terraform-provider-msgraph/internal/docstrings"
 	"github.com/microsoft/terraform-provider-msgraph/internal/dynamic"
+	"github.com/microsoft/terraform-provider-msgraph/internal/utils"
+	"github.com/microsoft/terraform-provider-msgraph/internal/utils/consistency"
 )
 
+// planDefaultApiVersion sets `api_version` in the plan to the default API version of the provider when it isn't
+// configured, so changing the default of the provider is shown in the plan.
+func planDefaultApiVersion(ctx context.Context, client *clients.MSGraphClient, request resource.ModifyPlanRequest, response *resource.ModifyPlanResponse) {
+	if request.Plan.Raw.IsNull() {
+		return
+	}
+
+	var apiVersion types.String
+	if response.Diagnostics.Append(request.Config.GetAttribute(ctx, path.Root("api_version"), &apiVersion)...); response.Diagnostics.HasError() {
+		return
+	}
+	if apiVersion.IsNull() {
+		response.Diagnostics.Append(response.Plan.SetAttribute(ctx, path.Root("api_version"), client.DefaultApiVersion())...)
+	}
+}
+
+// redactDiagnostics masks the values in the summaries and details of the diagnostics, e.g. the values of
+// `redact_plan_paths` echoed by the errors of the API.
+func redactDiagnostics(diags *diag.Diagnostics, values []string) {
+	if len(values) == 0 {
+		return
+	}
+	res := make(diag.Diagnostics, 0, len(*diags))
+	for _, d := range *diags {
+		summary := utils.RedactValues(d.Summary(), values)
+		detail := utils.RedactValues(d.Detail(), values)
+		if summary == d.Summary() && detail == d.Detail() {
+			res = append(res, d)
+			continue
+		}
+		var redacted diag.Diagnostic
+		if d.Severity() == diag.SeverityError {
+			redacted = diag.NewErrorDiagnostic(summary, detail)
+		} else {
+			redacted = diag.NewWarningDiagnostic(summary, detail)
+		}
+		if withPath, ok := d.(diag.DiagnosticWithPath); ok {
+			redacted = diag.WithPath(withPath.Path(), redacted)
+		}
+		res = append(res, redacted)
+	}
+	*diags = res
+}
+
 func AsMapOfString(input types.Map) map[string]string {
 	result := make(map[string]string)
 	diags := input.ElementsAs(context.Background(), &result, false)
@@ -29,6 +87,15 @@ func AsListOfString(input types.List) []string {
 	return result
 }
 
+func AsListOfInt64(input types.List) []int64 {
+	result := make([]int64, 0)
+	diags := input.ElementsAs(context.Background(), &result, false)
+	if diags.HasError() {
+		tflog.Warn(context.Background(), fmt.Sprintf("failed to convert input to list of int64s: %s", diags))
+	}
+	return result
+}
+
 func AsMapOfLists(input types.Map) map[string][]string {
 	result := make(map[string][]string)
 	diags := input.ElementsAs(context.Background(), &result, false)
@@ -59,3 +126,206 @@ func unmarshalBody(input types.Dynamic, out interface{}) error {
 	}
 	return nil
 }
+
+const (
+	outputFormatTyped      = "typed"
+	outputFormatJSONString = "json_string"
+)
+
+// AcceptableErrorCodeModel describes an error response which is treated as success.
+type AcceptableErrorCodeModel struct {
+	StatusCode types.Int64  `tfsdk:"status_code"`
+	ErrorCode  types.String `tfsdk:"error_code"`
+}
+
+var acceptableErrorCodeAttributeTypes = map[string]attr.Type{
+	"status_code": types.Int64Type,
+	"error_code":  types.StringType,
+}
+
+func acceptableErrorCodesSchema(description string) schema.ListNestedAttribute {
+	return schema.ListNestedAttribute{
+		MarkdownDescription: description,
+		Optional:            true,
+		NestedObject: schema.NestedAttributeObject{
+			Attributes: map[string]schema.Attribute{
+				"status_code": schema.Int64Attribute{
+					MarkdownDescription: "The HTTP status code of the error response, e.g. `404`.",
+					Required:            true,
+					Validators: []validator.Int64{
+						int64validator.Between(400, 599),
+					},
+				},
+				"error_code": schema.StringAttribute{
+					MarkdownDescription: "The Graph error code of the error response, e.g. `Request_ResourceNotFound`. It's compared case-insensitively. If not specified, any error with the status code is accepted.",
+					Optional:            true,
+					Validators: []validator.String{
+						stringvalidator.LengthAtLeast(1),
+					},
+				},
+			},
+		},
+		Validators: []validator.List{
+			listvalidator.SizeAtLeast(1),
+			listvalidator.UniqueValues(),
+		},
+	}
+}
+
+// ConsistencyModel configures how the existence of a resource is checked after it's changed.
+type ConsistencyModel struct {
+	PollIntervalSeconds types.Int64 `tfsdk:"poll_interval_seconds"`
+	MaxAttempts         types.Int64 `tfsdk:"max_attempts"`
+}
+
+var consistencyAttributeTypes = map[string]attr.Type{
+	"poll_interval_seconds": types.Int64Type,
+	"max_attempts":          types.Int64Type,
+}
+
+func consistencySchema() schema.SingleNestedAttribute {
+	return schema.SingleNestedAttribute{
+		MarkdownDescription: docstrings.Consistency(),
+		Optional:            true,
+		Attributes: map[string]schema.Attribute{
+			"poll_interval_seconds": schema.Int64Attribute{
+				MarkdownDescription: "The minimum number of seconds between two checks. The delay grows exponentially up to 10 seconds, unless it's longer. Defaults to `5`.",
+				Optional:            true,
+				Validators: []validator.Int64{
+					int64validator.AtLeast(1),
+				},
+			},
+			"max_attempts": schema.Int64Attribute{
+				MarkdownDescription: "The maximum number of checks, including the 3 consecutive checks which confirm the change, so it must be at least `3`. If not specified, the checks continue until the timeout of the operation.",
+				Optional:            true,
+				Validators: []validator.Int64{
+					int64validator.AtLeast(3),
+				},
+			},
+		},
+	}
+}
+
+// consistencyPollOptions returns the options of the consistency checks configured in the `consistency` attribute.
+func consistencyPollOptions(ctx context.Context, input types.Object) consistency.PollOptions {
+	options := consistency.PollOptions{}
+	if input.IsNull() || input.IsUnknown() {
+		return options
+	}
+	var model ConsistencyModel
+	if diags := input.As(ctx, &model, basetypes.ObjectAsOptions{}); diags.HasError() {
+		tflog.Warn(ctx, fmt.Sprintf("failed to convert consistency options: %s", diags))
+		return options
+	}
+	if !model.PollIntervalSeconds.IsNull() && !model.PollIntervalSeconds.IsUnknown() {
+		options.PollInterval = time.Duration(model.PollIntervalSeconds.ValueInt64()) * time.Second
+	}
+	if !model.MaxAttempts.IsNull() && !model.MaxAttempts.IsUnknown() {
+		options.MaxAttempts = int(model.MaxAttempts.ValueInt64())
+	}
+	return options
+}
+
+// CreateRetryModel configures the retries of the create request on transient errors.
+type CreateRetryModel struct {
+	ErrorCodes      types.List  `tfsdk:"error_codes"`
+	MaxAttempts     types.Int64 `tfsdk:"max_attempts"`
+	IntervalSeconds types.Int64 `tfsdk:"interval_seconds"`
+}
+
+var createRetryAttributeTypes = map[string]attr.Type{
+	"error_codes":      types.ListType{ElemType: types.StringType},
+	"max_attempts":     types.Int64Type,
+	"interval_seconds": types.Int64Type,
+}
+
+const (
+	defaultCreateRetryMaxAttempts = 3
+	defaultCreateRetryInterval    = 10 * time.Second
+)
+
+func createRetrySchema() schema.SingleNestedAttribute {
+	return schema.SingleNestedAttribute{
+		MarkdownDescription: docstrings.CreateRetry(),
+		Optional:            true,
+		Attributes: map[string]schema.Attribute{
+			"error_codes": schema.ListAttribute{
+				MarkdownDescription: "A list of Graph error codes which fail the create transiently, e.g. `Request_MultipleObjectsWithSameKeyValue`. They're compared case-insensitively.",
+				Required:            true,
+				ElementType:         types.StringType,
+				Validators: []validator.List{
+					listvalidator.SizeAtLeast(1),
+					listvalidator.ValueStringsAre(stringvalidator.LengthAtLeast(1)),
+				},
+			},
+			"max_attempts": schema.Int64Attribute{
+				MarkdownDescription: "The maximum number of attempts to create the object, including the first one. Defaults to `3`.",
+				Optional:            true,
+				Validators: []validator.Int64{
+					int64validator.AtLeast(2),
+				},
+			},
+			"interval_seconds": schema.Int64Attribute{
+				MarkdownDescription: "The number of seconds to wait before creating the object again. Defaults to `10`.",
+				Optional:            true,
+				Validators: []validator.Int64{
+					int64validator.AtLeast(1),
+				},
+			},
+		},
+	}
+}
+
+// createWithRetry calls create until it succeeds, or fails with an error which isn't one of the Graph error codes
+// configured in the `create_retry` attribute, or the maximum number of attempts is reached.
+func createWithRetry(ctx context.Context, input types.Object, create func() (interface{}, error)) (interface{}, error) {
+	if input.IsNull() || input.IsUnknown() {
+		return create()
+	}
+	var model CreateRetryModel
+	if diags := input.As(ctx, &model, basetypes.ObjectAsOptions{}); diags.HasError() {
+		tflog.Warn(ctx, fmt.Sprintf("failed to convert create retry options: %s", diags))
+		return create()
+	}
+	errorCodes := AsListOfString(model.ErrorCodes)
+	maxAttempts := defaultCreateRetryMaxAttempts
+	if !model.MaxAttempts.IsNull() && !model.MaxAttempts.IsUnknown() {
+		maxAttempts = int(model.MaxAttempts.ValueInt64())
+	}
+	interval := defaultCreateRetryInterval
+	if !model.IntervalSeconds.IsNull() && !model.IntervalSeconds.IsUnknown() {
+		interval = time.Duration(model.IntervalSeconds.ValueInt64()) * time.Second
+	}
+
+	for attempt := 1; ; attempt++ {
+		responseBody, err := create()
+		if err == nil || attempt >= maxAttempts || !utils.ResponseErrorHasCode(err, errorCodes) {
+			return responseBody, err
+		}
+		tflog.Info(ctx, fmt.Sprintf("Creating the object again in %s after attempt %d of %d failed: %s", interval, attempt, maxAttempts, err.Error()))
+		select {
+		case <-ctx.Done():
+			return nil, fmt.Errorf("%w: %w", ctx.Err(), err)
+		case <-time.After(interval):
+		}
+	}
+}
+
+// isAcceptableError returns true if the error matches any of the acceptable error codes.
+func isAcceptableError(ctx context.Context, acceptableErrorCodes types.List, err error) bool {
+	if err == nil || acceptableErrorCodes.IsNull() || acceptableErrorCodes.IsUnknown() {
+		return false
+	}
+	var codes []AcceptableErrorCodeModel
+	if diags := acceptableErrorCodes.ElementsAs(ctx, &codes, false); diags.HasError() {
+		tflog.Warn(ctx, fmt.Sprintf("failed to convert acceptable error codes: %s", diags))
+		return false
+	}
+	for _, code := range codes {
+		if utils.ResponseErrorMatches(err, int(code.StatusCode.ValueInt64()), code.ErrorCode.ValueString()) {
+			tflog.Info(ctx, fmt.Sprintf("Ignoring error as it matches the acceptable error code %d %s: %s", code.StatusCode.ValueInt64(), code.ErrorCode.ValueString(), err.Error()))
+			return true
+		}
+	}
+	return false
+}
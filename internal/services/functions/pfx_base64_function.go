@@ -0,0 +1,58 @@
+package functions
+
+import (
+	"context"
+	"encoding/base64"
+	"fmt"
+	"os"
+
+	"github.com/hashicorp/terraform-plugin-framework/function"
+)
+
+var _ function.Function = &PfxBase64Function{}
+
+func NewPfxBase64Function() function.Function {
+	return &PfxBase64Function{}
+}
+
+// PfxBase64Function reads a PKCS#12 bundle from disk and returns its base64-encoded content,
+// which is the format expected by the `client_certificate` provider attribute.
+type PfxBase64Function struct{}
+
+func (f *PfxBase64Function) Metadata(ctx context.Context, req function.MetadataRequest, resp *function.MetadataResponse) {
+	resp.Name = "pfx_base64"
+}
+
+func (f *PfxBase64Function) Definition(ctx context.Context, req function.DefinitionRequest, resp *function.DefinitionResponse) {
+	resp.Definition = function.Definition{
+		Summary:             "Encodes a PKCS#12 certificate file to base64",
+		MarkdownDescription: "This function reads a PKCS#12 bundle (`.pfx` or `.p12` file) and returns its content encoded in base64, which can be used as the `client_certificate` attribute of the provider.",
+		Parameters: []function.Parameter{
+			function.StringParameter{
+				Name:                "path",
+				MarkdownDescription: "The path to the PKCS#12 bundle.",
+			},
+		},
+		Return: function.StringReturn{},
+	}
+}
+
+func (f *PfxBase64Function) Run(ctx context.Context, req function.RunRequest, resp *function.RunResponse) {
+	var path string
+	if resp.Error = function.ConcatFuncErrors(req.Arguments.Get(ctx, &path)); resp.Error != nil {
+		return
+	}
+
+	// #nosec G304
+	data, err := os.ReadFile(path)
+	if err != nil {
+		resp.Error = function.NewArgumentFuncError(0, fmt.Sprintf(`failed to read certificate file "%s": %v`, path, err))
+		return
+	}
+	if len(data) == 0 {
+		resp.Error = function.NewArgumentFuncError(0, fmt.Sprintf(`certificate file "%s" is empty`, path))
+		return
+	}
+
+	resp.Error = function.ConcatFuncErrors(resp.Result.Set(ctx, base64.StdEncoding.EncodeToString(data)))
+}
@@ -0,0 +1,75 @@
+package functions_test
+
+import (
+	"context"
+	"encoding/base64"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/function"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/microsoft/terraform-provider-msgraph/internal/services/functions"
+)
+
+func TestPfxBase64Function(t *testing.T) {
+	dir := t.TempDir()
+	content := []byte{0x30, 0x82, 0x0a, 0x00, 0xff}
+	validPath := filepath.Join(dir, "cert.pfx")
+	if err := os.WriteFile(validPath, content, 0o600); err != nil {
+		t.Fatal(err)
+	}
+	emptyPath := filepath.Join(dir, "empty.pfx")
+	if err := os.WriteFile(emptyPath, nil, 0o600); err != nil {
+		t.Fatal(err)
+	}
+
+	testcases := []struct {
+		name      string
+		path      string
+		want      string
+		expectErr bool
+	}{
+		{
+			name: "valid file",
+			path: validPath,
+			want: base64.StdEncoding.EncodeToString(content),
+		},
+		{
+			name:      "empty file",
+			path:      emptyPath,
+			expectErr: true,
+		},
+		{
+			name:      "missing file",
+			path:      filepath.Join(dir, "missing.pfx"),
+			expectErr: true,
+		},
+	}
+
+	for _, tc := range testcases {
+		t.Run(tc.name, func(t *testing.T) {
+			req := function.RunRequest{
+				Arguments: function.NewArgumentsData([]attr.Value{types.StringValue(tc.path)}),
+			}
+			resp := &function.RunResponse{
+				Result: function.NewResultData(types.StringUnknown()),
+			}
+			functions.NewPfxBase64Function().Run(context.Background(), req, resp)
+
+			if tc.expectErr {
+				if resp.Error == nil {
+					t.Fatalf("expected an error, got none")
+				}
+				return
+			}
+			if resp.Error != nil {
+				t.Fatalf("unexpected error: %v", resp.Error)
+			}
+			if got := resp.Result.Value(); !got.Equal(types.StringValue(tc.want)) {
+				t.Fatalf("got %v, want %q", got, tc.want)
+			}
+		})
+	}
+}
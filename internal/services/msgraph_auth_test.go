@@ -58,3 +58,54 @@ func TestAccAuth_azurePowerShell(t *testing.T) {
 		},
 	})
 }
+
+// TestAccAuth_azureDeveloperCLI tests authentication using Azure Developer CLI
+func TestAccAuth_azureDeveloperCLI(t *testing.T) {
+	if ok := os.Getenv("ARM_USE_AZD_CLI"); ok == "" {
+		t.Skip("Skipping as `ARM_USE_AZD_CLI` is not specified")
+	}
+
+	data := acceptance.BuildTestData(t, "data.msgraph_resource", "test")
+	r := MSGraphTestDataSource{}
+
+	data.DataSourceTest(t, []resource.TestStep{
+		{
+			Config: r.basic(data),
+			Check:  resource.ComposeTestCheckFunc(),
+		},
+	})
+}
+
+// TestAccAuth_environmentCredential tests authentication using the credential configured by the AZURE_* environment variables
+func TestAccAuth_environmentCredential(t *testing.T) {
+	if ok := os.Getenv("ARM_USE_ENVIRONMENT_CREDENTIAL"); ok == "" {
+		t.Skip("Skipping as `ARM_USE_ENVIRONMENT_CREDENTIAL` is not specified")
+	}
+
+	data := acceptance.BuildTestData(t, "data.msgraph_resource", "test")
+	r := MSGraphTestDataSource{}
+
+	data.DataSourceTest(t, []resource.TestStep{
+		{
+			Config: r.basic(data),
+			Check:  resource.ComposeTestCheckFunc(),
+		},
+	})
+}
+
+// TestAccAuth_clientAssertion tests authentication using a client assertion
+func TestAccAuth_clientAssertion(t *testing.T) {
+	if os.Getenv("ARM_CLIENT_ASSERTION") == "" && os.Getenv("ARM_CLIENT_ASSERTION_FILE_PATH") == "" {
+		t.Skip("Skipping as `ARM_CLIENT_ASSERTION` or `ARM_CLIENT_ASSERTION_FILE_PATH` is not specified")
+	}
+
+	data := acceptance.BuildTestData(t, "data.msgraph_resource", "test")
+	r := MSGraphTestDataSource{}
+
+	data.DataSourceTest(t, []resource.TestStep{
+		{
+			Config: r.basic(data),
+			Check:  resource.ComposeTestCheckFunc(),
+		},
+	})
+}
@@ -2,7 +2,8 @@ package services
 
 import (
 	"context"
-	"time"
+	"net/http"
+	"net/url"
 
 	"github.com/hashicorp/terraform-plugin-framework-timeouts/resource/timeouts"
 	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
@@ -12,7 +13,9 @@ import (
 	"github.com/hashicorp/terraform-plugin-framework/types"
 	"github.com/microsoft/terraform-provider-msgraph/internal/clients"
 	"github.com/microsoft/terraform-provider-msgraph/internal/docstrings"
+	"github.com/microsoft/terraform-provider-msgraph/internal/myvalidator"
 	"github.com/microsoft/terraform-provider-msgraph/internal/retry"
+	"github.com/microsoft/terraform-provider-msgraph/internal/utils"
 )
 
 // Ensure provider defined types fully satisfy framework interfaces.
@@ -31,12 +34,18 @@ type MSGraphDataSource struct {
 type MSGraphDataSourceModel struct {
 	Id                   types.String      `tfsdk:"id"`
 	ApiVersion           types.String      `tfsdk:"api_version"`
+	TenantId             types.String      `tfsdk:"tenant_id"`
 	Url                  types.String      `tfsdk:"url"`
 	ResponseExportValues map[string]string `tfsdk:"response_export_values"`
+	OutputFormat         types.String      `tfsdk:"output_format"`
 	Headers              types.Map         `tfsdk:"headers"`
 	QueryParameters      types.Map         `tfsdk:"query_parameters"`
+	AutoPaginate         types.Bool        `tfsdk:"auto_paginate"`
 	Retry                retry.Value       `tfsdk:"retry"`
 	Output               types.Dynamic     `tfsdk:"output"`
+	NextLink             types.String      `tfsdk:"next_link"`
+	SkipToken            types.String      `tfsdk:"skip_token"`
+	ODataCount           types.Int64       `tfsdk:"odata_count"`
 	Timeouts             timeouts.Value    `tfsdk:"timeouts"`
 }
 
@@ -47,7 +56,7 @@ func (r *MSGraphDataSource) Metadata(ctx context.Context, req datasource.Metadat
 func (r *MSGraphDataSource) Schema(ctx context.Context, req datasource.SchemaRequest, resp *datasource.SchemaResponse) {
 	resp.Schema = schema.Schema{
 		// This description is used by the documentation generator and the language server.
-		MarkdownDescription: "This data source can list resources or read an individual resource from the Microsoft Graph API.",
+		MarkdownDescription: "This data source can list resources or read an individual resource from the Microsoft Graph API. When reading a delta query, e.g. `users/delta`, the items marked with the `@removed` annotation are returned in `removed` as a list of IDs instead of in `value`.",
 
 		Attributes: map[string]schema.Attribute{
 			"id": schema.StringAttribute{
@@ -58,13 +67,24 @@ func (r *MSGraphDataSource) Schema(ctx context.Context, req datasource.SchemaReq
 			"url": schema.StringAttribute{
 				MarkdownDescription: docstrings.Url("data"),
 				Required:            true,
+				Validators: []validator.String{
+					myvalidator.RelativeURL(),
+				},
 			},
 
 			"api_version": schema.StringAttribute{
 				MarkdownDescription: docstrings.ApiVersion(),
 				Optional:            true,
 				Validators: []validator.String{
-					stringvalidator.OneOf("v1.0", "beta"),
+					myvalidator.ApiVersion(),
+				},
+			},
+
+			"tenant_id": schema.StringAttribute{
+				MarkdownDescription: docstrings.TenantId("data source"),
+				Optional:            true,
+				Validators: []validator.String{
+					stringvalidator.LengthAtLeast(1),
 				},
 			},
 
@@ -74,6 +94,14 @@ func (r *MSGraphDataSource) Schema(ctx context.Context, req datasource.SchemaReq
 				ElementType:         types.StringType,
 			},
 
+			"output_format": schema.StringAttribute{
+				MarkdownDescription: docstrings.OutputFormat(),
+				Optional:            true,
+				Validators: []validator.String{
+					stringvalidator.OneOf(outputFormatTyped, outputFormatJSONString),
+				},
+			},
+
 			"headers": schema.MapAttribute{
 				ElementType:         types.StringType,
 				Optional:            true,
@@ -85,7 +113,12 @@ func (r *MSGraphDataSource) Schema(ctx context.Context, req datasource.SchemaReq
 					ElemType: types.StringType,
 				},
 				Optional:            true,
-				MarkdownDescription: "A map of query parameters to include in the request",
+				MarkdownDescription: "A map of query parameters to include in the request. The page size of a collection can be specified with `$top`, and its total number of items can be requested with `$count`.",
+			},
+
+			"auto_paginate": schema.BoolAttribute{
+				MarkdownDescription: "Whether to follow the `@odata.nextLink` of a collection and return the items of all pages in `value`. When `false`, only the first page is read, and the link to the next page is returned in `next_link` and `skip_token`, so the pages can be read one by one by passing `skip_token` as `$skiptoken` in `query_parameters`. Defaults to `true`.",
+				Optional:            true,
 			},
 
 			"retry": retry.Schema(ctx),
@@ -94,6 +127,21 @@ func (r *MSGraphDataSource) Schema(ctx context.Context, req datasource.SchemaReq
 				MarkdownDescription: docstrings.Output(),
 				Computed:            true,
 			},
+
+			"next_link": schema.StringAttribute{
+				MarkdownDescription: "The `@odata.nextLink` of the page which is read when `auto_paginate` is `false`. It's null if it's the last page.",
+				Computed:            true,
+			},
+
+			"skip_token": schema.StringAttribute{
+				MarkdownDescription: "The `$skiptoken` query parameter of `next_link`, which reads the next page when it's passed in `query_parameters`. It's null if it's the last page.",
+				Computed:            true,
+			},
+
+			"odata_count": schema.Int64Attribute{
+				MarkdownDescription: "The `@odata.count` of the last page which is read, i.e. the total number of items of the collection. It's only returned when `$count` is `true` in `query_parameters`.",
+				Computed:            true,
+			},
 		},
 
 		Blocks: map[string]schema.Block{
@@ -116,27 +164,37 @@ func (r *MSGraphDataSource) Read(ctx context.Context, req datasource.ReadRequest
 		return
 	}
 
-	readTimeout, diags := model.Timeouts.Read(ctx, 5*time.Minute)
+	readTimeout, diags := model.Timeouts.Read(ctx, r.client.DefaultReadTimeout())
 	resp.Diagnostics.Append(diags...)
 	ctx, cancelRead := context.WithTimeout(ctx, readTimeout)
 	defer cancelRead()
+	ctx = clients.WithTenantId(ctx, model.TenantId.ValueString())
 
-	apiVersion := "v1.0"
+	apiVersion := r.client.DefaultApiVersion()
 	if model.ApiVersion.ValueString() != "" {
 		apiVersion = model.ApiVersion.ValueString()
 	}
 
 	options := clients.RequestOptions{
-		Headers:         AsMapOfString(model.Headers),
+		Headers:         clients.NewHeaders(AsMapOfString(model.Headers)),
 		QueryParameters: clients.NewQueryParameters(AsMapOfLists(model.QueryParameters)),
-		RetryOptions:    clients.NewRetryOptions(model.Retry),
+		RetryOptions:    clients.NewRetryOptions(model.Retry, http.MethodGet),
+	}
+	var responseBody interface{}
+	var err error
+	if model.AutoPaginate.IsNull() || model.AutoPaginate.ValueBool() {
+		responseBody, err = r.client.Read(ctx, model.Url.ValueString(), apiVersion, options)
+	} else {
+		responseBody, err = r.client.ReadPage(ctx, model.Url.ValueString(), apiVersion, options)
 	}
-	responseBody, err := r.client.Read(ctx, model.Url.ValueString(), apiVersion, options)
 	if err != nil {
-		resp.Diagnostics.AddError("Failed to read data source", err.Error())
+		resp.Diagnostics.AddError("Failed to read data source", utils.ResponseErrorDetail(err))
 		return
 	}
 
+	// Removed items of delta queries are surfaced in `removed`, so consumers can process the deletions.
+	responseBody = utils.SeparateRemovedItems(responseBody)
+
 	responseId := model.Url.ValueString()
 	if responseBody != nil {
 		if responseMap, ok := responseBody.(map[string]interface{}); ok {
@@ -149,7 +207,27 @@ func (r *MSGraphDataSource) Read(ctx context.Context, req datasource.ReadRequest
 	}
 
 	model.Id = types.StringValue(responseId)
-	model.Output = types.DynamicValue(buildOutputFromBody(responseBody, model.ResponseExportValues))
+	model.NextLink, model.SkipToken, model.ODataCount = pagingOf(responseBody)
+	model.Output = types.DynamicValue(buildOutput(responseBody, model.ResponseExportValues, model.OutputFormat.ValueString()))
 
 	resp.Diagnostics.Append(resp.State.Set(ctx, &model)...)
 }
+
+// pagingOf returns the `@odata.nextLink` of the response, its `$skiptoken` and the `@odata.count` of the response.
+func pagingOf(responseBody interface{}) (types.String, types.String, types.Int64) {
+	nextLink, skipToken, count := types.StringNull(), types.StringNull(), types.Int64Null()
+	responseMap, ok := responseBody.(map[string]interface{})
+	if !ok {
+		return nextLink, skipToken, count
+	}
+	if v, ok := responseMap["@odata.nextLink"].(string); ok && v != "" {
+		nextLink = types.StringValue(v)
+		if parsedUrl, err := url.Parse(v); err == nil {
+			skipToken = types.StringValue(parsedUrl.Query().Get("$skiptoken"))
+		}
+	}
+	if v, ok := responseMap["@odata.count"].(float64); ok {
+		count = types.Int64Value(int64(v))
+	}
+	return nextLink, skipToken, count
+}
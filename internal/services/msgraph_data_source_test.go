@@ -2,6 +2,7 @@ package services_test
 
 import (
 	"fmt"
+	"os"
 	"regexp"
 	"testing"
 
@@ -27,6 +28,23 @@ func TestAcc_DataSourceBasic(t *testing.T) {
 	})
 }
 
+func TestAcc_DataSourceMe(t *testing.T) {
+	acceptance.PreCheckDelegated(t)
+
+	data := acceptance.BuildTestData(t, "data.msgraph_resource", "test")
+	r := MSGraphTestDataSource{}
+
+	data.DataSourceTest(t, []resource.TestStep{
+		{
+			Config: r.me(),
+			Check: resource.ComposeTestCheckFunc(
+				check.That(data.ResourceName).Key("id").IsUUID(),
+				check.That(data.ResourceName).Key("output.userPrincipalName").Exists(),
+			),
+		},
+	})
+}
+
 func TestAcc_DataSourceQueryParameters(t *testing.T) {
 	data := acceptance.BuildTestData(t, "data.msgraph_resource", "test")
 	r := MSGraphTestDataSource{}
@@ -55,6 +73,52 @@ func TestAcc_DataSourceList(t *testing.T) {
 	})
 }
 
+func TestAcc_DataSourceListProjections(t *testing.T) {
+	data := acceptance.BuildTestData(t, "data.msgraph_resource", "test")
+	r := MSGraphTestDataSource{}
+
+	data.DataSourceTest(t, []resource.TestStep{
+		{
+			Config: r.listProjections(data),
+			Check: resource.ComposeTestCheckFunc(
+				check.That(data.ResourceName).Key("output.display_names.#").Exists(),
+				check.That(data.ResourceName).Key("output.enabled_ids.#").Exists(),
+				check.That(data.ResourceName).Key("output.count").Exists(),
+			),
+		},
+	})
+}
+
+func TestAcc_DataSourceDelta(t *testing.T) {
+	data := acceptance.BuildTestData(t, "data.msgraph_resource", "test")
+	r := MSGraphTestDataSource{}
+
+	data.DataSourceTest(t, []resource.TestStep{
+		{
+			Config: r.delta(data),
+			Check: resource.ComposeTestCheckFunc(
+				check.That(data.ResourceName).Key("output.delta_link").Exists(),
+				check.That(data.ResourceName).Key("output.values.#").Exists(),
+			),
+		},
+	})
+}
+
+func TestAcc_DataSourceOutputFormatJSONString(t *testing.T) {
+	data := acceptance.BuildTestData(t, "data.msgraph_resource", "test")
+	r := MSGraphTestDataSource{}
+
+	data.DataSourceTest(t, []resource.TestStep{
+		{
+			Config: r.outputFormatJSONString(data),
+			Check: resource.ComposeTestCheckFunc(
+				check.That(data.ResourceName).Key("output_format").HasValue("json_string"),
+				check.That(data.ResourceName).Key("output").MatchesRegex(regexp.MustCompile(`^\{"appId":"[a-f0-9\-]+"\}$`)),
+			),
+		},
+	})
+}
+
 func TestAcc_DataSourceRetry(t *testing.T) {
 	data := acceptance.BuildTestData(t, "data.msgraph_resource", "test")
 	r := MSGraphTestDataSource{}
@@ -81,6 +145,63 @@ func TestAcc_DataSourceTimeouts_Read(t *testing.T) {
 	})
 }
 
+func TestAcc_DataSourceTimeouts_DefaultRead(t *testing.T) {
+	data := acceptance.BuildTestData(t, "data.msgraph_resource", "test")
+	r := MSGraphTestDataSource{}
+
+	data.DataSourceTest(t, []resource.TestStep{
+		{
+			Config:      r.withDefaultReadTimeout(""),
+			ExpectError: regexp.MustCompile(`context deadline exceeded`),
+		},
+		{
+			Config: r.withDefaultReadTimeout("5m"),
+			Check: resource.ComposeTestCheckFunc(
+				check.That(data.ResourceName).Key("output.%").Exists(),
+			),
+		},
+	})
+}
+
+func TestAcc_DataSourceTenantId(t *testing.T) {
+	tenantId := os.Getenv("ARM_TEST_OTHER_TENANT_ID")
+	if tenantId == "" {
+		t.Skip("Skipping as `ARM_TEST_OTHER_TENANT_ID` is not specified")
+	}
+
+	data := acceptance.BuildTestData(t, "data.msgraph_resource", "test")
+	r := MSGraphTestDataSource{}
+
+	data.DataSourceTest(t, []resource.TestStep{
+		{
+			Config: r.tenantId(tenantId),
+			Check: resource.ComposeTestCheckFunc(
+				check.That(data.ResourceName).Key("output.tenant_id").HasValue(tenantId),
+			),
+		},
+	})
+}
+
+func TestAcc_DataSourcePaging(t *testing.T) {
+	data := acceptance.BuildTestData(t, "data.msgraph_resource", "test")
+	r := MSGraphTestDataSource{}
+
+	data.DataSourceTest(t, []resource.TestStep{
+		{
+			Config: r.paging(data),
+			Check: resource.ComposeTestCheckFunc(
+				check.That(data.ResourceName).Key("output.items").HasValue("1"),
+				check.That(data.ResourceName).Key("next_link").IsSet(),
+				check.That(data.ResourceName).Key("skip_token").IsSet(),
+				check.That(data.ResourceName).Key("odata_count").MatchesRegex(regexp.MustCompile(`^([2-9]|[1-9][0-9]+)$`)),
+				check.That("data.msgraph_resource.next").Key("output.items").HasValue("1"),
+				check.That("data.msgraph_resource.all").Key("next_link").DoesNotExist(),
+				check.That("data.msgraph_resource.all").Key("output.items").MatchesRegex(regexp.MustCompile(`^([2-9]|[1-9][0-9]+)$`)),
+			),
+		},
+	})
+}
+
 func (r MSGraphTestDataSource) basic(data acceptance.TestData) string {
 	return fmt.Sprintf(`
 %s
@@ -91,6 +212,31 @@ data "msgraph_resource" "test" {
 `, MSGraphTestResource{}.basic(data))
 }
 
+func (r MSGraphTestDataSource) me() string {
+	return `
+data "msgraph_resource" "test" {
+  url = "me"
+  response_export_values = {
+    userPrincipalName = "userPrincipalName"
+  }
+}
+`
+}
+
+func (r MSGraphTestDataSource) outputFormatJSONString(data acceptance.TestData) string {
+	return fmt.Sprintf(`
+%s
+
+data "msgraph_resource" "test" {
+  url           = "applications/${msgraph_resource.test.id}"
+  output_format = "json_string"
+  response_export_values = {
+    appId = "appId"
+  }
+}
+`, MSGraphTestResource{}.basic(data))
+}
+
 func (r MSGraphTestDataSource) query(data acceptance.TestData) string {
 	return `
 locals {
@@ -118,6 +264,109 @@ data "msgraph_resource" "test" {
 }`
 }
 
+func (r MSGraphTestDataSource) listProjections(data acceptance.TestData) string {
+	return `
+data "msgraph_resource" "test" {
+  url = "users"
+  query_parameters = {
+    "$select" = ["id", "displayName", "accountEnabled"]
+    "$top"    = ["5"]
+  }
+  response_export_values = {
+    display_names = "value[].displayName"
+    enabled_ids   = "value[?accountEnabled].id"
+    count         = "length(value)"
+  }
+}`
+}
+
+func (r MSGraphTestDataSource) delta(data acceptance.TestData) string {
+	return `
+data "msgraph_resource" "test" {
+  url = "groups/delta"
+  query_parameters = {
+    "$select" = ["displayName"]
+  }
+  response_export_values = {
+    values     = "value"
+    removed    = "removed"
+    delta_link = "\"@odata.deltaLink\""
+  }
+}`
+}
+
+func (r MSGraphTestDataSource) paging(data acceptance.TestData) string {
+	return fmt.Sprintf(`
+resource "msgraph_resource" "first" {
+  url = "applications"
+  body = {
+    displayName = "acctest-%[1]s-first"
+  }
+}
+
+resource "msgraph_resource" "second" {
+  url = "applications"
+  body = {
+    displayName = "acctest-%[1]s-second"
+  }
+}
+
+data "msgraph_resource" "test" {
+  url = "applications"
+  headers = {
+    ConsistencyLevel = "eventual"
+  }
+  query_parameters = {
+    "$select" = ["id"]
+    "$top"    = ["1"]
+    "$count"  = ["true"]
+  }
+  auto_paginate = false
+  response_export_values = {
+    items = "length(value)"
+  }
+  depends_on = [msgraph_resource.first, msgraph_resource.second]
+}
+
+data "msgraph_resource" "next" {
+  url = "applications"
+  query_parameters = {
+    "$select"    = ["id"]
+    "$top"       = ["1"]
+    "$skiptoken" = [data.msgraph_resource.test.skip_token]
+  }
+  auto_paginate = false
+  response_export_values = {
+    items = "length(value)"
+  }
+}
+
+data "msgraph_resource" "all" {
+  url = "applications"
+  query_parameters = {
+    "$select" = ["id"]
+    "$top"    = ["1"]
+  }
+  response_export_values = {
+    items = "length(value)"
+  }
+  depends_on = [msgraph_resource.first, msgraph_resource.second]
+}
+`, data.RandomString)
+}
+
+func (r MSGraphTestDataSource) tenantId(tenantId string) string {
+	return fmt.Sprintf(`
+data "msgraph_resource" "test" {
+  url       = "organization"
+  tenant_id = "%s"
+  response_export_values = {
+    tenant_id = "value[0].id"
+  }
+}
+`, tenantId)
+}
+
 func (r MSGraphTestDataSource) withRetry(data acceptance.TestData) string {
 	return `
 data "msgraph_resource" "test" {
@@ -148,3 +397,25 @@ data "msgraph_resource" "test" {
   }
 }`
 }
+
+func (r MSGraphTestDataSource) withDefaultReadTimeout(readTimeout string) string {
+	timeouts := ""
+	if readTimeout != "" {
+		timeouts = fmt.Sprintf(`
+  timeouts {
+    read = "%s"
+  }`, readTimeout)
+	}
+	return fmt.Sprintf(`
+provider "msgraph" {
+  default_read_timeout = "1ns"
+}
+
+data "msgraph_resource" "test" {
+  url = "organization"
+  response_export_values = {
+    all = "@"
+  }%s
+}
+`, timeouts)
+}
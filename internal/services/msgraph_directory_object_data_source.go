@@ -0,0 +1,266 @@
+package services
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-framework-timeouts/resource/timeouts"
+	"github.com/hashicorp/terraform-plugin-framework-validators/listvalidator"
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/microsoft/terraform-provider-msgraph/internal/clients"
+	"github.com/microsoft/terraform-provider-msgraph/internal/docstrings"
+	"github.com/microsoft/terraform-provider-msgraph/internal/myvalidator"
+	"github.com/microsoft/terraform-provider-msgraph/internal/retry"
+	"github.com/microsoft/terraform-provider-msgraph/internal/utils"
+)
+
+// getByIdsBatchSize is the maximum number of IDs accepted by a `directoryObjects/getByIds` request.
+const getByIdsBatchSize = 1000
+
+// Ensure provider defined types fully satisfy framework interfaces.
+var (
+	_ datasource.DataSource                   = &MSGraphDirectoryObjectDataSource{}
+	_ datasource.DataSourceWithValidateConfig = &MSGraphDirectoryObjectDataSource{}
+)
+
+func NewMSGraphDirectoryObjectDataSource() datasource.DataSource {
+	return &MSGraphDirectoryObjectDataSource{}
+}
+
+// MSGraphDirectoryObjectDataSource defines the data source implementation.
+type MSGraphDirectoryObjectDataSource struct {
+	client *clients.MSGraphClient
+}
+
+// MSGraphDirectoryObjectDataSourceModel describes the data source data model.
+type MSGraphDirectoryObjectDataSourceModel struct {
+	Id                   types.String      `tfsdk:"id"`
+	ApiVersion           types.String      `tfsdk:"api_version"`
+	ObjectId             types.String      `tfsdk:"object_id"`
+	ObjectIds            types.List        `tfsdk:"object_ids"`
+	Types                types.List        `tfsdk:"types"`
+	ResponseExportValues map[string]string `tfsdk:"response_export_values"`
+	Retry                retry.Value       `tfsdk:"retry"`
+	ODataType            types.String      `tfsdk:"odata_type"`
+	ODataTypes           types.Map         `tfsdk:"odata_types"`
+	Output               types.Dynamic     `tfsdk:"output"`
+	Timeouts             timeouts.Value    `tfsdk:"timeouts"`
+}
+
+func (r *MSGraphDirectoryObjectDataSource) Metadata(ctx context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_directory_object"
+}
+
+func (r *MSGraphDirectoryObjectDataSource) Schema(ctx context.Context, req datasource.SchemaRequest, resp *datasource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		// This description is used by the documentation generator and the language server.
+		MarkdownDescription: "This data source resolves directory objects, e.g. users, groups or service principals, by their IDs without knowing their types. A single object is read with `GET directoryObjects/{id}`, and many objects are read with one `POST directoryObjects/getByIds` request per 1000 IDs. The `@odata.type` of the objects is returned in `odata_type` and `odata_types`, so modules can dispatch on the type of the objects.",
+
+		Attributes: map[string]schema.Attribute{
+			"id": schema.StringAttribute{
+				MarkdownDescription: "The ID of the data source. It's the ID of the object when `object_id` is specified, otherwise it's `directoryObjects/getByIds`.",
+				Computed:            true,
+			},
+
+			"api_version": schema.StringAttribute{
+				MarkdownDescription: docstrings.ApiVersion(),
+				Optional:            true,
+				Validators: []validator.String{
+					myvalidator.ApiVersion(),
+				},
+			},
+
+			"object_id": schema.StringAttribute{
+				MarkdownDescription: "The ID of the directory object to read. Exactly one of `object_id` and `object_ids` must be specified.",
+				Optional:            true,
+				Validators: []validator.String{
+					myvalidator.StringIsUUID(),
+				},
+			},
+
+			"object_ids": schema.ListAttribute{
+				MarkdownDescription: "The IDs of the directory objects to read. The duplicated IDs are only read once. The IDs which don't exist are not returned. Exactly one of `object_id` and `object_ids` must be specified.",
+				ElementType:         types.StringType,
+				Optional:            true,
+				Validators: []validator.List{
+					listvalidator.ValueStringsAre(myvalidator.StringIsUUID()),
+				},
+			},
+
+			"types": schema.ListAttribute{
+				MarkdownDescription: "The types of the directory objects to return when `object_ids` is specified, e.g. `user`, `group` or `servicePrincipal`. Defaults to all the types.",
+				ElementType:         types.StringType,
+				Optional:            true,
+				Validators: []validator.List{
+					listvalidator.SizeAtLeast(1),
+					listvalidator.ValueStringsAre(stringvalidator.LengthAtLeast(1)),
+				},
+			},
+
+			"response_export_values": schema.MapAttribute{
+				MarkdownDescription: docstrings.ResponseExportValues() + " When `object_ids` is specified, the objects are in `value`, e.g. `value[].displayName`.",
+				Optional:            true,
+				ElementType:         types.StringType,
+			},
+
+			"retry": retry.Schema(ctx),
+
+			"odata_type": schema.StringAttribute{
+				MarkdownDescription: "The `@odata.type` of the object read with `object_id`, e.g. `#microsoft.graph.user`.",
+				Computed:            true,
+			},
+
+			"odata_types": schema.MapAttribute{
+				MarkdownDescription: "A map of the IDs of the objects which are found to their `@odata.type`, e.g. `#microsoft.graph.group`.",
+				ElementType:         types.StringType,
+				Computed:            true,
+			},
+
+			"output": schema.DynamicAttribute{
+				MarkdownDescription: docstrings.Output(),
+				Computed:            true,
+			},
+		},
+
+		Blocks: map[string]schema.Block{
+			"timeouts": timeouts.Block(ctx, timeouts.Opts{
+				Read: true,
+			}),
+		},
+	}
+}
+
+func (r *MSGraphDirectoryObjectDataSource) ValidateConfig(ctx context.Context, req datasource.ValidateConfigRequest, resp *datasource.ValidateConfigResponse) {
+	var model *MSGraphDirectoryObjectDataSourceModel
+	if resp.Diagnostics.Append(req.Config.Get(ctx, &model)...); resp.Diagnostics.HasError() {
+		return
+	}
+
+	if model.ObjectId.IsUnknown() || model.ObjectIds.IsUnknown() {
+		return
+	}
+
+	if model.ObjectId.IsNull() == model.ObjectIds.IsNull() {
+		resp.Diagnostics.AddAttributeError(path.Root("object_id"), "Invalid configuration", "Exactly one of `object_id` and `object_ids` must be specified.")
+	}
+
+	if !model.Types.IsNull() && model.ObjectIds.IsNull() {
+		resp.Diagnostics.AddAttributeError(path.Root("types"), "Invalid configuration", "`types` can only be specified together with `object_ids`.")
+	}
+}
+
+func (r *MSGraphDirectoryObjectDataSource) Configure(ctx context.Context, req datasource.ConfigureRequest, resp *datasource.ConfigureResponse) {
+	if v, ok := req.ProviderData.(*clients.Client); ok {
+		r.client = v.MSGraphClient
+	}
+}
+
+func (r *MSGraphDirectoryObjectDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
+	var model MSGraphDirectoryObjectDataSourceModel
+	if resp.Diagnostics.Append(req.Config.Get(ctx, &model)...); resp.Diagnostics.HasError() {
+		return
+	}
+
+	readTimeout, diags := model.Timeouts.Read(ctx, r.client.DefaultReadTimeout())
+	resp.Diagnostics.Append(diags...)
+	ctx, cancelRead := context.WithTimeout(ctx, readTimeout)
+	defer cancelRead()
+
+	apiVersion := r.client.DefaultApiVersion()
+	if model.ApiVersion.ValueString() != "" {
+		apiVersion = model.ApiVersion.ValueString()
+	}
+
+	var responseBody interface{}
+	var objects []interface{}
+	if !model.ObjectId.IsNull() {
+		options := clients.RequestOptions{
+			RetryOptions: clients.NewRetryOptions(model.Retry, http.MethodGet),
+		}
+		body, err := r.client.Read(ctx, fmt.Sprintf("directoryObjects/%s", model.ObjectId.ValueString()), apiVersion, options)
+		if err != nil {
+			resp.Diagnostics.AddError("Failed to read directory object", utils.ResponseErrorDetail(err))
+			return
+		}
+		responseBody = body
+		objects = []interface{}{body}
+		model.Id = model.ObjectId
+	} else {
+		options := clients.RequestOptions{
+			// getByIds doesn't change anything, so it's retried like a read.
+			RetryOptions: clients.NewRetryOptions(model.Retry, http.MethodGet),
+		}
+		ids := uniqueIds(AsListOfString(model.ObjectIds))
+		objects = make([]interface{}, 0, len(ids))
+		for start := 0; start < len(ids); start += getByIdsBatchSize {
+			end := min(start+getByIdsBatchSize, len(ids))
+			requestBody := map[string]interface{}{
+				"ids": ids[start:end],
+			}
+			if !model.Types.IsNull() {
+				requestBody["types"] = AsListOfString(model.Types)
+			}
+			body, err := r.client.Action(ctx, http.MethodPost, "directoryObjects/getByIds", apiVersion, requestBody, options)
+			if err != nil {
+				resp.Diagnostics.AddError("Failed to read directory objects", utils.ResponseErrorDetail(err))
+				return
+			}
+			if bodyMap, ok := body.(map[string]interface{}); ok {
+				if value, ok := bodyMap["value"].([]interface{}); ok {
+					objects = append(objects, value...)
+				}
+			}
+		}
+		responseBody = map[string]interface{}{
+			"value": objects,
+		}
+		model.Id = types.StringValue("directoryObjects/getByIds")
+	}
+
+	odataTypes := make(map[string]string, len(objects))
+	for _, object := range objects {
+		objectMap, ok := object.(map[string]interface{})
+		if !ok {
+			continue
+		}
+		id, _ := objectMap["id"].(string)
+		odataType, _ := objectMap["@odata.type"].(string)
+		if id != "" {
+			odataTypes[id] = odataType
+		}
+	}
+	model.ODataType = types.StringNull()
+	if !model.ObjectId.IsNull() {
+		model.ODataType = types.StringValue(odataTypes[model.ObjectId.ValueString()])
+	}
+	odataTypesValue, diags := types.MapValueFrom(ctx, types.StringType, odataTypes)
+	if resp.Diagnostics.Append(diags...); resp.Diagnostics.HasError() {
+		return
+	}
+	model.ODataTypes = odataTypesValue
+	model.Output = types.DynamicValue(buildOutputFromBody(responseBody, model.ResponseExportValues))
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &model)...)
+}
+
+// uniqueIds returns the IDs without the duplicates in their original order, the IDs are compared case-insensitively.
+func uniqueIds(ids []string) []string {
+	seen := make(map[string]bool, len(ids))
+	res := make([]string, 0, len(ids))
+	for _, id := range ids {
+		key := strings.ToLower(id)
+		if seen[key] {
+			continue
+		}
+		seen[key] = true
+		res = append(res, id)
+	}
+	return res
+}
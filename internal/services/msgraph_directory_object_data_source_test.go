@@ -0,0 +1,127 @@
+package services_test
+
+import (
+	"fmt"
+	"regexp"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+	"github.com/microsoft/terraform-provider-msgraph/internal/acceptance"
+	"github.com/microsoft/terraform-provider-msgraph/internal/acceptance/check"
+)
+
+type MSGraphTestDirectoryObjectDataSource struct{}
+
+func TestAcc_DirectoryObjectDataSourceObjectId(t *testing.T) {
+	data := acceptance.BuildTestData(t, "data.msgraph_directory_object", "test")
+	r := MSGraphTestDirectoryObjectDataSource{}
+
+	data.DataSourceTest(t, []resource.TestStep{
+		{
+			Config: r.objectId(),
+			Check: resource.ComposeTestCheckFunc(
+				check.That(data.ResourceName).Key("id").IsUUID(),
+				check.That(data.ResourceName).Key("odata_type").HasValue("#microsoft.graph.group"),
+				check.That(data.ResourceName).Key("output.display_name").HasValue("My Group"),
+			),
+		},
+	})
+}
+
+func TestAcc_DirectoryObjectDataSourceObjectIds(t *testing.T) {
+	data := acceptance.BuildTestData(t, "data.msgraph_directory_object", "test")
+	r := MSGraphTestDirectoryObjectDataSource{}
+
+	data.DataSourceTest(t, []resource.TestStep{
+		{
+			Config: r.objectIds(),
+			Check: resource.ComposeTestCheckFunc(
+				check.That(data.ResourceName).Key("id").HasValue("directoryObjects/getByIds"),
+				check.That(data.ResourceName).Key("odata_types.%").HasValue("2"),
+				check.That(data.ResourceName).Key("output.count").HasValue("2"),
+			),
+		},
+	})
+}
+
+func TestAcc_DirectoryObjectDataSourceInvalid(t *testing.T) {
+	data := acceptance.BuildTestData(t, "data.msgraph_directory_object", "test")
+	r := MSGraphTestDirectoryObjectDataSource{}
+
+	data.DataSourceTest(t, []resource.TestStep{
+		{
+			Config:      r.invalid(),
+			ExpectError: regexp.MustCompile("Exactly one of `object_id` and `object_ids` must be specified"),
+		},
+	})
+}
+
+func (r MSGraphTestDirectoryObjectDataSource) template() string {
+	return `
+resource "msgraph_resource" "group" {
+  url = "groups"
+  body = {
+    displayName     = "My Group"
+    mailEnabled     = false
+    mailNickname    = "mygroup-directory-object"
+    securityEnabled = true
+  }
+}
+
+resource "msgraph_resource" "application" {
+  url = "applications"
+  body = {
+    displayName = "Demo App"
+  }
+  response_export_values = {
+    app_id = "appId"
+  }
+}
+
+resource "msgraph_resource" "service_principal" {
+  url = "servicePrincipals"
+  body = {
+    appId = msgraph_resource.application.output.app_id
+  }
+}
+`
+}
+
+func (r MSGraphTestDirectoryObjectDataSource) objectId() string {
+	return fmt.Sprintf(`
+%s
+
+data "msgraph_directory_object" "test" {
+  object_id = msgraph_resource.group.id
+  response_export_values = {
+    display_name = "displayName"
+  }
+}
+`, r.template())
+}
+
+func (r MSGraphTestDirectoryObjectDataSource) objectIds() string {
+	return fmt.Sprintf(`
+%s
+
+data "msgraph_directory_object" "test" {
+  object_ids = [
+    msgraph_resource.group.id,
+    msgraph_resource.service_principal.id,
+    msgraph_resource.group.id,
+  ]
+  response_export_values = {
+    count = "length(value)"
+  }
+}
+`, r.template())
+}
+
+func (r MSGraphTestDirectoryObjectDataSource) invalid() string {
+	return `
+data "msgraph_directory_object" "test" {
+  object_id  = "00000000-0000-0000-0000-000000000000"
+  object_ids = ["00000000-0000-0000-0000-000000000000"]
+}
+`
+}
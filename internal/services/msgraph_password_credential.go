@@ -0,0 +1,398 @@
+package services
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"regexp"
+	"strings"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-framework-timeouts/resource/timeouts"
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+	"github.com/microsoft/terraform-provider-msgraph/internal/clients"
+	"github.com/microsoft/terraform-provider-msgraph/internal/docstrings"
+	"github.com/microsoft/terraform-provider-msgraph/internal/myvalidator"
+	"github.com/microsoft/terraform-provider-msgraph/internal/retry"
+	"github.com/microsoft/terraform-provider-msgraph/internal/utils"
+	"github.com/microsoft/terraform-provider-msgraph/internal/utils/consistency"
+)
+
+var (
+	_ resource.Resource               = &MSGraphPasswordCredential{}
+	_ resource.ResourceWithConfigure  = &MSGraphPasswordCredential{}
+	_ resource.ResourceWithModifyPlan = &MSGraphPasswordCredential{}
+)
+
+func NewMSGraphPasswordCredential() resource.Resource {
+	return &MSGraphPasswordCredential{}
+}
+
+type MSGraphPasswordCredential struct{ client *clients.MSGraphClient }
+
+type MSGraphPasswordCredentialModel struct {
+	Id            types.String   `tfsdk:"id"`
+	ApiVersion    types.String   `tfsdk:"api_version"`
+	ResourceUrl   types.String   `tfsdk:"resource_url"`
+	DisplayName   types.String   `tfsdk:"display_name"`
+	StartDateTime types.String   `tfsdk:"start_date_time"`
+	EndDateTime   types.String   `tfsdk:"end_date_time"`
+	KeyId         types.String   `tfsdk:"key_id"`
+	SecretText    types.String   `tfsdk:"secret_text"`
+	Hint          types.String   `tfsdk:"hint"`
+	Retry         retry.Value    `tfsdk:"retry"`
+	Timeouts      timeouts.Value `tfsdk:"timeouts"`
+}
+
+func (r *MSGraphPasswordCredential) Metadata(ctx context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_password_credential"
+}
+
+func (r *MSGraphPasswordCredential) Schema(ctx context.Context, req resource.SchemaRequest, resp *resource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		MarkdownDescription: "This resource manages a password credential, i.e. a client secret, of an application or a service principal. " +
+			"The password is added with the `addPassword` action when the resource is created, and the generated secret, which is only returned once, is stored in `secret_text`. " +
+			"The password is removed with the `removePassword` action when the resource is destroyed.\n\n" +
+			"-> **Note** The secret is stored in the state in plain text, make sure the state is stored securely. The resource can't be imported, as the secret can't be read after the password is added.",
+		Attributes: map[string]schema.Attribute{
+			"id": schema.StringAttribute{
+				MarkdownDescription: "The ID of the resource. It's the same as `key_id`.",
+				Computed:            true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+
+			"resource_url": schema.StringAttribute{
+				MarkdownDescription: "The URL of the application or service principal which the password is added to, e.g. `applications/{id}` or `servicePrincipals/{id}`. You can use the `resource_url` of `msgraph_resource`. Changing this forces a new resource to be created.",
+				Required:            true,
+				Validators: []validator.String{
+					myvalidator.RelativeURL(),
+					stringvalidator.RegexMatches(regexp.MustCompile(`^/?(applications|servicePrincipals)/[^/]+$`), "must be the URL of an application or a service principal, e.g. `applications/{id}`"),
+				},
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+
+			"api_version": schema.StringAttribute{
+				MarkdownDescription: docstrings.ApiVersion() + " Changing this forces a new resource to be created.",
+				Optional:            true,
+				Computed:            true,
+				Validators:          []validator.String{myvalidator.ApiVersion()},
+			},
+
+			"display_name": schema.StringAttribute{
+				MarkdownDescription: "The friendly name of the password. Changing this forces a new resource to be created.",
+				Optional:            true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+
+			"start_date_time": schema.StringAttribute{
+				MarkdownDescription: "The date and time at which the password becomes valid in the ISO 8601 format, e.g. `2025-01-01T00:00:00Z`. Defaults to the time the password is added. Changing this forces a new resource to be created.",
+				Optional:            true,
+				Computed:            true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+
+			"end_date_time": schema.StringAttribute{
+				MarkdownDescription: "The date and time at which the password expires in the ISO 8601 format, e.g. `2026-01-01T00:00:00Z`. Defaults to two years after `start_date_time`. Changing this forces a new resource to be created.",
+				Optional:            true,
+				Computed:            true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+
+			"key_id": schema.StringAttribute{
+				MarkdownDescription: "The ID of the password, which identifies it in the `passwordCredentials` of the application or service principal.",
+				Computed:            true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+
+			"secret_text": schema.StringAttribute{
+				MarkdownDescription: "The generated secret of the password.",
+				Computed:            true,
+				Sensitive:           true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+
+			"hint": schema.StringAttribute{
+				MarkdownDescription: "The first three characters of the secret.",
+				Computed:            true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+
+			"retry": retry.Schema(ctx),
+		},
+		Blocks: map[string]schema.Block{
+			"timeouts": timeouts.Block(ctx, timeouts.Opts{
+				Create: true,
+				Read:   true,
+				Delete: true,
+			}),
+		},
+	}
+}
+
+func (r *MSGraphPasswordCredential) Configure(ctx context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
+	if v, ok := req.ProviderData.(*clients.Client); ok {
+		r.client = v.MSGraphClient
+	}
+}
+
+func (r *MSGraphPasswordCredential) ModifyPlan(ctx context.Context, request resource.ModifyPlanRequest, response *resource.ModifyPlanResponse) {
+	if planDefaultApiVersion(ctx, r.client, request, response); response.Diagnostics.HasError() {
+		return
+	}
+
+	var plan, state *MSGraphPasswordCredentialModel
+	if response.Diagnostics.Append(response.Plan.Get(ctx, &plan)...); response.Diagnostics.HasError() {
+		return
+	}
+	if response.Diagnostics.Append(request.State.Get(ctx, &state)...); response.Diagnostics.HasError() {
+		return
+	}
+	if plan == nil || state == nil {
+		return
+	}
+
+	if !plan.ApiVersion.Equal(state.ApiVersion) {
+		response.RequiresReplace.Append(path.Root("api_version"))
+	}
+}
+
+func (r *MSGraphPasswordCredential) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	var model *MSGraphPasswordCredentialModel
+	if resp.Diagnostics.Append(req.Plan.Get(ctx, &model)...); resp.Diagnostics.HasError() {
+		return
+	}
+
+	timeout, diags := model.Timeouts.Create(ctx, 30*time.Minute)
+	resp.Diagnostics.Append(diags...)
+	ctx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
+	ctx = clients.WithOperationMetrics(ctx)
+	defer clients.LogOperationMetrics(ctx, fmt.Sprintf("msgraph_password_credential create of %s", model.ResourceUrl.ValueString()))
+
+	passwordCredential := make(map[string]interface{})
+	if v := model.DisplayName.ValueString(); v != "" {
+		passwordCredential["displayName"] = v
+	}
+	if v := model.StartDateTime.ValueString(); v != "" {
+		passwordCredential["startDateTime"] = v
+	}
+	if v := model.EndDateTime.ValueString(); v != "" {
+		passwordCredential["endDateTime"] = v
+	}
+	requestBody := map[string]interface{}{
+		"passwordCredential": passwordCredential,
+	}
+
+	options := clients.RequestOptions{
+		Headers:         make(map[string]string),
+		QueryParameters: make(map[string]string),
+		RetryOptions:    clients.NewRetryOptions(model.Retry, http.MethodPost),
+	}
+	responseBody, err := r.client.Action(ctx, http.MethodPost, actionUrl(model.ResourceUrl.ValueString(), "addPassword"), model.ApiVersion.ValueString(), requestBody, options)
+	if err != nil {
+		resp.Diagnostics.AddError("Failed to add password", utils.ResponseErrorDetail(err))
+		return
+	}
+
+	credential, _ := responseBody.(map[string]interface{})
+	keyId, _ := credential["keyId"].(string)
+	if keyId == "" {
+		resp.Diagnostics.AddError("Failed to add password", fmt.Sprintf("The response of adding a password to %q doesn't contain the `keyId` of the password.", model.ResourceUrl.ValueString()))
+		return
+	}
+	model.Id = types.StringValue(keyId)
+	model.KeyId = types.StringValue(keyId)
+	secretText, _ := credential["secretText"].(string)
+	model.SecretText = types.StringValue(secretText)
+	setPasswordCredential(model, credential)
+
+	// The secret is only returned once, so the state is saved before waiting for the password to be listed.
+	if resp.Diagnostics.Append(resp.State.Set(ctx, &model)...); resp.Diagnostics.HasError() {
+		return
+	}
+
+	if err := consistency.WaitForUpdate(ctx, r.existenceFunc(model)); err != nil {
+		resp.Diagnostics.AddError("Error", fmt.Sprintf("waiting for the password %s of %s: %v", keyId, model.ResourceUrl.ValueString(), err))
+		return
+	}
+}
+
+func (r *MSGraphPasswordCredential) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	var model *MSGraphPasswordCredentialModel
+	if resp.Diagnostics.Append(req.State.Get(ctx, &model)...); resp.Diagnostics.HasError() {
+		return
+	}
+
+	timeout, diags := model.Timeouts.Read(ctx, r.client.DefaultReadTimeout())
+	resp.Diagnostics.Append(diags...)
+	ctx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
+	ctx = clients.WithOperationMetrics(ctx)
+	defer clients.LogOperationMetrics(ctx, fmt.Sprintf("msgraph_password_credential read of %s", model.ResourceUrl.ValueString()))
+
+	var credential map[string]interface{}
+	// A password added recently may not be listed yet, it's read again before it's removed from the state.
+	found, err := consistency.WaitForVisible(ctx, referenceScanAttempts, referenceScanInterval, func(ctx context.Context) (*bool, error) {
+		var err error
+		credential, err = r.readPasswordCredential(ctx, model)
+		found := credential != nil
+		return &found, err
+	})
+	if err != nil {
+		if utils.ResponseErrorWasNotFound(err) {
+			tflog.Info(ctx, fmt.Sprintf("%q not found - removing from state", model.ResourceUrl.ValueString()))
+			resp.State.RemoveResource(ctx)
+			return
+		}
+		resp.Diagnostics.AddError("Failed to read password", utils.ResponseErrorDetail(err))
+		return
+	}
+	if !found {
+		tflog.Info(ctx, fmt.Sprintf("Password %q not found in %q - removing from state", model.KeyId.ValueString(), model.ResourceUrl.ValueString()))
+		resp.State.RemoveResource(ctx)
+		return
+	}
+
+	setPasswordCredential(model, credential)
+	resp.Diagnostics.Append(resp.State.Set(ctx, &model)...)
+}
+
+func (r *MSGraphPasswordCredential) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	// all the arguments of the password force a new resource, except the retries and timeouts
+	var model *MSGraphPasswordCredentialModel
+	if resp.Diagnostics.Append(req.Plan.Get(ctx, &model)...); resp.Diagnostics.HasError() {
+		return
+	}
+	resp.Diagnostics.Append(resp.State.Set(ctx, &model)...)
+}
+
+func (r *MSGraphPasswordCredential) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	var model *MSGraphPasswordCredentialModel
+	if resp.Diagnostics.Append(req.State.Get(ctx, &model)...); resp.Diagnostics.HasError() {
+		return
+	}
+
+	timeout, diags := model.Timeouts.Delete(ctx, 30*time.Minute)
+	resp.Diagnostics.Append(diags...)
+	ctx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
+	ctx = clients.WithOperationMetrics(ctx)
+	defer clients.LogOperationMetrics(ctx, fmt.Sprintf("msgraph_password_credential delete of %s", model.ResourceUrl.ValueString()))
+
+	requestBody := map[string]interface{}{
+		"keyId": model.KeyId.ValueString(),
+	}
+	options := clients.RequestOptions{
+		Headers:         make(map[string]string),
+		QueryParameters: make(map[string]string),
+		RetryOptions:    clients.NewRetryOptions(model.Retry, http.MethodPost),
+	}
+	_, err := r.client.Action(ctx, http.MethodPost, actionUrl(model.ResourceUrl.ValueString(), "removePassword"), model.ApiVersion.ValueString(), requestBody, options)
+	if err != nil {
+		if utils.ResponseErrorWasNotFound(err) {
+			tflog.Info(ctx, fmt.Sprintf("%q has already been deleted: %s", model.ResourceUrl.ValueString(), err.Error()))
+			return
+		}
+		resp.Diagnostics.AddError("Failed to remove password", utils.ResponseErrorDetail(err))
+		return
+	}
+}
+
+// readPasswordCredential returns the password of the model listed in the `passwordCredentials` of the application or
+// service principal, or nil if it's not listed.
+func (r *MSGraphPasswordCredential) readPasswordCredential(ctx context.Context, model *MSGraphPasswordCredentialModel) (map[string]interface{}, error) {
+	options := clients.RequestOptions{
+		Headers:         make(map[string]string),
+		QueryParameters: map[string]string{"$select": "passwordCredentials"},
+		RetryOptions:    clients.NewRetryOptions(model.Retry, http.MethodGet),
+	}
+	responseBody, err := r.client.Read(ctx, strings.TrimPrefix(model.ResourceUrl.ValueString(), "/"), model.ApiVersion.ValueString(), options)
+	if err != nil {
+		return nil, err
+	}
+	responseMap, _ := responseBody.(map[string]interface{})
+	credentials, _ := responseMap["passwordCredentials"].([]interface{})
+	for _, item := range credentials {
+		credential, ok := item.(map[string]interface{})
+		if !ok {
+			continue
+		}
+		if keyId, _ := credential["keyId"].(string); strings.EqualFold(keyId, model.KeyId.ValueString()) {
+			return credential, nil
+		}
+	}
+	return nil, nil
+}
+
+// existenceFunc checks whether the password which was just added is listed in the application or service principal.
+func (r *MSGraphPasswordCredential) existenceFunc(model *MSGraphPasswordCredentialModel) consistency.ChangeFunc {
+	return func(ctx context.Context) (*bool, error) {
+		credential, err := r.readPasswordCredential(ctx, model)
+		if err != nil {
+			if utils.ResponseErrorWasNotFound(err) {
+				b := false
+				return &b, nil
+			}
+			return nil, err
+		}
+		b := credential != nil
+		return &b, nil
+	}
+}
+
+// setPasswordCredential sets the properties of the password which are returned by the API to the model.
+func setPasswordCredential(model *MSGraphPasswordCredentialModel, credential map[string]interface{}) {
+	if v, ok := credential["displayName"].(string); ok && !model.DisplayName.IsNull() {
+		model.DisplayName = types.StringValue(v)
+	}
+	if v, ok := credential["startDateTime"].(string); ok && (model.StartDateTime.IsNull() || model.StartDateTime.IsUnknown()) {
+		model.StartDateTime = types.StringValue(v)
+	}
+	if v, ok := credential["endDateTime"].(string); ok && (model.EndDateTime.IsNull() || model.EndDateTime.IsUnknown()) {
+		model.EndDateTime = types.StringValue(v)
+	}
+	if v, ok := credential["hint"].(string); ok {
+		model.Hint = types.StringValue(v)
+	}
+	// the values which are not returned are null rather than unknown after the password is added
+	if model.StartDateTime.IsUnknown() {
+		model.StartDateTime = types.StringNull()
+	}
+	if model.EndDateTime.IsUnknown() {
+		model.EndDateTime = types.StringNull()
+	}
+	if model.Hint.IsUnknown() {
+		model.Hint = types.StringNull()
+	}
+}
+
+// actionUrl returns the URL of the action bound to the object, e.g. `applications/{id}/addPassword`.
+func actionUrl(resourceUrl string, action string) string {
+	return fmt.Sprintf("%s/%s", strings.TrimSuffix(strings.TrimPrefix(resourceUrl, "/"), "/"), action)
+}
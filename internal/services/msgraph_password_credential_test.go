@@ -0,0 +1,133 @@
+package services_test
+
+import (
+	"context"
+	"fmt"
+	"regexp"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+	"github.com/hashicorp/terraform-plugin-testing/terraform"
+	"github.com/microsoft/terraform-provider-msgraph/internal/acceptance"
+	"github.com/microsoft/terraform-provider-msgraph/internal/acceptance/check"
+	"github.com/microsoft/terraform-provider-msgraph/internal/clients"
+	"github.com/microsoft/terraform-provider-msgraph/internal/utils"
+)
+
+type MSGraphTestPasswordCredential struct{}
+
+func TestAcc_PasswordCredentialBasic(t *testing.T) {
+	data := acceptance.BuildTestData(t, "msgraph_password_credential", "test")
+	r := MSGraphTestPasswordCredential{}
+
+	data.ResourceTest(t, r, []resource.TestStep{
+		{
+			Config: r.basic(data, "applications"),
+			Check: resource.ComposeTestCheckFunc(
+				check.That(data.ResourceName).Exists(r),
+				check.That(data.ResourceName).Key("key_id").IsUUID(),
+				check.That(data.ResourceName).Key("secret_text").IsSet(),
+				check.That(data.ResourceName).Key("hint").IsSet(),
+				check.That(data.ResourceName).Key("end_date_time").HasValue("2030-01-01T00:00:00Z"),
+			),
+		},
+		{
+			Config:   r.basic(data, "applications"),
+			PlanOnly: true,
+		},
+	})
+}
+
+func TestAcc_PasswordCredentialServicePrincipal(t *testing.T) {
+	data := acceptance.BuildTestData(t, "msgraph_password_credential", "test")
+	r := MSGraphTestPasswordCredential{}
+
+	data.ResourceTest(t, r, []resource.TestStep{
+		{
+			Config: r.basic(data, "servicePrincipals"),
+			Check: resource.ComposeTestCheckFunc(
+				check.That(data.ResourceName).Exists(r),
+				check.That(data.ResourceName).Key("key_id").IsUUID(),
+				check.That(data.ResourceName).Key("secret_text").IsSet(),
+			),
+		},
+	})
+}
+
+func TestAcc_PasswordCredentialInvalidUrl(t *testing.T) {
+	data := acceptance.BuildTestData(t, "msgraph_password_credential", "test")
+	r := MSGraphTestPasswordCredential{}
+
+	data.ResourceTest(t, r, []resource.TestStep{
+		{
+			Config:      r.invalidUrl(),
+			PlanOnly:    true,
+			ExpectError: regexp.MustCompile("application or a service principal"),
+		},
+	})
+}
+
+func (r MSGraphTestPasswordCredential) Exists(ctx context.Context, client *clients.Client, state *terraform.InstanceState) (*bool, error) {
+	apiVersion := state.Attributes["api_version"]
+	url := state.Attributes["resource_url"]
+
+	options := clients.DefaultRequestOptions()
+	options.QueryParameters["$select"] = "passwordCredentials"
+	responseBody, err := client.MSGraphClient.Read(ctx, url, apiVersion, options)
+	if err != nil {
+		if utils.ResponseErrorWasNotFound(err) {
+			b := false
+			return &b, nil
+		}
+		return nil, fmt.Errorf("checking for presence of existing password %s of %s(api_version=%s): %w", state.ID, url, apiVersion, err)
+	}
+	responseMap, _ := responseBody.(map[string]interface{})
+	credentials, _ := responseMap["passwordCredentials"].([]interface{})
+	for _, item := range credentials {
+		if credential, ok := item.(map[string]interface{}); ok && credential["keyId"] == state.Attributes["key_id"] {
+			b := true
+			return &b, nil
+		}
+	}
+	b := false
+	return &b, nil
+}
+
+func (r MSGraphTestPasswordCredential) basic(data acceptance.TestData, collection string) string {
+	owner := "msgraph_resource.application"
+	if collection == "servicePrincipals" {
+		owner = "msgraph_resource.servicePrincipal"
+	}
+	return fmt.Sprintf(`
+resource "msgraph_resource" "application" {
+  url = "applications"
+  body = {
+    displayName = "acctest-password-%[1]s"
+  }
+  response_export_values = {
+    appId = "appId"
+  }
+}
+
+resource "msgraph_resource" "servicePrincipal" {
+  url = "servicePrincipals"
+  body = {
+    appId = msgraph_resource.application.output.appId
+  }
+}
+
+resource "msgraph_password_credential" "test" {
+  resource_url  = %[2]s.resource_url
+  display_name  = "acctest"
+  end_date_time = "2030-01-01T00:00:00Z"
+}
+`, data.RandomString, owner)
+}
+
+func (r MSGraphTestPasswordCredential) invalidUrl() string {
+	return `
+resource "msgraph_password_credential" "test" {
+  resource_url = "groups/00000000-0000-0000-0000-000000000000"
+}
+`
+}
@@ -0,0 +1,184 @@
+package services
+
+import (
+	"context"
+	"net/http"
+
+	"github.com/hashicorp/terraform-plugin-framework-timeouts/resource/timeouts"
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/microsoft/terraform-provider-msgraph/internal/clients"
+	"github.com/microsoft/terraform-provider-msgraph/internal/docstrings"
+	"github.com/microsoft/terraform-provider-msgraph/internal/myvalidator"
+	"github.com/microsoft/terraform-provider-msgraph/internal/retry"
+	"github.com/microsoft/terraform-provider-msgraph/internal/utils"
+)
+
+const (
+	reportFormatJSON = "json"
+	reportFormatCSV  = "csv"
+)
+
+// Ensure provider defined types fully satisfy framework interfaces.
+var _ datasource.DataSource = &MSGraphReportDataSource{}
+
+func NewMSGraphReportDataSource() datasource.DataSource {
+	return &MSGraphReportDataSource{}
+}
+
+// MSGraphReportDataSource defines the data source implementation.
+type MSGraphReportDataSource struct {
+	client *clients.MSGraphClient
+}
+
+// MSGraphReportDataSourceModel describes the data source data model.
+type MSGraphReportDataSourceModel struct {
+	Id                   types.String      `tfsdk:"id"`
+	ApiVersion           types.String      `tfsdk:"api_version"`
+	Url                  types.String      `tfsdk:"url"`
+	Format               types.String      `tfsdk:"format"`
+	ResponseExportValues map[string]string `tfsdk:"response_export_values"`
+	Headers              types.Map         `tfsdk:"headers"`
+	QueryParameters      types.Map         `tfsdk:"query_parameters"`
+	Retry                retry.Value       `tfsdk:"retry"`
+	Output               types.Dynamic     `tfsdk:"output"`
+	Timeouts             timeouts.Value    `tfsdk:"timeouts"`
+}
+
+func (r *MSGraphReportDataSource) Metadata(ctx context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_report"
+}
+
+func (r *MSGraphReportDataSource) Schema(ctx context.Context, req datasource.SchemaRequest, resp *datasource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		// This description is used by the documentation generator and the language server.
+		MarkdownDescription: "This data source can read the reporting endpoints of the Microsoft Graph API, e.g. the sign-in activities of service principals or the usage reports of Microsoft 365. The reports are read-only, they are returned as JSON or CSV, which is parsed into a list of objects keyed by the column names. All pages of the report are read, the items are returned in `value`.",
+
+		Attributes: map[string]schema.Attribute{
+			"id": schema.StringAttribute{
+				MarkdownDescription: "The ID of the report. It's the URL of the report.",
+				Computed:            true,
+			},
+
+			"url": schema.StringAttribute{
+				MarkdownDescription: "The URL of the report, e.g. `reports/servicePrincipalSignInActivities` or `reports/getOffice365ActiveUserDetail(period='D7')`.",
+				Required:            true,
+				Validators: []validator.String{
+					myvalidator.RelativeURL(),
+				},
+			},
+
+			"api_version": schema.StringAttribute{
+				MarkdownDescription: docstrings.ApiVersion(),
+				Optional:            true,
+				Validators: []validator.String{
+					myvalidator.ApiVersion(),
+				},
+			},
+
+			"format": schema.StringAttribute{
+				MarkdownDescription: "The format of the report. The allowed values are `json` and `csv`. Reports returned as CSV, e.g. the usage reports `reports/getOffice365ActiveUserDetail(period='D7')`, are parsed into a list of objects keyed by the column names and returned in `value`, the values are strings. Defaults to `json`.",
+				Optional:            true,
+				Validators: []validator.String{
+					stringvalidator.OneOf(reportFormatJSON, reportFormatCSV),
+				},
+			},
+
+			"response_export_values": schema.MapAttribute{
+				MarkdownDescription: docstrings.ResponseExportValues(),
+				Optional:            true,
+				ElementType:         types.StringType,
+			},
+
+			"headers": schema.MapAttribute{
+				ElementType:         types.StringType,
+				Optional:            true,
+				MarkdownDescription: "A map of headers to include in the request",
+			},
+
+			"query_parameters": schema.MapAttribute{
+				ElementType: types.ListType{
+					ElemType: types.StringType,
+				},
+				Optional:            true,
+				MarkdownDescription: "A map of query parameters to include in the request",
+			},
+
+			"retry": retry.Schema(ctx),
+
+			"output": schema.DynamicAttribute{
+				MarkdownDescription: docstrings.Output(),
+				Computed:            true,
+			},
+		},
+
+		Blocks: map[string]schema.Block{
+			"timeouts": timeouts.Block(ctx, timeouts.Opts{
+				Read: true,
+			}),
+		},
+	}
+}
+
+func (r *MSGraphReportDataSource) Configure(ctx context.Context, req datasource.ConfigureRequest, resp *datasource.ConfigureResponse) {
+	if v, ok := req.ProviderData.(*clients.Client); ok {
+		r.client = v.MSGraphClient
+	}
+}
+
+func (r *MSGraphReportDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
+	var model MSGraphReportDataSourceModel
+	if resp.Diagnostics.Append(req.Config.Get(ctx, &model)...); resp.Diagnostics.HasError() {
+		return
+	}
+
+	readTimeout, diags := model.Timeouts.Read(ctx, r.client.DefaultReadTimeout())
+	resp.Diagnostics.Append(diags...)
+	ctx, cancelRead := context.WithTimeout(ctx, readTimeout)
+	defer cancelRead()
+
+	apiVersion := r.client.DefaultApiVersion()
+	if model.ApiVersion.ValueString() != "" {
+		apiVersion = model.ApiVersion.ValueString()
+	}
+
+	options := clients.RequestOptions{
+		Headers:         clients.NewHeaders(AsMapOfString(model.Headers)),
+		QueryParameters: clients.NewQueryParameters(AsMapOfLists(model.QueryParameters)),
+		RetryOptions:    clients.NewRetryOptions(model.Retry, http.MethodGet),
+	}
+
+	var responseBody interface{}
+	switch model.Format.ValueString() {
+	case reportFormatCSV:
+		// The CSV reports redirect to a pre-authenticated download URL, which is followed by the HTTP client.
+		content, err := r.client.ReadRaw(ctx, model.Url.ValueString(), apiVersion, options)
+		if err != nil {
+			resp.Diagnostics.AddError("Failed to read report", utils.ResponseErrorDetail(err))
+			return
+		}
+		items, err := utils.ParseCSV(content)
+		if err != nil {
+			resp.Diagnostics.AddError("Failed to parse report", err.Error())
+			return
+		}
+		responseBody = map[string]interface{}{
+			"value": items,
+		}
+	default:
+		body, err := r.client.List(ctx, model.Url.ValueString(), apiVersion, options)
+		if err != nil {
+			resp.Diagnostics.AddError("Failed to read report", utils.ResponseErrorDetail(err))
+			return
+		}
+		responseBody = body
+	}
+
+	model.Id = types.StringValue(model.Url.ValueString())
+	model.Output = types.DynamicValue(buildOutputFromBody(responseBody, model.ResponseExportValues))
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &model)...)
+}
@@ -0,0 +1,64 @@
+package services_test
+
+import (
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+	"github.com/microsoft/terraform-provider-msgraph/internal/acceptance"
+	"github.com/microsoft/terraform-provider-msgraph/internal/acceptance/check"
+)
+
+type MSGraphTestReportDataSource struct{}
+
+func TestAcc_ReportDataSourceJSON(t *testing.T) {
+	data := acceptance.BuildTestData(t, "data.msgraph_report", "test")
+	r := MSGraphTestReportDataSource{}
+
+	data.DataSourceTest(t, []resource.TestStep{
+		{
+			Config: r.json(),
+			Check: resource.ComposeTestCheckFunc(
+				check.That(data.ResourceName).Key("id").HasValue("reports/servicePrincipalSignInActivities"),
+				check.That(data.ResourceName).Key("output.value.#").Exists(),
+			),
+		},
+	})
+}
+
+func TestAcc_ReportDataSourceCSV(t *testing.T) {
+	data := acceptance.BuildTestData(t, "data.msgraph_report", "test")
+	r := MSGraphTestReportDataSource{}
+
+	data.DataSourceTest(t, []resource.TestStep{
+		{
+			Config: r.csv(),
+			Check: resource.ComposeTestCheckFunc(
+				check.That(data.ResourceName).Key("output.value.#").Exists(),
+			),
+		},
+	})
+}
+
+func (r MSGraphTestReportDataSource) json() string {
+	return `
+data "msgraph_report" "test" {
+  url         = "reports/servicePrincipalSignInActivities"
+  api_version = "beta"
+  response_export_values = {
+    value = "value[].{appId: appId, lastSignInDateTime: lastSignInActivity.lastSignInDateTime}"
+  }
+}
+`
+}
+
+func (r MSGraphTestReportDataSource) csv() string {
+	return `
+data "msgraph_report" "test" {
+  url    = "reports/getOffice365ActiveUserDetail(period='D7')"
+  format = "csv"
+  response_export_values = {
+    value = "value"
+  }
+}
+`
+}
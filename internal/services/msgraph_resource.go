@@ -4,14 +4,22 @@ import (
 	"context"
 	"encoding/json"
 	"fmt"
+	"net/http"
 	"net/url"
 	"reflect"
+	"regexp"
+	"slices"
 	"strings"
 	"time"
 
+	"github.com/hashicorp/go-uuid"
 	"github.com/hashicorp/terraform-plugin-framework-timeouts/resource/timeouts"
+	"github.com/hashicorp/terraform-plugin-framework-validators/int64validator"
+	"github.com/hashicorp/terraform-plugin-framework-validators/listvalidator"
+	"github.com/hashicorp/terraform-plugin-framework-validators/resourcevalidator"
 	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
 	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
 	"github.com/hashicorp/terraform-plugin-framework/path"
 	"github.com/hashicorp/terraform-plugin-framework/resource"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
@@ -21,16 +29,28 @@ import (
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
 	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
 	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
 	"github.com/hashicorp/terraform-plugin-log/tflog"
 	"github.com/microsoft/terraform-provider-msgraph/internal/clients"
 	"github.com/microsoft/terraform-provider-msgraph/internal/docstrings"
 	"github.com/microsoft/terraform-provider-msgraph/internal/dynamic"
+	"github.com/microsoft/terraform-provider-msgraph/internal/locks"
+	"github.com/microsoft/terraform-provider-msgraph/internal/myvalidator"
 	"github.com/microsoft/terraform-provider-msgraph/internal/retry"
 	"github.com/microsoft/terraform-provider-msgraph/internal/utils"
 	"github.com/microsoft/terraform-provider-msgraph/internal/utils/consistency"
 )
 
-const FlagMoveState = "move_state"
+const (
+	writeOncePolicyIgnore  = "ignore"
+	writeOncePolicyReplace = "replace"
+)
+
+const (
+	FlagMoveState = "move_state"
+	// FlagRemoteBody is the key of the private state which holds the snapshot of the remote body used by `full_body_sync`.
+	FlagRemoteBody = "remote_body"
+)
 
 // Ensure provider defined types fully satisfy framework interfaces.
 var (
@@ -38,6 +58,7 @@ var (
 	_ resource.ResourceWithImportState      = &MSGraphResource{}
 	_ resource.ResourceWithConfigValidators = &MSGraphResource{}
 	_ resource.ResourceWithModifyPlan       = &MSGraphResource{}
+	_ resource.ResourceWithValidateConfig   = &MSGraphResource{}
 	_ resource.ResourceWithMoveState        = &MSGraphResource{}
 )
 
@@ -51,26 +72,95 @@ type MSGraphResource struct {
 }
 
 func (r *MSGraphResource) ConfigValidators(ctx context.Context) []resource.ConfigValidator {
-	return []resource.ConfigValidator{}
+	return []resource.ConfigValidator{
+		resourcevalidator.Conflicting(path.MatchRoot("body"), path.MatchRoot("body_json")),
+	}
+}
+
+func (r *MSGraphResource) ValidateConfig(ctx context.Context, req resource.ValidateConfigRequest, resp *resource.ValidateConfigResponse) {
+	var model *MSGraphResourceModel
+	if resp.Diagnostics.Append(req.Config.Get(ctx, &model)...); resp.Diagnostics.HasError() {
+		return
+	}
+
+	if model.CreateMethod.ValueString() == http.MethodPut && strings.HasSuffix(model.Url.ValueString(), "/$ref") {
+		resp.Diagnostics.AddAttributeError(path.Root("create_method"), "Invalid configuration", "`create_method` can't be `PUT` when `url` ends with `/$ref`, references are always added with `POST`.")
+	}
+
+	if !model.IdPath.IsNull() && strings.HasSuffix(model.Url.ValueString(), "/$ref") {
+		resp.Diagnostics.AddAttributeError(path.Root("id_path"), "Invalid configuration", "`id_path` can't be specified when `url` ends with `/$ref`, the identifier of a reference is the last segment of its `@odata.id`.")
+	}
+
+	if !model.PrecheckExistsFilter.IsNull() && (model.CreateMethod.ValueString() == http.MethodPut || strings.HasSuffix(model.Url.ValueString(), "/$ref")) {
+		resp.Diagnostics.AddAttributeError(path.Root("precheck_exists_filter"), "Invalid configuration", "`precheck_exists_filter` can only be used when the object is created with `POST` in the collection `url`.")
+	}
+
+	if model.PreferReturnRepresentation.ValueBool() {
+		for key := range model.RequestHeaders.Elements() {
+			if strings.EqualFold(key, "Prefer") {
+				resp.Diagnostics.AddAttributeError(path.Root("prefer_return_representation"), "Invalid configuration", "`prefer_return_representation` can't be `true` when `request_headers` contains the `Prefer` header, set `Prefer = \"return=representation\"` in `request_headers` instead.")
+				break
+			}
+		}
+	}
+
+	if !model.BodyJson.IsNull() && !model.BodyJson.IsUnknown() {
+		if _, err := requestBodyOf(model); err != nil {
+			resp.Diagnostics.AddAttributeError(path.Root("body_json"), "Invalid configuration", fmt.Sprintf("`body_json` must be valid JSON: %s", err.Error()))
+		}
+	}
 }
 
 // MSGraphResourceModel describes the resource data model.
 type MSGraphResourceModel struct {
-	Id                    types.String      `tfsdk:"id"`
-	ResourceUrl           types.String      `tfsdk:"resource_url"`
-	ApiVersion            types.String      `tfsdk:"api_version"`
-	Url                   types.String      `tfsdk:"url"`
-	Body                  types.Dynamic     `tfsdk:"body"`
-	IgnoreMissingProperty types.Bool        `tfsdk:"ignore_missing_property"`
-	CreateQueryParameters types.Map         `tfsdk:"create_query_parameters"`
-	UpdateQueryParameters types.Map         `tfsdk:"update_query_parameters"`
-	ReadQueryParameters   types.Map         `tfsdk:"read_query_parameters"`
-	DeleteQueryParameters types.Map         `tfsdk:"delete_query_parameters"`
-	ResponseExportValues  map[string]string `tfsdk:"response_export_values"`
-	Retry                 retry.Value       `tfsdk:"retry"`
-	Output                types.Dynamic     `tfsdk:"output"`
-	Timeouts              timeouts.Value    `tfsdk:"timeouts"`
-	UpdateMethod          types.String      `tfsdk:"update_method"`
+	Id                         types.String      `tfsdk:"id"`
+	ResourceUrl                types.String      `tfsdk:"resource_url"`
+	ResourceFullUrl            types.String      `tfsdk:"resource_full_url"`
+	ResourceId                 types.String      `tfsdk:"resource_id"`
+	ApiVersion                 types.String      `tfsdk:"api_version"`
+	TenantId                   types.String      `tfsdk:"tenant_id"`
+	Url                        types.String      `tfsdk:"url"`
+	Body                       types.Dynamic     `tfsdk:"body"`
+	BodyJson                   types.String      `tfsdk:"body_json"`
+	IgnoreMissingProperty      types.Bool        `tfsdk:"ignore_missing_property"`
+	IgnoreCasing               types.Bool        `tfsdk:"ignore_casing"`
+	CreateQueryParameters      types.Map         `tfsdk:"create_query_parameters"`
+	UpdateQueryParameters      types.Map         `tfsdk:"update_query_parameters"`
+	ReadQueryParameters        types.Map         `tfsdk:"read_query_parameters"`
+	DeleteQueryParameters      types.Map         `tfsdk:"delete_query_parameters"`
+	RequestHeaders             types.Map         `tfsdk:"request_headers"`
+	ResponseExportValues       map[string]string `tfsdk:"response_export_values"`
+	OutputFormat               types.String      `tfsdk:"output_format"`
+	Retry                      retry.Value       `tfsdk:"retry"`
+	Output                     types.Dynamic     `tfsdk:"output"`
+	SensitiveOutput            types.Dynamic     `tfsdk:"sensitive_output"`
+	SensitiveOutputPatterns    types.List        `tfsdk:"sensitive_output_path_patterns"`
+	Timeouts                   timeouts.Value    `tfsdk:"timeouts"`
+	UpdateMethod               types.String      `tfsdk:"update_method"`
+	CreateMethod               types.String      `tfsdk:"create_method"`
+	PreferReturnRepresentation types.Bool        `tfsdk:"prefer_return_representation"`
+	ValidateOnPlan             types.Bool        `tfsdk:"validate_on_plan"`
+	PutMerge                   types.Bool        `tfsdk:"put_merge"`
+	FullBodySync               types.Bool        `tfsdk:"full_body_sync"`
+	ReadAfterCreate            types.Bool        `tfsdk:"read_after_create"`
+	ReadReferencedObject       types.Bool        `tfsdk:"read_referenced_object"`
+	AutoODataType              types.Bool        `tfsdk:"auto_odata_type"`
+	GranularReferenceUpdates   types.Bool        `tfsdk:"granular_reference_updates"`
+	AcceptableErrorCodes       types.List        `tfsdk:"acceptable_error_codes"`
+	DeleteIgnoreStatusCodes    types.List        `tfsdk:"delete_ignore_status_codes"`
+	IdAttribute                types.String      `tfsdk:"id_attribute"`
+	IdPath                     types.String      `tfsdk:"id_path"`
+	ExpandBodyNavigations      types.Bool        `tfsdk:"expand_body_navigations"`
+	LockId                     types.String      `tfsdk:"lock_id"`
+	RedactPlanPaths            types.List        `tfsdk:"redact_plan_paths"`
+	WriteOncePaths             types.List        `tfsdk:"write_once_paths"`
+	WriteOncePolicy            types.String      `tfsdk:"write_once_policy"`
+	StripBodyPaths             types.List        `tfsdk:"strip_body_paths"`
+	Consistency                types.Object      `tfsdk:"consistency"`
+	WriteOnlyBody              types.Dynamic     `tfsdk:"write_only_body"`
+	WriteOnlyBodyVersion       types.Int64       `tfsdk:"write_only_body_version"`
+	PrecheckExistsFilter       types.String      `tfsdk:"precheck_exists_filter"`
+	CreateRetry                types.Object      `tfsdk:"create_retry"`
 }
 
 func (r *MSGraphResource) Metadata(ctx context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
@@ -94,6 +184,9 @@ func (r *MSGraphResource) Schema(ctx context.Context, req resource.SchemaRequest
 			"url": schema.StringAttribute{
 				MarkdownDescription: docstrings.Url("resource"),
 				Required:            true,
+				Validators: []validator.String{
+					myvalidator.RelativeURL(),
+				},
 			},
 
 			"api_version": schema.StringAttribute{
@@ -101,9 +194,66 @@ func (r *MSGraphResource) Schema(ctx context.Context, req resource.SchemaRequest
 				Optional:            true,
 				Computed:            true,
 				Validators: []validator.String{
-					stringvalidator.OneOf("v1.0", "beta"),
+					myvalidator.ApiVersion(),
+				},
+			},
+
+			"tenant_id": schema.StringAttribute{
+				MarkdownDescription: docstrings.TenantId("resource") + " Changing this forces a new resource to be created. To import a resource of another tenant, append `?tenant_id={tenant-id}` to the import ID.",
+				Optional:            true,
+				Validators: []validator.String{
+					stringvalidator.LengthAtLeast(1),
+				},
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+
+			"write_only_body": schema.DynamicAttribute{
+				MarkdownDescription: docstrings.WriteOnlyBody(),
+				Optional:            true,
+				WriteOnly:           true,
+			},
+
+			"write_only_body_version": schema.Int64Attribute{
+				MarkdownDescription: "A version of `write_only_body`, which is an arbitrary number to increment when its values are changed, e.g. to rotate a secret. The values of `write_only_body` aren't stored, so they're only sent again in the update request when this version is changed.",
+				Optional:            true,
+			},
+
+			"redact_plan_paths": schema.ListAttribute{
+				MarkdownDescription: docstrings.RedactPlanPaths() + " The values exported to `output` at these paths are moved to `sensitive_output`, so the plan shows them as `(sensitive value)` instead of rendering them.",
+				ElementType:         types.StringType,
+				Optional:            true,
+			},
+
+			"write_once_paths": schema.ListAttribute{
+				MarkdownDescription: "A list of paths of `body` whose values can only be set when the object is created, e.g. `mailNickname` of a group or `web.homePageUrl`. The paths are separated by dots. What happens when the value of such a path is changed is controlled by `write_once_policy`. This replaces the `ignore_changes` lifecycle rules used to avoid the `PATCH` requests which are rejected by the API for these properties.",
+				ElementType:         types.StringType,
+				Optional:            true,
+				Validators: []validator.List{
+					listvalidator.SizeAtLeast(1),
+					listvalidator.ValueStringsAre(stringvalidator.LengthAtLeast(1)),
+				},
+			},
+
+			"strip_body_paths": schema.ListAttribute{
+				MarkdownDescription: "A list of paths of `body` which are removed from the request body before it's sent, e.g. `_comment` or `web._comment`. The paths are separated by dots. This allows annotating `body` with fields which are only meant for the readers of the configuration and would be rejected by the API. The fields are kept in the state and are not compared with the response, so they never show up as changes.",
+				ElementType:         types.StringType,
+				Optional:            true,
+				Validators: []validator.List{
+					listvalidator.SizeAtLeast(1),
+					listvalidator.ValueStringsAre(stringvalidator.LengthAtLeast(1)),
+				},
+			},
+
+			"write_once_policy": schema.StringAttribute{
+				MarkdownDescription: "What happens when the value of a path in `write_once_paths` is changed. Allowed values are `ignore` (default) and `replace`. With `ignore`, the changed value is not sent in the update request, the remote value is kept as is, and the path is not read back from the API, so the change is not reported as drift. With `replace`, the object is destroyed and created again with the new value, and the changes made outside of Terraform are reported as drift.",
+				Optional:            true,
+				Computed:            true,
+				Default:             stringdefault.StaticString(writeOncePolicyIgnore),
+				Validators: []validator.String{
+					stringvalidator.OneOf(writeOncePolicyIgnore, writeOncePolicyReplace),
 				},
-				Default: stringdefault.StaticString("v1.0"),
 			},
 
 			"body": schema.DynamicAttribute{
@@ -111,6 +261,11 @@ func (r *MSGraphResource) Schema(ctx context.Context, req resource.SchemaRequest
 				Optional:            true,
 			},
 
+			"body_json": schema.StringAttribute{
+				MarkdownDescription: "A JSON-encoded string of the request body, e.g. the body copied from the Microsoft Graph documentation or Graph Explorer. It's an alternative to `body` and can't be specified together with it. It's useful when the body contains `@odata.type` discriminators, numbers or nulls which are cumbersome to express in HCL. Changes made outside of Terraform are reported as changes of the whole string.",
+				Optional:            true,
+			},
+
 			"ignore_missing_property": schema.BoolAttribute{
 				MarkdownDescription: docstrings.IgnoreMissingProperty(),
 				Optional:            true,
@@ -118,6 +273,68 @@ func (r *MSGraphResource) Schema(ctx context.Context, req resource.SchemaRequest
 				Default:             booldefault.StaticBool(true),
 			},
 
+			"ignore_casing": schema.BoolAttribute{
+				MarkdownDescription: docstrings.IgnoreCasing(),
+				Optional:            true,
+				Computed:            true,
+				Default:             booldefault.StaticBool(false),
+			},
+
+			"auto_odata_type": schema.BoolAttribute{
+				MarkdownDescription: docstrings.AutoODataType(),
+				Optional:            true,
+				Computed:            true,
+				Default:             booldefault.StaticBool(false),
+			},
+
+			"full_body_sync": schema.BoolAttribute{
+				MarkdownDescription: "Whether to detect changes made outside of Terraform to the properties which are not configured in `body`. When enabled, a snapshot of the remote object is kept after it's created or updated, and the properties which differ from the snapshot are added to `body` when reading the resource, so they show up as drift and are reverted to the values of the snapshot by the next apply. Properties which are not returned anymore are only reported when `ignore_missing_property` is `false`. Defaults to `false`.",
+				Optional:            true,
+				Computed:            true,
+				Default:             booldefault.StaticBool(false),
+			},
+
+			"read_after_create": schema.BoolAttribute{
+				MarkdownDescription: "Whether to read the object after it's created, e.g. `false` for write-only or action-like endpoints which don't support `GET`, so the read fails even though the object was created. When `false`, the provider doesn't wait for the object to exist nor read it after it's created or updated, and it isn't refreshed when reading the resource, so `output` is empty and the changes made outside of Terraform, including the deletion of the object, are not detected. Defaults to `true`.",
+				Optional:            true,
+				Computed:            true,
+				Default:             booldefault.StaticBool(true),
+			},
+
+			"read_referenced_object": schema.BoolAttribute{
+				MarkdownDescription: "Whether to read the directory object referred to by a relationship, i.e. when `url` ends with `/$ref`, with `GET /directoryObjects/{id}`, so `response_export_values` can export its properties, e.g. the `displayName` of a member of a group. Defaults to `false`, the relationships don't have an `output` and the referenced object isn't read.",
+				Optional:            true,
+				Computed:            true,
+				Default:             booldefault.StaticBool(false),
+			},
+
+			"granular_reference_updates": schema.BoolAttribute{
+				MarkdownDescription: "Whether to update the collections of references in `body`, e.g. `owners@odata.bind` or `members@odata.bind`, by adding and removing the changed references individually with `POST .../{navigation property}/$ref` and `DELETE .../{navigation property}/{id}/$ref` requests, instead of sending the whole collection in the `PATCH` request. The references are compared by the ID of the object they refer to. Only used when `update_method` is `PATCH`. Defaults to `false`.",
+				Optional:            true,
+				Computed:            true,
+				Default:             booldefault.StaticBool(false),
+			},
+
+			"acceptable_error_codes": acceptableErrorCodesSchema(docstrings.AcceptableErrorCodes("the update and delete requests")),
+
+			"delete_ignore_status_codes": schema.ListAttribute{
+				MarkdownDescription: "A list of HTTP status codes of the delete request which mean that the object is already deleted, e.g. `400` or `405` for the services which don't return `404` for an object which is gone. The resource is removed from the state without waiting for the deletion. A `404` is always treated as already deleted.",
+				ElementType:         types.Int64Type,
+				Optional:            true,
+				Validators: []validator.List{
+					listvalidator.SizeAtLeast(1),
+					listvalidator.UniqueValues(),
+					listvalidator.ValueInt64sAre(int64validator.Between(400, 599)),
+				},
+			},
+
+			"expand_body_navigations": schema.BoolAttribute{
+				MarkdownDescription: "Whether to add the navigation properties bound in `body` with `<navigation property>@odata.bind`, e.g. `owners@odata.bind`, to `$expand` when reading the resource. This allows detecting changes of the bound navigation properties made outside of Terraform. Defaults to `false`. Note that the API limits the number of expanded items, e.g. up to 20 `members` of a group are returned.",
+				Optional:            true,
+				Computed:            true,
+				Default:             booldefault.StaticBool(false),
+			},
+
 			"create_query_parameters": schema.MapAttribute{
 				ElementType: types.ListType{
 					ElemType: types.StringType,
@@ -139,7 +356,7 @@ func (r *MSGraphResource) Schema(ctx context.Context, req resource.SchemaRequest
 					ElemType: types.StringType,
 				},
 				Optional:            true,
-				MarkdownDescription: "A mapping of query parameters to be sent with the read request.",
+				MarkdownDescription: "A mapping of query parameters to be sent with the read request. When they're changed, the plan shows a warning with the resulting query string, as they change the properties which are read.",
 			},
 
 			"delete_query_parameters": schema.MapAttribute{
@@ -150,12 +367,28 @@ func (r *MSGraphResource) Schema(ctx context.Context, req resource.SchemaRequest
 				MarkdownDescription: "A mapping of query parameters to be sent with the delete request.",
 			},
 
+			"request_headers": schema.MapAttribute{
+				ElementType:         types.StringType,
+				Optional:            true,
+				MarkdownDescription: "A mapping of HTTP headers to be sent with every request made for this resource, e.g. `Prefer` or `ConsistencyLevel`. Headers with empty values are not sent. Note that authentication headers are automatically handled. When `Prefer = \"return=representation\"` is set and the `PATCH` request returns the updated object, it's used instead of reading the object again after the update, unless `read_query_parameters` is set.",
+			},
+
 			"response_export_values": schema.MapAttribute{
 				MarkdownDescription: docstrings.ResponseExportValues(),
 				Optional:            true,
 				ElementType:         types.StringType,
 			},
 
+			"output_format": schema.StringAttribute{
+				MarkdownDescription: docstrings.OutputFormat(),
+				Optional:            true,
+				Computed:            true,
+				Default:             stringdefault.StaticString(outputFormatTyped),
+				Validators: []validator.String{
+					stringvalidator.OneOf(outputFormatTyped, outputFormatJSONString),
+				},
+			},
+
 			"retry": retry.Schema(ctx),
 
 			"output": schema.DynamicAttribute{
@@ -163,6 +396,33 @@ func (r *MSGraphResource) Schema(ctx context.Context, req resource.SchemaRequest
 				Computed:            true,
 			},
 
+			"sensitive_output_path_patterns": schema.ListAttribute{
+				MarkdownDescription: docstrings.SensitiveOutputPathPatterns(),
+				Optional:            true,
+				ElementType:         types.StringType,
+				Validators: []validator.List{
+					listvalidator.SizeAtLeast(1),
+					listvalidator.ValueStringsAre(myvalidator.StringIsValidRegex()),
+				},
+			},
+
+			"sensitive_output": schema.DynamicAttribute{
+				MarkdownDescription: docstrings.SensitiveOutput(),
+				Computed:            true,
+				Sensitive:           true,
+			},
+
+			"create_method": schema.StringAttribute{
+				MarkdownDescription: "The HTTP method to use for creating the resource. Allowed values are `POST` (default) and `PUT`. With `POST`, the object is created in the collection `url`. With `PUT`, the object is created at the known URL `url`, e.g. `users/{user-id}/authentication/phoneMethods/3179e48a-750b-4051-897c-87b9720928f7`, which is also used to read, update and delete it. The `id` is read from the response, or is the last segment of `url` if it's not returned. To import a resource created with `PUT`, append `?create_method=PUT` to the import ID.",
+				Optional:            true,
+				Validators: []validator.String{
+					stringvalidator.OneOf("POST", "PUT"),
+				},
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+
 			"update_method": schema.StringAttribute{
 				MarkdownDescription: "The HTTP method to use for updating the resource. Allowed values are `PATCH` (default) and `PUT`.",
 				Optional:            true,
@@ -171,8 +431,82 @@ func (r *MSGraphResource) Schema(ctx context.Context, req resource.SchemaRequest
 				},
 			},
 
+			"prefer_return_representation": schema.BoolAttribute{
+				MarkdownDescription: "Whether to send the `Prefer: return=representation` header with the `PATCH` request, so the updated object returned by the request is used to build `output` instead of reading the object again after the update. The object is read as before when the response is empty, e.g. `204 No Content` if the endpoint ignores the preference, or when `read_query_parameters` is set. It conflicts with the `Prefer` header in `request_headers`. Defaults to `false`.",
+				Optional:            true,
+				Computed:            true,
+				Default:             booldefault.StaticBool(false),
+			},
+
+			"validate_on_plan": schema.BoolAttribute{
+				MarkdownDescription: "Whether to validate the `body` with Microsoft Graph when planning, so the errors are reported before applying. Only the objects which have a validation endpoint are validated, i.e. the `displayName` and `mailNickname` of groups, which are validated against the group naming policy by `directoryObjects/validateProperties` when the group is created, and by `groups/{id}/validateProperties` when they're changed, in which case only the changed properties are validated. The `url` must be `groups`. The other objects are not validated. Defaults to `false`.",
+				Optional:            true,
+				Computed:            true,
+				Default:             booldefault.StaticBool(false),
+			},
+
+			"put_merge": schema.BoolAttribute{
+				MarkdownDescription: "Whether to merge the `body` into the current remote object before sending it when `update_method` is `PUT`, like `msgraph_update_resource` does. This avoids clearing the properties which are not managed in `body`. Defaults to `false`.",
+				Optional:            true,
+				Computed:            true,
+				Default:             booldefault.StaticBool(false),
+			},
+
+			"id_attribute": schema.StringAttribute{
+				MarkdownDescription: "The name of the property in the create response which holds the identifier of the resource. Defaults to `id`. Use this for resources keyed by another property, e.g. `tenantId` for `policies/crossTenantAccessPolicy/partners`.",
+				Optional:            true,
+				Validators: []validator.String{
+					stringvalidator.LengthAtLeast(1),
+				},
+			},
+
+			"id_path": schema.StringAttribute{
+				MarkdownDescription: "A JMESPath expression to extract the identifier of the resource from the create response, e.g. `tenantId` or `settings.id`, for the resources whose key isn't a top-level property. Conflicts with `id_attribute`. If neither is specified, `id` is used. It's not used when `url` ends with `/$ref`, as the identifier of a reference is the last segment of its `@odata.id`.",
+				Optional:            true,
+				Validators: []validator.String{
+					stringvalidator.LengthAtLeast(1),
+					stringvalidator.ConflictsWith(path.MatchRoot("id_attribute")),
+				},
+			},
+
+			"lock_id": schema.StringAttribute{
+				MarkdownDescription: "A name which serializes the create, update and delete of the resources sharing it, e.g. the URL of the group whose members are changed. This avoids the conflicts returned by the API when a resource is changed concurrently. Defaults to the URL of the parent resource for `$ref` URLs, e.g. `groups/{group-id}` for `groups/{group-id}/members/$ref`, otherwise the writes are not serialized.",
+				Optional:            true,
+				Validators: []validator.String{
+					stringvalidator.LengthAtLeast(1),
+				},
+			},
+
+			"consistency": consistencySchema(),
+
+			"create_retry": createRetrySchema(),
+
+			"precheck_exists_filter": schema.StringAttribute{
+				MarkdownDescription: "An OData `$filter` expression which matches the object by its unique key, e.g. `mailNickname eq 'my-group'`. If specified, the collection `url` is queried with it before the object is created, and the create fails with the import ID of the existing object if any object matches, instead of the error returned by the API for the conflict. This costs an extra request for each create, so it's not done by default.",
+				Optional:            true,
+				Validators: []validator.String{
+					stringvalidator.LengthAtLeast(1),
+				},
+			},
+
 			"resource_url": schema.StringAttribute{
-				MarkdownDescription: "The full URL path to this resource instance.",
+				MarkdownDescription: "The canonical URL path to this resource instance relative to the API version, e.g. `applications/{application-id}/federatedIdentityCredentials/{credential-id}`. It can be used as the `url` of other resources and data sources directly. It doesn't have a leading slash, and the `$ref` segment of reference collections is removed, e.g. it's `groups/{group-id}/members/{member-id}` for the `url` `groups/{group-id}/members/$ref`.",
+				Computed:            true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+
+			"resource_full_url": schema.StringAttribute{
+				MarkdownDescription: "The absolute URL of this resource instance, which is `resource_url` prefixed with the Microsoft Graph endpoint and the API version, e.g. `https://graph.microsoft.com/v1.0/groups/{group-id}`.",
+				Computed:            true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+
+			"resource_id": schema.StringAttribute{
+				MarkdownDescription: "The ID to import this resource instance with, e.g. `groups/{group-id}/members/{member-id}/$ref`. The `api-version`, `tenant_id` and `create_method` query parameters are appended when they're not the defaults, e.g. `applications/{application-id}?api-version=beta`, so it can be used in an `import` block directly.",
 				Computed:            true,
 				PlanModifiers: []planmodifier.String{
 					stringplanmodifier.UseStateForUnknown(),
@@ -193,8 +527,12 @@ func (r *MSGraphResource) Configure(ctx context.Context, req resource.ConfigureR
 }
 
 func (r *MSGraphResource) ModifyPlan(ctx context.Context, request resource.ModifyPlanRequest, response *resource.ModifyPlanResponse) {
+	if planDefaultApiVersion(ctx, r.client, request, response); response.Diagnostics.HasError() {
+		return
+	}
+
 	var plan *MSGraphResourceModel
-	if response.Diagnostics.Append(request.Plan.Get(ctx, &plan)...); response.Diagnostics.HasError() {
+	if response.Diagnostics.Append(response.Plan.Get(ctx, &plan)...); response.Diagnostics.HasError() {
 		return
 	}
 
@@ -203,48 +541,228 @@ func (r *MSGraphResource) ModifyPlan(ctx context.Context, request resource.Modif
 		return
 	}
 
+	if plan != nil {
+		defer redactDiagnostics(&response.Diagnostics, redactedPlanValues(plan))
+	}
+
+	if plan != nil && plan.ValidateOnPlan.ValueBool() {
+		if response.Diagnostics.Append(r.validateBody(ctx, plan, state)...); response.Diagnostics.HasError() {
+			return
+		}
+	}
+
 	if plan == nil || state == nil {
 		return
 	}
 
+	if !plan.ApiVersion.Equal(state.ApiVersion) {
+		response.Diagnostics.Append(response.Plan.SetAttribute(ctx, path.Root("resource_full_url"), types.StringUnknown())...)
+		response.Diagnostics.Append(response.Plan.SetAttribute(ctx, path.Root("resource_id"), types.StringUnknown())...)
+	}
+
+	if !plan.ReadQueryParameters.IsUnknown() && !plan.ReadQueryParameters.Equal(state.ReadQueryParameters) {
+		// The changes of the query parameters are rendered as map changes in the plan, the summary shows their effect.
+		response.Diagnostics.AddAttributeWarning(path.Root("read_query_parameters"), "The read query parameters are changed",
+			fmt.Sprintf("%q will be read with the query string %s instead of %s. This changes the properties which are returned by Microsoft Graph, reconciled with `body` and exported to `output`.",
+				state.ResourceUrl.ValueString(), readQueryString(plan), readQueryString(state)))
+	}
+
+	if properties := immutablePropertiesOf(plan.Url.ValueString()); len(properties) != 0 {
+		var planBody, stateBody map[string]interface{}
+		if unmarshalModelBody(plan, &planBody) == nil && unmarshalModelBody(state, &stateBody) == nil {
+			for _, property := range properties {
+				if planValue, ok := planBody[property]; ok && !reflect.DeepEqual(planValue, stateBody[property]) {
+					tflog.Info(ctx, fmt.Sprintf("The property %q can't be changed after the object is created in %q, the object is replaced", property, plan.Url.ValueString()))
+					if plan.BodyJson.IsNull() {
+						response.RequiresReplace.Append(path.Root("body"))
+					} else {
+						response.RequiresReplace.Append(path.Root("body_json"))
+					}
+					break
+				}
+			}
+		}
+	}
+
+	if paths := AsListOfString(plan.WriteOncePaths); len(paths) != 0 && plan.WriteOncePolicy.ValueString() == writeOncePolicyReplace {
+		var planBody, stateBody interface{}
+		if unmarshalModelBody(plan, &planBody) == nil && unmarshalModelBody(state, &stateBody) == nil {
+			for _, p := range paths {
+				planValue, _ := utils.ValueAtPath(planBody, p)
+				stateValue, _ := utils.ValueAtPath(stateBody, p)
+				if !reflect.DeepEqual(planValue, stateValue) {
+					tflog.Info(ctx, fmt.Sprintf("The write-once path %q is changed, the object is replaced", p))
+					if plan.BodyJson.IsNull() {
+						response.RequiresReplace.Append(path.Root("body"))
+					} else {
+						response.RequiresReplace.Append(path.Root("body_json"))
+					}
+					break
+				}
+			}
+		}
+	}
+
+	if !strings.Contains(plan.Url.ValueString(), "/$ref") && isSemanticallyEqualBody(plan, state) {
+		// The body only differs in its formatting, e.g. the order of the keys, so the computed values are kept.
+		if planRaw, ok := withStateForUnknown(response.Plan.Raw, request.State.Raw); ok {
+			response.Plan.Raw = planRaw
+		}
+	}
+
 	if strings.Contains(plan.Url.ValueString(), "/$ref") {
 		if !dynamic.SemanticallyEqual(plan.Body, state.Body) {
 			response.RequiresReplace.Append(path.Root("body"))
 		}
+		if utils.NormalizeJson(plan.BodyJson.ValueString()) != utils.NormalizeJson(state.BodyJson.ValueString()) {
+			response.RequiresReplace.Append(path.Root("body_json"))
+		}
 		if !reflect.DeepEqual(plan.ResponseExportValues, state.ResponseExportValues) {
 			response.RequiresReplace.Append(path.Root("response_export_values"))
 		}
+		if !plan.OutputFormat.Equal(state.OutputFormat) {
+			response.RequiresReplace.Append(path.Root("output_format"))
+		}
+		if !plan.SensitiveOutputPatterns.Equal(state.SensitiveOutputPatterns) {
+			response.RequiresReplace.Append(path.Root("sensitive_output_path_patterns"))
+		}
+		if !plan.RedactPlanPaths.Equal(state.RedactPlanPaths) {
+			response.RequiresReplace.Append(path.Root("redact_plan_paths"))
+		}
 		if !reflect.DeepEqual(plan.ApiVersion, state.ApiVersion) {
 			response.RequiresReplace.Append(path.Root("api_version"))
 		}
 	}
 }
 
+// isSemanticallyEqualBody returns true if the planned body differs from the state, but is semantically equal to it.
+func isSemanticallyEqualBody(plan, state *MSGraphResourceModel) bool {
+	if !plan.BodyJson.IsNull() || !state.BodyJson.IsNull() {
+		if plan.BodyJson.IsUnknown() || plan.BodyJson.IsNull() || state.BodyJson.IsNull() || plan.BodyJson.Equal(state.BodyJson) {
+			return false
+		}
+		return utils.NormalizeJson(plan.BodyJson.ValueString()) == utils.NormalizeJson(state.BodyJson.ValueString())
+	}
+	if !dynamic.IsFullyKnown(plan.Body) || plan.Body.Equal(state.Body) {
+		return false
+	}
+	return dynamic.SemanticallyEqual(plan.Body, state.Body)
+}
+
+// withStateForUnknown replaces the unknown values of the plan with the values in the state. It returns false if the plan
+// has other changes than the body, as the computed values can't be known then.
+func withStateForUnknown(plan, state tftypes.Value) (tftypes.Value, bool) {
+	result, err := tftypes.Transform(plan, func(p *tftypes.AttributePath, v tftypes.Value) (tftypes.Value, error) {
+		if v.IsKnown() {
+			return v, nil
+		}
+		stateValue, _, err := tftypes.WalkAttributePath(state, p)
+		if err != nil {
+			return v, nil
+		}
+		if value, ok := stateValue.(tftypes.Value); ok {
+			return value, nil
+		}
+		return v, nil
+	})
+	if err != nil {
+		return plan, false
+	}
+	candidate, err := tftypes.Transform(result, func(p *tftypes.AttributePath, v tftypes.Value) (tftypes.Value, error) {
+		if p.Equal(tftypes.NewAttributePath().WithAttributeName("body")) || p.Equal(tftypes.NewAttributePath().WithAttributeName("body_json")) {
+			stateValue, _, err := tftypes.WalkAttributePath(state, p)
+			if err != nil {
+				return v, err
+			}
+			if value, ok := stateValue.(tftypes.Value); ok {
+				return value, nil
+			}
+		}
+		return v, nil
+	})
+	if err != nil || !candidate.Equal(state) {
+		return plan, false
+	}
+	return result, true
+}
+
 func (r *MSGraphResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
 	var model *MSGraphResourceModel
 	if resp.Diagnostics.Append(req.Plan.Get(ctx, &model)...); resp.Diagnostics.HasError() {
 		return
 	}
+	// The write-only properties are only in the configuration.
+	if resp.Diagnostics.Append(req.Config.GetAttribute(ctx, path.Root("write_only_body"), &model.WriteOnlyBody)...); resp.Diagnostics.HasError() {
+		return
+	}
 	isRelationship := strings.HasSuffix(model.Url.ValueString(), "/$ref")
 
 	createTimeout, diags := model.Timeouts.Create(ctx, 30*time.Minute)
 	resp.Diagnostics.Append(diags...)
 	ctx, cancel := context.WithTimeout(ctx, createTimeout)
 	defer cancel()
+	ctx = clients.WithOperationMetrics(ctx)
+	defer clients.LogOperationMetrics(ctx, fmt.Sprintf("msgraph_resource create of %s", model.Url.ValueString()))
+	ctx = clients.WithRedactedPaths(ctx, redactedPaths(model))
+	defer redactDiagnostics(&resp.Diagnostics, redactedPlanValues(model))
+	ctx = clients.WithTenantId(ctx, model.TenantId.ValueString())
+
+	if name := lockName(model); name != "" {
+		locks.ByName(name)
+		defer locks.UnlockByName(name)
+	}
 
 	var requestBody interface{}
-	if err := unmarshalBody(model.Body, &requestBody); err != nil {
+	if err := unmarshalModelBody(model, &requestBody); err != nil {
 		resp.Diagnostics.AddError("Failed to unmarshal body", err.Error())
 		return
 	}
+	requestBody = utils.WithoutPaths(requestBody, AsListOfString(model.StripBodyPaths))
+	writeOnlyBody, err := writeOnlyBodyOf(model)
+	if err != nil {
+		resp.Diagnostics.AddError("Failed to unmarshal write_only_body", err.Error())
+		return
+	}
+	if writeOnlyBody != nil {
+		requestBody = utils.MergeObject(requestBody, writeOnlyBody)
+	}
+	if model.AutoODataType.ValueBool() {
+		requestBody = withInferredODataType(ctx, model.Url.ValueString(), requestBody, requestBody)
+	}
+
+	createMethod := http.MethodPost
+	if model.CreateMethod.ValueString() == http.MethodPut {
+		createMethod = http.MethodPut
+	}
+
+	if filter := model.PrecheckExistsFilter.ValueString(); filter != "" && !isRelationship {
+		existingId, err := r.existingObjectId(ctx, model, filter)
+		if err != nil {
+			resp.Diagnostics.AddError("Failed to check whether the resource exists", utils.ResponseErrorDetail(err))
+			return
+		}
+		if existingId != "" {
+			existing := *model
+			existing.Id = types.StringValue(existingId)
+			resp.Diagnostics.AddError("Resource already exists", fmt.Sprintf("An object matching the filter %q already exists in %q with the ID %q. "+
+				"To manage it with this resource, import it with the ID %q, e.g. with `terraform import` or an `import` block.", filter, model.Url.ValueString(), existingId, r.resourceId(&existing)))
+			return
+		}
+	}
 
 	options := clients.RequestOptions{
+		Headers:         clients.NewHeaders(AsMapOfString(model.RequestHeaders)),
 		QueryParameters: clients.NewQueryParameters(AsMapOfLists(model.CreateQueryParameters)),
-		RetryOptions:    clients.NewRetryOptions(model.Retry),
+		RetryOptions:    clients.NewRetryOptions(model.Retry, createMethod),
 	}
-	responseBody, err := r.client.Create(ctx, model.Url.ValueString(), model.ApiVersion.ValueString(), requestBody, options)
+	responseBody, err := createWithRetry(ctx, model.CreateRetry, func() (interface{}, error) {
+		if createMethod == http.MethodPut {
+			return r.client.Action(ctx, http.MethodPut, model.Url.ValueString(), model.ApiVersion.ValueString(), requestBody, options)
+		}
+		return r.client.Create(ctx, model.Url.ValueString(), model.ApiVersion.ValueString(), requestBody, options)
+	})
 	if err != nil {
-		resp.Diagnostics.AddError("Failed to create resource", err.Error())
+		resp.Diagnostics.AddError("Failed to create resource", utils.ResponseErrorDetail(err))
 		return
 	}
 
@@ -254,50 +772,87 @@ func (r *MSGraphResource) Create(ctx context.Context, req resource.CreateRequest
 				if idString, ok := idValue.(string); ok {
 					uuidValue := idString[strings.LastIndex(idString, "/")+1:]
 					model.Id = types.StringValue(uuidValue)
-					// For $ref URLs, resource_url should be the collection URL without $ref + the ID
-					baseUrl := strings.TrimSuffix(model.Url.ValueString(), "/$ref")
-					model.ResourceUrl = types.StringValue(fmt.Sprintf("%s/%s", baseUrl, uuidValue))
+					model.ResourceUrl = types.StringValue(resourceUrlOf(model))
 				}
 			}
 		}
 	} else {
-		responseId := ""
-		if responseBody != nil {
-			if responseMap, ok := responseBody.(map[string]interface{}); ok {
-				if idValue, ok := responseMap["id"]; ok && idValue != nil {
-					if idString, ok := idValue.(string); ok {
-						responseId = idString
-					}
-				}
-			}
+		responseId := idOfResponse(model, responseBody)
+
+		if model.CreateMethod.ValueString() == http.MethodPut && responseId == "" {
+			responseId = utils.LastSegment(model.Url.ValueString())
+		}
+		if responseId == "" {
+			// The object can't be read, updated or deleted without an ID, so it's not saved in the state.
+			resp.Diagnostics.AddError("Failed to create resource", fmt.Sprintf("The response of creating the object in %q doesn't contain a non-empty string property %q, so the ID of the object is unknown. "+
+				"The object may have been created and need to be deleted manually. If the object is keyed by another property, e.g. `tenantId`, set `id_attribute` to its name, or `id_path` to the JMESPath expression of a nested property. "+
+				"If the object is created with PUT at a known URL, e.g. a settings object, set `create_method` to `PUT`.", model.Url.ValueString(), idDescription(model)))
+			return
 		}
 
 		model.Id = types.StringValue(responseId)
-		model.ResourceUrl = types.StringValue(fmt.Sprintf("%s/%s", model.Url.ValueString(), responseId))
+		model.ResourceUrl = types.StringValue(resourceUrlOf(model))
+	}
+
+	model.ResourceFullUrl = types.StringValue(r.resourceFullUrl(model))
+	model.ResourceId = types.StringValue(r.resourceId(model))
+
+	if !model.ReadAfterCreate.ValueBool() {
+		// The object can't be read, so neither the existence is waited for nor the output is built.
+		tflog.Debug(ctx, fmt.Sprintf("read_after_create is false, skipping the read of %q", itemUrl(model)))
+		output, sensitiveOutput, err := buildOutputs(nil, nil, model.OutputFormat.ValueString(), sensitiveOutputPatterns(model))
+		if err != nil {
+			resp.Diagnostics.AddError("Failed to build the output", err.Error())
+			return
+		}
+		model.Output = types.DynamicValue(output)
+		model.SensitiveOutput = types.DynamicValue(sensitiveOutput)
+		resp.Diagnostics.Append(resp.State.Set(ctx, &model)...)
+		return
 	}
 
 	// Wait for the resource to be available
-	if err = consistency.WaitForUpdate(ctx, ResourceExistenceFunc(r.client, model)); err != nil {
+	existenceFunc := ResourceExistenceFunc(r.client, model)
+	if isRelationship {
+		existenceFunc = referenceCreationFunc(r.client, model)
+	}
+	if err = consistency.WaitForUpdateWithOptions(ctx, consistencyPollOptions(ctx, model.Consistency), existenceFunc); err != nil {
 		resp.Diagnostics.AddError("Error", fmt.Sprintf("waiting for creation of %s: %v", model.Url.ValueString(), err))
 		return
 	}
 
 	if !isRelationship {
 		options = clients.RequestOptions{
+			Headers:         clients.NewHeaders(AsMapOfString(model.RequestHeaders)),
 			QueryParameters: clients.NewQueryParameters(AsMapOfLists(model.ReadQueryParameters)),
 			RetryOptions: clients.CombineRetryOptions(
 				clients.NewRetryOptionsForReadAfterCreate(),
-				clients.NewRetryOptions(model.Retry),
+				clients.NewRetryOptions(model.Retry, http.MethodGet),
 			),
 		}
-		responseBody, err = r.client.Read(ctx, fmt.Sprintf("%s/%s", model.Url.ValueString(), model.Id.ValueString()), model.ApiVersion.ValueString(), options)
+		responseBody, err = r.client.Read(ctx, itemUrl(model), model.ApiVersion.ValueString(), options)
 		if err != nil {
-			resp.Diagnostics.AddError("Failed to read data source", err.Error())
+			resp.Diagnostics.AddError("Failed to read data source", utils.ResponseErrorDetail(err))
+			return
+		}
+		if model.FullBodySync.ValueBool() {
+			resp.Diagnostics.Append(setRemoteBodySnapshot(ctx, resp.Private, withoutExpandedProperties(model, responseBody))...)
+		}
+	} else if model.ReadReferencedObject.ValueBool() {
+		responseBody, err = r.readReferencedObject(ctx, model)
+		if err != nil {
+			resp.Diagnostics.AddError("Failed to read the referenced object", utils.ResponseErrorDetail(err))
 			return
 		}
 	}
 
-	model.Output = types.DynamicValue(buildOutputFromBody(responseBody, model.ResponseExportValues))
+	output, sensitiveOutput, err := buildOutputs(responseBody, model.ResponseExportValues, model.OutputFormat.ValueString(), sensitiveOutputPatterns(model))
+	if err != nil {
+		resp.Diagnostics.AddError("Failed to build the output", err.Error())
+		return
+	}
+	model.Output = types.DynamicValue(output)
+	model.SensitiveOutput = types.DynamicValue(sensitiveOutput)
 
 	resp.Diagnostics.Append(resp.State.Set(ctx, &model)...)
 }
@@ -310,6 +865,10 @@ func (r *MSGraphResource) Update(ctx context.Context, req resource.UpdateRequest
 	if resp.Diagnostics.Append(req.State.Get(ctx, &state)...); resp.Diagnostics.HasError() {
 		return
 	}
+	// The write-only properties are only in the configuration.
+	if resp.Diagnostics.Append(req.Config.GetAttribute(ctx, path.Root("write_only_body"), &model.WriteOnlyBody)...); resp.Diagnostics.HasError() {
+		return
+	}
 
 	// relationship updates are not supported
 
@@ -317,16 +876,59 @@ func (r *MSGraphResource) Update(ctx context.Context, req resource.UpdateRequest
 	resp.Diagnostics.Append(diags...)
 	ctx, cancel := context.WithTimeout(ctx, updateTimeout)
 	defer cancel()
+	ctx = clients.WithOperationMetrics(ctx)
+	defer clients.LogOperationMetrics(ctx, fmt.Sprintf("msgraph_resource update of %s", model.Url.ValueString()))
+	ctx = clients.WithRedactedPaths(ctx, redactedPaths(model))
+	defer redactDiagnostics(&resp.Diagnostics, redactedPlanValues(model))
+	ctx = clients.WithTenantId(ctx, model.TenantId.ValueString())
+
+	if name := lockName(model); name != "" {
+		locks.ByName(name)
+		defer locks.UnlockByName(name)
+	}
 
 	var requestBody interface{}
-	if err := unmarshalBody(model.Body, &requestBody); err != nil {
+	if err := unmarshalModelBody(model, &requestBody); err != nil {
 		resp.Diagnostics.AddError("Failed to unmarshal body", err.Error())
 		return
 	}
 
-	options := clients.RequestOptions{
-		QueryParameters: clients.NewQueryParameters(AsMapOfLists(model.UpdateQueryParameters)),
-		RetryOptions:    clients.NewRetryOptions(model.Retry),
+	if model.FullBodySync.ValueBool() {
+		// The properties which are not configured but were changed outside of Terraform are reverted to the snapshot.
+		snapshot, diags := remoteBodySnapshot(ctx, req.Private)
+		if resp.Diagnostics.Append(diags...); resp.Diagnostics.HasError() {
+			return
+		}
+		var previousBody interface{}
+		if err := unmarshalModelBody(state, &previousBody); err != nil {
+			resp.Diagnostics.AddError("Invalid body in prior state", fmt.Sprintf(`The state "body" is invalid: %s`, err.Error()))
+			return
+		}
+		requestBody = revertUnmanagedProperties(requestBody, previousBody, snapshot)
+	}
+
+	if paths := writeOncePathsIgnored(model); len(paths) != 0 {
+		// The write-once properties keep the values they were created with.
+		var previousBody interface{}
+		if err := unmarshalModelBody(state, &previousBody); err != nil {
+			resp.Diagnostics.AddError("Invalid body in prior state", fmt.Sprintf(`The state "body" is invalid: %s`, err.Error()))
+			return
+		}
+		for _, p := range paths {
+			requestBody = utils.ReplacePath(requestBody, previousBody, p)
+		}
+		tflog.Debug(ctx, fmt.Sprintf("The changes of the write-once paths %v are not sent", paths))
+	}
+	requestBody = utils.WithoutPaths(requestBody, AsListOfString(model.StripBodyPaths))
+
+	// The write-only properties are only sent when their version is changed, as they're never read back.
+	var writeOnlyBody map[string]interface{}
+	if !model.WriteOnlyBodyVersion.Equal(state.WriteOnlyBodyVersion) {
+		var err error
+		if writeOnlyBody, err = writeOnlyBodyOf(model); err != nil {
+			resp.Diagnostics.AddError("Failed to unmarshal write_only_body", err.Error())
+			return
+		}
 	}
 
 	// default to PATCH
@@ -334,54 +936,153 @@ func (r *MSGraphResource) Update(ctx context.Context, req resource.UpdateRequest
 	if !model.UpdateMethod.IsNull() {
 		updateMethod = model.UpdateMethod.ValueString()
 	}
+
+	options := clients.RequestOptions{
+		Headers:         clients.NewHeaders(AsMapOfString(model.RequestHeaders)),
+		QueryParameters: clients.NewQueryParameters(AsMapOfLists(model.UpdateQueryParameters)),
+		RetryOptions:    clients.NewRetryOptions(model.Retry, updateMethod),
+	}
+	if updateMethod == "PATCH" && model.PreferReturnRepresentation.ValueBool() {
+		options.Headers = clients.WithPreference(options.Headers, "return=representation")
+	}
+	// The updated object returned by the PATCH request when `Prefer: return=representation` is sent.
+	var representation interface{}
 	if updateMethod == "PUT" {
-		_, err := r.client.Action(ctx, "PUT", fmt.Sprintf("%s/%s", model.Url.ValueString(), model.Id.ValueString()), model.ApiVersion.ValueString(), requestBody, options)
-		if err != nil {
-			resp.Diagnostics.AddError("Failed to update resource", err.Error())
+		if model.PutMerge.ValueBool() {
+			readOptions := clients.RequestOptions{
+				Headers:         clients.NewHeaders(AsMapOfString(model.RequestHeaders)),
+				QueryParameters: clients.NewQueryParameters(AsMapOfLists(model.ReadQueryParameters)),
+				RetryOptions:    clients.NewRetryOptions(model.Retry, http.MethodGet),
+			}
+			existingBody, err := r.client.Read(ctx, itemUrl(model), model.ApiVersion.ValueString(), readOptions)
+			if err != nil {
+				resp.Diagnostics.AddError("Failed to read existing resource for PUT update", utils.ResponseErrorDetail(err))
+				return
+			}
+
+			// The items removed from the configuration are not merged back from the existing resource.
+			var previousBody interface{}
+			if err := unmarshalModelBody(state, &previousBody); err != nil {
+				resp.Diagnostics.AddError("Invalid body in prior state", fmt.Sprintf(`The state "body" is invalid: %s`, err.Error()))
+				return
+			}
+			requestBody = utils.MergeObjectWithPrevious(existingBody, requestBody, previousBody)
+		}
+		if writeOnlyBody != nil {
+			requestBody = utils.MergeObject(requestBody, writeOnlyBody)
+		}
+		if model.AutoODataType.ValueBool() {
+			requestBody = withInferredODataType(ctx, model.Url.ValueString(), requestBody, requestBody)
+		}
+
+		_, err := r.client.Action(ctx, "PUT", itemUrl(model), model.ApiVersion.ValueString(), requestBody, options)
+		if err != nil && !isAcceptableError(ctx, model.AcceptableErrorCodes, err) {
+			resp.Diagnostics.AddError("Failed to update resource", utils.ResponseErrorDetail(err))
 			return
 		}
 	} else {
 		var previousBody interface{}
-		if err := unmarshalBody(state.Body, &previousBody); err != nil {
+		if err := unmarshalModelBody(state, &previousBody); err != nil {
 			resp.Diagnostics.AddError("Invalid body in prior state", fmt.Sprintf(`The state "body" is invalid: %s`, err.Error()))
 			return
 		}
+		previousBody = utils.WithoutPaths(previousBody, AsListOfString(state.StripBodyPaths))
+
+		if model.GranularReferenceUpdates.ValueBool() {
+			refOptions := clients.RequestOptions{
+				Headers:      clients.NewHeaders(AsMapOfString(model.RequestHeaders)),
+				RetryOptions: clients.NewRetryOptions(model.Retry, http.MethodPost),
+			}
+			updatedBody, err := r.updateReferencesIndividually(ctx, model, previousBody, requestBody, refOptions)
+			if err != nil {
+				resp.Diagnostics.AddError("Failed to update references", err.Error())
+				return
+			}
+			previousBody = updatedBody
+		}
 
 		diffOption := utils.UpdateJsonOption{
-			IgnoreCasing:          false,
+			IgnoreCasing:          model.IgnoreCasing.ValueBool(),
 			IgnoreMissingProperty: false,
 			IgnoreNullProperty:    false,
 		}
 		patchBody := utils.DiffObject(previousBody, requestBody, diffOption)
+		if writeOnlyBody != nil {
+			if patchBody == nil {
+				patchBody = make(map[string]interface{})
+			}
+			patchBody = utils.MergeObject(patchBody, writeOnlyBody)
+		}
+		if patchBody != nil && model.AutoODataType.ValueBool() {
+			// The type is inferred from the whole body, as the patch may only contain the changed properties.
+			patchBody = withInferredODataType(ctx, model.Url.ValueString(), patchBody, requestBody)
+		}
 
 		// If there's something to update, send PATCH
 		if patchBody != nil {
-			_, err := r.client.Update(ctx, fmt.Sprintf("%s/%s", model.Url.ValueString(), model.Id.ValueString()), model.ApiVersion.ValueString(), patchBody, options)
-			if err != nil {
-				resp.Diagnostics.AddError("Failed to create resource", err.Error())
+			responseBody, err := r.client.Update(ctx, itemUrl(model), model.ApiVersion.ValueString(), patchBody, options)
+			if err != nil && !isAcceptableError(ctx, model.AcceptableErrorCodes, err) {
+				resp.Diagnostics.AddError("Failed to create resource", utils.ResponseErrorDetail(err))
 				return
 			}
+			if err == nil {
+				representation = responseBody
+			}
 		} else {
 			tflog.Info(ctx, "No changes detected in body, skipping update")
 		}
 	}
 
+	model.ResourceFullUrl = types.StringValue(r.resourceFullUrl(model))
+	model.ResourceId = types.StringValue(r.resourceId(model))
+
+	if !model.Output.IsUnknown() && !model.SensitiveOutput.IsUnknown() {
+		// The planned body is semantically equal to the state, so the outputs are kept as planned.
+		tflog.Debug(ctx, "The body is semantically unchanged, skipping read")
+		resp.Diagnostics.Append(resp.State.Set(ctx, &model)...)
+		return
+	}
+
+	if !model.ReadAfterCreate.ValueBool() {
+		tflog.Debug(ctx, fmt.Sprintf("read_after_create is false, skipping the read of %q", itemUrl(model)))
+		model.Output = state.Output
+		model.SensitiveOutput = state.SensitiveOutput
+		resp.Diagnostics.Append(resp.State.Set(ctx, &model)...)
+		return
+	}
+
 	// Wait for the resource to be available
-	if err := consistency.WaitForUpdate(ctx, ResourceExistenceFunc(r.client, model)); err != nil {
+	if err := consistency.WaitForUpdateWithOptions(ctx, consistencyPollOptions(ctx, model.Consistency), ResourceExistenceFunc(r.client, model)); err != nil {
 		resp.Diagnostics.AddError("Error", fmt.Sprintf("waiting for creation of %s: %v", model.Url.ValueString(), err))
 		return
 	}
 
-	options = clients.RequestOptions{
-		QueryParameters: clients.NewQueryParameters(AsMapOfLists(model.ReadQueryParameters)),
-		RetryOptions:    clients.NewRetryOptions(model.Retry),
+	responseBody := representation
+	if !isRepresentation(model, representation) {
+		options = clients.RequestOptions{
+			Headers:         clients.NewHeaders(AsMapOfString(model.RequestHeaders)),
+			QueryParameters: clients.NewQueryParameters(AsMapOfLists(model.ReadQueryParameters)),
+			RetryOptions:    clients.NewRetryOptions(model.Retry, http.MethodGet),
+		}
+		var err error
+		responseBody, err = r.client.Read(ctx, itemUrl(model), model.ApiVersion.ValueString(), options)
+		if err != nil {
+			resp.Diagnostics.AddError("Failed to read data source", utils.ResponseErrorDetail(err))
+			return
+		}
+	} else {
+		tflog.Debug(ctx, "Using the object returned by the update, skipping read")
+	}
+	if model.FullBodySync.ValueBool() {
+		resp.Diagnostics.Append(setRemoteBodySnapshot(ctx, resp.Private, withoutExpandedProperties(model, responseBody))...)
 	}
-	responseBody, err := r.client.Read(ctx, fmt.Sprintf("%s/%s", model.Url.ValueString(), model.Id.ValueString()), model.ApiVersion.ValueString(), options)
+	output, sensitiveOutput, err := buildOutputs(responseBody, model.ResponseExportValues, model.OutputFormat.ValueString(), sensitiveOutputPatterns(model))
 	if err != nil {
-		resp.Diagnostics.AddError("Failed to read data source", err.Error())
+		resp.Diagnostics.AddError("Failed to build the output", err.Error())
 		return
 	}
-	model.Output = types.DynamicValue(buildOutputFromBody(responseBody, model.ResponseExportValues))
+	model.Output = types.DynamicValue(output)
+	model.SensitiveOutput = types.DynamicValue(sensitiveOutput)
 	resp.Diagnostics.Append(resp.State.Set(ctx, &model)...)
 }
 
@@ -392,25 +1093,39 @@ func (r *MSGraphResource) Read(ctx context.Context, req resource.ReadRequest, re
 	}
 	isRelationship := strings.HasSuffix(model.Url.ValueString(), "/$ref")
 
-	// Apply read timeout (default 5m if not configured)
-	readTimeout, diags := model.Timeouts.Read(ctx, 5*time.Minute)
+	// Apply read timeout (defaults to the provider's default_read_timeout if not configured)
+	readTimeout, diags := model.Timeouts.Read(ctx, r.client.DefaultReadTimeout())
 	resp.Diagnostics.Append(diags...)
 	ctx, cancel := context.WithTimeout(ctx, readTimeout)
 	defer cancel()
+	ctx = clients.WithOperationMetrics(ctx)
+	defer clients.LogOperationMetrics(ctx, fmt.Sprintf("msgraph_resource read of %s", model.Url.ValueString()))
+	ctx = clients.WithRedactedPaths(ctx, redactedPaths(model))
+	defer redactDiagnostics(&resp.Diagnostics, redactedPlanValues(model))
+	ctx = clients.WithTenantId(ctx, model.TenantId.ValueString())
 
 	if model.ApiVersion.ValueString() == "" {
-		model.ApiVersion = types.StringValue("v1.0")
+		model.ApiVersion = types.StringValue(r.client.DefaultApiVersion())
 	}
+	// The URLs are normalized, e.g. the states moved from other resources have a leading slash in `url`.
+	model.ResourceUrl = types.StringValue(resourceUrlOf(model))
+	model.ResourceFullUrl = types.StringValue(r.resourceFullUrl(model))
+	model.ResourceId = types.StringValue(r.resourceId(model))
 
 	state := model
 	if isRelationship {
 		// Check if the resource exists in the collection
 		collectionUrl := baseCollectionUrl(model.Url.ValueString())
 		options := clients.RequestOptions{
+			Headers:         clients.NewHeaders(AsMapOfString(model.RequestHeaders)),
 			QueryParameters: clients.NewQueryParameters(AsMapOfLists(model.ReadQueryParameters)),
-			RetryOptions:    clients.NewRetryOptions(model.Retry),
+			RetryOptions:    clients.NewRetryOptions(model.Retry, http.MethodGet),
 		}
-		referenceIds, err := r.client.ListRefIDs(ctx, collectionUrl, model.ApiVersion.ValueString(), options)
+		// A reference added recently may not be listed yet, it's scanned again before it's removed from the state.
+		found, err := consistency.WaitForVisible(ctx, referenceScanAttempts, referenceScanInterval, func(ctx context.Context) (*bool, error) {
+			found, err := referenceExists(ctx, r.client, collectionUrl, model.Id.ValueString(), model.ApiVersion.ValueString(), options)
+			return &found, err
+		})
 		if err != nil {
 			if utils.ResponseErrorWasNotFound(err) {
 				tflog.Info(ctx, fmt.Sprintf("Collection %q not found - removing from state", collectionUrl))
@@ -420,13 +1135,6 @@ func (r *MSGraphResource) Read(ctx context.Context, req resource.ReadRequest, re
 			resp.Diagnostics.AddError("Failed to read collection", err.Error())
 			return
 		}
-		found := false
-		for _, refId := range referenceIds {
-			if refId == model.Id.ValueString() {
-				found = true
-				break
-			}
-		}
 		if !found {
 			tflog.Info(ctx, fmt.Sprintf("Resource %q not found in collection %q - removing from state", model.Id.ValueString(), collectionUrl))
 			resp.State.RemoveResource(ctx)
@@ -451,23 +1159,65 @@ func (r *MSGraphResource) Read(ctx context.Context, req resource.ReadRequest, re
 			resp.Diagnostics.Append(resp.Private.SetKey(ctx, FlagMoveState, []byte("false"))...)
 		}
 
-		state.Output = types.DynamicNull()
+		state.Output = types.DynamicNull()
+		if model.ReadReferencedObject.ValueBool() {
+			responseBody, err := r.readReferencedObject(ctx, model)
+			if err != nil {
+				resp.Diagnostics.AddError("Failed to read the referenced object", utils.ResponseErrorDetail(err))
+				return
+			}
+			output, sensitiveOutput, err := buildOutputs(responseBody, model.ResponseExportValues, model.OutputFormat.ValueString(), sensitiveOutputPatterns(model))
+			if err != nil {
+				resp.Diagnostics.AddError("Failed to build the output", err.Error())
+				return
+			}
+			state.Output = types.DynamicValue(output)
+			state.SensitiveOutput = types.DynamicValue(sensitiveOutput)
+		}
+		resp.Diagnostics.Append(resp.State.Set(ctx, &state)...)
+		return
+	}
+
+	if !model.ReadAfterCreate.IsNull() && !model.ReadAfterCreate.ValueBool() {
+		// The object can't be read, so the state is kept as is.
+		tflog.Debug(ctx, fmt.Sprintf("read_after_create is false, skipping the refresh of %q", itemUrl(model)))
 		resp.Diagnostics.Append(resp.State.Set(ctx, &state)...)
 		return
 	}
 
-	options := clients.NewRequestOptions(nil, AsMapOfLists(model.ReadQueryParameters))
-	responseBody, err := r.client.Read(ctx, fmt.Sprintf("%s/%s", model.Url.ValueString(), model.Id.ValueString()), model.ApiVersion.ValueString(), options)
+	readQueryParameters := AsMapOfLists(model.ReadQueryParameters)
+	if model.ExpandBodyNavigations.ValueBool() && (!model.Body.IsNull() || !model.BodyJson.IsNull()) {
+		requestBody := make(map[string]interface{})
+		if err := unmarshalModelBody(model, &requestBody); err != nil {
+			resp.Diagnostics.AddError("Invalid body", fmt.Sprintf(`The argument "body" is invalid: %s`, err.Error()))
+			return
+		}
+		readQueryParameters = expandQueryParameters(readQueryParameters, utils.NavigationPropertiesOfBody(requestBody))
+	}
+	options := clients.RequestOptions{
+		Headers:         clients.NewHeaders(AsMapOfString(model.RequestHeaders)),
+		QueryParameters: clients.NewQueryParameters(readQueryParameters),
+		RetryOptions:    clients.NewRetryOptions(model.Retry, http.MethodGet),
+	}
+	responseBody, err := r.client.Read(ctx, itemUrl(model), model.ApiVersion.ValueString(), options)
 	if err != nil {
 		if utils.ResponseErrorWasNotFound(err) {
 			tflog.Info(ctx, fmt.Sprintf("Error reading %q - removing from state", model.Id.ValueString()))
 			resp.State.RemoveResource(ctx)
 			return
 		}
-		resp.Diagnostics.AddError("Failed to read data source", err.Error())
+		resp.Diagnostics.AddError("Failed to read data source", utils.ResponseErrorDetail(err))
+		return
+	}
+	responseBody = utils.RewriteODataHosts(responseBody, r.client.GraphBaseUrl())
+	output, sensitiveOutput, err := buildOutputs(responseBody, model.ResponseExportValues, model.OutputFormat.ValueString(), sensitiveOutputPatterns(model))
+	if err != nil {
+		resp.Diagnostics.AddError("Failed to build the output", err.Error())
 		return
 	}
-	state.Output = types.DynamicValue(buildOutputFromBody(responseBody, model.ResponseExportValues))
+	state.Output = types.DynamicValue(output)
+	state.SensitiveOutput = types.DynamicValue(sensitiveOutput)
+	responseBody = withoutExpandedProperties(model, responseBody)
 
 	if v, _ := req.Private.GetKey(ctx, FlagMoveState); v != nil && string(v) == "true" {
 		data, err := json.Marshal(responseBody)
@@ -482,25 +1232,63 @@ func (r *MSGraphResource) Read(ctx context.Context, req resource.ReadRequest, re
 		}
 		state.Body = payload
 		resp.Diagnostics.Append(resp.Private.SetKey(ctx, FlagMoveState, []byte("false"))...)
-	} else if !model.Body.IsNull() {
+	} else if !model.Body.IsNull() || !model.BodyJson.IsNull() {
 		requestBody := make(map[string]interface{})
-		if err := unmarshalBody(model.Body, &requestBody); err != nil {
+		if err := unmarshalModelBody(model, &requestBody); err != nil {
 			resp.Diagnostics.AddError("Invalid body", fmt.Sprintf(`The argument "body" is invalid: %s`, err.Error()))
 			return
 		}
 
 		option := utils.UpdateJsonOption{
-			IgnoreCasing:          false,
+			IgnoreCasing:          model.IgnoreCasing.ValueBool(),
 			IgnoreMissingProperty: model.IgnoreMissingProperty.ValueBool(),
 			IgnoreNullProperty:    false,
 		}
-		body := utils.UpdateObject(requestBody, responseBody, option)
+		if model.ExpandBodyNavigations.ValueBool() {
+			responseBody = utils.UpdateNavigationBindings(requestBody, responseBody, fmt.Sprintf("%s/%s", r.client.GraphBaseUrl(), model.ApiVersion.ValueString()))
+		}
+		responseBody = withAuthenticationMethodAsConfigured(model, requestBody, responseBody)
+		body := utils.UpdateObject(requestBody, withExtensionPropertyName(model, requestBody, responseBody), option)
+		for _, p := range writeOncePathsIgnored(model) {
+			// The write-once properties are not read back, as their changes are never sent.
+			body = utils.ReplacePath(body, requestBody, p)
+		}
+		for _, p := range AsListOfString(model.StripBodyPaths) {
+			// The stripped fields are never sent, so they're kept as configured.
+			body = utils.ReplacePath(body, requestBody, p)
+		}
+
+		if model.FullBodySync.ValueBool() {
+			snapshot, diags := remoteBodySnapshot(ctx, req.Private)
+			if resp.Diagnostics.Append(diags...); resp.Diagnostics.HasError() {
+				return
+			}
+			if snapshot == nil {
+				// There's no snapshot yet, e.g. after importing the resource, so the current remote body is the baseline.
+				resp.Diagnostics.Append(setRemoteBodySnapshot(ctx, resp.Private, responseBody)...)
+			} else if bodyMap, ok := body.(map[string]interface{}); ok {
+				for key, value := range utils.ChangedUnmanagedProperties(requestBody, snapshot, responseBody, option) {
+					tflog.Info(ctx, fmt.Sprintf("Property %q of %q was changed outside of Terraform", key, model.Id.ValueString()))
+					bodyMap[key] = value
+				}
+			}
+		} else {
+			resp.Diagnostics.Append(resp.Private.SetKey(ctx, FlagRemoteBody, nil)...)
+		}
 
 		data, err := json.Marshal(body)
 		if err != nil {
 			resp.Diagnostics.AddError("Invalid body", err.Error())
 			return
 		}
+		if !model.BodyJson.IsNull() {
+			// The configured string is kept unless the body has changed, so its formatting doesn't cause a diff.
+			if utils.NormalizeJson(model.BodyJson.ValueString()) != utils.NormalizeJson(string(data)) {
+				state.BodyJson = types.StringValue(string(data))
+			}
+			resp.Diagnostics.Append(resp.State.Set(ctx, &state)...)
+			return
+		}
 		payload, err := dynamic.FromJSON(data, model.Body.UnderlyingValue().Type(ctx))
 		if err != nil {
 			tflog.Warn(ctx, fmt.Sprintf("Failed to parse payload: %s", err.Error()))
@@ -525,30 +1313,402 @@ func (r *MSGraphResource) Delete(ctx context.Context, req resource.DeleteRequest
 	resp.Diagnostics.Append(diags...)
 	ctx, cancel := context.WithTimeout(ctx, deleteTimeout)
 	defer cancel()
+	ctx = clients.WithOperationMetrics(ctx)
+	defer clients.LogOperationMetrics(ctx, fmt.Sprintf("msgraph_resource delete of %s", model.Url.ValueString()))
+	ctx = clients.WithRedactedPaths(ctx, redactedPaths(model))
+	defer redactDiagnostics(&resp.Diagnostics, redactedPlanValues(model))
+	ctx = clients.WithTenantId(ctx, model.TenantId.ValueString())
+
+	if name := lockName(model); name != "" {
+		locks.ByName(name)
+		defer locks.UnlockByName(name)
+	}
 
-	var itemUrl string
+	deleteUrl := itemUrl(model)
 	if strings.HasSuffix(model.Url.ValueString(), "/$ref") {
-		itemUrl = strings.ReplaceAll(model.Url.ValueString(), "/$ref", fmt.Sprintf("/%s/$ref", model.Id.ValueString()))
-	} else {
-		itemUrl = fmt.Sprintf("%s/%s", model.Url.ValueString(), model.Id.ValueString())
+		deleteUrl = strings.ReplaceAll(model.Url.ValueString(), "/$ref", fmt.Sprintf("/%s/$ref", model.Id.ValueString()))
+	}
+	if pattern := r.client.ProtectedUrlPattern(deleteUrl); pattern != "" {
+		resp.Diagnostics.AddError("Protected resource", fmt.Sprintf("The URL %q matches the protected URL pattern %q of the provider, so it can't be deleted. Remove the pattern from `protected_url_patterns`, or set the `ARM_MSGRAPH_ALLOW_PROTECTED_DELETION` environment variable to `true` to delete it anyway.", deleteUrl, pattern))
+		return
 	}
 
 	options := clients.RequestOptions{
+		Headers:         clients.NewHeaders(AsMapOfString(model.RequestHeaders)),
 		QueryParameters: clients.NewQueryParameters(AsMapOfLists(model.DeleteQueryParameters)),
-		RetryOptions:    clients.NewRetryOptions(model.Retry),
+		RetryOptions:    clients.NewRetryOptions(model.Retry, http.MethodDelete),
 	}
-	err := r.client.Delete(ctx, itemUrl, model.ApiVersion.ValueString(), options)
+	err := r.client.Delete(ctx, deleteUrl, model.ApiVersion.ValueString(), options)
 	if err != nil {
-		resp.Diagnostics.AddError("Failed to delete resource", err.Error())
+		// The accepted error is a no-op, the object might still exist, so there's nothing to wait for.
+		if isAcceptableError(ctx, model.AcceptableErrorCodes, err) {
+			return
+		}
+		if utils.ResponseErrorWasNotFound(err) || isDeleteIgnoredStatusCode(model, err) {
+			tflog.Info(ctx, fmt.Sprintf("%q has already been deleted - removing from state: %s", deleteUrl, err.Error()))
+			return
+		}
+		resp.Diagnostics.AddError("Failed to delete resource", utils.ResponseErrorDetail(err))
 		return
 	}
 
 	// Wait for deletion to complete
-	if err = consistency.WaitForDeletion(ctx, ResourceExistenceFunc(r.client, model)); err != nil {
+	if err = consistency.WaitForDeletionWithOptions(ctx, consistencyPollOptions(ctx, model.Consistency), ResourceExistenceFunc(r.client, model)); err != nil {
 		resp.Diagnostics.AddError("Error waiting for deletion", err.Error())
 	}
 }
 
+// updateReferencesIndividually adds and removes the changed references of the collections bound with `@odata.bind`
+// in the body one by one. It returns a copy of the previous body with the updated collections, so they're not sent
+// again in the PATCH request.
+func (r *MSGraphResource) updateReferencesIndividually(ctx context.Context, model *MSGraphResourceModel, previousBody interface{}, requestBody interface{}, options clients.RequestOptions) (interface{}, error) {
+	previousMap, ok := previousBody.(map[string]interface{})
+	if !ok {
+		return previousBody, nil
+	}
+	requestMap, ok := requestBody.(map[string]interface{})
+	if !ok {
+		return previousBody, nil
+	}
+
+	res := make(map[string]interface{}, len(previousMap))
+	for key, value := range previousMap {
+		res[key] = value
+	}
+	resourceUrl := itemUrl(model)
+	for _, name := range utils.NavigationPropertiesOfBody(requestMap) {
+		bindKey := name + "@odata.bind"
+		newReferences, ok := requestMap[bindKey].([]interface{})
+		if !ok {
+			continue
+		}
+		oldReferences, ok := previousMap[bindKey].([]interface{})
+		if !ok {
+			continue
+		}
+
+		added, removed := utils.DiffNavigationBindings(oldReferences, newReferences)
+		for _, reference := range added {
+			tflog.Info(ctx, fmt.Sprintf("Adding reference %q to %q of %q", reference, name, resourceUrl))
+			body := map[string]interface{}{
+				"@odata.id": reference,
+			}
+			if _, err := r.client.Create(ctx, fmt.Sprintf("%s/%s/$ref", resourceUrl, name), model.ApiVersion.ValueString(), body, options); err != nil {
+				return nil, fmt.Errorf("adding reference %q to %q: %w", reference, name, err)
+			}
+		}
+		for _, id := range removed {
+			tflog.Info(ctx, fmt.Sprintf("Removing reference %q from %q of %q", id, name, resourceUrl))
+			if err := r.client.Delete(ctx, fmt.Sprintf("%s/%s/%s/$ref", resourceUrl, name, id), model.ApiVersion.ValueString(), options); err != nil && !utils.ResponseErrorWasNotFound(err) {
+				return nil, fmt.Errorf("removing reference %q from %q: %w", id, name, err)
+			}
+		}
+		res[bindKey] = newReferences
+	}
+	return res, nil
+}
+
+// referenceExists checks whether the collection contains a reference to the object with the ID. It reads the object
+// through the collection first, e.g. `groups/{id}/members/{member-id}`, which is much faster than enumerating large
+// collections. The collection is enumerated when the direct read fails with `404`, as the navigation properties which
+// don't support reading by key return it too, or when it isn't supported. The enumeration stops once the reference is
+// found, and it fails with `404` when the collection itself doesn't exist.
+func referenceExists(ctx context.Context, client *clients.MSGraphClient, collectionUrl string, id string, apiVersion string, options clients.RequestOptions) (bool, error) {
+	directOptions := clients.RequestOptions{
+		Headers:         options.Headers,
+		QueryParameters: map[string]string{"$select": "id"},
+		RetryOptions:    options.RetryOptions,
+	}
+	_, err := client.Read(ctx, fmt.Sprintf("%s/%s", collectionUrl, id), apiVersion, directOptions)
+	switch {
+	case err == nil:
+		return true, nil
+	case utils.ResponseErrorWasStatusCode(err, http.StatusNotFound),
+		utils.ResponseErrorWasStatusCode(err, http.StatusBadRequest),
+		utils.ResponseErrorWasStatusCode(err, http.StatusMethodNotAllowed),
+		utils.ResponseErrorWasStatusCode(err, http.StatusNotImplemented):
+		tflog.Debug(ctx, fmt.Sprintf("Failed to read %q in collection %q directly, falling back to enumeration: %s", id, collectionUrl, err.Error()))
+	default:
+		return false, err
+	}
+
+	return client.ContainsRefID(ctx, collectionUrl, apiVersion, id, options)
+}
+
+// referenceScanAttempts and referenceScanInterval bound how long a reference which isn't listed in its collection is
+// scanned for before it's considered removed.
+const (
+	referenceScanAttempts = 3
+	referenceScanInterval = 5 * time.Second
+)
+
+// readReferencedObject reads the directory object referred to by a relationship, whose ID is the ID of the resource.
+func (r *MSGraphResource) readReferencedObject(ctx context.Context, model *MSGraphResourceModel) (interface{}, error) {
+	options := clients.RequestOptions{
+		Headers:         clients.NewHeaders(AsMapOfString(model.RequestHeaders)),
+		QueryParameters: make(map[string]string),
+		RetryOptions:    clients.NewRetryOptions(model.Retry, http.MethodGet),
+	}
+	return r.client.Read(ctx, fmt.Sprintf("directoryObjects/%s", model.Id.ValueString()), model.ApiVersion.ValueString(), options)
+}
+
+// validateBody validates the planned body with the validation endpoint of the collection, if there is one. It's only
+// validated when the object is created or the validated properties are changed, in which case only the changed
+// properties are validated against the existing object.
+func (r *MSGraphResource) validateBody(ctx context.Context, plan *MSGraphResourceModel, state *MSGraphResourceModel) diag.Diagnostics {
+	var diags diag.Diagnostics
+	if r.client == nil || plan.Url.IsUnknown() || plan.ApiVersion.IsUnknown() || plan.Body.IsUnknown() || plan.BodyJson.IsUnknown() || !dynamic.IsFullyKnown(plan.Body) {
+		return diags
+	}
+	var planBody, stateBody map[string]interface{}
+	if err := unmarshalModelBody(plan, &planBody); err != nil {
+		return diags
+	}
+	id := ""
+	if state != nil {
+		id = state.Id.ValueString()
+		_ = unmarshalModelBody(state, &stateBody)
+	}
+	validationUrl, requestBody, ok := utils.PropertiesValidation(plan.Url.ValueString(), id, planBody, stateBody)
+	if !ok {
+		tflog.Debug(ctx, fmt.Sprintf("There's no validation endpoint for %q or no property to validate, the body isn't validated", plan.Url.ValueString()))
+		return diags
+	}
+
+	options := clients.RequestOptions{
+		Headers:      clients.NewHeaders(AsMapOfString(plan.RequestHeaders)),
+		RetryOptions: clients.NewRetryOptions(plan.Retry, http.MethodPost),
+	}
+	if _, err := r.client.Action(ctx, http.MethodPost, validationUrl, plan.ApiVersion.ValueString(), requestBody, options); err != nil {
+		bodyPath := path.Root("body")
+		if !plan.BodyJson.IsNull() {
+			bodyPath = path.Root("body_json")
+		}
+		if utils.ResponseErrorWasStatusCode(err, http.StatusBadRequest) || utils.ResponseErrorWasStatusCode(err, http.StatusUnprocessableEntity) {
+			diags.AddAttributeError(bodyPath, "Invalid body", fmt.Sprintf("The body was rejected by %s: %s", validationUrl, utils.ResponseErrorDetail(err)))
+		} else {
+			diags.AddAttributeWarning(bodyPath, "Failed to validate the body", fmt.Sprintf("The body couldn't be validated by %s, it's validated when it's applied: %s", validationUrl, utils.ResponseErrorDetail(err)))
+		}
+	}
+	return diags
+}
+
+// referenceCreationFunc checks whether a reference which was just added is listed in its collection. The collection
+// itself is read with the retries for reading after create, as it may not be replicated yet either, e.g. the members
+// of a group which was just created.
+func referenceCreationFunc(client *clients.MSGraphClient, model *MSGraphResourceModel) consistency.ChangeFunc {
+	return func(ctx context.Context) (*bool, error) {
+		options := clients.RequestOptions{
+			Headers:         clients.NewHeaders(AsMapOfString(model.RequestHeaders)),
+			QueryParameters: clients.NewQueryParameters(AsMapOfLists(model.ReadQueryParameters)),
+			RetryOptions:    clients.NewRetryOptionsForReadAfterCreate(),
+		}
+		found, err := client.ContainsRefID(ctx, baseCollectionUrl(model.Url.ValueString()), model.ApiVersion.ValueString(), model.Id.ValueString(), options)
+		if err != nil {
+			return nil, err
+		}
+		return &found, nil
+	}
+}
+
+// existingObjectId returns the ID of the first object in the collection `url` which matches the filter, or an empty
+// string if none matches.
+func (r *MSGraphResource) existingObjectId(ctx context.Context, model *MSGraphResourceModel, filter string) (string, error) {
+	options := clients.RequestOptions{
+		Headers: clients.NewHeaders(AsMapOfString(model.RequestHeaders)),
+		QueryParameters: map[string]string{
+			"$filter": filter,
+			"$top":    "1",
+		},
+		RetryOptions: clients.NewRetryOptions(model.Retry, http.MethodGet),
+	}
+	if model.IdPath.IsNull() {
+		options.QueryParameters["$select"] = idAttributeOf(model)
+	}
+	responseBody, err := r.client.Read(ctx, model.Url.ValueString(), model.ApiVersion.ValueString(), options)
+	if err != nil {
+		return "", err
+	}
+	responseMap, ok := responseBody.(map[string]interface{})
+	if !ok {
+		return "", nil
+	}
+	items, ok := responseMap["value"].([]interface{})
+	if !ok || len(items) == 0 {
+		return "", nil
+	}
+	if id := idOfResponse(model, items[0]); id != "" {
+		return id, nil
+	}
+	return "", fmt.Errorf("the object matching the filter %q in %q doesn't contain a string property %q", filter, model.Url.ValueString(), idDescription(model))
+}
+
+// idAttributeOf returns the name of the property which holds the identifier of the resource, `id` by default.
+func idAttributeOf(model *MSGraphResourceModel) string {
+	if !model.IdAttribute.IsNull() {
+		return model.IdAttribute.ValueString()
+	}
+	return "id"
+}
+
+// idDescription returns the `id_path` or the name of the property which holds the identifier of the resource, for the
+// error messages.
+func idDescription(model *MSGraphResourceModel) string {
+	if !model.IdPath.IsNull() {
+		return model.IdPath.ValueString()
+	}
+	return idAttributeOf(model)
+}
+
+// idOfResponse returns the identifier of the resource in the response, which is extracted with `id_path` or read from
+// the property `id_attribute`. It returns an empty string if the identifier isn't found.
+func idOfResponse(model *MSGraphResourceModel, responseBody interface{}) string {
+	if !model.IdPath.IsNull() {
+		id, err := utils.ExtractStringJMES(responseBody, model.IdPath.ValueString())
+		if err != nil {
+			return ""
+		}
+		return id
+	}
+	if responseMap, ok := responseBody.(map[string]interface{}); ok {
+		if id, ok := responseMap[idAttributeOf(model)].(string); ok {
+			return id
+		}
+	}
+	return ""
+}
+
+// isDeleteIgnoredStatusCode returns whether the status code of the error of the delete request is one of
+// `delete_ignore_status_codes`.
+func isDeleteIgnoredStatusCode(model *MSGraphResourceModel, err error) bool {
+	for _, statusCode := range AsListOfInt64(model.DeleteIgnoreStatusCodes) {
+		if utils.ResponseErrorWasStatusCode(err, int(statusCode)) {
+			return true
+		}
+	}
+	return false
+}
+
+// lockName returns the name which serializes the writes of the resource, it's `lock_id` if specified, or the URL of
+// the parent resource for `$ref` URLs, e.g. `groups/{id}` for `groups/{id}/members/$ref`.
+func lockName(model *MSGraphResourceModel) string {
+	if v := model.LockId.ValueString(); v != "" {
+		return v
+	}
+	if parentUrl, ok := strings.CutSuffix(model.Url.ValueString(), "/$ref"); ok {
+		if i := strings.LastIndex(parentUrl, "/"); i > 0 {
+			return parentUrl[:i]
+		}
+		return parentUrl
+	}
+	return ""
+}
+
+// resourceUrlOf returns the canonical URL of the object managed by the resource, which is exported in `resource_url`.
+func resourceUrlOf(model *MSGraphResourceModel) string {
+	if model.CreateMethod.ValueString() == http.MethodPut {
+		return utils.ItemUrl(model.Url.ValueString(), "")
+	}
+	return utils.ItemUrl(model.Url.ValueString(), model.Id.ValueString())
+}
+
+// resourceFullUrl returns the absolute URL of the object managed by the resource, which is exported in `resource_full_url`.
+func (r *MSGraphResource) resourceFullUrl(model *MSGraphResourceModel) string {
+	return fmt.Sprintf("%s/%s/%s", r.client.GraphBaseUrl(), model.ApiVersion.ValueString(), resourceUrlOf(model))
+}
+
+// resourceId returns the import ID of the object managed by the resource, which is exported in `resource_id`. The
+// query parameters understood by ImportState are appended when they're not the defaults.
+func (r *MSGraphResource) resourceId(model *MSGraphResourceModel) string {
+	importId := itemUrl(model)
+	if strings.HasSuffix(model.Url.ValueString(), "/$ref") {
+		importId = fmt.Sprintf("%s/%s/$ref", baseCollectionUrl(model.Url.ValueString()), model.Id.ValueString())
+	}
+	query := url.Values{}
+	if apiVersion := model.ApiVersion.ValueString(); apiVersion != r.client.DefaultApiVersion() {
+		query.Set("api-version", apiVersion)
+	}
+	if tenantId := model.TenantId.ValueString(); tenantId != "" {
+		query.Set("tenant_id", tenantId)
+	}
+	if model.CreateMethod.ValueString() == http.MethodPut {
+		query.Set("create_method", http.MethodPut)
+	}
+	if len(query) != 0 {
+		importId = fmt.Sprintf("%s?%s", importId, query.Encode())
+	}
+	return importId
+}
+
+// itemUrl returns the URL of the object managed by the resource. It's `url` itself for the objects created with PUT
+// at a known URL, otherwise it's the ID appended to the collection URL `url`.
+func itemUrl(model *MSGraphResourceModel) string {
+	if model.CreateMethod.ValueString() == http.MethodPut {
+		return model.Url.ValueString()
+	}
+	return fmt.Sprintf("%s/%s", model.Url.ValueString(), model.Id.ValueString())
+}
+
+// requestBodyOf returns the request body of the model, which is configured either in `body` or as a JSON string in `body_json`.
+func requestBodyOf(model *MSGraphResourceModel) (types.Dynamic, error) {
+	if model.BodyJson.IsNull() || model.BodyJson.IsUnknown() {
+		return model.Body, nil
+	}
+	return dynamic.FromJSONImplied([]byte(model.BodyJson.ValueString()))
+}
+
+// writeOnlyBodyOf returns the object in `write_only_body`, or nil if it's not specified.
+func writeOnlyBodyOf(model *MSGraphResourceModel) (map[string]interface{}, error) {
+	var out map[string]interface{}
+	if err := unmarshalBody(model.WriteOnlyBody, &out); err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// sensitiveOutputPatterns returns the patterns of the paths moved to `sensitive_output`, which are
+// `sensitive_output_path_patterns` and the paths of `redact_plan_paths`.
+func sensitiveOutputPatterns(model *MSGraphResourceModel) []string {
+	patterns := AsListOfString(model.SensitiveOutputPatterns)
+	for _, p := range AsListOfString(model.RedactPlanPaths) {
+		patterns = append(patterns, "^"+regexp.QuoteMeta(p)+"$")
+	}
+	return patterns
+}
+
+// redactedPlanValues returns the values of `body` at the paths of `redact_plan_paths`, which are masked in the
+// diagnostics.
+func redactedPlanValues(model *MSGraphResourceModel) []string {
+	paths := AsListOfString(model.RedactPlanPaths)
+	if len(paths) == 0 {
+		return nil
+	}
+	var body interface{}
+	if err := unmarshalModelBody(model, &body); err != nil {
+		return nil
+	}
+	return utils.StringValuesAtPaths(body, paths)
+}
+
+// redactedPaths returns the paths masked in the logs, which are `redact_plan_paths` and the properties of
+// `write_only_body`.
+func redactedPaths(model *MSGraphResourceModel) []string {
+	paths := AsListOfString(model.RedactPlanPaths)
+	writeOnlyBody, _ := writeOnlyBodyOf(model)
+	for key := range writeOnlyBody {
+		paths = append(paths, key)
+	}
+	return paths
+}
+
+func unmarshalModelBody(model *MSGraphResourceModel, out interface{}) error {
+	body, err := requestBodyOf(model)
+	if err != nil {
+		return fmt.Errorf("invalid body_json: %+v", err)
+	}
+	return unmarshalBody(body, out)
+}
+
 func ResourceExistenceFunc(client *clients.MSGraphClient, model *MSGraphResourceModel) consistency.ChangeFunc {
 	return func(ctx context.Context) (*bool, error) {
 		if model == nil {
@@ -567,9 +1727,10 @@ func ResourceExistenceFunc(client *clients.MSGraphClient, model *MSGraphResource
 		if strings.HasSuffix(model.Url.ValueString(), "/$ref") {
 			collectionUrl := baseCollectionUrl(model.Url.ValueString())
 			options := clients.RequestOptions{
+				Headers:         clients.NewHeaders(AsMapOfString(model.RequestHeaders)),
 				QueryParameters: clients.NewQueryParameters(AsMapOfLists(model.ReadQueryParameters)),
 			}
-			referenceIds, err := client.ListRefIDs(ctx, collectionUrl, model.ApiVersion.ValueString(), options)
+			found, err := referenceExists(ctx, client, collectionUrl, model.Id.ValueString(), model.ApiVersion.ValueString(), options)
 			if err != nil {
 				if utils.ResponseErrorWasNotFound(err) {
 					b := false
@@ -577,21 +1738,14 @@ func ResourceExistenceFunc(client *clients.MSGraphClient, model *MSGraphResource
 				}
 				return nil, err
 			}
-			found := false
-			for _, refId := range referenceIds {
-				if refId == model.Id.ValueString() {
-					found = true
-					break
-				}
-			}
 			return &found, nil
 		}
 
 		options := clients.RequestOptions{
+			Headers:         clients.NewHeaders(AsMapOfString(model.RequestHeaders)),
 			QueryParameters: clients.NewQueryParameters(AsMapOfLists(model.ReadQueryParameters)),
 		}
-		itemUrl := fmt.Sprintf("%s/%s", model.Url.ValueString(), model.Id.ValueString())
-		_, err := client.Read(ctx, itemUrl, model.ApiVersion.ValueString(), options)
+		_, err := client.Read(ctx, itemUrl(model), model.ApiVersion.ValueString(), options)
 		if err != nil {
 			if utils.ResponseErrorWasNotFound(err) {
 				b := false
@@ -612,11 +1766,21 @@ func (r *MSGraphResource) ImportState(ctx context.Context, req resource.ImportSt
 		return
 	}
 
-	apiVersion := "v1.0"
+	apiVersion := r.client.DefaultApiVersion()
 	if parsedUrl.Query().Get("api-version") != "" {
 		apiVersion = parsedUrl.Query().Get("api-version")
 	}
 
+	tenantId := types.StringNull()
+	if v := parsedUrl.Query().Get("tenant_id"); v != "" {
+		tenantId = types.StringValue(v)
+	}
+
+	createMethod := types.StringNull()
+	if strings.EqualFold(parsedUrl.Query().Get("create_method"), http.MethodPut) {
+		createMethod = types.StringValue(http.MethodPut)
+	}
+
 	if strings.HasSuffix(parsedUrl.Path, "/$ref") {
 		reqIdWithoutRef := strings.TrimSuffix(parsedUrl.Path, "/$ref")
 		lastIndex := strings.LastIndex(reqIdWithoutRef, "/")
@@ -632,40 +1796,67 @@ func (r *MSGraphResource) ImportState(ctx context.Context, req resource.ImportSt
 		urlValue = strings.TrimPrefix(urlValue, "/")
 		urlValue = fmt.Sprintf("%s/$ref", urlValue)
 	} else {
-		lastIndex := strings.LastIndex(parsedUrl.Path, "/")
-		if lastIndex == -1 {
+		importPath := strings.TrimPrefix(parsedUrl.Path, "/")
+		lastIndex := strings.LastIndex(importPath, "/")
+		if lastIndex == -1 || lastIndex == len(importPath)-1 {
+			// A single segment which isn't an id, or a path ending with "/", points to a collection rather than an item.
+			if _, err := uuid.ParseUUID(importPath); lastIndex != -1 || err != nil {
+				resp.Diagnostics.AddError(
+					"Import ID Refers To A Collection",
+					fmt.Sprintf("The import ID %q refers to a collection, but it must refer to an item of the collection in the format 'url/id'. Please append the id of the item to import, for example: '%s/{id}'.", req.ID, strings.TrimSuffix(importPath, "/")),
+				)
+				return
+			}
 			resp.Diagnostics.AddError(
 				"Invalid Import ID",
 				fmt.Sprintf("The import ID must be in the format 'url/id'. For example: 'identity/conditionalAccess/policies/{policy-id}'. Got: %s", req.ID),
 			)
 			return
 		}
-		id = parsedUrl.Path[lastIndex+1:]
-		urlValue = strings.TrimPrefix(parsedUrl.Path[0:lastIndex], "/")
-	}
-
-	// Construct the resource_url based on the URL pattern
-	var resourceUrl string
-	if strings.HasSuffix(urlValue, "/$ref") {
-		// For $ref URLs, resource_url should be the collection URL without $ref + the ID
-		baseUrl := strings.TrimSuffix(urlValue, "/$ref")
-		resourceUrl = fmt.Sprintf("%s/%s", baseUrl, id)
-	} else {
-		// For regular URLs, resource_url is url + ID
-		resourceUrl = fmt.Sprintf("%s/%s", urlValue, id)
+		id = importPath[lastIndex+1:]
+		urlValue = importPath[0:lastIndex]
+		if !createMethod.IsNull() {
+			// The objects created with PUT are managed at their known URL
+			urlValue = importPath
+		}
 	}
 
 	model := &MSGraphResourceModel{
-		Id:                    types.StringValue(id),
-		ResourceUrl:           types.StringValue(resourceUrl),
-		Url:                   types.StringValue(urlValue),
-		ApiVersion:            types.StringValue(apiVersion),
-		IgnoreMissingProperty: types.BoolValue(true),
-		CreateQueryParameters: types.MapNull(types.ListType{ElemType: types.StringType}),
-		UpdateQueryParameters: types.MapNull(types.ListType{ElemType: types.StringType}),
-		ReadQueryParameters:   types.MapNull(types.ListType{ElemType: types.StringType}),
-		DeleteQueryParameters: types.MapNull(types.ListType{ElemType: types.StringType}),
-		Retry:                 retry.NewValueNull(),
+		Id:                         types.StringValue(id),
+		Url:                        types.StringValue(urlValue),
+		ApiVersion:                 types.StringValue(apiVersion),
+		TenantId:                   tenantId,
+		CreateMethod:               createMethod,
+		IgnoreMissingProperty:      types.BoolValue(true),
+		IgnoreCasing:               types.BoolValue(false),
+		PreferReturnRepresentation: types.BoolValue(false),
+		ValidateOnPlan:             types.BoolValue(false),
+		PutMerge:                   types.BoolValue(false),
+		FullBodySync:               types.BoolValue(false),
+		ReadAfterCreate:            types.BoolValue(true),
+		ReadReferencedObject:       types.BoolValue(false),
+		AutoODataType:              types.BoolValue(false),
+		GranularReferenceUpdates:   types.BoolValue(false),
+		ExpandBodyNavigations:      types.BoolValue(false),
+		CreateQueryParameters:      types.MapNull(types.ListType{ElemType: types.StringType}),
+		UpdateQueryParameters:      types.MapNull(types.ListType{ElemType: types.StringType}),
+		ReadQueryParameters:        types.MapNull(types.ListType{ElemType: types.StringType}),
+		DeleteQueryParameters:      types.MapNull(types.ListType{ElemType: types.StringType}),
+		RequestHeaders:             types.MapNull(types.StringType),
+		RedactPlanPaths:            types.ListNull(types.StringType),
+		WriteOncePaths:             types.ListNull(types.StringType),
+		StripBodyPaths:             types.ListNull(types.StringType),
+		WriteOncePolicy:            types.StringValue(writeOncePolicyIgnore),
+		Consistency:                types.ObjectNull(consistencyAttributeTypes),
+		WriteOnlyBody:              types.DynamicNull(),
+		WriteOnlyBodyVersion:       types.Int64Null(),
+		SensitiveOutputPatterns:    types.ListNull(types.StringType),
+		SensitiveOutput:            types.DynamicNull(),
+		CreateRetry:                types.ObjectNull(createRetryAttributeTypes),
+		OutputFormat:               types.StringValue(outputFormatTyped),
+		AcceptableErrorCodes:       types.ListNull(types.ObjectType{AttrTypes: acceptableErrorCodeAttributeTypes}),
+		DeleteIgnoreStatusCodes:    types.ListNull(types.Int64Type),
+		Retry:                      retry.NewValueNull(),
 		Timeouts: timeouts.Value{
 			Object: types.ObjectNull(map[string]attr.Type{
 				"create": types.StringType,
@@ -675,10 +1866,209 @@ func (r *MSGraphResource) ImportState(ctx context.Context, req resource.ImportSt
 			}),
 		},
 	}
+	model.ResourceUrl = types.StringValue(resourceUrlOf(model))
+	model.ResourceFullUrl = types.StringValue(r.resourceFullUrl(model))
+	model.ResourceId = types.StringValue(r.resourceId(model))
 	resp.Diagnostics.Append(resp.State.Set(ctx, model)...)
 }
 
+// withInferredODataType returns the body with the `@odata.type` inferred from the URL and the full body when it's
+// omitted, see utils.InferODataType for the supported endpoints.
+func withInferredODataType(ctx context.Context, url string, body interface{}, fullBody interface{}) interface{} {
+	body, odataType := utils.WithODataType(url, body, fullBody)
+	if odataType != "" {
+		tflog.Info(ctx, fmt.Sprintf("Added the inferred @odata.type %q to the request body of %q", odataType, url))
+	}
+	return body
+}
+
+// isRepresentation returns whether the response of the update is the updated object, i.e. `Prefer: return=representation`
+// was honored, and it can be used instead of reading the object. It's read when `read_query_parameters` is set, as the
+// response isn't shaped by e.g. `$select` or `$expand`.
+func isRepresentation(model *MSGraphResourceModel, responseBody interface{}) bool {
+	if !model.ReadQueryParameters.IsNull() && len(model.ReadQueryParameters.Elements()) != 0 {
+		return false
+	}
+	body, ok := responseBody.(map[string]interface{})
+	return ok && len(body) != 0
+}
+
+// readQueryString returns the query string of `read_query_parameters` quoted for the plan, or `(none)` if it's empty.
+func readQueryString(model *MSGraphResourceModel) string {
+	queryString := clients.QueryString(clients.NewQueryParameters(AsMapOfLists(model.ReadQueryParameters)))
+	if queryString == "" {
+		return "(none)"
+	}
+	return fmt.Sprintf("`?%s`", queryString)
+}
+
+// writeOncePathsIgnored returns the paths of `write_once_paths` whose changes are ignored by `write_once_policy`.
+func writeOncePathsIgnored(model *MSGraphResourceModel) []string {
+	if model.WriteOncePolicy.ValueString() == writeOncePolicyReplace {
+		return nil
+	}
+	return AsListOfString(model.WriteOncePaths)
+}
+
+// immutableProperties are the properties which can't be updated after the object is created, keyed by the name of
+// the collection, e.g. the `name` of federated identity credentials.
+var immutableProperties = map[string][]string{
+	"federatedIdentityCredentials": {"name"},
+	"extensionProperties":          {"name", "dataType", "isMultiValued", "targetObjects"},
+	"phoneMethods":                 {"phoneType"},
+	// scoped role memberships of administrative units can't be updated, a new membership must be created instead
+	"scopedRoleMembers": {"roleId", "roleMemberInfo"},
+}
+
+// immutablePropertiesOf returns the properties which can't be updated for the objects created in the collection URL.
+func immutablePropertiesOf(collectionUrl string) []string {
+	for collection, properties := range immutableProperties {
+		if strings.EqualFold(utils.LastSegment(collectionUrl), collection) {
+			return properties
+		}
+	}
+	return nil
+}
+
+// withExtensionPropertyName returns the response of an extension property of an application whose `name`, which is
+// prefixed with the app ID of the application, e.g. `extension_b7d8e648520f41d3b9d0fdeb0c4ba8e3_jobGroup`, is replaced
+// with the name configured in the body, e.g. `jobGroup`, so it's not reported as drift.
+func withExtensionPropertyName(model *MSGraphResourceModel, requestBody interface{}, responseBody interface{}) interface{} {
+	if !strings.EqualFold(utils.LastSegment(model.Url.ValueString()), "extensionProperties") {
+		return responseBody
+	}
+	requestMap, ok := requestBody.(map[string]interface{})
+	if !ok {
+		return responseBody
+	}
+	responseMap, ok := responseBody.(map[string]interface{})
+	if !ok {
+		return responseBody
+	}
+	configuredName, _ := requestMap["name"].(string)
+	name, _ := responseMap["name"].(string)
+	if configuredName == "" || utils.IsExtensionAttributeName(configuredName) || utils.ExtensionPropertyName(name) != configuredName {
+		return responseBody
+	}
+	res := make(map[string]interface{}, len(responseMap))
+	for key, value := range responseMap {
+		res[key] = value
+	}
+	res["name"] = configuredName
+	return res
+}
+
+// withAuthenticationMethodAsConfigured returns the response of an authentication method of a user whose phone number
+// or email address is replaced with the one configured in the body when they're the same, because Microsoft Graph
+// returns them normalized, e.g. `+1 2065555555` for `+1 (206) 555-5555`, so they're not reported as drift.
+func withAuthenticationMethodAsConfigured(model *MSGraphResourceModel, requestBody interface{}, responseBody interface{}) interface{} {
+	var property string
+	var equal func(string, string) bool
+	switch utils.AuthenticationMethodCollection(model.Url.ValueString()) {
+	case "phoneMethods":
+		property, equal = "phoneNumber", utils.PhoneNumbersEqual
+	case "emailMethods":
+		property, equal = "emailAddress", strings.EqualFold
+	default:
+		return responseBody
+	}
+	requestMap, ok := requestBody.(map[string]interface{})
+	if !ok {
+		return responseBody
+	}
+	responseMap, ok := responseBody.(map[string]interface{})
+	if !ok {
+		return responseBody
+	}
+	configured, _ := requestMap[property].(string)
+	value, _ := responseMap[property].(string)
+	if configured == "" || configured == value || !equal(configured, value) {
+		return responseBody
+	}
+	res := make(map[string]interface{}, len(responseMap))
+	for key, value := range responseMap {
+		res[key] = value
+	}
+	res[property] = configured
+	return res
+}
+
+// withoutExpandedProperties returns a copy of the response without the navigation properties expanded by `$expand` in
+// `read_query_parameters`, so they're exported to `output` but not reconciled with `body`. The properties which are
+// configured in `body`, or bound with `@odata.bind`, are kept.
+func withoutExpandedProperties(model *MSGraphResourceModel, responseBody interface{}) interface{} {
+	expanded := utils.ExpandedProperties(AsMapOfLists(model.ReadQueryParameters)["$expand"])
+	if len(expanded) == 0 {
+		return responseBody
+	}
+	var requestBody map[string]interface{}
+	if !model.Body.IsNull() || !model.BodyJson.IsNull() {
+		_ = unmarshalModelBody(model, &requestBody)
+	}
+	names := make([]string, 0, len(expanded))
+	for _, name := range expanded {
+		if _, ok := requestBody[name]; ok {
+			continue
+		}
+		if _, ok := requestBody[name+"@odata.bind"]; ok {
+			continue
+		}
+		names = append(names, name)
+	}
+	return utils.WithoutProperties(responseBody, names)
+}
+
+// expandQueryParameters returns a copy of the query parameters with the navigation properties added to `$expand`.
+func expandQueryParameters(queryParameters map[string][]string, navigationProperties []string) map[string][]string {
+	res := make(map[string][]string, len(queryParameters)+1)
+	for key, values := range queryParameters {
+		res[key] = values
+	}
+	expand := append([]string{}, res["$expand"]...)
+	for _, navigationProperty := range navigationProperties {
+		if !slices.Contains(expand, navigationProperty) {
+			expand = append(expand, navigationProperty)
+		}
+	}
+	if len(expand) != 0 {
+		res["$expand"] = expand
+	}
+	return res
+}
+
 func buildOutputFromBody(body interface{}, paths map[string]string) attr.Value {
+	return buildOutput(body, paths, outputFormatTyped)
+}
+
+// buildOutput builds the output from the values of the body specified in paths. In the `json_string` format,
+// the output is a JSON-encoded string instead of an object whose types are inferred from the JSON values.
+func buildOutput(body interface{}, paths map[string]string, format string) attr.Value {
+	return outputValue(exportValues(body, paths), format)
+}
+
+// buildOutputs builds the output like buildOutput, and moves the values whose paths in the output match any of the
+// patterns to the sensitive output. The sensitive output is null if there are no patterns.
+func buildOutputs(body interface{}, paths map[string]string, format string, patterns []string) (attr.Value, attr.Value, error) {
+	if len(patterns) == 0 {
+		return buildOutput(body, paths, format), types.DynamicNull(), nil
+	}
+	expressions := make([]*regexp.Regexp, 0, len(patterns))
+	for _, pattern := range patterns {
+		expression, err := regexp.Compile(pattern)
+		if err != nil {
+			return nil, nil, fmt.Errorf("invalid pattern %q in `sensitive_output_path_patterns`: %w", pattern, err)
+		}
+		expressions = append(expressions, expression)
+	}
+	output, sensitiveOutput := utils.SplitSensitivePaths(exportValues(body, paths), expressions)
+	if sensitiveOutput == nil {
+		sensitiveOutput = make(map[string]interface{})
+	}
+	return outputValue(output, format), outputValue(sensitiveOutput, format), nil
+}
+
+// exportValues returns an object with the values of the body specified in paths.
+func exportValues(body interface{}, paths map[string]string) interface{} {
 	var output interface{}
 	output = make(map[string]interface{})
 	for pathKey, path := range paths {
@@ -688,10 +2078,17 @@ func buildOutputFromBody(body interface{}, paths map[string]string) attr.Value {
 		}
 		output = utils.MergeObject(output, part)
 	}
+	return output
+}
+
+func outputValue(output interface{}, format string) attr.Value {
 	data, err := json.Marshal(output)
 	if err != nil {
 		return nil
 	}
+	if format == outputFormatJSONString {
+		return types.StringValue(string(data))
+	}
 	out, err := dynamic.FromJSONImplied(data)
 	if err != nil {
 		return nil
@@ -765,21 +2162,40 @@ func (r *MSGraphResource) MoveState(ctx context.Context) []resource.StateMover {
 					idValue = requestID[lastIndex+1:]
 				}
 
-				// For $ref URLs, resource_url should be the collection URL without $ref + the ID
-				baseUrl := strings.TrimSuffix(urlValue, "/$ref")
-				resourceUrl := fmt.Sprintf("%s/%s", baseUrl, idValue)
-
 				state := MSGraphResourceModel{
-					Id:                    types.StringValue(idValue),
-					Url:                   types.StringValue(urlValue),
-					ApiVersion:            types.StringValue("v1.0"),
-					ResourceUrl:           types.StringValue(resourceUrl),
-					IgnoreMissingProperty: types.BoolValue(true),
-					CreateQueryParameters: types.MapNull(types.ListType{ElemType: types.StringType}),
-					UpdateQueryParameters: types.MapNull(types.ListType{ElemType: types.StringType}),
-					ReadQueryParameters:   types.MapNull(types.ListType{ElemType: types.StringType}),
-					DeleteQueryParameters: types.MapNull(types.ListType{ElemType: types.StringType}),
-					Retry:                 retry.NewValueNull(),
+					Id:                         types.StringValue(idValue),
+					Url:                        types.StringValue(urlValue),
+					ApiVersion:                 types.StringValue("v1.0"),
+					IgnoreMissingProperty:      types.BoolValue(true),
+					IgnoreCasing:               types.BoolValue(false),
+					PreferReturnRepresentation: types.BoolValue(false),
+					ValidateOnPlan:             types.BoolValue(false),
+					PutMerge:                   types.BoolValue(false),
+					FullBodySync:               types.BoolValue(false),
+					ReadAfterCreate:            types.BoolValue(true),
+					ReadReferencedObject:       types.BoolValue(false),
+					AutoODataType:              types.BoolValue(false),
+					GranularReferenceUpdates:   types.BoolValue(false),
+					ExpandBodyNavigations:      types.BoolValue(false),
+					CreateQueryParameters:      types.MapNull(types.ListType{ElemType: types.StringType}),
+					UpdateQueryParameters:      types.MapNull(types.ListType{ElemType: types.StringType}),
+					ReadQueryParameters:        types.MapNull(types.ListType{ElemType: types.StringType}),
+					DeleteQueryParameters:      types.MapNull(types.ListType{ElemType: types.StringType}),
+					RequestHeaders:             types.MapNull(types.StringType),
+					RedactPlanPaths:            types.ListNull(types.StringType),
+					WriteOncePaths:             types.ListNull(types.StringType),
+					StripBodyPaths:             types.ListNull(types.StringType),
+					WriteOncePolicy:            types.StringValue(writeOncePolicyIgnore),
+					Consistency:                types.ObjectNull(consistencyAttributeTypes),
+					WriteOnlyBody:              types.DynamicNull(),
+					WriteOnlyBodyVersion:       types.Int64Null(),
+					SensitiveOutputPatterns:    types.ListNull(types.StringType),
+					SensitiveOutput:            types.DynamicNull(),
+					CreateRetry:                types.ObjectNull(createRetryAttributeTypes),
+					OutputFormat:               types.StringValue(outputFormatTyped),
+					AcceptableErrorCodes:       types.ListNull(types.ObjectType{AttrTypes: acceptableErrorCodeAttributeTypes}),
+					DeleteIgnoreStatusCodes:    types.ListNull(types.Int64Type),
+					Retry:                      retry.NewValueNull(),
 					Timeouts: timeouts.Value{
 						Object: types.ObjectNull(map[string]attr.Type{
 							"create": types.StringType,
@@ -789,6 +2205,9 @@ func (r *MSGraphResource) MoveState(ctx context.Context) []resource.StateMover {
 						}),
 					},
 				}
+				state.ResourceUrl = types.StringValue(resourceUrlOf(&state))
+				state.ResourceFullUrl = types.StringValue(r.resourceFullUrl(&state))
+				state.ResourceId = types.StringValue(r.resourceId(&state))
 
 				response.Diagnostics.Append(response.TargetPrivate.SetKey(ctx, FlagMoveState, []byte("true"))...)
 				response.Diagnostics.Append(response.TargetState.Set(ctx, &state)...)
@@ -796,3 +2215,67 @@ func (r *MSGraphResource) MoveState(ctx context.Context) []resource.StateMover {
 		},
 	}
 }
+
+type privateStateSetter interface {
+	SetKey(ctx context.Context, key string, value []byte) diag.Diagnostics
+}
+
+type privateStateGetter interface {
+	GetKey(ctx context.Context, key string) ([]byte, diag.Diagnostics)
+}
+
+// setRemoteBodySnapshot stores the remote body in the private state, it's used by `full_body_sync` to detect changes
+// made outside of Terraform to the properties which are not configured.
+func setRemoteBodySnapshot(ctx context.Context, private privateStateSetter, responseBody interface{}) diag.Diagnostics {
+	data, err := json.Marshal(responseBody)
+	if err != nil {
+		var diags diag.Diagnostics
+		diags.AddError("Invalid response body", err.Error())
+		return diags
+	}
+	return private.SetKey(ctx, FlagRemoteBody, data)
+}
+
+// remoteBodySnapshot returns the remote body stored in the private state, or nil if there's none.
+func remoteBodySnapshot(ctx context.Context, private privateStateGetter) (interface{}, diag.Diagnostics) {
+	data, diags := private.GetKey(ctx, FlagRemoteBody)
+	if diags.HasError() || len(data) == 0 {
+		return nil, diags
+	}
+	var snapshot interface{}
+	if err := json.Unmarshal(data, &snapshot); err != nil {
+		diags.AddError("Invalid private state", fmt.Sprintf("The snapshot of the remote body is invalid: %s", err.Error()))
+		return nil, diags
+	}
+	return snapshot, diags
+}
+
+// revertUnmanagedProperties returns a copy of the request body which contains the values of the snapshot for the
+// properties which are in the prior state, but not in the request body, i.e. the ones changed outside of Terraform.
+func revertUnmanagedProperties(requestBody interface{}, previousBody interface{}, snapshot interface{}) interface{} {
+	requestMap, ok := requestBody.(map[string]interface{})
+	if !ok {
+		return requestBody
+	}
+	previousMap, ok := previousBody.(map[string]interface{})
+	if !ok {
+		return requestBody
+	}
+	snapshotMap, ok := snapshot.(map[string]interface{})
+	if !ok {
+		return requestBody
+	}
+	res := make(map[string]interface{})
+	for key, value := range requestMap {
+		res[key] = value
+	}
+	for key := range previousMap {
+		if _, ok := requestMap[key]; ok {
+			continue
+		}
+		if value, ok := snapshotMap[key]; ok {
+			res[key] = value
+		}
+	}
+	return res
+}
@@ -7,18 +7,22 @@ import (
 	"time"
 
 	"github.com/hashicorp/terraform-plugin-framework-timeouts/resource/timeouts"
+	"github.com/hashicorp/terraform-plugin-framework-validators/listvalidator"
 	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
 	"github.com/hashicorp/terraform-plugin-framework/resource"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
-	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringdefault"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
 	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
 	"github.com/hashicorp/terraform-plugin-framework/types"
 	"github.com/hashicorp/terraform-plugin-log/tflog"
 	"github.com/microsoft/terraform-provider-msgraph/internal/clients"
 	"github.com/microsoft/terraform-provider-msgraph/internal/docstrings"
+	"github.com/microsoft/terraform-provider-msgraph/internal/myvalidator"
 	"github.com/microsoft/terraform-provider-msgraph/internal/retry"
+	"github.com/microsoft/terraform-provider-msgraph/internal/utils"
 )
 
 // Ensure provider defined types fully satisfy framework interfaces.
@@ -38,18 +42,23 @@ type MSGraphResourceAction struct {
 
 // MSGraphResourceActionModel describes the resource data model.
 type MSGraphResourceActionModel struct {
-	Id                   types.String      `tfsdk:"id"`
-	ApiVersion           types.String      `tfsdk:"api_version"`
-	ResourceUrl          types.String      `tfsdk:"resource_url"`
-	Action               types.String      `tfsdk:"action"`
-	Method               types.String      `tfsdk:"method"`
-	Body                 types.Dynamic     `tfsdk:"body"`
-	QueryParameters      types.Map         `tfsdk:"query_parameters"`
-	Headers              types.Map         `tfsdk:"headers"`
-	ResponseExportValues map[string]string `tfsdk:"response_export_values"`
-	Retry                retry.Value       `tfsdk:"retry"`
-	Output               types.Dynamic     `tfsdk:"output"`
-	Timeouts             timeouts.Value    `tfsdk:"timeouts"`
+	Id                      types.String      `tfsdk:"id"`
+	ApiVersion              types.String      `tfsdk:"api_version"`
+	ResourceUrl             types.String      `tfsdk:"resource_url"`
+	Action                  types.String      `tfsdk:"action"`
+	Method                  types.String      `tfsdk:"method"`
+	Body                    types.Dynamic     `tfsdk:"body"`
+	QueryParameters         types.Map         `tfsdk:"query_parameters"`
+	Headers                 types.Map         `tfsdk:"headers"`
+	ResponseExportValues    map[string]string `tfsdk:"response_export_values"`
+	IdPath                  types.String      `tfsdk:"id_path"`
+	Triggers                types.Map         `tfsdk:"triggers"`
+	AcceptableErrorCodes    types.List        `tfsdk:"acceptable_error_codes"`
+	Retry                   retry.Value       `tfsdk:"retry"`
+	Output                  types.Dynamic     `tfsdk:"output"`
+	SensitiveOutput         types.Dynamic     `tfsdk:"sensitive_output"`
+	SensitiveOutputPatterns types.List        `tfsdk:"sensitive_output_path_patterns"`
+	Timeouts                timeouts.Value    `tfsdk:"timeouts"`
 }
 
 func (r *MSGraphResourceAction) Metadata(ctx context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
@@ -63,7 +72,7 @@ func (r *MSGraphResourceAction) Schema(ctx context.Context, req resource.SchemaR
 
 		Attributes: map[string]schema.Attribute{
 			"id": schema.StringAttribute{
-				MarkdownDescription: docstrings.ResourceID(),
+				MarkdownDescription: "The ID of the action resource. It's the URL of the action, or the value extracted from the response with `id_path`.",
 				Computed:            true,
 				PlanModifiers: []planmodifier.String{
 					stringplanmodifier.UseStateForUnknown(),
@@ -73,6 +82,9 @@ func (r *MSGraphResourceAction) Schema(ctx context.Context, req resource.SchemaR
 			"resource_url": schema.StringAttribute{
 				MarkdownDescription: "The URL of the resource to perform the action on. This should be the full resource path, for example `applications/12345678-1234-1234-1234-123456789abc` or `users/user@example.com`. You can use the `resource_url` output from `msgraph_resource`.",
 				Required:            true,
+				Validators: []validator.String{
+					myvalidator.RelativeURL(),
+				},
 				PlanModifiers: []planmodifier.String{
 					stringplanmodifier.RequiresReplace(),
 				},
@@ -102,11 +114,7 @@ func (r *MSGraphResourceAction) Schema(ctx context.Context, req resource.SchemaR
 				Optional:            true,
 				Computed:            true,
 				Validators: []validator.String{
-					stringvalidator.OneOf("v1.0", "beta"),
-				},
-				Default: stringdefault.StaticString("v1.0"),
-				PlanModifiers: []planmodifier.String{
-					stringplanmodifier.RequiresReplace(),
+					myvalidator.ApiVersion(),
 				},
 			},
 
@@ -135,12 +143,44 @@ func (r *MSGraphResourceAction) Schema(ctx context.Context, req resource.SchemaR
 				ElementType:         types.StringType,
 			},
 
+			"id_path": schema.StringAttribute{
+				MarkdownDescription: "A JMESPath expression to extract the ID of the object created by the action from the response into `id`, e.g. `servicePrincipal.id` for `applicationTemplates/{id}/instantiate` or `keyId` for `addKey`. If not specified, or if the expression doesn't match the response, the URL of the action is used as the ID.",
+				Optional:            true,
+				Validators: []validator.String{
+					stringvalidator.LengthAtLeast(1),
+				},
+			},
+
+			"triggers": schema.MapAttribute{
+				MarkdownDescription: "A map of arbitrary values which cause the action to be executed again in place when any of them changes, like the `triggers` of `null_resource`, e.g. `{ rotation = time_rotating.example.id }`. This is the supported way to invoke an idempotent action again without replacing the resource. The `output` of the last execution is kept in the state.",
+				Optional:            true,
+				ElementType:         types.StringType,
+			},
+
+			"acceptable_error_codes": acceptableErrorCodesSchema(docstrings.AcceptableErrorCodes("the action request") + " When the error is accepted, `output` is empty and `id` is the URL of the action."),
+
 			"retry": retry.Schema(ctx),
 
 			"output": schema.DynamicAttribute{
 				MarkdownDescription: docstrings.Output(),
 				Computed:            true,
 			},
+
+			"sensitive_output_path_patterns": schema.ListAttribute{
+				MarkdownDescription: docstrings.SensitiveOutputPathPatterns(),
+				Optional:            true,
+				ElementType:         types.StringType,
+				Validators: []validator.List{
+					listvalidator.SizeAtLeast(1),
+					listvalidator.ValueStringsAre(myvalidator.StringIsValidRegex()),
+				},
+			},
+
+			"sensitive_output": schema.DynamicAttribute{
+				MarkdownDescription: docstrings.SensitiveOutput(),
+				Computed:            true,
+				Sensitive:           true,
+			},
 		},
 
 		Blocks: map[string]schema.Block{
@@ -158,8 +198,12 @@ func (r *MSGraphResourceAction) Configure(ctx context.Context, req resource.Conf
 }
 
 func (r *MSGraphResourceAction) ModifyPlan(ctx context.Context, request resource.ModifyPlanRequest, response *resource.ModifyPlanResponse) {
+	if planDefaultApiVersion(ctx, r.client, request, response); response.Diagnostics.HasError() {
+		return
+	}
+
 	var plan *MSGraphResourceActionModel
-	if response.Diagnostics.Append(request.Plan.Get(ctx, &plan)...); response.Diagnostics.HasError() {
+	if response.Diagnostics.Append(response.Plan.Get(ctx, &plan)...); response.Diagnostics.HasError() {
 		return
 	}
 
@@ -167,6 +211,16 @@ func (r *MSGraphResourceAction) ModifyPlan(ctx context.Context, request resource
 	if response.Diagnostics.Append(request.State.Get(ctx, &state)...); response.Diagnostics.HasError() {
 		return
 	}
+
+	// The API version is resolved from the provider when it isn't configured, so the replacement is decided here.
+	if plan != nil && state != nil && !plan.ApiVersion.Equal(state.ApiVersion) {
+		response.RequiresReplace.Append(path.Root("api_version"))
+	}
+
+	// The action is executed again on any update, so the ID extracted from the response may change.
+	if plan != nil && state != nil && (!plan.IdPath.IsNull() || !state.IdPath.IsNull()) && !response.Plan.Raw.Equal(request.State.Raw) {
+		response.Diagnostics.Append(response.Plan.SetAttribute(ctx, path.Root("id"), types.StringUnknown())...)
+	}
 }
 
 func (r *MSGraphResourceAction) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
@@ -179,19 +233,11 @@ func (r *MSGraphResourceAction) Create(ctx context.Context, req resource.CreateR
 	resp.Diagnostics.Append(diags...)
 	ctx, cancel := context.WithTimeout(ctx, createTimeout)
 	defer cancel()
+	ctx = clients.WithOperationMetrics(ctx)
+	defer clients.LogOperationMetrics(ctx, fmt.Sprintf("msgraph_resource_action create of %s", model.ResourceUrl.ValueString()))
 
-	// Construct the full URL from resource_url and action
-	fullUrl := model.ResourceUrl.ValueString()
-	if !model.Action.IsNull() && model.Action.ValueString() != "" {
-		fullUrl = fmt.Sprintf("%s/%s", fullUrl, model.Action.ValueString())
-	}
-
-	// Use the full URL as the ID for this action resource
-	model.Id = types.StringValue(fullUrl)
-
-	// Execute the action
-	if err := r.executeAction(ctx, model); err != nil {
-		resp.Diagnostics.AddError("Failed to execute action", err.Error())
+	// Execute the action, the ID is the full URL unless it's extracted from the response with id_path
+	if resp.Diagnostics.Append(r.executeAction(ctx, model)...); resp.Diagnostics.HasError() {
 		return
 	}
 
@@ -209,31 +255,34 @@ func (r *MSGraphResourceAction) Update(ctx context.Context, req resource.UpdateR
 	resp.Diagnostics.Append(diags...)
 	ctx, cancel := context.WithTimeout(ctx, createTimeout)
 	defer cancel()
+	ctx = clients.WithOperationMetrics(ctx)
+	defer clients.LogOperationMetrics(ctx, fmt.Sprintf("msgraph_resource_action update of %s", model.ResourceUrl.ValueString()))
 
 	// Re-execute the action
-	if err := r.executeAction(ctx, model); err != nil {
-		resp.Diagnostics.AddError("Failed to execute action", err.Error())
+	if resp.Diagnostics.Append(r.executeAction(ctx, model)...); resp.Diagnostics.HasError() {
 		return
 	}
 
 	resp.Diagnostics.Append(resp.State.Set(ctx, &model)...)
 }
 
-// executeAction is a helper function that performs the actual API call
-func (r *MSGraphResourceAction) executeAction(ctx context.Context, model *MSGraphResourceActionModel) error {
+// executeAction is a helper function that performs the actual API call. The action can't be undone once it's executed,
+// so if the ID can't be extracted with id_path, the full URL is used with a warning instead of failing without state.
+func (r *MSGraphResourceAction) executeAction(ctx context.Context, model *MSGraphResourceActionModel) (diags diag.Diagnostics) {
 	// Prepare request body
 	var requestBody interface{}
 	if !model.Body.IsNull() && !model.Body.IsUnknown() {
 		if err := unmarshalBody(model.Body, &requestBody); err != nil {
-			return fmt.Errorf("failed to unmarshal body: %w", err)
+			diags.AddError("Failed to execute action", fmt.Sprintf("failed to unmarshal body: %s", err))
+			return
 		}
 	}
 
 	// Prepare request options
 	options := clients.RequestOptions{
-		Headers:         AsMapOfString(model.Headers),
+		Headers:         clients.NewHeaders(AsMapOfString(model.Headers)),
 		QueryParameters: clients.NewQueryParameters(AsMapOfLists(model.QueryParameters)),
-		RetryOptions:    clients.NewRetryOptions(model.Retry),
+		RetryOptions:    clients.NewRetryOptions(model.Retry, model.Method.ValueString()),
 	}
 
 	// Construct the full URL from resource_url and action
@@ -248,13 +297,42 @@ func (r *MSGraphResourceAction) executeAction(ctx context.Context, model *MSGrap
 	// Execute the action
 	responseBody, err := r.client.Action(ctx, model.Method.ValueString(), fullUrl, model.ApiVersion.ValueString(), requestBody, options)
 	if err != nil {
-		return fmt.Errorf("API call failed: %w", err)
+		if !isAcceptableError(ctx, model.AcceptableErrorCodes, err) {
+			diags.AddError("Failed to execute action", utils.ResponseErrorDetail(fmt.Errorf("API call failed: %w", err)))
+			return
+		}
+		output, sensitiveOutput, err := buildOutputs(nil, nil, outputFormatTyped, AsListOfString(model.SensitiveOutputPatterns))
+		if err != nil {
+			diags.AddError("Failed to execute action", err.Error())
+			return
+		}
+		model.Output = types.DynamicValue(output)
+		model.SensitiveOutput = types.DynamicValue(sensitiveOutput)
+		model.Id = types.StringValue(fullUrl)
+		return
 	}
 
 	// Build output from response
-	model.Output = types.DynamicValue(buildOutputFromBody(responseBody, model.ResponseExportValues))
+	output, sensitiveOutput, err := buildOutputs(responseBody, model.ResponseExportValues, outputFormatTyped, AsListOfString(model.SensitiveOutputPatterns))
+	if err != nil {
+		diags.AddError("Failed to execute action", err.Error())
+		return
+	}
+	model.Output = types.DynamicValue(output)
+	model.SensitiveOutput = types.DynamicValue(sensitiveOutput)
+
+	model.Id = types.StringValue(fullUrl)
+	if idPath := model.IdPath.ValueString(); idPath != "" {
+		id, err := utils.ExtractStringJMES(responseBody, idPath)
+		if err != nil {
+			diags.AddAttributeWarning(path.Root("id_path"), "Failed to extract the ID",
+				fmt.Sprintf("The action was executed, but the ID couldn't be extracted from the response with `id_path`, the URL %s is used instead: %s", fullUrl, err))
+			return
+		}
+		model.Id = types.StringValue(id)
+	}
 
-	return nil
+	return
 }
 
 func (r *MSGraphResourceAction) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
@@ -4,22 +4,28 @@ import (
 	"context"
 	"fmt"
 	"net/http"
-	"time"
+	"strings"
 
 	"github.com/hashicorp/terraform-plugin-framework-timeouts/resource/timeouts"
 	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
 	"github.com/hashicorp/terraform-plugin-framework/datasource"
 	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/path"
 	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
 	"github.com/hashicorp/terraform-plugin-framework/types"
 	"github.com/hashicorp/terraform-plugin-log/tflog"
 	"github.com/microsoft/terraform-provider-msgraph/internal/clients"
 	"github.com/microsoft/terraform-provider-msgraph/internal/docstrings"
+	"github.com/microsoft/terraform-provider-msgraph/internal/myvalidator"
 	"github.com/microsoft/terraform-provider-msgraph/internal/retry"
+	"github.com/microsoft/terraform-provider-msgraph/internal/utils"
 )
 
 // Ensure provider defined types fully satisfy framework interfaces.
-var _ datasource.DataSource = &MSGraphResourceActionDataSource{}
+var (
+	_ datasource.DataSource                   = &MSGraphResourceActionDataSource{}
+	_ datasource.DataSourceWithValidateConfig = &MSGraphResourceActionDataSource{}
+)
 
 func NewMSGraphResourceActionDataSource() datasource.DataSource {
 	return &MSGraphResourceActionDataSource{}
@@ -38,6 +44,7 @@ type MSGraphResourceActionDataSourceModel struct {
 	Action               types.String      `tfsdk:"action"`
 	Method               types.String      `tfsdk:"method"`
 	Body                 types.Dynamic     `tfsdk:"body"`
+	FunctionParameters   types.Dynamic     `tfsdk:"function_parameters"`
 	QueryParameters      types.Map         `tfsdk:"query_parameters"`
 	Headers              types.Map         `tfsdk:"headers"`
 	ResponseExportValues map[string]string `tfsdk:"response_export_values"`
@@ -53,7 +60,7 @@ func (r *MSGraphResourceActionDataSource) Metadata(ctx context.Context, req data
 func (r *MSGraphResourceActionDataSource) Schema(ctx context.Context, req datasource.SchemaRequest, resp *datasource.SchemaResponse) {
 	resp.Schema = schema.Schema{
 		// This description is used by the documentation generator and the language server.
-		MarkdownDescription: "This data source can perform any Microsoft Graph API action and return the result. Use this for read-only operations like retrieving calculated values, checking status, or performing queries.",
+		MarkdownDescription: "This data source can perform any Microsoft Graph API action or function and return the result. Use this for read-only operations like retrieving calculated values, checking status, or performing queries. When the response is a paged collection, the `@odata.nextLink` of the pages is followed and the items of all pages are aggregated into `value`.",
 
 		Attributes: map[string]schema.Attribute{
 			"id": schema.StringAttribute{
@@ -64,15 +71,18 @@ func (r *MSGraphResourceActionDataSource) Schema(ctx context.Context, req dataso
 			"resource_url": schema.StringAttribute{
 				MarkdownDescription: "The URL of the resource to perform the action on. This should be the full resource path, for example `applications/12345678-1234-1234-1234-123456789abc` or `users/user@example.com`. You can use the `resource_url` output from `msgraph_resource`.",
 				Required:            true,
+				Validators: []validator.String{
+					myvalidator.RelativeURL(),
+				},
 			},
 
 			"action": schema.StringAttribute{
-				MarkdownDescription: "The action to perform on the resource. This is the action path that will be appended to the resource URL, for example `getMemberGroups`, `checkMemberGroups`, `calculateDisplayNames`, or `members`. Leave empty for actions directly on the resource.",
+				MarkdownDescription: "The action to perform on the resource. This is the action path that will be appended to the resource URL, for example `getMemberGroups`, `checkMemberGroups`, `calculateDisplayNames`, or `members`. The parameters of functions called with `GET` are passed in the URL path, for example `reminderView(StartDateTime='2024-01-01T00:00:00',EndDateTime='2024-01-07T00:00:00')`, while the parameters of actions called with `POST` are passed in `body`. Leave empty for actions directly on the resource.",
 				Optional:            true,
 			},
 
 			"method": schema.StringAttribute{
-				MarkdownDescription: "The HTTP method to use for the action. For data sources, this is typically `GET` or `POST` for actions that require a request body.",
+				MarkdownDescription: "The HTTP method to use for the action. The allowed values are `POST`, for actions like `getMemberGroups` which take their parameters in `body`, and `GET`, for functions like `delta` or `reminderView(...)` which take their parameters in the URL path. Defaults to `POST`.",
 				Optional:            true,
 				Validators: []validator.String{
 					stringvalidator.OneOf(http.MethodGet, http.MethodPost),
@@ -83,7 +93,7 @@ func (r *MSGraphResourceActionDataSource) Schema(ctx context.Context, req dataso
 				MarkdownDescription: docstrings.ApiVersion(),
 				Optional:            true,
 				Validators: []validator.String{
-					stringvalidator.OneOf("v1.0", "beta"),
+					myvalidator.ApiVersion(),
 				},
 			},
 
@@ -92,6 +102,11 @@ func (r *MSGraphResourceActionDataSource) Schema(ctx context.Context, req dataso
 				Optional:            true,
 			},
 
+			"function_parameters": schema.DynamicAttribute{
+				MarkdownDescription: "An object of the parameters of the function called with `GET`, which are inlined in the URL path after `action`, e.g. `{ StartDateTime = \"2024-01-01T00:00:00\", EndDateTime = \"2024-01-07T00:00:00\" }` with `action = \"reminderView\"` calls `reminderView(EndDateTime='2024-01-07T00:00:00',StartDateTime='2024-01-01T00:00:00')`. The strings are quoted and escaped, the numbers, booleans and nulls are written as is. It can only be specified when `method` is `GET` and `action` is the name of the function without parameters.",
+				Optional:            true,
+			},
+
 			"query_parameters": schema.MapAttribute{
 				ElementType: types.ListType{
 					ElemType: types.StringType,
@@ -134,13 +149,33 @@ func (r *MSGraphResourceActionDataSource) Configure(ctx context.Context, req dat
 	}
 }
 
+func (r *MSGraphResourceActionDataSource) ValidateConfig(ctx context.Context, req datasource.ValidateConfigRequest, resp *datasource.ValidateConfigResponse) {
+	var model *MSGraphResourceActionDataSourceModel
+	if resp.Diagnostics.Append(req.Config.Get(ctx, &model)...); resp.Diagnostics.HasError() {
+		return
+	}
+
+	if model.Method.ValueString() == http.MethodGet && !model.Body.IsNull() {
+		resp.Diagnostics.AddAttributeError(path.Root("body"), "Invalid configuration", "`body` can't be specified when `method` is `GET`. The parameters of functions called with `GET` must be passed in the URL path, e.g. `action = \"reminderView(StartDateTime='2024-01-01T00:00:00',EndDateTime='2024-01-07T00:00:00')\"`.")
+	}
+
+	if !model.FunctionParameters.IsNull() {
+		if model.Method.ValueString() != http.MethodGet {
+			resp.Diagnostics.AddAttributeError(path.Root("function_parameters"), "Invalid configuration", "`function_parameters` can only be specified when `method` is `GET`. The parameters of actions called with `POST` must be passed in `body`.")
+		}
+		if !model.Action.IsUnknown() && (model.Action.ValueString() == "" || strings.Contains(model.Action.ValueString(), "(")) {
+			resp.Diagnostics.AddAttributeError(path.Root("function_parameters"), "Invalid configuration", "`function_parameters` requires `action` to be the name of the function without parameters, e.g. `action = \"reminderView\"`.")
+		}
+	}
+}
+
 func (r *MSGraphResourceActionDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
 	var model *MSGraphResourceActionDataSourceModel
 	if resp.Diagnostics.Append(req.Config.Get(ctx, &model)...); resp.Diagnostics.HasError() {
 		return
 	}
 
-	readTimeout, diags := model.Timeouts.Read(ctx, 5*time.Minute)
+	readTimeout, diags := model.Timeouts.Read(ctx, r.client.DefaultReadTimeout())
 	resp.Diagnostics.Append(diags...)
 	ctx, cancel := context.WithTimeout(ctx, readTimeout)
 	defer cancel()
@@ -154,29 +189,42 @@ func (r *MSGraphResourceActionDataSource) Read(ctx context.Context, req datasour
 		}
 	}
 
-	// Prepare request options
-	options := clients.RequestOptions{
-		Headers:         AsMapOfString(model.Headers),
-		QueryParameters: clients.NewQueryParameters(AsMapOfLists(model.QueryParameters)),
-		RetryOptions:    clients.NewRetryOptions(model.Retry),
-	}
-
 	// Construct the full URL from resource_url and action
+	action := model.Action.ValueString()
+	if !model.FunctionParameters.IsNull() {
+		var parameters map[string]interface{}
+		if err := unmarshalBody(model.FunctionParameters, &parameters); err != nil {
+			resp.Diagnostics.AddError("Invalid function_parameters", fmt.Sprintf("`function_parameters` must be an object: %s", err.Error()))
+			return
+		}
+		var err error
+		if action, err = utils.FunctionCall(action, parameters); err != nil {
+			resp.Diagnostics.AddError("Invalid function_parameters", err.Error())
+			return
+		}
+	}
 	fullUrl := model.ResourceUrl.ValueString()
-	if !model.Action.IsNull() && model.Action.ValueString() != "" {
-		fullUrl = fmt.Sprintf("%s/%s", fullUrl, model.Action.ValueString())
+	if action != "" {
+		fullUrl = fmt.Sprintf("%s/%s", fullUrl, action)
 	}
 
-	// Default to GET method if not specified
+	// Default to POST method if not specified
 	method := model.Method.ValueString()
 	if method == "" {
-		method = http.MethodGet
+		method = http.MethodPost
 	}
 
-	// Default to v1.0 API version if not specified
+	// Prepare request options
+	options := clients.RequestOptions{
+		Headers:         clients.NewHeaders(AsMapOfString(model.Headers)),
+		QueryParameters: clients.NewQueryParameters(AsMapOfLists(model.QueryParameters)),
+		RetryOptions:    clients.NewRetryOptions(model.Retry, method),
+	}
+
+	// Default to the API version of the provider if not specified
 	apiVersion := model.ApiVersion.ValueString()
 	if apiVersion == "" {
-		apiVersion = "v1.0"
+		apiVersion = r.client.DefaultApiVersion()
 	}
 
 	// Log the action
@@ -185,7 +233,14 @@ func (r *MSGraphResourceActionDataSource) Read(ctx context.Context, req datasour
 	// Execute the action
 	responseBody, err := r.client.Action(ctx, method, fullUrl, apiVersion, requestBody, options)
 	if err != nil {
-		resp.Diagnostics.AddError("API call failed", err.Error())
+		resp.Diagnostics.AddError("API call failed", utils.ResponseErrorDetail(err))
+		return
+	}
+
+	// Functions and actions returning collections are paged, the next pages are always read with GET
+	responseBody, err = r.client.ListFromPage(ctx, responseBody, options)
+	if err != nil {
+		resp.Diagnostics.AddError("Failed to read the next pages", err.Error())
 		return
 	}
 
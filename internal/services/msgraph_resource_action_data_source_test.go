@@ -2,11 +2,13 @@ package services_test
 
 import (
 	"context"
+	"regexp"
 	"testing"
 
 	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
 	"github.com/hashicorp/terraform-plugin-testing/terraform"
 	"github.com/microsoft/terraform-provider-msgraph/internal/acceptance"
+	"github.com/microsoft/terraform-provider-msgraph/internal/acceptance/check"
 	"github.com/microsoft/terraform-provider-msgraph/internal/clients"
 )
 
@@ -43,6 +45,24 @@ func TestAcc_DataSourceResourceActionWithQueryParams(t *testing.T) {
 	})
 }
 
+func TestAcc_DataSourceResourceActionWithSelectQuery(t *testing.T) {
+	data := acceptance.BuildTestData(t, "msgraph_resource_action", "test")
+
+	r := MSGraphResourceActionDataSourceTestResource{}
+
+	data.ResourceTest(t, r, []resource.TestStep{
+		{
+			Config: r.withSelectQuery(),
+			Check: resource.ComposeTestCheckFunc(
+				// mailNickname isn't selected, so its key is exported with a null value
+				resource.TestCheckResourceAttr("data.msgraph_resource_action.test", "output.%", "3"),
+				resource.TestCheckResourceAttr("data.msgraph_resource_action.test", "output.display_name", "Test Group"),
+				resource.TestCheckNoResourceAttr("data.msgraph_resource_action.test", "output.mail_nickname"),
+			),
+		},
+	})
+}
+
 func TestAcc_DataSourceResourceActionWithHeaders(t *testing.T) {
 	data := acceptance.BuildTestData(t, "msgraph_resource_action", "test")
 
@@ -69,6 +89,80 @@ func TestAcc_DataSourceResourceActionWithBody(t *testing.T) {
 	})
 }
 
+func TestAcc_DataSourceResourceActionPaging(t *testing.T) {
+	data := acceptance.BuildTestData(t, "data.msgraph_resource_action", "test")
+
+	r := MSGraphResourceActionDataSourceTestResource{}
+
+	data.DataSourceTest(t, []resource.TestStep{
+		{
+			Config: r.paging(),
+			Check: resource.ComposeTestCheckFunc(
+				check.That(data.ResourceName).Key("output.member_ids.#").HasValue("2"),
+			),
+		},
+	})
+}
+
+func TestAcc_DataSourceResourceActionFunctionWithPathParameters(t *testing.T) {
+	data := acceptance.BuildTestData(t, "data.msgraph_resource_action", "test")
+
+	r := MSGraphResourceActionDataSourceTestResource{}
+
+	data.DataSourceTest(t, []resource.TestStep{
+		{
+			Config: r.functionWithPathParameters(),
+			Check: resource.ComposeTestCheckFunc(
+				check.That(data.ResourceName).Key("output.app_id").HasValue("00000003-0000-0000-c000-000000000000"),
+			),
+		},
+	})
+}
+
+func TestAcc_DataSourceResourceActionGetWithBody(t *testing.T) {
+	data := acceptance.BuildTestData(t, "data.msgraph_resource_action", "test")
+
+	r := MSGraphResourceActionDataSourceTestResource{}
+
+	data.DataSourceTest(t, []resource.TestStep{
+		{
+			Config:      r.getWithBody(),
+			ExpectError: regexp.MustCompile("`body` can't be specified when `method` is `GET`"),
+		},
+	})
+}
+
+func TestAcc_DataSourceResourceActionFunctionParameters(t *testing.T) {
+	acceptance.PreCheckDelegated(t)
+
+	data := acceptance.BuildTestData(t, "data.msgraph_resource_action", "test")
+
+	r := MSGraphResourceActionDataSourceTestResource{}
+
+	data.DataSourceTest(t, []resource.TestStep{
+		{
+			Config: r.functionParameters(),
+			Check: resource.ComposeTestCheckFunc(
+				check.That(data.ResourceName).Key("id").HasValue("me/reminderView(EndDateTime='2024-01-07T00:00:00',StartDateTime='2024-01-01T00:00:00')"),
+				check.That(data.ResourceName).Key("output.reminders.#").Exists(),
+			),
+		},
+	})
+}
+
+func TestAcc_DataSourceResourceActionFunctionParametersInvalid(t *testing.T) {
+	data := acceptance.BuildTestData(t, "data.msgraph_resource_action", "test")
+
+	r := MSGraphResourceActionDataSourceTestResource{}
+
+	data.DataSourceTest(t, []resource.TestStep{
+		{
+			Config:      r.functionParametersWithPost(),
+			ExpectError: regexp.MustCompile("`function_parameters` can only be specified when `method` is `GET`"),
+		},
+	})
+}
+
 func (r MSGraphResourceActionDataSourceTestResource) basic() string {
 	return `
 provider "msgraph" {}
@@ -126,6 +220,37 @@ data "msgraph_resource_action" "test" {
 `
 }
 
+func (r MSGraphResourceActionDataSourceTestResource) withSelectQuery() string {
+	return `
+provider "msgraph" {}
+
+resource "msgraph_resource" "group" {
+  url = "groups"
+  body = {
+    displayName     = "Test Group"
+    mailEnabled     = false
+    mailNickname    = "mygroup"
+    securityEnabled = true
+  }
+}
+
+data "msgraph_resource_action" "test" {
+  resource_url = msgraph_resource.group.resource_url
+  method       = "GET"
+
+  query_parameters = {
+    "$select" = ["id", "displayName"]
+  }
+
+  response_export_values = {
+    id            = "id"
+    display_name  = "displayName"
+    mail_nickname = "mailNickname"
+  }
+}
+`
+}
+
 func (r MSGraphResourceActionDataSourceTestResource) withHeaders() string {
 	return `
 provider "msgraph" {}
@@ -189,3 +314,117 @@ data "msgraph_resource_action" "test" {
 }
 `
 }
+
+func (r MSGraphResourceActionDataSourceTestResource) paging() string {
+	return `
+provider "msgraph" {}
+
+resource "msgraph_resource" "group" {
+  url = "groups"
+  body = {
+    displayName     = "Test Group"
+    mailEnabled     = false
+    mailNickname    = "mygroup"
+    securityEnabled = true
+    "members@odata.bind" = [
+      "https://graph.microsoft.com/v1.0/directoryObjects/${msgraph_resource.member[0].id}",
+      "https://graph.microsoft.com/v1.0/directoryObjects/${msgraph_resource.member[1].id}",
+    ]
+  }
+}
+
+resource "msgraph_resource" "member" {
+  count = 2
+  url   = "groups"
+  body = {
+    displayName     = "Test Member Group ${count.index}"
+    mailEnabled     = false
+    mailNickname    = "mymembergroup${count.index}"
+    securityEnabled = true
+  }
+}
+
+data "msgraph_resource_action" "test" {
+  resource_url = msgraph_resource.group.resource_url
+  action       = "members"
+  method       = "GET"
+
+  // one member per page, so the next page has to be read
+  query_parameters = {
+    "$top" = ["1"]
+  }
+
+  response_export_values = {
+    member_ids = "value[].id"
+  }
+}
+`
+}
+
+func (r MSGraphResourceActionDataSourceTestResource) functionWithPathParameters() string {
+	return `
+provider "msgraph" {}
+
+data "msgraph_resource_action" "test" {
+  resource_url = "servicePrincipals(appId='00000003-0000-0000-c000-000000000000')"
+  method       = "GET"
+
+  response_export_values = {
+    app_id = "appId"
+  }
+}
+`
+}
+
+func (r MSGraphResourceActionDataSourceTestResource) getWithBody() string {
+	return `
+provider "msgraph" {}
+
+data "msgraph_resource_action" "test" {
+  resource_url = "me"
+  action       = "getMemberGroups"
+  method       = "GET"
+
+  body = {
+    securityEnabledOnly = false
+  }
+}
+`
+}
+
+func (r MSGraphResourceActionDataSourceTestResource) functionParameters() string {
+	return `
+provider "msgraph" {}
+
+data "msgraph_resource_action" "test" {
+  resource_url = "me"
+  action       = "reminderView"
+  method       = "GET"
+
+  function_parameters = {
+    StartDateTime = "2024-01-01T00:00:00"
+    EndDateTime   = "2024-01-07T00:00:00"
+  }
+
+  response_export_values = {
+    reminders = "value"
+  }
+}
+`
+}
+
+func (r MSGraphResourceActionDataSourceTestResource) functionParametersWithPost() string {
+	return `
+provider "msgraph" {}
+
+data "msgraph_resource_action" "test" {
+  resource_url = "me"
+  action       = "getMemberGroups"
+  method       = "POST"
+
+  function_parameters = {
+    securityEnabledOnly = false
+  }
+}
+`
+}
@@ -2,10 +2,14 @@ package services_test
 
 import (
 	"context"
+	"fmt"
+	"regexp"
 	"testing"
 
 	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+	"github.com/hashicorp/terraform-plugin-testing/plancheck"
 	"github.com/hashicorp/terraform-plugin-testing/terraform"
+	"github.com/hashicorp/terraform-plugin-testing/tfjsonpath"
 	"github.com/microsoft/terraform-provider-msgraph/internal/acceptance"
 	"github.com/microsoft/terraform-provider-msgraph/internal/acceptance/check"
 	"github.com/microsoft/terraform-provider-msgraph/internal/clients"
@@ -39,6 +43,24 @@ func TestAcc_ResourceActionWithQueryParams(t *testing.T) {
 	})
 }
 
+func TestAcc_ResourceActionWithSelectQuery(t *testing.T) {
+	data := acceptance.BuildTestData(t, "msgraph_resource_action", "test")
+
+	r := MSGraphResourceActionTestResource{}
+
+	data.ResourceTest(t, r, []resource.TestStep{
+		{
+			Config: r.withSelectQuery(),
+			Check: resource.ComposeTestCheckFunc(
+				// mailNickname isn't selected, so its key is exported with a null value
+				resource.TestCheckResourceAttr("msgraph_resource_action.test", "output.%", "3"),
+				resource.TestCheckResourceAttr("msgraph_resource_action.test", "output.display_name", "Test Group"),
+				resource.TestCheckNoResourceAttr("msgraph_resource_action.test", "output.mail_nickname"),
+			),
+		},
+	})
+}
+
 func TestAcc_ResourceActionWithHeaders(t *testing.T) {
 	data := acceptance.BuildTestData(t, "msgraph_resource_action", "test")
 
@@ -67,6 +89,162 @@ func TestAcc_ResourceActionWithExportValues(t *testing.T) {
 	})
 }
 
+func TestAcc_ResourceActionWithIdPath(t *testing.T) {
+	data := acceptance.BuildTestData(t, "msgraph_resource_action", "test")
+
+	r := MSGraphResourceActionTestResource{}
+
+	data.ResourceTest(t, r, []resource.TestStep{
+		{
+			Config: r.withIdPath(),
+			Check: resource.ComposeTestCheckFunc(
+				check.That(data.ResourceName).Key("id").IsUUID(),
+			),
+		},
+	})
+}
+
+func TestAcc_ResourceActionWithIdPathUpdate(t *testing.T) {
+	data := acceptance.BuildTestData(t, "msgraph_resource_action", "test")
+
+	r := MSGraphResourceActionTestResource{}
+
+	var keyId string
+	data.ResourceTest(t, r, []resource.TestStep{
+		{
+			Config: r.withIdPath(),
+			Check: resource.ComposeTestCheckFunc(
+				check.That(data.ResourceName).Key("id").IsUUID(),
+				resource.TestCheckResourceAttrWith(data.ResourceName, "id", func(value string) error {
+					keyId = value
+					return nil
+				}),
+			),
+		},
+		{
+			// Any change executes the action again, so the ID is extracted from the new response.
+			Config: r.withIdPathAndExportValues(),
+			Check: resource.ComposeTestCheckFunc(
+				check.That(data.ResourceName).Key("id").IsUUID(),
+				resource.TestCheckResourceAttrWith(data.ResourceName, "id", func(value string) error {
+					if value == keyId {
+						return fmt.Errorf("expected the ID of the new execution, got the previous one %s", value)
+					}
+					return nil
+				}),
+			),
+		},
+	})
+}
+
+func TestAcc_ResourceActionWithIdPathNotFound(t *testing.T) {
+	data := acceptance.BuildTestData(t, "msgraph_resource_action", "test")
+
+	r := MSGraphResourceActionTestResource{}
+
+	data.ResourceTest(t, r, []resource.TestStep{
+		{
+			// The action was executed, so the state is saved with the URL as the ID.
+			Config: r.withIdPathNotFound(),
+			Check: resource.ComposeTestCheckFunc(
+				resource.TestMatchResourceAttr(data.ResourceName, "id", regexp.MustCompile(`/addPassword$`)),
+			),
+		},
+	})
+}
+
+func TestAcc_ResourceActionTriggers(t *testing.T) {
+	data := acceptance.BuildTestData(t, "msgraph_resource_action", "test")
+
+	r := MSGraphResourceActionTestResource{}
+
+	var keyId string
+	data.ResourceTest(t, r, []resource.TestStep{
+		{
+			Config: r.withTriggers("1"),
+			Check: resource.ComposeTestCheckFunc(
+				check.That(data.ResourceName).Key("id").IsUUID(),
+				check.That(data.ResourceName).Key("output.key_id").IsUUID(),
+				resource.TestCheckResourceAttrWith(data.ResourceName, "id", func(value string) error {
+					keyId = value
+					return nil
+				}),
+			),
+		},
+		{
+			// The action is executed again in place when the triggers change, which adds another password.
+			Config: r.withTriggers("2"),
+			ConfigPlanChecks: resource.ConfigPlanChecks{
+				PreApply: []plancheck.PlanCheck{
+					plancheck.ExpectResourceAction(data.ResourceName, plancheck.ResourceActionUpdate),
+				},
+			},
+			Check: resource.ComposeTestCheckFunc(
+				check.That(data.ResourceName).Key("id").IsUUID(),
+				resource.TestCheckResourceAttrWith(data.ResourceName, "id", func(value string) error {
+					if value == keyId {
+						return fmt.Errorf("expected the action to be executed again with a new key ID, got the previous one %s", value)
+					}
+					keyId = value
+					return nil
+				}),
+			),
+		},
+		{
+			// The triggers change together with the body.
+			Config: r.withTriggersAndBody("3", "Rotated"),
+			ConfigPlanChecks: resource.ConfigPlanChecks{
+				PreApply: []plancheck.PlanCheck{
+					plancheck.ExpectResourceAction(data.ResourceName, plancheck.ResourceActionUpdate),
+					plancheck.ExpectUnknownValue(data.ResourceName, tfjsonpath.New("id")),
+				},
+			},
+			Check: resource.ComposeTestCheckFunc(
+				check.That(data.ResourceName).Key("id").IsUUID(),
+				resource.TestCheckResourceAttrWith(data.ResourceName, "id", func(value string) error {
+					if value == keyId {
+						return fmt.Errorf("expected the action to be executed again with a new key ID, got the previous one %s", value)
+					}
+					return nil
+				}),
+			),
+		},
+	})
+}
+
+func TestAcc_ResourceActionWithSensitiveOutputPathPatterns(t *testing.T) {
+	data := acceptance.BuildTestData(t, "msgraph_resource_action", "test")
+
+	r := MSGraphResourceActionTestResource{}
+
+	data.ResourceTest(t, r, []resource.TestStep{
+		{
+			Config: r.withSensitiveOutputPathPatterns(),
+			Check: resource.ComposeTestCheckFunc(
+				check.That(data.ResourceName).Key("output.credential.keyId").IsUUID(),
+				check.That(data.ResourceName).Key("output.credential.secretText").DoesNotExist(),
+				check.That(data.ResourceName).Key("sensitive_output.credential.secretText").Exists(),
+			),
+		},
+	})
+}
+
+func TestAcc_ResourceActionWithAcceptableErrorCodes(t *testing.T) {
+	data := acceptance.BuildTestData(t, "msgraph_resource_action", "test")
+
+	r := MSGraphResourceActionTestResource{}
+
+	data.ResourceTest(t, r, []resource.TestStep{
+		{
+			Config: r.withAcceptableErrorCodes(),
+			Check: resource.ComposeTestCheckFunc(
+				check.That(data.ResourceName).Key("id").Exists(),
+				check.That(data.ResourceName).Key("acceptable_error_codes.#").HasValue("1"),
+			),
+		},
+	})
+}
+
 func (r MSGraphResourceActionTestResource) Exists(ctx context.Context, clients *clients.Client, state *terraform.InstanceState) (*bool, error) {
 	exists := false
 	return &exists, nil
@@ -134,6 +312,37 @@ resource "msgraph_resource_action" "test" {
 `
 }
 
+func (r MSGraphResourceActionTestResource) withSelectQuery() string {
+	return `
+provider "msgraph" {}
+
+resource "msgraph_resource" "group" {
+  url = "groups"
+  body = {
+    displayName     = "Test Group"
+    mailEnabled     = false
+    mailNickname    = "mygroup"
+    securityEnabled = true
+  }
+}
+
+resource "msgraph_resource_action" "test" {
+  resource_url = msgraph_resource.group.resource_url
+  method       = "GET"
+
+  query_parameters = {
+    "$select" = ["id", "displayName"]
+  }
+
+  response_export_values = {
+    id            = "id"
+    display_name  = "displayName"
+    mail_nickname = "mailNickname"
+  }
+}
+`
+}
+
 func (r MSGraphResourceActionTestResource) withHeaders() string {
 	return `
 provider "msgraph" {}
@@ -201,3 +410,239 @@ resource "msgraph_resource_action" "test" {
 }
 `
 }
+
+func (r MSGraphResourceActionTestResource) withIdPath() string {
+	return `
+provider "msgraph" {}
+
+resource "msgraph_resource" "application" {
+  url = "applications"
+  body = {
+    displayName = "Test App With Password"
+  }
+}
+
+resource "msgraph_resource_action" "test" {
+  resource_url = msgraph_resource.application.resource_url
+  action       = "addPassword"
+  method       = "POST"
+  id_path      = "keyId"
+
+  body = {
+    passwordCredential = {
+      displayName = "Terraform"
+    }
+  }
+}
+`
+}
+
+func (r MSGraphResourceActionTestResource) withIdPathAndExportValues() string {
+	return `
+provider "msgraph" {}
+
+resource "msgraph_resource" "application" {
+  url = "applications"
+  body = {
+    displayName = "Test App With Password"
+  }
+}
+
+resource "msgraph_resource_action" "test" {
+  resource_url = msgraph_resource.application.resource_url
+  action       = "addPassword"
+  method       = "POST"
+  id_path      = "keyId"
+
+  body = {
+    passwordCredential = {
+      displayName = "Terraform"
+    }
+  }
+
+  response_export_values = {
+    key_id = "keyId"
+  }
+}
+`
+}
+
+func (r MSGraphResourceActionTestResource) withIdPathNotFound() string {
+	return `
+provider "msgraph" {}
+
+resource "msgraph_resource" "application" {
+  url = "applications"
+  body = {
+    displayName = "Test App With Password"
+  }
+}
+
+resource "msgraph_resource_action" "test" {
+  resource_url = msgraph_resource.application.resource_url
+  action       = "addPassword"
+  method       = "POST"
+  id_path      = "unknownProperty"
+
+  body = {
+    passwordCredential = {
+      displayName = "Terraform"
+    }
+  }
+}
+`
+}
+
+func (r MSGraphResourceActionTestResource) withTriggers(rotation string) string {
+	return fmt.Sprintf(`
+provider "msgraph" {}
+
+resource "msgraph_resource" "application" {
+  url = "applications"
+  body = {
+    displayName = "Test App With Triggers"
+  }
+}
+
+resource "msgraph_resource_action" "test" {
+  resource_url = msgraph_resource.application.resource_url
+  action       = "addPassword"
+  method       = "POST"
+  id_path      = "keyId"
+
+  body = {
+    passwordCredential = {
+      displayName = "Terraform"
+    }
+  }
+
+  response_export_values = {
+    key_id = "keyId"
+  }
+
+  triggers = {
+    rotation = "%s"
+  }
+}
+`, rotation)
+}
+
+func (r MSGraphResourceActionTestResource) withTriggersAndBody(rotation, displayName string) string {
+	return fmt.Sprintf(`
+provider "msgraph" {}
+
+resource "msgraph_resource" "application" {
+  url = "applications"
+  body = {
+    displayName = "Test App With Triggers"
+  }
+}
+
+resource "msgraph_resource_action" "test" {
+  resource_url = msgraph_resource.application.resource_url
+  action       = "addPassword"
+  method       = "POST"
+  id_path      = "keyId"
+
+  body = {
+    passwordCredential = {
+      displayName = "%s"
+    }
+  }
+
+  response_export_values = {
+    key_id = "keyId"
+  }
+
+  triggers = {
+    rotation = "%s"
+  }
+}
+`, displayName, rotation)
+}
+
+func (r MSGraphResourceActionTestResource) withSensitiveOutputPathPatterns() string {
+	return `
+provider "msgraph" {}
+
+resource "msgraph_resource" "application" {
+  url = "applications"
+  body = {
+    displayName = "Test App With Password"
+  }
+}
+
+resource "msgraph_resource_action" "test" {
+  resource_url = msgraph_resource.application.resource_url
+  action       = "addPassword"
+  method       = "POST"
+
+  body = {
+    passwordCredential = {
+      displayName = "Terraform"
+    }
+  }
+
+  response_export_values = {
+    credential = "@"
+  }
+  sensitive_output_path_patterns = ["(?i)(secretText|password|token)$"]
+}
+`
+}
+
+func (r MSGraphResourceActionTestResource) withAcceptableErrorCodes() string {
+	return `
+provider "msgraph" {}
+
+resource "msgraph_resource" "group" {
+  url = "groups"
+  body = {
+    displayName     = "Test Group"
+    mailEnabled     = false
+    mailNickname    = "mygroup"
+    securityEnabled = true
+  }
+}
+
+resource "msgraph_resource" "member" {
+  url = "groups"
+  body = {
+    displayName     = "Test Member Group"
+    mailEnabled     = false
+    mailNickname    = "mymembergroup"
+    securityEnabled = true
+  }
+}
+
+resource "msgraph_resource_action" "add_member" {
+  resource_url = msgraph_resource.group.resource_url
+  action       = "members/$ref"
+  method       = "POST"
+
+  body = {
+    "@odata.id" = "https://graph.microsoft.com/v1.0/directoryObjects/${msgraph_resource.member.id}"
+  }
+}
+
+# adding the same member again fails with 400, as the reference already exists
+resource "msgraph_resource_action" "test" {
+  resource_url = msgraph_resource.group.resource_url
+  action       = "members/$ref"
+  method       = "POST"
+
+  body = {
+    "@odata.id" = "https://graph.microsoft.com/v1.0/directoryObjects/${msgraph_resource.member.id}"
+  }
+
+  acceptable_error_codes = [
+    {
+      status_code = 400
+      error_code  = "Request_BadRequest"
+    },
+  ]
+
+  depends_on = [msgraph_resource_action.add_member]
+}
+`
+}
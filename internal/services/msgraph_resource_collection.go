@@ -4,16 +4,17 @@ import (
 	"context"
 	"encoding/json"
 	"fmt"
+	"net/http"
 	"reflect"
+	"regexp"
+	"slices"
 	"strings"
 	"time"
 
 	"github.com/hashicorp/terraform-plugin-framework-timeouts/resource/timeouts"
-	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
 	"github.com/hashicorp/terraform-plugin-framework/resource"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
-	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringdefault"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
 	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
 	"github.com/hashicorp/terraform-plugin-framework/types"
@@ -56,7 +57,7 @@ func (r *MSGraphResourceCollection) Metadata(ctx context.Context, req resource.M
 
 func (r *MSGraphResourceCollection) Schema(ctx context.Context, req resource.SchemaRequest, resp *resource.SchemaResponse) {
 	resp.Schema = schema.Schema{
-		MarkdownDescription: "Manage the full contents of a child reference collection (such as group members or owners) for an existing Microsoft Graph resource. Missing items are added; extra remote items are removed.",
+		MarkdownDescription: "Manage the full contents of a child reference collection (such as group members or owners) for an existing Microsoft Graph resource. Missing items are added; extra remote items are removed. The extra items which already exist when the resource is created are not removed, a warning is returned and they're detected as drift by the next refresh. Items added or removed outside of Terraform are detected as drift. The members of groups are added in batches of up to 20 items per request.",
 		Attributes: map[string]schema.Attribute{
 			"id": schema.StringAttribute{
 				MarkdownDescription: "Identifier of this managed collection. This is the normalized collection URL with the trailing '/$ref' removed (e.g. for 'groups/{group-id}/members/$ref' the id becomes 'groups/{group-id}/members').",
@@ -81,8 +82,7 @@ func (r *MSGraphResourceCollection) Schema(ctx context.Context, req resource.Sch
 				MarkdownDescription: docstrings.ApiVersion(),
 				Optional:            true,
 				Computed:            true,
-				Validators:          []validator.String{stringvalidator.OneOf("v1.0", "beta")},
-				Default:             stringdefault.StaticString("v1.0"),
+				Validators:          []validator.String{myvalidator.ApiVersion()},
 			},
 
 			"reference_ids": schema.ListAttribute{
@@ -124,8 +124,12 @@ func (r *MSGraphResourceCollection) Configure(ctx context.Context, req resource.
 }
 
 func (r *MSGraphResourceCollection) ModifyPlan(ctx context.Context, request resource.ModifyPlanRequest, response *resource.ModifyPlanResponse) {
+	if planDefaultApiVersion(ctx, r.client, request, response); response.Diagnostics.HasError() {
+		return
+	}
+
 	var plan, state *MSGraphResourceCollectionModel
-	if response.Diagnostics.Append(request.Plan.Get(ctx, &plan)...); response.Diagnostics.HasError() {
+	if response.Diagnostics.Append(response.Plan.Get(ctx, &plan)...); response.Diagnostics.HasError() {
 		return
 	}
 	if response.Diagnostics.Append(request.State.Get(ctx, &state)...); response.Diagnostics.HasError() {
@@ -153,9 +157,30 @@ func (r *MSGraphResourceCollection) Create(ctx context.Context, req resource.Cre
 	resp.Diagnostics.Append(diags...)
 	ctx, cancel := context.WithTimeout(ctx, timeout)
 	defer cancel()
+	ctx = clients.WithOperationMetrics(ctx)
+	defer clients.LogOperationMetrics(ctx, fmt.Sprintf("msgraph_resource_collection create of %s", model.Url.ValueString()))
 
+	// The items which already exist are kept. The items which are not configured are not removed when the resource
+	// is created, they're detected as drift by the next refresh and removed by the next apply.
+	currentItems, err := r.listReferenceIds(ctx, model)
+	if err != nil {
+		resp.Diagnostics.AddError("Failed to read collection", err.Error())
+		return
+	}
 	newItems := AsListOfString(model.ReferenceIds)
-	if err := r.syncCollection(ctx, model, nil, newItems); err != nil {
+	existingItems := make([]string, 0)
+	unmanagedItems := make([]string, 0)
+	for _, item := range currentItems {
+		if slices.Contains(newItems, item) {
+			existingItems = append(existingItems, item)
+		} else {
+			unmanagedItems = append(unmanagedItems, item)
+		}
+	}
+	if len(unmanagedItems) != 0 {
+		resp.Diagnostics.AddWarning("Collection contains unmanaged items", fmt.Sprintf("The collection %s contains items which are not in `reference_ids`: %s. They will be removed by the next apply unless they're added to `reference_ids`.", model.Url.ValueString(), strings.Join(unmanagedItems, ", ")))
+	}
+	if err := r.syncCollection(ctx, model, existingItems, newItems); err != nil {
 		resp.Diagnostics.AddError("Failed to sync collection", err.Error())
 		return
 	}
@@ -165,7 +190,7 @@ func (r *MSGraphResourceCollection) Create(ctx context.Context, req resource.Cre
 	base := baseCollectionUrl(model.Url.ValueString())
 	opts := clients.RequestOptions{
 		QueryParameters: clients.NewQueryParameters(AsMapOfLists(model.ReadQueryParameters)),
-		RetryOptions:    clients.NewRetryOptions(model.Retry),
+		RetryOptions:    clients.NewRetryOptions(model.Retry, http.MethodGet),
 	}
 	body, err := r.client.List(ctx, base, model.ApiVersion.ValueString(), opts)
 	if err != nil {
@@ -178,22 +203,26 @@ func (r *MSGraphResourceCollection) Create(ctx context.Context, req resource.Cre
 }
 
 func (r *MSGraphResourceCollection) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
-	var model, state *MSGraphResourceCollectionModel
+	var model *MSGraphResourceCollectionModel
 	if resp.Diagnostics.Append(req.Plan.Get(ctx, &model)...); resp.Diagnostics.HasError() {
 		return
 	}
-	if resp.Diagnostics.Append(req.State.Get(ctx, &state)...); resp.Diagnostics.HasError() {
-		return
-	}
 
 	timeout, diags := model.Timeouts.Update(ctx, 30*time.Minute)
 	resp.Diagnostics.Append(diags...)
 	ctx, cancel := context.WithTimeout(ctx, timeout)
 	defer cancel()
+	ctx = clients.WithOperationMetrics(ctx)
+	defer clients.LogOperationMetrics(ctx, fmt.Sprintf("msgraph_resource_collection update of %s", model.Url.ValueString()))
 
+	// The delta is computed against the current items, which may have been changed since the last refresh.
+	currentItems, err := r.listReferenceIds(ctx, model)
+	if err != nil {
+		resp.Diagnostics.AddError("Failed to read collection", err.Error())
+		return
+	}
 	newItems := AsListOfString(model.ReferenceIds)
-	oldItems := AsListOfString(state.ReferenceIds)
-	if err := r.syncCollection(ctx, model, oldItems, newItems); err != nil {
+	if err := r.syncCollection(ctx, model, currentItems, newItems); err != nil {
 		resp.Diagnostics.AddError("Failed to sync collection", err.Error())
 		return
 	}
@@ -201,7 +230,7 @@ func (r *MSGraphResourceCollection) Update(ctx context.Context, req resource.Upd
 	base := baseCollectionUrl(model.Url.ValueString())
 	opts := clients.RequestOptions{
 		QueryParameters: clients.NewQueryParameters(AsMapOfLists(model.ReadQueryParameters)),
-		RetryOptions:    clients.NewRetryOptions(model.Retry),
+		RetryOptions:    clients.NewRetryOptions(model.Retry, http.MethodGet),
 	}
 	body, err := r.client.List(ctx, base, model.ApiVersion.ValueString(), opts)
 	if err != nil {
@@ -219,15 +248,17 @@ func (r *MSGraphResourceCollection) Read(ctx context.Context, req resource.ReadR
 		return
 	}
 
-	timeout, diags := model.Timeouts.Read(ctx, 5*time.Minute)
+	timeout, diags := model.Timeouts.Read(ctx, r.client.DefaultReadTimeout())
 	resp.Diagnostics.Append(diags...)
 	ctx, cancel := context.WithTimeout(ctx, timeout)
 	defer cancel()
+	ctx = clients.WithOperationMetrics(ctx)
+	defer clients.LogOperationMetrics(ctx, fmt.Sprintf("msgraph_resource_collection read of %s", model.Url.ValueString()))
 
 	base := baseCollectionUrl(model.Url.ValueString())
 	opts := clients.RequestOptions{
 		QueryParameters: clients.NewQueryParameters(AsMapOfLists(model.ReadQueryParameters)),
-		RetryOptions:    clients.NewRetryOptions(model.Retry),
+		RetryOptions:    clients.NewRetryOptions(model.Retry, http.MethodGet),
 	}
 	body, err := r.client.List(ctx, base, model.ApiVersion.ValueString(), opts)
 	if err != nil {
@@ -260,8 +291,24 @@ func (r *MSGraphResourceCollection) Delete(ctx context.Context, req resource.Del
 	resp.Diagnostics.Append(diags...)
 	ctx, cancel := context.WithTimeout(ctx, timeout)
 	defer cancel()
+	ctx = clients.WithOperationMetrics(ctx)
+	defer clients.LogOperationMetrics(ctx, fmt.Sprintf("msgraph_resource_collection delete of %s", model.Url.ValueString()))
 
-	oldItems := AsListOfString(model.ReferenceIds)
+	currentItems, err := r.listReferenceIds(ctx, model)
+	if err != nil {
+		if utils.ResponseErrorWasNotFound(err) {
+			return
+		}
+		resp.Diagnostics.AddError("Failed to read collection", err.Error())
+		return
+	}
+	// Only the managed items which still exist are removed.
+	oldItems := make([]string, 0)
+	for _, item := range AsListOfString(model.ReferenceIds) {
+		if slices.Contains(currentItems, item) {
+			oldItems = append(oldItems, item)
+		}
+	}
 	if err := r.syncCollection(ctx, model, oldItems, nil); err != nil {
 		resp.Diagnostics.AddError("Failed to sync collection", err.Error())
 		return
@@ -292,19 +339,44 @@ func (r *MSGraphResourceCollection) syncCollection(ctx context.Context, model *M
 	return r.applyCollection(ctx, model, toRemove, toAdd)
 }
 
+// listReferenceIds returns the IDs of the items which are currently in the collection.
+func (r *MSGraphResourceCollection) listReferenceIds(ctx context.Context, model *MSGraphResourceCollectionModel) ([]string, error) {
+	opts := clients.RequestOptions{
+		RetryOptions: clients.NewRetryOptions(model.Retry, http.MethodGet),
+	}
+	return r.client.ListRefIDs(ctx, baseCollectionUrl(model.Url.ValueString()), model.ApiVersion.ValueString(), opts)
+}
+
 func (r *MSGraphResourceCollection) applyCollection(ctx context.Context, model *MSGraphResourceCollectionModel, toRemove []string, toAdd []string) error {
 	errs := make([]error, 0)
+	if bindUrl, property := bindPropertyOf(model.Url.ValueString()); bindUrl != "" {
+		// The items are added in batches by binding them to the parent object, instead of one request per item.
+		for chunk := range slices.Chunk(toAdd, maxBindItemsPerRequest) {
+			references := make([]string, 0, len(chunk))
+			for _, item := range chunk {
+				references = append(references, fmt.Sprintf("%s/%s/directoryObjects/%s", r.client.GraphBaseUrl(), model.ApiVersion.ValueString(), item))
+			}
+			body := map[string]interface{}{
+				property: references,
+			}
+			_, err := r.client.Update(ctx, bindUrl, model.ApiVersion.ValueString(), body, clients.RequestOptions{RetryOptions: clients.NewRetryOptions(model.Retry, http.MethodPatch)})
+			if err != nil {
+				errs = append(errs, err)
+			}
+		}
+		toAdd = nil
+	}
 	for _, item := range toAdd {
 		body := map[string]string{}
 		body["@odata.id"] = fmt.Sprintf("%s/%s/directoryObjects/%s", r.client.GraphBaseUrl(), model.ApiVersion.ValueString(), item)
-		_, err := r.client.Create(ctx, model.Url.ValueString(), model.ApiVersion.ValueString(), body, clients.RequestOptions{RetryOptions: clients.NewRetryOptions(model.Retry)})
+		_, err := r.client.Create(ctx, model.Url.ValueString(), model.ApiVersion.ValueString(), body, clients.RequestOptions{RetryOptions: clients.NewRetryOptions(model.Retry, http.MethodPost)})
 		if err != nil {
 			errs = append(errs, err)
 		}
 	}
 	for _, item := range toRemove {
 		delUrl := fmt.Sprintf("%s/%s/$ref", baseCollectionUrl(model.Url.ValueString()), item)
-		err := r.client.Delete(ctx, delUrl, model.ApiVersion.ValueString(), clients.RequestOptions{RetryOptions: clients.NewRetryOptions(model.Retry)})
+		err := r.client.Delete(ctx, delUrl, model.ApiVersion.ValueString(), clients.RequestOptions{RetryOptions: clients.NewRetryOptions(model.Retry, http.MethodDelete)})
 		if err != nil {
 			errs = append(errs, err)
 		}
@@ -338,3 +410,19 @@ func flattenReferenceIds(body interface{}) ([]string, error) {
 }
 
 func baseCollectionUrl(url string) string { return strings.TrimSuffix(url, "/$ref") }
+
+// maxBindItemsPerRequest is the maximum number of items which can be bound to an object in a single request.
+const maxBindItemsPerRequest = 20
+
+// groupMembersUrlRegex matches the members collection of a group, whose members can be added in batches by binding
+// them with `members@odata.bind`.
+var groupMembersUrlRegex = regexp.MustCompile(`^/?(groups/[^/]+)/members/\$ref$`)
+
+// bindPropertyOf returns the URL of the parent object and the property to bind the items of the collection with, or
+// an empty URL if the items can't be bound in batches.
+func bindPropertyOf(url string) (string, string) {
+	if matches := groupMembersUrlRegex.FindStringSubmatch(url); matches != nil {
+		return matches[1], "members@odata.bind"
+	}
+	return "", ""
+}
@@ -65,6 +65,63 @@ func TestAcc_ResourceCollectionUpdate(t *testing.T) {
 	})
 }
 
+func TestAcc_ResourceCollectionDrift(t *testing.T) {
+	data := acceptance.BuildTestData(t, "msgraph_resource_collection", "test")
+	r := MSGraphTestResourceCollection{}
+
+	data.ResourceTest(t, r, []resource.TestStep{
+		{
+			Config: r.oneMemberWithUnmanagedServicePrincipal(),
+			Check: resource.ComposeTestCheckFunc(
+				check.That(data.ResourceName).Exists(r),
+				resource.TestCheckResourceAttr(data.ResourceName, "reference_ids.#", "1"),
+				// the member added outside of Terraform is detected by the refresh after this step
+				r.addMemberOutsideOfTerraform("msgraph_resource.sp_b"),
+			),
+			ExpectNonEmptyPlan: true,
+		},
+		{
+			// the member added outside of Terraform is removed again
+			Config: r.oneMemberWithUnmanagedServicePrincipal(),
+			Check: resource.ComposeTestCheckFunc(
+				check.That(data.ResourceName).Exists(r),
+				resource.TestCheckResourceAttr(data.ResourceName, "reference_ids.#", "1"),
+			),
+		},
+	})
+}
+
+func TestAcc_ResourceCollectionCreateWithUnmanagedMember(t *testing.T) {
+	data := acceptance.BuildTestData(t, "msgraph_resource_collection", "test")
+	r := MSGraphTestResourceCollection{}
+
+	data.ResourceTest(t, r, []resource.TestStep{
+		{
+			Config: r.groupWithServicePrincipals(),
+			Check: resource.ComposeTestCheckFunc(
+				r.addGroupMemberOutsideOfTerraform("msgraph_resource.group", "msgraph_resource.sp_b"),
+			),
+		},
+		{
+			// the member which already exists is not removed when the collection is created, it's detected as drift
+			Config: r.oneMemberWithUnmanagedServicePrincipal(),
+			Check: resource.ComposeTestCheckFunc(
+				check.That(data.ResourceName).Exists(r),
+				resource.TestCheckResourceAttr(data.ResourceName, "reference_ids.#", "1"),
+			),
+			ExpectNonEmptyPlan: true,
+		},
+		{
+			// the unmanaged member is removed by the next apply
+			Config: r.oneMemberWithUnmanagedServicePrincipal(),
+			Check: resource.ComposeTestCheckFunc(
+				check.That(data.ResourceName).Exists(r),
+				resource.TestCheckResourceAttr(data.ResourceName, "reference_ids.#", "1"),
+			),
+		},
+	})
+}
+
 func TestAcc_ResourceCollectionRetry(t *testing.T) {
 	data := acceptance.BuildTestData(t, "msgraph_resource_collection", "test")
 	r := MSGraphTestResourceCollection{}
@@ -139,6 +196,76 @@ func TestAcc_ResourceCollectionTimeouts_Read(t *testing.T) {
 	})
 }
 
+func TestAcc_ResourceCollectionTimeouts_Delete(t *testing.T) {
+	data := acceptance.BuildTestData(t, "msgraph_resource_collection", "test")
+	r := MSGraphTestResourceCollection{}
+
+	data.ResourceTest(t, r, []resource.TestStep{
+		{
+			Config: r.withDeleteTimeoutOneMember(),
+			Check: resource.ComposeTestCheckFunc(
+				check.That(data.ResourceName).Exists(r),
+				resource.TestCheckResourceAttr(data.ResourceName, "reference_ids.#", "1"),
+			),
+		},
+		{
+			Config:      r.withDeleteTimeoutOneMember(),
+			Destroy:     true,
+			ExpectError: regexp.MustCompile(`context deadline exceeded`),
+		},
+		{
+			// The delete timeout is removed, so the collection can be destroyed at the end of the test.
+			Config: r.updateOneMember(),
+			Check: resource.ComposeTestCheckFunc(
+				check.That(data.ResourceName).Exists(r),
+			),
+		},
+	})
+}
+
+// addMemberOutsideOfTerraform adds the object of the resource to the group of the collection with a direct API call.
+func (r MSGraphTestResourceCollection) addMemberOutsideOfTerraform(resourceName string) resource.TestCheckFunc {
+	return func(s *terraform.State) error {
+		client, err := acceptance.BuildTestClient()
+		if err != nil {
+			return err
+		}
+		member, ok := s.RootModule().Resources[resourceName]
+		if !ok {
+			return fmt.Errorf("resource %q not found in state", resourceName)
+		}
+		collection := s.RootModule().Resources["msgraph_resource_collection.test"].Primary
+		body := map[string]string{
+			"@odata.id": fmt.Sprintf("%s/v1.0/directoryObjects/%s", client.MSGraphClient.GraphBaseUrl(), member.Primary.ID),
+		}
+		_, err = client.MSGraphClient.Create(context.Background(), collection.Attributes["url"], collection.Attributes["api_version"], body, clients.DefaultRequestOptions())
+		return err
+	}
+}
+
+// addGroupMemberOutsideOfTerraform adds the object of the resource to the members of the group with a direct API call.
+func (r MSGraphTestResourceCollection) addGroupMemberOutsideOfTerraform(groupResourceName string, resourceName string) resource.TestCheckFunc {
+	return func(s *terraform.State) error {
+		client, err := acceptance.BuildTestClient()
+		if err != nil {
+			return err
+		}
+		group, ok := s.RootModule().Resources[groupResourceName]
+		if !ok {
+			return fmt.Errorf("resource %q not found in state", groupResourceName)
+		}
+		member, ok := s.RootModule().Resources[resourceName]
+		if !ok {
+			return fmt.Errorf("resource %q not found in state", resourceName)
+		}
+		body := map[string]string{
+			"@odata.id": fmt.Sprintf("%s/v1.0/directoryObjects/%s", client.MSGraphClient.GraphBaseUrl(), member.Primary.ID),
+		}
+		_, err = client.MSGraphClient.Create(context.Background(), fmt.Sprintf("groups/%s/members/$ref", group.Primary.ID), "beta", body, clients.DefaultRequestOptions())
+		return err
+	}
+}
+
 // Exists checks that the underlying collection endpoint exists by listing it.
 func (r MSGraphTestResourceCollection) Exists(ctx context.Context, client *clients.Client, state *terraform.InstanceState) (*bool, error) {
 	apiVersion := state.Attributes["api_version"]
@@ -527,3 +654,145 @@ resource "msgraph_resource_collection" "test" {
 }
 `
 }
+
+func (r MSGraphTestResourceCollection) withDeleteTimeoutOneMember() string {
+	return `
+resource "msgraph_resource" "application_a" {
+  url = "applications"
+  body = {
+    displayName = "Collection App a"
+  }
+  response_export_values = {
+    appId = "appId"
+  }
+}
+
+resource "msgraph_resource" "sp_a" {
+  url = "servicePrincipals"
+  body = {
+    appId = msgraph_resource.application_a.output.appId
+  }
+}
+
+resource "msgraph_resource" "group" {
+  url = "groups"
+  body = {
+    displayName     = "Collection Group"
+    mailEnabled     = false
+    mailNickname    = "collection-group"
+    securityEnabled = true
+  }
+}
+
+resource "msgraph_resource_collection" "test" {
+  url = "groups/${msgraph_resource.group.id}/members/$ref"
+  timeouts {
+    delete = "1ns"
+  }
+  api_version   = "beta"
+  reference_ids = [msgraph_resource.sp_a.id]
+}
+`
+}
+
+func (r MSGraphTestResourceCollection) oneMemberWithUnmanagedServicePrincipal() string {
+	return `
+resource "msgraph_resource" "application_a" {
+  url = "applications"
+  body = {
+    displayName = "Collection App a"
+  }
+  response_export_values = {
+    appId = "appId"
+  }
+}
+
+resource "msgraph_resource" "sp_a" {
+  url = "servicePrincipals"
+  body = {
+    appId = msgraph_resource.application_a.output.appId
+  }
+}
+
+resource "msgraph_resource" "application_b" {
+  url = "applications"
+  body = {
+    displayName = "Collection App b"
+  }
+  response_export_values = {
+    appId = "appId"
+  }
+}
+
+resource "msgraph_resource" "sp_b" {
+  url = "servicePrincipals"
+  body = {
+    appId = msgraph_resource.application_b.output.appId
+  }
+}
+
+resource "msgraph_resource" "group" {
+  url = "groups"
+  body = {
+    displayName     = "Collection Group"
+    mailEnabled     = false
+    mailNickname    = "collection-group"
+    securityEnabled = true
+  }
+}
+
+resource "msgraph_resource_collection" "test" {
+  url           = "groups/${msgraph_resource.group.id}/members/$ref"
+  api_version   = "beta"
+  reference_ids = [msgraph_resource.sp_a.id]
+}
+`
+}
+
+func (r MSGraphTestResourceCollection) groupWithServicePrincipals() string {
+	return `
+resource "msgraph_resource" "application_a" {
+  url = "applications"
+  body = {
+    displayName = "Collection App a"
+  }
+  response_export_values = {
+    appId = "appId"
+  }
+}
+
+resource "msgraph_resource" "sp_a" {
+  url = "servicePrincipals"
+  body = {
+    appId = msgraph_resource.application_a.output.appId
+  }
+}
+
+resource "msgraph_resource" "application_b" {
+  url = "applications"
+  body = {
+    displayName = "Collection App b"
+  }
+  response_export_values = {
+    appId = "appId"
+  }
+}
+
+resource "msgraph_resource" "sp_b" {
+  url = "servicePrincipals"
+  body = {
+    appId = msgraph_resource.application_b.output.appId
+  }
+}
+
+resource "msgraph_resource" "group" {
+  url = "groups"
+  body = {
+    displayName     = "Collection Group"
+    mailEnabled     = false
+    mailNickname    = "collection-group"
+    securityEnabled = true
+  }
+}
+`
+}
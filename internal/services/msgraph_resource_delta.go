@@ -0,0 +1,352 @@
+package services
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"regexp"
+	"sort"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-framework-timeouts/resource/timeouts"
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+	"github.com/microsoft/terraform-provider-msgraph/internal/clients"
+	"github.com/microsoft/terraform-provider-msgraph/internal/docstrings"
+	"github.com/microsoft/terraform-provider-msgraph/internal/dynamic"
+	"github.com/microsoft/terraform-provider-msgraph/internal/myvalidator"
+	"github.com/microsoft/terraform-provider-msgraph/internal/retry"
+	"github.com/microsoft/terraform-provider-msgraph/internal/utils"
+)
+
+// FlagDeltaObjectIds is the key of the private state which holds the IDs of the objects returned by the delta query,
+// they're used to tell the added objects from the updated ones.
+const FlagDeltaObjectIds = "delta_object_ids"
+
+var (
+	_ resource.Resource               = &MSGraphResourceDelta{}
+	_ resource.ResourceWithConfigure  = &MSGraphResourceDelta{}
+	_ resource.ResourceWithModifyPlan = &MSGraphResourceDelta{}
+)
+
+func NewMSGraphResourceDelta() resource.Resource {
+	return &MSGraphResourceDelta{}
+}
+
+type MSGraphResourceDelta struct{ client *clients.MSGraphClient }
+
+type MSGraphResourceDeltaModel struct {
+	Id              types.String   `tfsdk:"id"`
+	ApiVersion      types.String   `tfsdk:"api_version"`
+	Url             types.String   `tfsdk:"url"`
+	QueryParameters types.Map      `tfsdk:"query_parameters"`
+	Headers         types.Map      `tfsdk:"headers"`
+	Retry           retry.Value    `tfsdk:"retry"`
+	DeltaLink       types.String   `tfsdk:"delta_link"`
+	Added           types.Dynamic  `tfsdk:"added"`
+	Updated         types.Dynamic  `tfsdk:"updated"`
+	Removed         types.List     `tfsdk:"removed"`
+	Timeouts        timeouts.Value `tfsdk:"timeouts"`
+}
+
+func (r *MSGraphResourceDelta) Metadata(ctx context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_resource_delta"
+}
+
+func (r *MSGraphResourceDelta) Schema(ctx context.Context, req resource.SchemaRequest, resp *resource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		MarkdownDescription: "This resource tracks the incremental changes of a collection with the `delta` function of Microsoft Graph, e.g. `users/delta` or `groups/delta`. " +
+			"The whole collection is read when the resource is created, and the returned `@odata.deltaLink` is stored in the state. Each following read only requests the changes since the previous read by following the `@odata.deltaLink`, and exposes them in `added`, `updated` and `removed`. " +
+			"When the delta token has expired, i.e. the API responds with `410 Gone`, the collection is synchronized again from scratch, and the changes are computed against the objects which were known before.\n\n" +
+			"-> **Note** The IDs of the known objects are kept in the private state of the resource. Use `$select` in `query_parameters` to limit the properties which are stored in the state for large collections.",
+		Attributes: map[string]schema.Attribute{
+			"id": schema.StringAttribute{
+				MarkdownDescription: "The ID of the resource. It's the same as `url`.",
+				Computed:            true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+
+			"url": schema.StringAttribute{
+				MarkdownDescription: "The relative URL of the `delta` function of a collection, e.g. `users/delta` or `groups/delta`. Changing this forces a new resource to be created.",
+				Required:            true,
+				Validators: []validator.String{
+					myvalidator.RelativeURL(),
+					stringvalidator.RegexMatches(regexp.MustCompile(`(?i)/delta(\(\))?$`), "must be the URL of a `delta` function, e.g. `users/delta`"),
+				},
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+
+			"api_version": schema.StringAttribute{
+				MarkdownDescription: docstrings.ApiVersion() + " Changing this forces a new resource to be created.",
+				Optional:            true,
+				Computed:            true,
+				Validators:          []validator.String{myvalidator.ApiVersion()},
+			},
+
+			"query_parameters": schema.MapAttribute{
+				ElementType:         types.ListType{ElemType: types.StringType},
+				Optional:            true,
+				MarkdownDescription: "A mapping of query parameters to be sent with the initial delta query, e.g. `$select` or `$filter`. The following requests reuse them through the `@odata.deltaLink`. Changing this forces a new resource to be created.",
+			},
+
+			"headers": schema.MapAttribute{
+				ElementType:         types.StringType,
+				Optional:            true,
+				MarkdownDescription: "A mapping of HTTP headers to be sent with the delta queries. Note that authentication headers are automatically handled.",
+			},
+
+			"retry": retry.Schema(ctx),
+
+			"delta_link": schema.StringAttribute{
+				MarkdownDescription: "The `@odata.deltaLink` returned by the last delta query, which is followed to read the next changes.",
+				Computed:            true,
+			},
+
+			"added": schema.DynamicAttribute{
+				MarkdownDescription: "The objects which were added since the previous read. When the resource is created, it contains all the objects of the collection.",
+				Computed:            true,
+			},
+
+			"updated": schema.DynamicAttribute{
+				MarkdownDescription: "The objects which were changed since the previous read. The delta query only returns the changed properties of some objects, e.g. the `members@delta` of groups.",
+				Computed:            true,
+			},
+
+			"removed": schema.ListAttribute{
+				MarkdownDescription: "The IDs of the objects which were removed since the previous read.",
+				ElementType:         types.StringType,
+				Computed:            true,
+			},
+		},
+		Blocks: map[string]schema.Block{
+			"timeouts": timeouts.BlockAll(ctx),
+		},
+	}
+}
+
+func (r *MSGraphResourceDelta) Configure(ctx context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
+	if v, ok := req.ProviderData.(*clients.Client); ok {
+		r.client = v.MSGraphClient
+	}
+}
+
+func (r *MSGraphResourceDelta) ModifyPlan(ctx context.Context, request resource.ModifyPlanRequest, response *resource.ModifyPlanResponse) {
+	if planDefaultApiVersion(ctx, r.client, request, response); response.Diagnostics.HasError() {
+		return
+	}
+
+	var plan, state *MSGraphResourceDeltaModel
+	if response.Diagnostics.Append(response.Plan.Get(ctx, &plan)...); response.Diagnostics.HasError() {
+		return
+	}
+	if response.Diagnostics.Append(request.State.Get(ctx, &state)...); response.Diagnostics.HasError() {
+		return
+	}
+	if plan == nil || state == nil {
+		return
+	}
+
+	if !plan.ApiVersion.Equal(state.ApiVersion) {
+		response.RequiresReplace.Append(path.Root("api_version"))
+	}
+	if !plan.QueryParameters.Equal(state.QueryParameters) {
+		response.RequiresReplace.Append(path.Root("query_parameters"))
+	}
+	if len(response.RequiresReplace) != 0 {
+		return
+	}
+
+	// the changes are only tracked by the reads, so an update keeps the result of the last one
+	plan.DeltaLink = state.DeltaLink
+	plan.Added = state.Added
+	plan.Updated = state.Updated
+	plan.Removed = state.Removed
+	response.Diagnostics.Append(response.Plan.Set(ctx, &plan)...)
+}
+
+func (r *MSGraphResourceDelta) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	var model *MSGraphResourceDeltaModel
+	if resp.Diagnostics.Append(req.Plan.Get(ctx, &model)...); resp.Diagnostics.HasError() {
+		return
+	}
+
+	timeout, diags := model.Timeouts.Create(ctx, 30*time.Minute)
+	resp.Diagnostics.Append(diags...)
+	ctx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
+	ctx = clients.WithOperationMetrics(ctx)
+	defer clients.LogOperationMetrics(ctx, fmt.Sprintf("msgraph_resource_delta create of %s", model.Url.ValueString()))
+
+	body, err := r.client.List(ctx, model.Url.ValueString(), model.ApiVersion.ValueString(), r.requestOptions(model, true))
+	if err != nil {
+		resp.Diagnostics.AddError("Failed to query delta", utils.ResponseErrorDetail(err))
+		return
+	}
+
+	model.Id = model.Url
+	if resp.Diagnostics.Append(r.applyDelta(ctx, model, body, map[string]bool{}, true, resp.Private)...); resp.Diagnostics.HasError() {
+		return
+	}
+	resp.Diagnostics.Append(resp.State.Set(ctx, &model)...)
+}
+
+func (r *MSGraphResourceDelta) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	var model *MSGraphResourceDeltaModel
+	if resp.Diagnostics.Append(req.State.Get(ctx, &model)...); resp.Diagnostics.HasError() {
+		return
+	}
+
+	timeout, diags := model.Timeouts.Read(ctx, r.client.DefaultReadTimeout())
+	resp.Diagnostics.Append(diags...)
+	ctx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
+	ctx = clients.WithOperationMetrics(ctx)
+	defer clients.LogOperationMetrics(ctx, fmt.Sprintf("msgraph_resource_delta read of %s", model.Url.ValueString()))
+
+	knownIds, diags := deltaObjectIds(ctx, req.Private)
+	if resp.Diagnostics.Append(diags...); resp.Diagnostics.HasError() {
+		return
+	}
+
+	var body interface{}
+	var err error
+	fullSync := model.DeltaLink.ValueString() == ""
+	if !fullSync {
+		body, err = r.client.ListFromLink(ctx, model.DeltaLink.ValueString(), r.requestOptions(model, false))
+		if err != nil && utils.ResponseErrorWasStatusCode(err, http.StatusGone) {
+			tflog.Warn(ctx, fmt.Sprintf("The delta token of %s has expired, synchronizing the collection again", model.Url.ValueString()))
+			fullSync = true
+		}
+	}
+	if fullSync {
+		body, err = r.client.List(ctx, model.Url.ValueString(), model.ApiVersion.ValueString(), r.requestOptions(model, true))
+	}
+	if err != nil {
+		resp.Diagnostics.AddError("Failed to query delta", utils.ResponseErrorDetail(err))
+		return
+	}
+
+	if resp.Diagnostics.Append(r.applyDelta(ctx, model, body, knownIds, fullSync, resp.Private)...); resp.Diagnostics.HasError() {
+		return
+	}
+	resp.Diagnostics.Append(resp.State.Set(ctx, &model)...)
+}
+
+func (r *MSGraphResourceDelta) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	var model *MSGraphResourceDeltaModel
+	if resp.Diagnostics.Append(req.Plan.Get(ctx, &model)...); resp.Diagnostics.HasError() {
+		return
+	}
+	resp.Diagnostics.Append(resp.State.Set(ctx, &model)...)
+}
+
+func (r *MSGraphResourceDelta) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	// the delta query doesn't create anything remotely, so there's nothing to delete
+}
+
+// requestOptions returns the options of the delta queries. The query parameters are only sent with the initial query,
+// because the @odata.deltaLink already contains them.
+func (r *MSGraphResourceDelta) requestOptions(model *MSGraphResourceDeltaModel, initial bool) clients.RequestOptions {
+	options := clients.RequestOptions{
+		Headers:      AsMapOfString(model.Headers),
+		RetryOptions: clients.NewRetryOptions(model.Retry, http.MethodGet),
+	}
+	if initial {
+		options.QueryParameters = clients.NewQueryParameters(AsMapOfLists(model.QueryParameters))
+	}
+	return options
+}
+
+// applyDelta sets the changes of the delta query response to the model, and stores the IDs of the known objects in
+// the private state.
+func (r *MSGraphResourceDelta) applyDelta(ctx context.Context, model *MSGraphResourceDeltaModel, body interface{}, knownIds map[string]bool, fullSync bool, private privateStateSetter) diag.Diagnostics {
+	var diags diag.Diagnostics
+
+	added, updated, removed := utils.DiffDeltaItems(body, knownIds, fullSync)
+	for _, item := range added {
+		if id, ok := item.(map[string]interface{})["id"].(string); ok && id != "" {
+			knownIds[id] = true
+		}
+	}
+	for _, id := range removed {
+		delete(knownIds, id)
+	}
+
+	deltaLink := ""
+	if bodyMap, ok := body.(map[string]interface{}); ok {
+		deltaLink, _ = bodyMap["@odata.deltaLink"].(string)
+	}
+	if deltaLink == "" {
+		diags.AddError("Invalid delta response", fmt.Sprintf("The response of %s doesn't contain an `@odata.deltaLink`, make sure that `url` points to a `delta` function.", model.Url.ValueString()))
+		return diags
+	}
+	model.DeltaLink = types.StringValue(deltaLink)
+
+	var err error
+	if model.Added, err = deltaItemsValue(added); err != nil {
+		diags.AddError("Invalid delta response", err.Error())
+		return diags
+	}
+	if model.Updated, err = deltaItemsValue(updated); err != nil {
+		diags.AddError("Invalid delta response", err.Error())
+		return diags
+	}
+	model.Removed = ToListOfString(removed)
+
+	return setDeltaObjectIds(ctx, private, knownIds)
+}
+
+func deltaItemsValue(items []interface{}) (types.Dynamic, error) {
+	data, err := json.Marshal(items)
+	if err != nil {
+		return types.DynamicNull(), err
+	}
+	return dynamic.FromJSONImplied(data)
+}
+
+// setDeltaObjectIds stores the IDs of the objects known by the delta query in the private state.
+func setDeltaObjectIds(ctx context.Context, private privateStateSetter, ids map[string]bool) diag.Diagnostics {
+	values := make([]string, 0, len(ids))
+	for id := range ids {
+		values = append(values, id)
+	}
+	sort.Strings(values)
+	data, err := json.Marshal(values)
+	if err != nil {
+		var diags diag.Diagnostics
+		diags.AddError("Invalid private state", err.Error())
+		return diags
+	}
+	return private.SetKey(ctx, FlagDeltaObjectIds, data)
+}
+
+// deltaObjectIds returns the IDs of the objects known by the delta query which are stored in the private state.
+func deltaObjectIds(ctx context.Context, private privateStateGetter) (map[string]bool, diag.Diagnostics) {
+	ids := make(map[string]bool)
+	data, diags := private.GetKey(ctx, FlagDeltaObjectIds)
+	if diags.HasError() || len(data) == 0 {
+		return ids, diags
+	}
+	var values []string
+	if err := json.Unmarshal(data, &values); err != nil {
+		diags.AddError("Invalid private state", fmt.Sprintf("The IDs of the known objects are invalid: %s", err.Error()))
+		return ids, diags
+	}
+	for _, id := range values {
+		ids[id] = true
+	}
+	return ids, diags
+}
@@ -0,0 +1,110 @@
+package services_test
+
+import (
+	"context"
+	"fmt"
+	"regexp"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+	"github.com/hashicorp/terraform-plugin-testing/terraform"
+	"github.com/microsoft/terraform-provider-msgraph/internal/acceptance"
+	"github.com/microsoft/terraform-provider-msgraph/internal/acceptance/check"
+	"github.com/microsoft/terraform-provider-msgraph/internal/clients"
+	"github.com/microsoft/terraform-provider-msgraph/internal/utils"
+)
+
+type MSGraphTestResourceDelta struct{}
+
+func TestAcc_ResourceDeltaBasic(t *testing.T) {
+	data := acceptance.BuildTestData(t, "msgraph_resource_delta", "test")
+	r := MSGraphTestResourceDelta{}
+
+	data.ResourceTest(t, r, []resource.TestStep{
+		{
+			Config: r.basic(data, ""),
+			Check: resource.ComposeTestCheckFunc(
+				check.That(data.ResourceName).Exists(r),
+				check.That(data.ResourceName).Key("id").HasValue("groups/delta"),
+				check.That(data.ResourceName).Key("delta_link").MatchesRegex(regexp.MustCompile(`deltatoken=`)),
+				check.That(data.ResourceName).Key("added").Exists(),
+				check.That(data.ResourceName).Key("removed.#").HasValue("0"),
+			),
+		},
+		{
+			// changing the headers keeps the tracked changes
+			Config: r.basic(data, "eventual"),
+			Check: resource.ComposeTestCheckFunc(
+				check.That(data.ResourceName).Exists(r),
+				check.That(data.ResourceName).Key("delta_link").MatchesRegex(regexp.MustCompile(`deltatoken=`)),
+			),
+		},
+	})
+}
+
+func TestAcc_ResourceDeltaInvalidUrl(t *testing.T) {
+	data := acceptance.BuildTestData(t, "msgraph_resource_delta", "test")
+	r := MSGraphTestResourceDelta{}
+
+	data.ResourceTest(t, r, []resource.TestStep{
+		{
+			Config:      r.invalidUrl(),
+			PlanOnly:    true,
+			ExpectError: regexp.MustCompile("delta"),
+		},
+	})
+}
+
+func (r MSGraphTestResourceDelta) Exists(ctx context.Context, client *clients.Client, state *terraform.InstanceState) (*bool, error) {
+	apiVersion := state.Attributes["api_version"]
+	url := state.Attributes["url"]
+
+	_, err := client.MSGraphClient.ReadPage(ctx, url, apiVersion, clients.DefaultRequestOptions())
+	if err == nil {
+		b := true
+		return &b, nil
+	}
+	if utils.ResponseErrorWasNotFound(err) {
+		b := false
+		return &b, nil
+	}
+	return nil, fmt.Errorf("checking for presence of existing delta query %s(api_version=%s): %w", url, apiVersion, err)
+}
+
+func (r MSGraphTestResourceDelta) basic(data acceptance.TestData, consistencyLevel string) string {
+	headers := ""
+	if consistencyLevel != "" {
+		headers = fmt.Sprintf(`
+  headers = {
+    ConsistencyLevel = "%s"
+  }`, consistencyLevel)
+	}
+	return fmt.Sprintf(`
+resource "msgraph_resource" "group" {
+  url = "groups"
+  body = {
+    displayName     = "acctest-delta-%[1]s"
+    mailEnabled     = false
+    mailNickname    = "acctest-delta-%[1]s"
+    securityEnabled = true
+  }
+}
+
+resource "msgraph_resource_delta" "test" {
+  url = "groups/delta"
+  query_parameters = {
+    "$select" = ["displayName"]
+  }%[2]s
+
+  depends_on = [msgraph_resource.group]
+}
+`, data.RandomString, headers)
+}
+
+func (r MSGraphTestResourceDelta) invalidUrl() string {
+	return `
+resource "msgraph_resource_delta" "test" {
+  url = "groups"
+}
+`
+}
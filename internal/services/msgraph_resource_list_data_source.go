@@ -0,0 +1,170 @@
+package services
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+
+	"github.com/hashicorp/terraform-plugin-framework-timeouts/resource/timeouts"
+	"github.com/hashicorp/terraform-plugin-framework-validators/int64validator"
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/microsoft/terraform-provider-msgraph/internal/clients"
+	"github.com/microsoft/terraform-provider-msgraph/internal/docstrings"
+	"github.com/microsoft/terraform-provider-msgraph/internal/dynamic"
+	"github.com/microsoft/terraform-provider-msgraph/internal/myvalidator"
+	"github.com/microsoft/terraform-provider-msgraph/internal/retry"
+)
+
+// Ensure provider defined types fully satisfy framework interfaces.
+var _ datasource.DataSource = &MSGraphResourceListDataSource{}
+
+func NewMSGraphResourceListDataSource() datasource.DataSource {
+	return &MSGraphResourceListDataSource{}
+}
+
+// MSGraphResourceListDataSource defines the data source implementation.
+type MSGraphResourceListDataSource struct {
+	client *clients.MSGraphClient
+}
+
+// MSGraphResourceListDataSourceModel describes the data source data model.
+type MSGraphResourceListDataSourceModel struct {
+	Id              types.String   `tfsdk:"id"`
+	ApiVersion      types.String   `tfsdk:"api_version"`
+	Url             types.String   `tfsdk:"url"`
+	Headers         types.Map      `tfsdk:"headers"`
+	QueryParameters types.Map      `tfsdk:"query_parameters"`
+	MaxResults      types.Int64    `tfsdk:"max_results"`
+	Retry           retry.Value    `tfsdk:"retry"`
+	TotalCount      types.Int64    `tfsdk:"total_count"`
+	Output          types.Dynamic  `tfsdk:"output"`
+	Timeouts        timeouts.Value `tfsdk:"timeouts"`
+}
+
+func (r *MSGraphResourceListDataSource) Metadata(ctx context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_resource_list"
+}
+
+func (r *MSGraphResourceListDataSource) Schema(ctx context.Context, req datasource.SchemaRequest, resp *datasource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		// This description is used by the documentation generator and the language server.
+		MarkdownDescription: "This data source can list all items of a collection from the Microsoft Graph API. It follows `@odata.nextLink` until the collection is exhausted or `max_results` items have been read.",
+
+		Attributes: map[string]schema.Attribute{
+			"id": schema.StringAttribute{
+				MarkdownDescription: "The URL of the collection.",
+				Computed:            true,
+			},
+
+			"url": schema.StringAttribute{
+				MarkdownDescription: "The URL of the collection to list, e.g. `groups`. It's relative to the root of the API version, and it should not contain the query parameters.",
+				Required:            true,
+				Validators: []validator.String{
+					myvalidator.RelativeURL(),
+				},
+			},
+
+			"api_version": schema.StringAttribute{
+				MarkdownDescription: docstrings.ApiVersion(),
+				Optional:            true,
+				Validators: []validator.String{
+					myvalidator.ApiVersion(),
+				},
+			},
+
+			"headers": schema.MapAttribute{
+				ElementType:         types.StringType,
+				Optional:            true,
+				MarkdownDescription: "A map of headers to include in the requests, e.g. `ConsistencyLevel = \"eventual\"` which is required by advanced queries like `$count`.",
+			},
+
+			"query_parameters": schema.MapAttribute{
+				ElementType: types.ListType{
+					ElemType: types.StringType,
+				},
+				Optional:            true,
+				MarkdownDescription: "A map of query parameters to include in the first request, e.g. `$filter` or `$select`. The following requests use the query parameters of `@odata.nextLink`.",
+			},
+
+			"max_results": schema.Int64Attribute{
+				MarkdownDescription: "The maximum number of items to return. No more pages are requested once it has been reached. If not specified, all items are returned.",
+				Optional:            true,
+				Validators: []validator.Int64{
+					int64validator.AtLeast(1),
+				},
+			},
+
+			"retry": retry.Schema(ctx),
+
+			"total_count": schema.Int64Attribute{
+				MarkdownDescription: "The total number of items in the collection. It's only set when `$count = [\"true\"]` is specified in `query_parameters`.",
+				Computed:            true,
+			},
+
+			"output": schema.DynamicAttribute{
+				MarkdownDescription: "The aggregated `value` array of all pages of the collection.",
+				Computed:            true,
+			},
+		},
+
+		Blocks: map[string]schema.Block{
+			"timeouts": timeouts.Block(ctx, timeouts.Opts{
+				Read: true,
+			}),
+		},
+	}
+}
+
+func (r *MSGraphResourceListDataSource) Configure(ctx context.Context, req datasource.ConfigureRequest, resp *datasource.ConfigureResponse) {
+	if v, ok := req.ProviderData.(*clients.Client); ok {
+		r.client = v.MSGraphClient
+	}
+}
+
+func (r *MSGraphResourceListDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
+	var model MSGraphResourceListDataSourceModel
+	if resp.Diagnostics.Append(req.Config.Get(ctx, &model)...); resp.Diagnostics.HasError() {
+		return
+	}
+
+	readTimeout, diags := model.Timeouts.Read(ctx, r.client.DefaultReadTimeout())
+	resp.Diagnostics.Append(diags...)
+	ctx, cancelRead := context.WithTimeout(ctx, readTimeout)
+	defer cancelRead()
+
+	apiVersion := r.client.DefaultApiVersion()
+	if model.ApiVersion.ValueString() != "" {
+		apiVersion = model.ApiVersion.ValueString()
+	}
+
+	options := clients.RequestOptions{
+		Headers:         clients.NewHeaders(AsMapOfString(model.Headers)),
+		QueryParameters: clients.NewQueryParameters(AsMapOfLists(model.QueryParameters)),
+		RetryOptions:    clients.NewRetryOptions(model.Retry, http.MethodGet),
+	}
+	items, count, err := r.client.ListWithLimit(ctx, model.Url.ValueString(), apiVersion, int(model.MaxResults.ValueInt64()), options)
+	if err != nil {
+		resp.Diagnostics.AddError("Failed to list resources", err.Error())
+		return
+	}
+
+	data, err := json.Marshal(items)
+	if err != nil {
+		resp.Diagnostics.AddError("Failed to marshal response", err.Error())
+		return
+	}
+	output, err := dynamic.FromJSONImplied(data)
+	if err != nil {
+		resp.Diagnostics.AddError("Failed to parse response", err.Error())
+		return
+	}
+
+	model.Id = types.StringValue(model.Url.ValueString())
+	model.TotalCount = types.Int64PointerValue(count)
+	model.Output = types.DynamicValue(output)
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &model)...)
+}
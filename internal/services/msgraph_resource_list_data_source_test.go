@@ -0,0 +1,95 @@
+package services_test
+
+import (
+	"regexp"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+	"github.com/microsoft/terraform-provider-msgraph/internal/acceptance"
+	"github.com/microsoft/terraform-provider-msgraph/internal/acceptance/check"
+)
+
+type MSGraphTestResourceListDataSource struct{}
+
+func TestAcc_ResourceListDataSourceBasic(t *testing.T) {
+	data := acceptance.BuildTestData(t, "data.msgraph_resource_list", "test")
+	r := MSGraphTestResourceListDataSource{}
+
+	data.DataSourceTest(t, []resource.TestStep{
+		{
+			Config: r.basic(),
+			Check: resource.ComposeTestCheckFunc(
+				check.That(data.ResourceName).Key("id").HasValue("servicePrincipals"),
+				check.That(data.ResourceName).Key("output.#").Exists(),
+			),
+		},
+	})
+}
+
+func TestAcc_ResourceListDataSourceMaxResults(t *testing.T) {
+	data := acceptance.BuildTestData(t, "data.msgraph_resource_list", "test")
+	r := MSGraphTestResourceListDataSource{}
+
+	data.DataSourceTest(t, []resource.TestStep{
+		{
+			Config: r.maxResults(),
+			Check: resource.ComposeTestCheckFunc(
+				check.That(data.ResourceName).Key("output.#").HasValue("3"),
+			),
+		},
+	})
+}
+
+func TestAcc_ResourceListDataSourceCount(t *testing.T) {
+	data := acceptance.BuildTestData(t, "data.msgraph_resource_list", "test")
+	r := MSGraphTestResourceListDataSource{}
+
+	data.DataSourceTest(t, []resource.TestStep{
+		{
+			Config: r.count(),
+			Check: resource.ComposeTestCheckFunc(
+				check.That(data.ResourceName).Key("total_count").MatchesRegex(regexp.MustCompile(`^[0-9]+$`)),
+			),
+		},
+	})
+}
+
+func (r MSGraphTestResourceListDataSource) basic() string {
+	return `
+data "msgraph_resource_list" "test" {
+  url = "servicePrincipals"
+  query_parameters = {
+    "$select" = ["id", "appId"]
+    "$top"    = ["100"]
+  }
+}
+`
+}
+
+func (r MSGraphTestResourceListDataSource) maxResults() string {
+	return `
+data "msgraph_resource_list" "test" {
+  url = "servicePrincipals"
+  query_parameters = {
+    "$select" = ["id"]
+    "$top"    = ["1"]
+  }
+  max_results = 3
+}
+`
+}
+
+func (r MSGraphTestResourceListDataSource) count() string {
+	return `
+data "msgraph_resource_list" "test" {
+  url = "groups"
+  headers = {
+    ConsistencyLevel = "eventual"
+  }
+  query_parameters = {
+    "$count"  = ["true"]
+    "$select" = ["id"]
+  }
+}
+`
+}
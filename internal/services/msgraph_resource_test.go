@@ -3,12 +3,17 @@ package services_test
 import (
 	"context"
 	"fmt"
+	"net/http"
+	"os"
 	"regexp"
 	"strings"
 	"testing"
 
 	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+	"github.com/hashicorp/terraform-plugin-testing/knownvalue"
+	"github.com/hashicorp/terraform-plugin-testing/plancheck"
 	"github.com/hashicorp/terraform-plugin-testing/terraform"
+	"github.com/hashicorp/terraform-plugin-testing/tfjsonpath"
 	"github.com/microsoft/terraform-provider-msgraph/internal/acceptance"
 	"github.com/microsoft/terraform-provider-msgraph/internal/acceptance/check"
 	"github.com/microsoft/terraform-provider-msgraph/internal/clients"
@@ -84,6 +89,7 @@ func TestAcc_ResourceGroupMember(t *testing.T) {
 				check.That(data.ResourceName).Key("id").IsUUID(),
 				check.That(data.ResourceName).Key("id").MatchesOtherKey(check.That("msgraph_resource.servicePrincipal_application").Key("id")),
 				check.That(data.ResourceName).Key("resource_url").MatchesRegex(regexp.MustCompile(`^groups/[a-f0-9\-]+/members/[a-f0-9\-]+$`)),
+				check.That(data.ResourceName).Key("resource_id").MatchesRegex(regexp.MustCompile(`^groups/[a-f0-9\-]+/members/[a-f0-9\-]+/\$ref\?api-version=beta$`)),
 			),
 		},
 		importStep,
@@ -138,6 +144,83 @@ func TestAcc_ResourceGroupOwnerBind_UpdateDisplayName(t *testing.T) {
 	})
 }
 
+func TestAcc_ResourceGroupOwnerBind_ExpandBodyNavigations(t *testing.T) {
+	data := acceptance.BuildTestData(t, "msgraph_resource", "test")
+
+	r := MSGraphTestResource{}
+
+	data.ResourceTest(t, r, []resource.TestStep{
+		{
+			Config: r.groupOwnerBindExpandBodyNavigations("My Group Owners Bind"),
+			Check: resource.ComposeTestCheckFunc(
+				check.That(data.ResourceName).Exists(r),
+				check.That(data.ResourceName).Key("id").IsUUID(),
+				check.That(data.ResourceName).Key("expand_body_navigations").HasValue("true"),
+			),
+		},
+		{
+			Config: r.groupOwnerBindExpandBodyNavigations("My Group Owners Bind Updated"),
+			Check: resource.ComposeTestCheckFunc(
+				check.That(data.ResourceName).Exists(r),
+				check.That(data.ResourceName).Key("id").IsUUID(),
+			),
+		},
+		data.ImportStepWithImportStateIdFunc(r.ImportIdFunc, append(defaultIgnores(), "expand_body_navigations")...),
+	})
+}
+
+func TestAcc_ResourceGroupOwnerBind_GranularReferenceUpdates(t *testing.T) {
+	data := acceptance.BuildTestData(t, "msgraph_resource", "test")
+
+	r := MSGraphTestResource{}
+
+	data.ResourceTest(t, r, []resource.TestStep{
+		{
+			Config: r.groupOwnerBindGranularReferenceUpdates([]string{"first"}),
+			Check: resource.ComposeTestCheckFunc(
+				check.That(data.ResourceName).Exists(r),
+				check.That(data.ResourceName).Key("granular_reference_updates").HasValue("true"),
+			),
+		},
+		{
+			Config: r.groupOwnerBindGranularReferenceUpdates([]string{"first", "second"}),
+			Check: resource.ComposeTestCheckFunc(
+				check.That(data.ResourceName).Exists(r),
+			),
+		},
+		{
+			Config: r.groupOwnerBindGranularReferenceUpdates([]string{"second"}),
+			Check: resource.ComposeTestCheckFunc(
+				check.That(data.ResourceName).Exists(r),
+			),
+		},
+	})
+}
+
+func TestAcc_ResourceAcceptableErrorCodes(t *testing.T) {
+	data := acceptance.BuildTestData(t, "msgraph_resource", "test")
+
+	r := MSGraphTestResource{}
+
+	data.ResourceTest(t, r, []resource.TestStep{
+		{
+			Config: r.withAcceptableErrorCodes("Demo App"),
+			Check: resource.ComposeTestCheckFunc(
+				check.That(data.ResourceName).Exists(r),
+				check.That(data.ResourceName).Key("acceptable_error_codes.#").HasValue("1"),
+				check.That(data.ResourceName).Key("acceptable_error_codes.0.status_code").HasValue("404"),
+			),
+		},
+		data.ImportStepWithImportStateIdFunc(r.ImportIdFunc, append(defaultIgnores(), "acceptable_error_codes")...),
+		{
+			Config: r.withAcceptableErrorCodes("Demo App Updated"),
+			Check: resource.ComposeTestCheckFunc(
+				check.That(data.ResourceName).Exists(r),
+			),
+		},
+	})
+}
+
 func TestAcc_ResourceRetry(t *testing.T) {
 	data := acceptance.BuildTestData(t, "msgraph_resource", "test")
 
@@ -156,6 +239,88 @@ func TestAcc_ResourceRetry(t *testing.T) {
 	})
 }
 
+func TestAcc_ResourceRetryStatusCodes(t *testing.T) {
+	data := acceptance.BuildTestData(t, "msgraph_resource", "test")
+
+	r := MSGraphTestResource{}
+
+	data.ResourceTest(t, r, []resource.TestStep{
+		{
+			Config: r.withRetryStatusCodes(),
+			Check: resource.ComposeTestCheckFunc(
+				check.That(data.ResourceName).Exists(r),
+				check.That(data.ResourceName).Key("id").IsUUID(),
+				check.That(data.ResourceName).Key("retry.status_codes.#").HasValue("4"),
+			),
+		},
+		data.ImportStepWithImportStateIdFunc(r.ImportIdFunc, defaultIgnores()...),
+	})
+}
+
+func TestAcc_ResourceRetryBackoff(t *testing.T) {
+	data := acceptance.BuildTestData(t, "msgraph_resource", "test")
+
+	r := MSGraphTestResource{}
+
+	data.ResourceTest(t, r, []resource.TestStep{
+		{
+			Config: r.withRetryBackoff(),
+			Check: resource.ComposeTestCheckFunc(
+				check.That(data.ResourceName).Exists(r),
+				check.That(data.ResourceName).Key("id").IsUUID(),
+				check.That(data.ResourceName).Key("retry.interval_seconds").HasValue("2"),
+				check.That(data.ResourceName).Key("retry.max_interval_seconds").HasValue("30"),
+				check.That(data.ResourceName).Key("retry.multiplier").HasValue("1.5"),
+				check.That(data.ResourceName).Key("retry.randomization_factor").HasValue("0.5"),
+			),
+		},
+		data.ImportStepWithImportStateIdFunc(r.ImportIdFunc, defaultIgnores()...),
+	})
+}
+
+func TestAcc_ResourceRetryIdempotentOnly(t *testing.T) {
+	data := acceptance.BuildTestData(t, "msgraph_resource", "test")
+
+	r := MSGraphTestResource{}
+
+	data.ResourceTest(t, r, []resource.TestStep{
+		{
+			Config: r.withRetryIdempotentOnly(),
+			Check: resource.ComposeTestCheckFunc(
+				check.That(data.ResourceName).Exists(r),
+				check.That(data.ResourceName).Key("retry.idempotent_only").HasValue("true"),
+			),
+		},
+		data.ImportStepWithImportStateIdFunc(r.ImportIdFunc, defaultIgnores()...),
+	})
+}
+
+func TestAcc_ResourceRetryBackoffInvalid(t *testing.T) {
+	data := acceptance.BuildTestData(t, "msgraph_resource", "test")
+
+	r := MSGraphTestResource{}
+
+	data.ResourceTest(t, r, []resource.TestStep{
+		{
+			Config:      r.withRetryBackoffInvalid(),
+			ExpectError: regexp.MustCompile(`Invalid Attribute Value`),
+		},
+	})
+}
+
+func TestAcc_ResourceRetryInvalid(t *testing.T) {
+	data := acceptance.BuildTestData(t, "msgraph_resource", "test")
+
+	r := MSGraphTestResource{}
+
+	data.ResourceTest(t, r, []resource.TestStep{
+		{
+			Config:      r.withRetryEmpty(),
+			ExpectError: regexp.MustCompile(`Invalid Attribute Combination`),
+		},
+	})
+}
+
 func TestAcc_ResourceTimeouts_Create(t *testing.T) {
 	data := acceptance.BuildTestData(t, "msgraph_resource", "test")
 
@@ -221,66 +386,105 @@ func TestAcc_ResourceNamedLocationWithODataType(t *testing.T) {
 	})
 }
 
-func TestAcc_ResourceWithPutUpdateMethod(t *testing.T) {
+func TestAcc_ResourceNamedLocationODataTypePreserved(t *testing.T) {
 	data := acceptance.BuildTestData(t, "msgraph_resource", "test")
 
 	r := MSGraphTestResource{}
 
+	// The API echoes the type as `#microsoft.graph.ipNamedLocation`, which must not be reported as a change.
 	data.ResourceTest(t, r, []resource.TestStep{
 		{
-			Config: r.updateMethod("Example Policy"),
+			Config: r.namedLocationWithODataType("microsoft.graph.ipNamedLocation"),
 			Check: resource.ComposeTestCheckFunc(
 				check.That(data.ResourceName).Exists(r),
-				check.That(data.ResourceName).Key("id").IsUUID(),
+				check.That(data.ResourceName).Key("body.@odata.type").HasValue("microsoft.graph.ipNamedLocation"),
 			),
 		},
 		{
-			Config: r.updateMethod("Updated Example Policy"),
+			Config:   r.namedLocationWithODataType("microsoft.graph.ipNamedLocation"),
+			PlanOnly: true,
+		},
+		data.ImportStepWithImportStateIdFunc(r.ImportIdFunc, defaultIgnores()...),
+	})
+}
+
+func TestAcc_ResourceCountryNamedLocation(t *testing.T) {
+	data := acceptance.BuildTestData(t, "msgraph_resource", "test")
+
+	r := MSGraphTestResource{}
+
+	data.ResourceTest(t, r, []resource.TestStep{
+		{
+			Config: r.countryNamedLocation("Example Country Location", []string{"US", "GB", "DE"}, false),
 			Check: resource.ComposeTestCheckFunc(
 				check.That(data.ResourceName).Exists(r),
 				check.That(data.ResourceName).Key("id").IsUUID(),
 			),
 		},
+		data.ImportStepWithImportStateIdFunc(r.ImportIdFunc, defaultIgnores()...),
+		{
+			// reordering the countries is not a change
+			Config: r.countryNamedLocation("Example Country Location", []string{"DE", "US", "GB"}, false),
+			Check: resource.ComposeTestCheckFunc(
+				check.That(data.ResourceName).Exists(r),
+			),
+		},
+		{
+			Config: r.countryNamedLocation("Updated Country Location", []string{"GB", "FR"}, true),
+			Check: resource.ComposeTestCheckFunc(
+				check.That(data.ResourceName).Exists(r),
+				check.That(data.ResourceName).Key("body.@odata.type").HasValue("#microsoft.graph.countryNamedLocation"),
+			),
+		},
 	})
 }
 
-func TestAcc_ResourceImport_InvalidIDFormat(t *testing.T) {
+func TestAcc_ResourceConditionalAccessPolicyControls(t *testing.T) {
 	data := acceptance.BuildTestData(t, "msgraph_resource", "test")
 
 	r := MSGraphTestResource{}
 
 	data.ResourceTest(t, r, []resource.TestStep{
 		{
-			Config: r.basic(data),
+			Config: r.conditionalAccessPolicy([]string{"mfa", "compliantDevice"}, 4),
 			Check: resource.ComposeTestCheckFunc(
 				check.That(data.ResourceName).Exists(r),
+				check.That(data.ResourceName).Key("id").IsUUID(),
 			),
 		},
 		{
-			ResourceName:      data.ResourceName,
-			ImportState:       true,
-			ImportStateId:     "00000000-0000-0000-0000-000000000000", // Invalid: just an ID without path
-			ExpectError:       regexp.MustCompile(`Invalid Import ID`),
-			ImportStateVerify: false,
+			Config: r.conditionalAccessPolicy([]string{"mfa", "domainJoinedDevice"}, 4),
+			Check: resource.ComposeTestCheckFunc(
+				check.That(data.ResourceName).Exists(r),
+				check.That(data.ResourceName).Key("id").IsUUID(),
+			),
+		},
+		{
+			Config: r.conditionalAccessPolicy([]string{"mfa", "domainJoinedDevice"}, 8),
+			Check: resource.ComposeTestCheckFunc(
+				check.That(data.ResourceName).Exists(r),
+				check.That(data.ResourceName).Key("id").IsUUID(),
+			),
 		},
+		data.ImportStepWithImportStateIdFunc(r.ImportIdFunc, defaultIgnores()...),
 	})
 }
 
-func TestAcc_ResourceApplicationRemovesPasswordCredentials(t *testing.T) {
+func TestAcc_ResourceWithPutUpdateMethod(t *testing.T) {
 	data := acceptance.BuildTestData(t, "msgraph_resource", "test")
 
 	r := MSGraphTestResource{}
 
 	data.ResourceTest(t, r, []resource.TestStep{
 		{
-			Config: r.applicationWithPasswordCredentials(true),
+			Config: r.updateMethod("Example Policy"),
 			Check: resource.ComposeTestCheckFunc(
 				check.That(data.ResourceName).Exists(r),
 				check.That(data.ResourceName).Key("id").IsUUID(),
 			),
 		},
 		{
-			Config: r.applicationWithPasswordCredentials(false),
+			Config: r.updateMethod("Updated Example Policy"),
 			Check: resource.ComposeTestCheckFunc(
 				check.That(data.ResourceName).Exists(r),
 				check.That(data.ResourceName).Key("id").IsUUID(),
@@ -289,94 +493,2702 @@ func TestAcc_ResourceApplicationRemovesPasswordCredentials(t *testing.T) {
 	})
 }
 
-func (r MSGraphTestResource) Exists(ctx context.Context, client *clients.Client, state *terraform.InstanceState) (*bool, error) {
-	apiVersion := state.Attributes["api_version"]
-	url := state.Attributes["url"]
-
-	if strings.Contains(url, "/$ref") {
-		collectionUrl := strings.TrimSuffix(url, "/$ref")
-		referenceIds, err := client.MSGraphClient.ListRefIDs(ctx, collectionUrl, "beta", clients.DefaultRequestOptions())
+func TestAcc_ResourceWithPutMerge(t *testing.T) {
+	data := acceptance.BuildTestData(t, "msgraph_resource", "test")
 
-		found := false
-		if err != nil {
-			return &found, err
-		}
-		// Check if state.ID exists in the responseBody
-		for _, refId := range referenceIds {
-			if refId == state.ID {
-				found = true
-				break
-			}
-		}
-		return &found, nil
-	}
+	r := MSGraphTestResource{}
 
-	checkUrl := fmt.Sprintf("%s/%s", url, state.ID)
-	_, err := client.MSGraphClient.Read(ctx, checkUrl, apiVersion, clients.DefaultRequestOptions())
-	if err == nil {
-		b := true
-		return &b, nil
-	}
-	if utils.ResponseErrorWasNotFound(err) {
-		b := false
-		return &b, nil
-	}
-	return nil, fmt.Errorf("checking for presence of existing %s(api_version=%s) resource: %w", state.ID, apiVersion, err)
+	data.ResourceTest(t, r, []resource.TestStep{
+		{
+			Config: r.updateMethodWithPutMerge("Example Policy"),
+			Check: resource.ComposeTestCheckFunc(
+				check.That(data.ResourceName).Exists(r),
+				check.That(data.ResourceName).Key("put_merge").HasValue("true"),
+			),
+		},
+		{
+			Config: r.updateMethodWithPutMerge("Updated Example Policy"),
+			Check: resource.ComposeTestCheckFunc(
+				check.That(data.ResourceName).Exists(r),
+				check.That(data.ResourceName).Key("id").IsUUID(),
+			),
+		},
+	})
 }
 
-func (r MSGraphTestResource) ImportIdFuncWithBetaApiVersion(tfState *terraform.State) (string, error) {
-	state := tfState.RootModule().Resources["msgraph_resource.test"].Primary
-	url := state.Attributes["url"]
-	if !strings.Contains(url, "/$ref") {
-		return fmt.Sprintf("%s/%s?api-version=beta", url, state.ID), nil
-	}
-	return strings.ReplaceAll(url, "/$ref", fmt.Sprintf("/%s/$ref", state.ID)) + "?api-version=beta", nil
-}
+func TestAcc_ResourceFullBodySync(t *testing.T) {
+	data := acceptance.BuildTestData(t, "msgraph_resource", "test")
 
-func (r MSGraphTestResource) ImportIdFunc(tfState *terraform.State) (string, error) {
-	state := tfState.RootModule().Resources["msgraph_resource.test"].Primary
-	url := state.Attributes["url"]
-	if !strings.Contains(url, "/$ref") {
-		return fmt.Sprintf("%s/%s", url, state.ID), nil
-	}
-	return strings.ReplaceAll(url, "/$ref", fmt.Sprintf("/%s/$ref", state.ID)), nil
+	r := MSGraphTestResource{}
+
+	data.ResourceTest(t, r, []resource.TestStep{
+		{
+			Config: r.fullBodySync("Demo App"),
+			Check: resource.ComposeTestCheckFunc(
+				check.That(data.ResourceName).Exists(r),
+				check.That(data.ResourceName).Key("full_body_sync").HasValue("true"),
+			),
+		},
+		{
+			Config: r.fullBodySync("Updated Demo App"),
+			Check: resource.ComposeTestCheckFunc(
+				check.That(data.ResourceName).Exists(r),
+			),
+		},
+		data.ImportStepWithImportStateIdFunc(r.ImportIdFunc, append(defaultIgnores(), "full_body_sync")...),
+	})
 }
 
-func (r MSGraphTestResource) basic(data acceptance.TestData) string {
-	return `
-resource "msgraph_resource" "test" {
-  url = "applications"
+func TestAcc_ResourceRequestHeaders(t *testing.T) {
+	data := acceptance.BuildTestData(t, "msgraph_resource", "test")
+
+	r := MSGraphTestResource{}
+
+	data.ResourceTest(t, r, []resource.TestStep{
+		{
+			Config: r.requestHeaders("Demo App"),
+			Check: resource.ComposeTestCheckFunc(
+				check.That(data.ResourceName).Exists(r),
+				check.That(data.ResourceName).Key("id").IsUUID(),
+			),
+		},
+		{
+			Config: r.requestHeaders("Updated Demo App"),
+			Check: resource.ComposeTestCheckFunc(
+				check.That(data.ResourceName).Exists(r),
+				check.That(data.ResourceName).Key("id").IsUUID(),
+			),
+		},
+		data.ImportStepWithImportStateIdFunc(r.ImportIdFunc, append(defaultIgnores(), "request_headers")...),
+	})
+}
+
+func TestAcc_ResourceOutputFormatJSONString(t *testing.T) {
+	data := acceptance.BuildTestData(t, "msgraph_resource", "test")
+
+	r := MSGraphTestResource{}
+
+	data.ResourceTest(t, r, []resource.TestStep{
+		{
+			Config: r.outputFormat("json_string"),
+			Check: resource.ComposeTestCheckFunc(
+				check.That(data.ResourceName).Exists(r),
+				check.That(data.ResourceName).Key("output_format").HasValue("json_string"),
+				check.That(data.ResourceName).Key("output").MatchesRegex(regexp.MustCompile(`^\{"appId":"[a-f0-9\-]+"\}$`)),
+			),
+		},
+		data.ImportStepWithImportStateIdFunc(r.ImportIdFunc, append(defaultIgnores(), "output_format")...),
+		{
+			Config: r.outputFormat("typed"),
+			Check: resource.ComposeTestCheckFunc(
+				check.That(data.ResourceName).Exists(r),
+				check.That(data.ResourceName).Key("output.appId").IsUUID(),
+			),
+		},
+	})
+}
+
+func TestAcc_ResourceIgnoreCasing(t *testing.T) {
+	data := acceptance.BuildTestData(t, "msgraph_resource", "test")
+
+	r := MSGraphTestResource{}
+
+	data.ResourceTest(t, r, []resource.TestStep{
+		{
+			Config: r.ignoreCasing("Demo App"),
+			Check: resource.ComposeTestCheckFunc(
+				check.That(data.ResourceName).Exists(r),
+				check.That(data.ResourceName).Key("ignore_casing").HasValue("true"),
+			),
+		},
+		{
+			Config: r.ignoreCasing("Updated Demo App"),
+			Check: resource.ComposeTestCheckFunc(
+				check.That(data.ResourceName).Exists(r),
+			),
+		},
+		data.ImportStepWithImportStateIdFunc(r.ImportIdFunc, append(defaultIgnores(), "ignore_casing")...),
+	})
+}
+
+func TestAcc_ResourceGroupSettings(t *testing.T) {
+	data := acceptance.BuildTestData(t, "msgraph_resource", "test")
+
+	r := MSGraphTestResource{}
+
+	data.ResourceTest(t, r, []resource.TestStep{
+		{
+			Config: r.groupSettings("true"),
+			Check: resource.ComposeTestCheckFunc(
+				check.That(data.ResourceName).Exists(r),
+				check.That(data.ResourceName).Key("id").IsUUID(),
+			),
+		},
+		{
+			Config: r.groupSettings("false"),
+			Check: resource.ComposeTestCheckFunc(
+				check.That(data.ResourceName).Exists(r),
+			),
+		},
+		data.ImportStepWithImportStateIdFunc(r.ImportIdFunc, defaultIgnores()...),
+	})
+}
+
+func TestAcc_ResourceCrossTenantAccessPartner(t *testing.T) {
+	data := acceptance.BuildTestData(t, "msgraph_resource", "test")
+
+	r := MSGraphTestResource{}
+
+	data.ResourceTest(t, r, []resource.TestStep{
+		{
+			Config: r.crossTenantAccessPartner(true),
+			Check: resource.ComposeTestCheckFunc(
+				check.That(data.ResourceName).Exists(r),
+				check.That(data.ResourceName).Key("id").HasValue(crossTenantAccessPartnerTenantId),
+				check.That(data.ResourceName).Key("resource_url").HasValue("policies/crossTenantAccessPolicy/partners/"+crossTenantAccessPartnerTenantId),
+			),
+		},
+		data.ImportStepWithImportStateIdFunc(r.ImportIdFunc, append(defaultIgnores(), "id_attribute")...),
+		{
+			Config: r.crossTenantAccessPartner(false),
+			Check: resource.ComposeTestCheckFunc(
+				check.That(data.ResourceName).Exists(r),
+				check.That(data.ResourceName).Key("id").HasValue(crossTenantAccessPartnerTenantId),
+			),
+		},
+		data.ImportStepWithImportStateIdFunc(r.ImportIdFunc, append(defaultIgnores(), "id_attribute")...),
+	})
+}
+
+func TestAcc_ResourceIdPath(t *testing.T) {
+	data := acceptance.BuildTestData(t, "msgraph_resource", "test")
+
+	r := MSGraphTestResource{}
+
+	data.ResourceTest(t, r, []resource.TestStep{
+		{
+			Config: r.crossTenantAccessPartnerIdPath(),
+			Check: resource.ComposeTestCheckFunc(
+				check.That(data.ResourceName).Exists(r),
+				check.That(data.ResourceName).Key("id").HasValue(crossTenantAccessPartnerTenantId),
+			),
+		},
+		data.ImportStepWithImportStateIdFunc(r.ImportIdFunc, append(defaultIgnores(), "id_path")...),
+	})
+}
+
+func TestAcc_ResourceIdPathInvalid(t *testing.T) {
+	data := acceptance.BuildTestData(t, "msgraph_resource", "test")
+
+	r := MSGraphTestResource{}
+
+	data.ResourceTest(t, r, []resource.TestStep{
+		{
+			Config:      r.idPathWithRef(),
+			ExpectError: regexp.MustCompile("`id_path` can't be specified when `url` ends with `/\\$ref`"),
+		},
+	})
+}
+
+func TestAcc_ResourceCreateMethodPut(t *testing.T) {
+	data := acceptance.BuildTestData(t, "msgraph_resource", "test")
+
+	r := MSGraphTestResource{}
+
+	data.ResourceTest(t, r, []resource.TestStep{
+		{
+			Config: r.identitySynchronization("Demo Sync"),
+			Check: resource.ComposeTestCheckFunc(
+				check.That(data.ResourceName).Exists(r),
+				check.That(data.ResourceName).Key("id").HasValue("identitySynchronization"),
+				check.That(data.ResourceName).Key("resource_url").HasValue("policies/crossTenantAccessPolicy/partners/"+crossTenantAccessPartnerTenantId+"/identitySynchronization"),
+			),
+		},
+		data.ImportStepWithImportStateIdFunc(r.ImportIdFunc, defaultIgnores()...),
+		{
+			Config: r.identitySynchronization("Demo Sync Updated"),
+			Check: resource.ComposeTestCheckFunc(
+				check.That(data.ResourceName).Exists(r),
+			),
+		},
+	})
+}
+
+func TestAcc_ResourceCreateMethodPutWithRef(t *testing.T) {
+	data := acceptance.BuildTestData(t, "msgraph_resource", "test")
+
+	r := MSGraphTestResource{}
+
+	data.ResourceTest(t, r, []resource.TestStep{
+		{
+			Config:      r.createMethodPutWithRef(),
+			ExpectError: regexp.MustCompile("`create_method` can't be `PUT`"),
+		},
+	})
+}
+
+func TestAcc_ResourceBodyJson(t *testing.T) {
+	data := acceptance.BuildTestData(t, "msgraph_resource", "test")
+
+	r := MSGraphTestResource{}
+
+	data.ResourceTest(t, r, []resource.TestStep{
+		{
+			Config: r.bodyJson("Example Country Location"),
+			Check: resource.ComposeTestCheckFunc(
+				check.That(data.ResourceName).Exists(r),
+				check.That(data.ResourceName).Key("id").IsUUID(),
+			),
+		},
+		data.ImportStepWithImportStateIdFunc(r.ImportIdFunc, append(defaultIgnores(), "body_json")...),
+		{
+			Config: r.bodyJson("Updated Country Location"),
+			Check: resource.ComposeTestCheckFunc(
+				check.That(data.ResourceName).Exists(r),
+			),
+		},
+	})
+}
+
+func TestAcc_ResourceBodyJsonConflictsWithBody(t *testing.T) {
+	data := acceptance.BuildTestData(t, "msgraph_resource", "test")
+
+	r := MSGraphTestResource{}
+
+	data.ResourceTest(t, r, []resource.TestStep{
+		{
+			Config:      r.bodyJsonWithBody(),
+			ExpectError: regexp.MustCompile(`Invalid Attribute Combination`),
+		},
+		{
+			Config:      r.bodyJsonInvalid(),
+			ExpectError: regexp.MustCompile("`body_json` must be valid JSON"),
+		},
+	})
+}
+
+func TestAcc_ResourceEmptyId(t *testing.T) {
+	data := acceptance.BuildTestData(t, "msgraph_resource", "test")
+
+	r := MSGraphTestResource{}
+
+	data.ResourceTest(t, r, []resource.TestStep{
+		{
+			Config:      r.emptyId(),
+			ExpectError: regexp.MustCompile("set `id_attribute` to its name"),
+		},
+	})
+}
+
+func TestAcc_ResourceTeam(t *testing.T) {
+	data := acceptance.BuildTestData(t, "msgraph_resource", "test")
+
+	r := MSGraphTestResource{}
+
+	data.ResourceTest(t, r, []resource.TestStep{
+		{
+			Config: r.team(data),
+			Check: resource.ComposeTestCheckFunc(
+				check.That(data.ResourceName).Exists(r),
+				check.That(data.ResourceName).Key("id").IsUUID(),
+				check.That(data.ResourceName).Key("output.displayName").HasValue(fmt.Sprintf("acctest%s", data.RandomString)),
+			),
+		},
+		data.ImportStepWithImportStateIdFunc(r.ImportIdFunc, defaultIgnores()...),
+	})
+}
+
+func TestAcc_ResourceAbsoluteUrl(t *testing.T) {
+	data := acceptance.BuildTestData(t, "msgraph_resource", "test")
+
+	r := MSGraphTestResource{}
+
+	data.ResourceTest(t, r, []resource.TestStep{
+		{
+			Config:      r.withUrl("https://graph.microsoft.com/v1.0/applications"),
+			ExpectError: regexp.MustCompile(`URL must be relative to the API version`),
+		},
+	})
+}
+
+func TestAcc_ResourceProviderDefaultApiVersion(t *testing.T) {
+	data := acceptance.BuildTestData(t, "msgraph_resource", "test")
+
+	r := MSGraphTestResource{}
+
+	data.ResourceTest(t, r, []resource.TestStep{
+		{
+			Config: r.providerDefaultApiVersion(""),
+			Check: resource.ComposeTestCheckFunc(
+				check.That(data.ResourceName).Exists(r),
+				check.That(data.ResourceName).Key("api_version").HasValue("beta"),
+			),
+		},
+		{
+			Config: r.providerDefaultApiVersion("v1.0"),
+			Check: resource.ComposeTestCheckFunc(
+				check.That(data.ResourceName).Exists(r),
+				check.That(data.ResourceName).Key("api_version").HasValue("v1.0"),
+			),
+		},
+	})
+}
+
+func TestAcc_ResourceRedactPlanPaths(t *testing.T) {
+	data := acceptance.BuildTestData(t, "msgraph_resource", "test")
+
+	r := MSGraphTestResource{}
+
+	data.ResourceTest(t, r, []resource.TestStep{
+		{
+			Config: r.redactPlanPaths(),
+			Check: resource.ComposeTestCheckFunc(
+				check.That(data.ResourceName).Exists(r),
+				check.That(data.ResourceName).Key("redact_plan_paths.#").HasValue("1"),
+				check.That(data.ResourceName).Key("output.web.redirectUris").DoesNotExist(),
+				check.That(data.ResourceName).Key("sensitive_output.web.redirectUris.#").HasValue("1"),
+			),
+		},
+		data.ImportStepWithImportStateIdFunc(r.ImportIdFunc, append(defaultIgnores(), "redact_plan_paths", "response_export_values", "sensitive_output")...),
+	})
+}
+
+func TestAcc_ResourceGroupMembersInParallel(t *testing.T) {
+	data := acceptance.BuildTestData(t, "msgraph_resource", "test")
+
+	r := MSGraphTestResource{}
+
+	data.ResourceTest(t, r, []resource.TestStep{
+		{
+			Config: r.groupMembersInParallel(),
+			Check: resource.ComposeTestCheckFunc(
+				check.That(data.ResourceName).Exists(r),
+				check.That("msgraph_resource.member.0").Exists(r),
+				check.That("msgraph_resource.member.1").Exists(r),
+				check.That("msgraph_resource.member.2").Exists(r),
+			),
+		},
+		data.ImportStepWithImportStateIdFunc(r.ImportIdFunc, append(defaultIgnores(), "lock_id")...),
+	})
+}
+
+func TestAcc_ResourceNullProperty(t *testing.T) {
+	data := acceptance.BuildTestData(t, "msgraph_resource", "test")
+
+	r := MSGraphTestResource{}
+
+	data.ResourceTest(t, r, []resource.TestStep{
+		{
+			Config: r.withDescription(`"Demo App Description"`),
+			Check: resource.ComposeTestCheckFunc(
+				check.That(data.ResourceName).Exists(r),
+				check.That(data.ResourceName).Key("body.description").HasValue("Demo App Description"),
+			),
+		},
+		{
+			Config: r.withDescription("null"),
+			Check: resource.ComposeTestCheckFunc(
+				check.That(data.ResourceName).Exists(r),
+				check.That(data.ResourceName).Key("body.description").IsEmpty(),
+			),
+		},
+	})
+}
+
+func TestAcc_ResourceMe(t *testing.T) {
+	acceptance.PreCheckDelegated(t)
+
+	data := acceptance.BuildTestData(t, "msgraph_resource", "test")
+
+	r := MSGraphTestResource{}
+
+	data.ResourceTest(t, r, []resource.TestStep{
+		{
+			Config: r.meTodoList("Demo List"),
+			Check: resource.ComposeTestCheckFunc(
+				check.That(data.ResourceName).Exists(r),
+				check.That(data.ResourceName).Key("id").IsSet(),
+				check.That(data.ResourceName).Key("resource_url").MatchesRegex(regexp.MustCompile(`^me/todo/lists/.+$`)),
+			),
+		},
+		data.ImportStepWithImportStateIdFunc(r.ImportIdFunc, defaultIgnores()...),
+		{
+			Config: r.meTodoList("Demo List Updated"),
+			Check: resource.ComposeTestCheckFunc(
+				check.That(data.ResourceName).Exists(r),
+				check.That(data.ResourceName).Key("body.displayName").HasValue("Demo List Updated"),
+			),
+		},
+		data.ImportStepWithImportStateIdFunc(r.ImportIdFunc, defaultIgnores()...),
+	})
+}
+
+func TestAcc_ResourceConsistency(t *testing.T) {
+	data := acceptance.BuildTestData(t, "msgraph_resource", "test")
+
+	r := MSGraphTestResource{}
+
+	data.ResourceTest(t, r, []resource.TestStep{
+		{
+			Config: r.withConsistency(),
+			Check: resource.ComposeTestCheckFunc(
+				check.That(data.ResourceName).Exists(r),
+				check.That(data.ResourceName).Key("consistency.poll_interval_seconds").HasValue("2"),
+				check.That(data.ResourceName).Key("consistency.max_attempts").HasValue("30"),
+			),
+		},
+		data.ImportStepWithImportStateIdFunc(r.ImportIdFunc, append(defaultIgnores(), "consistency")...),
+	})
+}
+
+func TestAcc_ResourceConsistencyInvalid(t *testing.T) {
+	data := acceptance.BuildTestData(t, "msgraph_resource", "test")
+
+	r := MSGraphTestResource{}
+
+	data.ResourceTest(t, r, []resource.TestStep{
+		{
+			Config:      r.withConsistencyMaxAttempts(2),
+			ExpectError: regexp.MustCompile(`Invalid Attribute Value`),
+		},
+	})
+}
+
+func TestAcc_ResourceResponseExportValuesCurrentNode(t *testing.T) {
+	data := acceptance.BuildTestData(t, "msgraph_resource", "test")
+
+	r := MSGraphTestResource{}
+
+	data.ResourceTest(t, r, []resource.TestStep{
+		{
+			Config: r.withResponseExportValuesCurrentNode(),
+			Check: resource.ComposeTestCheckFunc(
+				check.That(data.ResourceName).Exists(r),
+				check.That(data.ResourceName).Key("output.full.displayName").HasValue("Demo App"),
+				check.That(data.ResourceName).Key("output.full.id").IsUUID(),
+				check.That(data.ResourceName).Key("output.full.appId").IsUUID(),
+				check.That(data.ResourceName).Key("output.app_id").IsUUID(),
+			),
+		},
+		data.ImportStepWithImportStateIdFunc(r.ImportIdFunc, append(defaultIgnores(), "response_export_values")...),
+	})
+}
+
+func TestAcc_ResourcePrecheckExistsFilter(t *testing.T) {
+	data := acceptance.BuildTestData(t, "msgraph_resource", "test")
+
+	r := MSGraphTestResource{}
+
+	data.ResourceTest(t, r, []resource.TestStep{
+		{
+			Config: r.withPrecheckExistsFilter(false),
+			Check: resource.ComposeTestCheckFunc(
+				check.That(data.ResourceName).Exists(r),
+				check.That(data.ResourceName).Key("precheck_exists_filter").HasValue("mailNickname eq 'mygroup-precheck'"),
+			),
+		},
+		data.ImportStepWithImportStateIdFunc(r.ImportIdFunc, append(defaultIgnores(), "precheck_exists_filter")...),
+		{
+			Config:      r.withPrecheckExistsFilter(true),
+			ExpectError: regexp.MustCompile(`Resource already exists`),
+		},
+	})
+}
+
+func TestAcc_ResourcePrecheckExistsFilterInvalid(t *testing.T) {
+	data := acceptance.BuildTestData(t, "msgraph_resource", "test")
+
+	r := MSGraphTestResource{}
+
+	data.ResourceTest(t, r, []resource.TestStep{
+		{
+			Config:      r.withPrecheckExistsFilterPut(),
+			ExpectError: regexp.MustCompile("`precheck_exists_filter` can only be used"),
+		},
+	})
+}
+
+func TestAcc_ResourceCreateRetry(t *testing.T) {
+	data := acceptance.BuildTestData(t, "msgraph_resource", "test")
+
+	r := MSGraphTestResource{}
+
+	data.ResourceTest(t, r, []resource.TestStep{
+		{
+			Config: r.withCreateRetry(),
+			Check: resource.ComposeTestCheckFunc(
+				check.That(data.ResourceName).Exists(r),
+				check.That(data.ResourceName).Key("create_retry.error_codes.#").HasValue("1"),
+				check.That(data.ResourceName).Key("create_retry.max_attempts").HasValue("5"),
+			),
+		},
+		data.ImportStepWithImportStateIdFunc(r.ImportIdFunc, append(defaultIgnores(), "create_retry")...),
+	})
+}
+
+func TestAcc_ResourceSensitiveOutputPathPatterns(t *testing.T) {
+	data := acceptance.BuildTestData(t, "msgraph_resource", "test")
+
+	r := MSGraphTestResource{}
+
+	data.ResourceTest(t, r, []resource.TestStep{
+		{
+			Config: r.withSensitiveOutputPathPatterns(),
+			Check: resource.ComposeTestCheckFunc(
+				check.That(data.ResourceName).Exists(r),
+				check.That(data.ResourceName).Key("output.display_name").HasValue("Demo App"),
+				check.That(data.ResourceName).Key("output.app_id").DoesNotExist(),
+				check.That(data.ResourceName).Key("sensitive_output.app_id").IsUUID(),
+			),
+		},
+		data.ImportStepWithImportStateIdFunc(r.ImportIdFunc, append(defaultIgnores(), "response_export_values", "sensitive_output", "sensitive_output_path_patterns")...),
+	})
+}
+
+func TestAcc_ResourceReadExpand(t *testing.T) {
+	data := acceptance.BuildTestData(t, "msgraph_resource", "test")
+
+	r := MSGraphTestResource{}
+
+	data.ResourceTest(t, r, []resource.TestStep{
+		{
+			Config: r.withReadExpand(),
+			Check: resource.ComposeTestCheckFunc(
+				check.That(data.ResourceName).Exists(r),
+				check.That(data.ResourceName).Key("output.owners.#").Exists(),
+				check.That(data.ResourceName).Key("body.owners").DoesNotExist(),
+			),
+		},
+		{
+			Config:   r.withReadExpand(),
+			PlanOnly: true,
+		},
+	})
+}
+
+func TestAcc_ResourceWriteOnlyBody(t *testing.T) {
+	data := acceptance.BuildTestData(t, "msgraph_resource", "test")
+
+	r := MSGraphTestResource{}
+
+	data.ResourceTest(t, r, []resource.TestStep{
+		{
+			Config: r.withWriteOnlyBody(data, "P@ssw0rd-1-Terraform", 1),
+			Check: resource.ComposeTestCheckFunc(
+				check.That(data.ResourceName).Exists(r),
+				check.That(data.ResourceName).Key("write_only_body").DoesNotExist(),
+				check.That(data.ResourceName).Key("body.passwordProfile").DoesNotExist(),
+				check.That(data.ResourceName).Key("output.all.passwordProfile").DoesNotExist(),
+			),
+		},
+		{
+			// The value isn't in the state, so it's only sent again when its version is changed.
+			Config: r.withWriteOnlyBody(data, "P@ssw0rd-2-Terraform", 2),
+			ConfigPlanChecks: resource.ConfigPlanChecks{
+				PreApply: []plancheck.PlanCheck{
+					plancheck.ExpectResourceAction(data.ResourceName, plancheck.ResourceActionUpdate),
+				},
+			},
+			Check: resource.ComposeTestCheckFunc(
+				check.That(data.ResourceName).Exists(r),
+				check.That(data.ResourceName).Key("write_only_body").DoesNotExist(),
+				check.That(data.ResourceName).Key("write_only_body_version").HasValue("2"),
+				check.That(data.ResourceName).Key("body.passwordProfile").DoesNotExist(),
+			),
+		},
+		data.ImportStepWithImportStateIdFunc(r.ImportIdFunc, append(defaultIgnores(), "write_only_body", "write_only_body_version", "response_export_values")...),
+	})
+}
+
+func TestAcc_ResourceFederatedIdentityCredentials(t *testing.T) {
+	data := acceptance.BuildTestData(t, "msgraph_resource", "test")
+
+	r := MSGraphTestResource{}
+
+	data.ResourceTest(t, r, []resource.TestStep{
+		{
+			Config: r.federatedIdentityCredentials("main", "My credential"),
+			Check: resource.ComposeTestCheckFunc(
+				check.That(data.ResourceName).Exists(r),
+				check.That(data.ResourceName).Key("id").IsUUID(),
+				check.That("msgraph_resource.other").Key("id").IsUUID(),
+			),
+		},
+		data.ImportStepWithImportStateIdFunc(r.ImportIdFunc, defaultIgnores()...),
+		{
+			Config: r.federatedIdentityCredentials("main", "My updated credential"),
+			ConfigPlanChecks: resource.ConfigPlanChecks{
+				PreApply: []plancheck.PlanCheck{
+					plancheck.ExpectResourceAction(data.ResourceName, plancheck.ResourceActionUpdate),
+				},
+			},
+			Check: resource.ComposeTestCheckFunc(
+				check.That(data.ResourceName).Key("body.description").HasValue("My updated credential"),
+			),
+		},
+		{
+			Config: r.federatedIdentityCredentials("renamed", "My updated credential"),
+			ConfigPlanChecks: resource.ConfigPlanChecks{
+				PreApply: []plancheck.PlanCheck{
+					plancheck.ExpectResourceAction(data.ResourceName, plancheck.ResourceActionReplace),
+					plancheck.ExpectResourceAction("msgraph_resource.other", plancheck.ResourceActionNoop),
+				},
+			},
+			Check: resource.ComposeTestCheckFunc(
+				check.That(data.ResourceName).Key("body.name").HasValue("renamed"),
+			),
+		},
+	})
+}
+
+func TestAcc_ResourceUpdateReturnRepresentation(t *testing.T) {
+	data := acceptance.BuildTestData(t, "msgraph_resource", "test")
+
+	r := MSGraphTestResource{}
+
+	data.ResourceTest(t, r, []resource.TestStep{
+		{
+			Config: r.returnRepresentation("Demo App"),
+			Check: resource.ComposeTestCheckFunc(
+				check.That(data.ResourceName).Exists(r),
+				check.That(data.ResourceName).Key("output.displayName").HasValue("Demo App"),
+			),
+		},
+		{
+			Config: r.returnRepresentation("Updated Demo App"),
+			Check: resource.ComposeTestCheckFunc(
+				check.That(data.ResourceName).Key("output.displayName").HasValue("Updated Demo App"),
+			),
+		},
+		{
+			Config:   r.returnRepresentation("Updated Demo App"),
+			PlanOnly: true,
+		},
+		data.ImportStepWithImportStateIdFunc(r.ImportIdFunc, append(defaultIgnores(), "request_headers", "response_export_values")...),
+	})
+}
+
+func TestAcc_ResourcePreferReturnRepresentation(t *testing.T) {
+	data := acceptance.BuildTestData(t, "msgraph_resource", "test")
+
+	r := MSGraphTestResource{}
+
+	data.ResourceTest(t, r, []resource.TestStep{
+		{
+			Config: r.preferReturnRepresentation("Demo App"),
+			Check: resource.ComposeTestCheckFunc(
+				check.That(data.ResourceName).Exists(r),
+				check.That(data.ResourceName).Key("prefer_return_representation").HasValue("true"),
+				check.That(data.ResourceName).Key("output.displayName").HasValue("Demo App"),
+			),
+		},
+		{
+			Config: r.preferReturnRepresentation("Updated Demo App"),
+			Check: resource.ComposeTestCheckFunc(
+				check.That(data.ResourceName).Key("output.displayName").HasValue("Updated Demo App"),
+			),
+		},
+		{
+			Config:   r.preferReturnRepresentation("Updated Demo App"),
+			PlanOnly: true,
+		},
+		data.ImportStepWithImportStateIdFunc(r.ImportIdFunc, append(defaultIgnores(), "prefer_return_representation", "response_export_values")...),
+	})
+}
+
+func TestAcc_ResourcePreferReturnRepresentationWithPreferHeader(t *testing.T) {
+	data := acceptance.BuildTestData(t, "msgraph_resource", "test")
+
+	r := MSGraphTestResource{}
+
+	data.ResourceTest(t, r, []resource.TestStep{
+		{
+			Config:      r.preferReturnRepresentationWithPreferHeader("Demo App"),
+			ExpectError: regexp.MustCompile("`prefer_return_representation` can't be `true` when `request_headers` contains"),
+		},
+	})
+}
+
+func TestAcc_ResourceAutoODataType(t *testing.T) {
+	data := acceptance.BuildTestData(t, "msgraph_resource", "test")
+
+	r := MSGraphTestResource{}
+
+	data.ResourceTest(t, r, []resource.TestStep{
+		{
+			Config: r.autoODataType("Demo Location", "US"),
+			Check: resource.ComposeTestCheckFunc(
+				check.That(data.ResourceName).Exists(r),
+				check.That(data.ResourceName).Key("output.odata_type").HasValue("#microsoft.graph.countryNamedLocation"),
+			),
+		},
+		{
+			Config: r.autoODataType("Demo Location", "GB"),
+			Check: resource.ComposeTestCheckFunc(
+				check.That(data.ResourceName).Key("body.countriesAndRegions.0").HasValue("GB"),
+			),
+		},
+		data.ImportStepWithImportStateIdFunc(r.ImportIdFunc, append(defaultIgnores(), "response_export_values", "auto_odata_type")...),
+	})
+}
+
+func TestAcc_ResourceWriteOnceIgnore(t *testing.T) {
+	data := acceptance.BuildTestData(t, "msgraph_resource", "test")
+
+	r := MSGraphTestResource{}
+
+	data.ResourceTest(t, r, []resource.TestStep{
+		{
+			Config: r.writeOnce("mygroup-write-once", "ignore"),
+			Check: resource.ComposeTestCheckFunc(
+				check.That(data.ResourceName).Exists(r),
+				check.That(data.ResourceName).Key("write_once_policy").HasValue("ignore"),
+			),
+		},
+		data.ImportStepWithImportStateIdFunc(r.ImportIdFunc, append(defaultIgnores(), "response_export_values", "write_once_paths")...),
+		{
+			Config: r.writeOnce("mygroup-write-once-changed", "ignore"),
+			ConfigPlanChecks: resource.ConfigPlanChecks{
+				PreApply: []plancheck.PlanCheck{
+					plancheck.ExpectResourceAction(data.ResourceName, plancheck.ResourceActionUpdate),
+				},
+			},
+			Check: resource.ComposeTestCheckFunc(
+				check.That(data.ResourceName).Key("body.mailNickname").HasValue("mygroup-write-once-changed"),
+				check.That(data.ResourceName).Key("output.mailNickname").HasValue("mygroup-write-once"),
+			),
+		},
+	})
+}
+
+func TestAcc_ResourceWriteOnceReplace(t *testing.T) {
+	data := acceptance.BuildTestData(t, "msgraph_resource", "test")
+
+	r := MSGraphTestResource{}
+
+	data.ResourceTest(t, r, []resource.TestStep{
+		{
+			Config: r.writeOnce("mygroup-write-once", "replace"),
+			Check: resource.ComposeTestCheckFunc(
+				check.That(data.ResourceName).Exists(r),
+			),
+		},
+		{
+			Config: r.writeOnce("mygroup-write-once-changed", "replace"),
+			ConfigPlanChecks: resource.ConfigPlanChecks{
+				PreApply: []plancheck.PlanCheck{
+					plancheck.ExpectResourceAction(data.ResourceName, plancheck.ResourceActionReplace),
+				},
+			},
+			Check: resource.ComposeTestCheckFunc(
+				check.That(data.ResourceName).Key("output.mailNickname").HasValue("mygroup-write-once-changed"),
+			),
+		},
+	})
+}
+
+func TestAcc_ResourceTenantId(t *testing.T) {
+	tenantId := os.Getenv("ARM_TEST_OTHER_TENANT_ID")
+	if tenantId == "" {
+		t.Skip("Skipping as `ARM_TEST_OTHER_TENANT_ID` is not specified")
+	}
+
+	data := acceptance.BuildTestData(t, "msgraph_resource", "test")
+
+	r := MSGraphTestResource{}
+
+	data.ResourceTest(t, r, []resource.TestStep{
+		{
+			Config: r.tenantId(tenantId),
+			Check: resource.ComposeTestCheckFunc(
+				check.That(data.ResourceName).Exists(r),
+				check.That(data.ResourceName).Key("tenant_id").HasValue(tenantId),
+			),
+		},
+		data.ImportStepWithImportStateIdFunc(r.ImportIdFunc, defaultIgnores()...),
+	})
+}
+
+func TestAcc_ResourceReadQueryParametersChanged(t *testing.T) {
+	data := acceptance.BuildTestData(t, "msgraph_resource", "test")
+
+	r := MSGraphTestResource{}
+
+	data.ResourceTest(t, r, []resource.TestStep{
+		{
+			Config: r.withReadSelect(nil),
+			Check: resource.ComposeTestCheckFunc(
+				check.That(data.ResourceName).Exists(r),
+				check.That(data.ResourceName).Key("output.app_id").IsUUID(),
+			),
+		},
+		{
+			Config: r.withReadSelect([]string{"id", "displayName"}),
+			ConfigPlanChecks: resource.ConfigPlanChecks{
+				PreApply: []plancheck.PlanCheck{
+					plancheck.ExpectResourceAction(data.ResourceName, plancheck.ResourceActionUpdate),
+				},
+			},
+			Check: resource.ComposeTestCheckFunc(
+				check.That(data.ResourceName).Key("read_query_parameters.$select.#").HasValue("2"),
+			),
+		},
+	})
+}
+
+func TestAcc_ResourceExtensionProperties(t *testing.T) {
+	data := acceptance.BuildTestData(t, "msgraph_resource", "test")
+
+	r := MSGraphTestResource{}
+
+	data.ResourceTest(t, r, []resource.TestStep{
+		{
+			Config: r.extensionProperties("jobGroup"),
+			Check: resource.ComposeTestCheckFunc(
+				check.That(data.ResourceName).Exists(r),
+				check.That(data.ResourceName).Key("body.name").HasValue("jobGroup"),
+				check.That(data.ResourceName).Key("output.attribute_name").MatchesRegex(regexp.MustCompile(`^extension_[0-9a-f]{32}_jobGroup$`)),
+			),
+		},
+		{
+			Config:   r.extensionProperties("jobGroup"),
+			PlanOnly: true,
+		},
+		data.ImportStepWithImportStateIdFunc(r.ImportIdFunc, append(defaultIgnores(), "response_export_values")...),
+		{
+			Config: r.extensionProperties("costCenter"),
+			ConfigPlanChecks: resource.ConfigPlanChecks{
+				PreApply: []plancheck.PlanCheck{
+					plancheck.ExpectResourceAction(data.ResourceName, plancheck.ResourceActionReplace),
+				},
+			},
+			Check: resource.ComposeTestCheckFunc(
+				check.That(data.ResourceName).Key("output.attribute_name").MatchesRegex(regexp.MustCompile(`^extension_[0-9a-f]{32}_costCenter$`)),
+			),
+		},
+	})
+}
+
+func TestAcc_ResourcePhoneMethods(t *testing.T) {
+	data := acceptance.BuildTestData(t, "msgraph_resource", "test")
+
+	r := MSGraphTestResource{}
+
+	data.ResourceTest(t, r, []resource.TestStep{
+		{
+			Config: r.phoneMethods(data, "+1 (206) 555-5555", "mobile"),
+			Check: resource.ComposeTestCheckFunc(
+				check.That(data.ResourceName).Exists(r),
+				check.That(data.ResourceName).Key("id").HasValue("3179e48a-750b-4051-897c-87b9720928f7"),
+				check.That(data.ResourceName).Key("body.phoneNumber").HasValue("+1 (206) 555-5555"),
+			),
+		},
+		{
+			Config:   r.phoneMethods(data, "+1 (206) 555-5555", "mobile"),
+			PlanOnly: true,
+		},
+		data.ImportStepWithImportStateIdFunc(r.ImportIdFunc, defaultIgnores()...),
+		{
+			Config: r.phoneMethods(data, "+1 2065555556", "mobile"),
+			ConfigPlanChecks: resource.ConfigPlanChecks{
+				PreApply: []plancheck.PlanCheck{
+					plancheck.ExpectResourceAction(data.ResourceName, plancheck.ResourceActionUpdate),
+				},
+			},
+			Check: resource.ComposeTestCheckFunc(
+				check.That(data.ResourceName).Exists(r),
+			),
+		},
+		{
+			Config: r.phoneMethods(data, "+1 2065555556", "alternateMobile"),
+			ConfigPlanChecks: resource.ConfigPlanChecks{
+				PreApply: []plancheck.PlanCheck{
+					plancheck.ExpectResourceAction(data.ResourceName, plancheck.ResourceActionReplace),
+				},
+			},
+			Check: resource.ComposeTestCheckFunc(
+				check.That(data.ResourceName).Exists(r),
+				check.That(data.ResourceName).Key("id").HasValue("b6332ec1-7057-4abe-9331-3d72feddfe41"),
+			),
+		},
+	})
+}
+
+func TestAcc_ResourceEmailMethods(t *testing.T) {
+	data := acceptance.BuildTestData(t, "msgraph_resource", "test")
+
+	r := MSGraphTestResource{}
+
+	data.ResourceTest(t, r, []resource.TestStep{
+		{
+			Config: r.emailMethods(data, "Acctest@Example.com"),
+			Check: resource.ComposeTestCheckFunc(
+				check.That(data.ResourceName).Exists(r),
+				check.That(data.ResourceName).Key("id").HasValue("3ddfcfc8-9383-446f-83cc-3ab9be4be18f"),
+			),
+		},
+		{
+			Config:   r.emailMethods(data, "Acctest@Example.com"),
+			PlanOnly: true,
+		},
+		data.ImportStepWithImportStateIdFunc(r.ImportIdFunc, defaultIgnores()...),
+		{
+			Config: r.emailMethods(data, "acctest-updated@example.com"),
+			Check: resource.ComposeTestCheckFunc(
+				check.That(data.ResourceName).Exists(r),
+				check.That(data.ResourceName).Key("body.emailAddress").HasValue("acctest-updated@example.com"),
+			),
+		},
+	})
+}
+
+func TestAcc_ResourceScopedRoleMembers(t *testing.T) {
+	data := acceptance.BuildTestData(t, "msgraph_resource", "test")
+
+	r := MSGraphTestResource{}
+
+	data.ResourceTest(t, r, []resource.TestStep{
+		{
+			Config: r.scopedRoleMembers(data, "user_a"),
+			Check: resource.ComposeTestCheckFunc(
+				check.That(data.ResourceName).Exists(r),
+				check.That(data.ResourceName).Key("body.roleMemberInfo.id").IsUUID(),
+			),
+		},
+		{
+			Config:   r.scopedRoleMembers(data, "user_a"),
+			PlanOnly: true,
+		},
+		data.ImportStepWithImportStateIdFunc(r.ImportIdFunc, defaultIgnores()...),
+		{
+			Config: r.scopedRoleMembers(data, "user_b"),
+			ConfigPlanChecks: resource.ConfigPlanChecks{
+				PreApply: []plancheck.PlanCheck{
+					plancheck.ExpectResourceAction(data.ResourceName, plancheck.ResourceActionReplace),
+				},
+			},
+			Check: resource.ComposeTestCheckFunc(
+				check.That(data.ResourceName).Exists(r),
+			),
+		},
+	})
+}
+
+func TestAcc_ResourceStripBodyPaths(t *testing.T) {
+	data := acceptance.BuildTestData(t, "msgraph_resource", "test")
+
+	r := MSGraphTestResource{}
+
+	data.ResourceTest(t, r, []resource.TestStep{
+		{
+			Config: r.stripBodyPaths("The group of the on-call engineers"),
+			Check: resource.ComposeTestCheckFunc(
+				check.That(data.ResourceName).Exists(r),
+				check.That(data.ResourceName).Key("body._comment").HasValue("The group of the on-call engineers"),
+			),
+		},
+		{
+			Config:   r.stripBodyPaths("The group of the on-call engineers"),
+			PlanOnly: true,
+		},
+		data.ImportStepWithImportStateIdFunc(r.ImportIdFunc, append(defaultIgnores(), "strip_body_paths")...),
+		{
+			Config: r.stripBodyPaths("The group of the engineers on call this week"),
+			ConfigPlanChecks: resource.ConfigPlanChecks{
+				PreApply: []plancheck.PlanCheck{
+					plancheck.ExpectResourceAction(data.ResourceName, plancheck.ResourceActionUpdate),
+				},
+			},
+			Check: resource.ComposeTestCheckFunc(
+				check.That(data.ResourceName).Key("body._comment").HasValue("The group of the engineers on call this week"),
+			),
+		},
+	})
+}
+
+func TestAcc_ResourceDeleteIgnoreStatusCodes(t *testing.T) {
+	data := acceptance.BuildTestData(t, "msgraph_resource", "test")
+
+	r := MSGraphTestResource{}
+
+	data.ResourceTest(t, r, []resource.TestStep{
+		{
+			Config: r.deleteIgnoreStatusCodes(),
+			Check: resource.ComposeTestCheckFunc(
+				check.That(data.ResourceName).Exists(r),
+				check.That(data.ResourceName).Key("delete_ignore_status_codes.#").HasValue("2"),
+			),
+		},
+		data.ImportStepWithImportStateIdFunc(r.ImportIdFunc, append(defaultIgnores(), "delete_ignore_status_codes")...),
+	})
+}
+
+func TestAcc_ResourceRetryInvalidErrorMessageRegex(t *testing.T) {
+	data := acceptance.BuildTestData(t, "msgraph_resource", "test")
+
+	r := MSGraphTestResource{}
+
+	data.ResourceTest(t, r, []resource.TestStep{
+		{
+			Config:      r.withRetryInvalidErrorMessageRegex(),
+			PlanOnly:    true,
+			ExpectError: regexp.MustCompile(`Invalid regular expression`),
+		},
+	})
+}
+
+func TestAcc_ResourceUrlDeepPath(t *testing.T) {
+	data := acceptance.BuildTestData(t, "msgraph_resource", "test")
+
+	r := MSGraphTestResource{}
+
+	data.ResourceTest(t, r, []resource.TestStep{
+		{
+			Config: r.resourceUrlDeepPath(),
+			Check: resource.ComposeTestCheckFunc(
+				check.That(data.ResourceName).Exists(r),
+				check.That(data.ResourceName).Key("resource_url").MatchesRegex(regexp.MustCompile(`^applications/[a-f0-9\-]+/federatedIdentityCredentials/[a-f0-9\-]+$`)),
+				check.That(data.ResourceName).Key("resource_full_url").MatchesRegex(regexp.MustCompile(`^https://graph\.microsoft\.com/v1\.0/applications/[a-f0-9\-]+/federatedIdentityCredentials/[a-f0-9\-]+$`)),
+				check.That("data.msgraph_resource.test").Key("output.name").HasValue("deep-path"),
+			),
+		},
+		data.ImportStepWithImportStateIdFunc(r.ImportIdFunc, defaultIgnores()...),
+	})
+}
+
+func TestAcc_ResourceUrlReference(t *testing.T) {
+	data := acceptance.BuildTestData(t, "msgraph_resource", "test")
+
+	r := MSGraphTestResource{}
+
+	data.ResourceTest(t, r, []resource.TestStep{
+		{
+			Config: r.resourceUrlReference(),
+			Check: resource.ComposeTestCheckFunc(
+				check.That(data.ResourceName).Exists(r),
+				check.That(data.ResourceName).Key("resource_url").MatchesRegex(regexp.MustCompile(`^groups/[a-f0-9\-]+/members/[a-f0-9\-]+$`)),
+				check.That(data.ResourceName).Key("resource_full_url").MatchesRegex(regexp.MustCompile(`^https://graph\.microsoft\.com/v1\.0/groups/[a-f0-9\-]+/members/[a-f0-9\-]+$`)),
+				check.That("data.msgraph_resource.test").Key("output.id").IsUUID(),
+			),
+		},
+	})
+}
+
+func TestAcc_ResourceReferenceReadReferencedObject(t *testing.T) {
+	data := acceptance.BuildTestData(t, "msgraph_resource", "test")
+
+	r := MSGraphTestResource{}
+
+	data.ResourceTest(t, r, []resource.TestStep{
+		{
+			Config: r.referenceReadReferencedObject(data),
+			Check: resource.ComposeTestCheckFunc(
+				check.That(data.ResourceName).Exists(r),
+				check.That(data.ResourceName).Key("read_referenced_object").HasValue("true"),
+				check.That(data.ResourceName).Key("output.displayName").HasValue(fmt.Sprintf("acctest-member-%s", data.RandomString)),
+			),
+		},
+		{
+			Config:   r.referenceReadReferencedObject(data),
+			PlanOnly: true,
+		},
+	})
+}
+
+func TestAcc_ResourceProtectedUrlPatterns(t *testing.T) {
+	data := acceptance.BuildTestData(t, "msgraph_resource", "test")
+
+	r := MSGraphTestResource{}
+
+	data.ResourceTest(t, r, []resource.TestStep{
+		{
+			Config: r.basic(data),
+			Check: resource.ComposeTestCheckFunc(
+				check.That(data.ResourceName).Exists(r),
+			),
+		},
+		{
+			// the application is removed from the configuration, but its URL is protected
+			Config:      r.withProtectedUrlPatterns(`"^applications/"`),
+			ExpectError: regexp.MustCompile("Protected resource"),
+		},
+		{
+			Config: r.withProtectedUrlPatterns(`"^groups/"`),
+		},
+	})
+}
+
+func TestAcc_ResourceReadAfterCreateDisabled(t *testing.T) {
+	data := acceptance.BuildTestData(t, "msgraph_resource", "test")
+
+	r := MSGraphTestResource{}
+
+	data.ResourceTest(t, r, []resource.TestStep{
+		{
+			Config: r.readAfterCreateDisabled(data, "acctest"),
+			Check: resource.ComposeTestCheckFunc(
+				check.That(data.ResourceName).Exists(r),
+				check.That(data.ResourceName).Key("id").IsUUID(),
+				check.That(data.ResourceName).Key("read_after_create").HasValue("false"),
+				check.That(data.ResourceName).Key("output.%").HasValue("0"),
+			),
+		},
+		{
+			Config: r.readAfterCreateDisabled(data, "acctest-updated"),
+			Check: resource.ComposeTestCheckFunc(
+				check.That(data.ResourceName).Exists(r),
+				check.That(data.ResourceName).Key("output.%").HasValue("0"),
+			),
+		},
+	})
+}
+
+func TestAcc_ResourceBodyJsonSemanticallyEqual(t *testing.T) {
+	data := acceptance.BuildTestData(t, "msgraph_resource", "test")
+
+	r := MSGraphTestResource{}
+
+	data.ResourceTest(t, r, []resource.TestStep{
+		{
+			Config: r.bodyJson("Example Country Location"),
+			Check: resource.ComposeTestCheckFunc(
+				check.That(data.ResourceName).Exists(r),
+			),
+		},
+		{
+			// The reordered keys and the changed whitespace don't change the object, so the output stays known.
+			Config: r.bodyJsonReordered("Example Country Location"),
+			ConfigPlanChecks: resource.ConfigPlanChecks{
+				PreApply: []plancheck.PlanCheck{
+					plancheck.ExpectResourceAction(data.ResourceName, plancheck.ResourceActionUpdate),
+					plancheck.ExpectKnownValue(data.ResourceName, tfjsonpath.New("output"), knownvalue.NotNull()),
+				},
+			},
+			Check: resource.ComposeTestCheckFunc(
+				check.That(data.ResourceName).Exists(r),
+			),
+		},
+		{
+			Config: r.bodyJsonReordered("Updated Country Location"),
+			ConfigPlanChecks: resource.ConfigPlanChecks{
+				PreApply: []plancheck.PlanCheck{
+					plancheck.ExpectUnknownValue(data.ResourceName, tfjsonpath.New("output")),
+				},
+			},
+			Check: resource.ComposeTestCheckFunc(
+				check.That(data.ResourceName).Exists(r),
+			),
+		},
+	})
+}
+
+func TestAcc_ResourceValidateOnPlan(t *testing.T) {
+	data := acceptance.BuildTestData(t, "msgraph_resource", "test")
+
+	r := MSGraphTestResource{}
+
+	data.ResourceTest(t, r, []resource.TestStep{
+		{
+			// The mail nickname can't contain spaces, which is reported when planning.
+			Config:      r.validateOnPlan(data, "acctest group"),
+			PlanOnly:    true,
+			ExpectError: regexp.MustCompile("Invalid body"),
+		},
+		{
+			Config: r.validateOnPlan(data, "acctest"),
+			Check: resource.ComposeTestCheckFunc(
+				check.That(data.ResourceName).Exists(r),
+				check.That(data.ResourceName).Key("validate_on_plan").HasValue("true"),
+			),
+		},
+		{
+			Config: r.validateOnPlan(data, "acctest-updated"),
+			Check: resource.ComposeTestCheckFunc(
+				check.That(data.ResourceName).Exists(r),
+			),
+		},
+		{
+			// Only the changed display name is validated, the unchanged mail nickname isn't reported as a duplicate.
+			Config: r.validateOnPlanWithDisplayName(data, "acctest-renamed", "acctest-updated"),
+			Check: resource.ComposeTestCheckFunc(
+				check.That(data.ResourceName).Exists(r),
+				check.That(data.ResourceName).Key("body.displayName").HasValue(fmt.Sprintf("acctest-renamed%s", data.RandomString)),
+			),
+		},
+	})
+}
+
+func TestAcc_ResourceResourceIdImport(t *testing.T) {
+	data := acceptance.BuildTestData(t, "msgraph_resource", "test")
+
+	r := MSGraphTestResource{}
+
+	data.ResourceTest(t, r, []resource.TestStep{
+		{
+			Config: r.betaApiVersion(),
+			Check: resource.ComposeTestCheckFunc(
+				check.That(data.ResourceName).Exists(r),
+				check.That(data.ResourceName).Key("resource_id").MatchesRegex(regexp.MustCompile(`^applications/[a-f0-9\-]+\?api-version=beta$`)),
+			),
+		},
+		data.ImportStepWithImportStateIdFunc(r.ImportIdFuncFromResourceId, defaultIgnores()...),
+	})
+}
+
+func TestAcc_ResourceImport_InvalidIDFormat(t *testing.T) {
+	data := acceptance.BuildTestData(t, "msgraph_resource", "test")
+
+	r := MSGraphTestResource{}
+
+	data.ResourceTest(t, r, []resource.TestStep{
+		{
+			Config: r.basic(data),
+			Check: resource.ComposeTestCheckFunc(
+				check.That(data.ResourceName).Exists(r),
+			),
+		},
+		{
+			ResourceName:      data.ResourceName,
+			ImportState:       true,
+			ImportStateId:     "00000000-0000-0000-0000-000000000000", // Invalid: just an ID without path
+			ExpectError:       regexp.MustCompile(`Invalid Import ID`),
+			ImportStateVerify: false,
+		},
+	})
+}
+
+func TestAcc_ResourceApplicationRemovesPasswordCredentials(t *testing.T) {
+	data := acceptance.BuildTestData(t, "msgraph_resource", "test")
+
+	r := MSGraphTestResource{}
+
+	data.ResourceTest(t, r, []resource.TestStep{
+		{
+			Config: r.applicationWithPasswordCredentials(true),
+			Check: resource.ComposeTestCheckFunc(
+				check.That(data.ResourceName).Exists(r),
+				check.That(data.ResourceName).Key("id").IsUUID(),
+			),
+		},
+		{
+			Config: r.applicationWithPasswordCredentials(false),
+			Check: resource.ComposeTestCheckFunc(
+				check.That(data.ResourceName).Exists(r),
+				check.That(data.ResourceName).Key("id").IsUUID(),
+			),
+		},
+	})
+}
+
+func TestAcc_ResourceImport_CollectionID(t *testing.T) {
+	data := acceptance.BuildTestData(t, "msgraph_resource", "test")
+
+	r := MSGraphTestResource{}
+
+	data.ResourceTest(t, r, []resource.TestStep{
+		{
+			Config: r.basic(data),
+			Check: resource.ComposeTestCheckFunc(
+				check.That(data.ResourceName).Exists(r),
+			),
+		},
+		{
+			ResourceName:      data.ResourceName,
+			ImportState:       true,
+			ImportStateId:     "applications", // Invalid: the collection without the id of an item
+			ExpectError:       regexp.MustCompile(`Import ID Refers To A Collection`),
+			ImportStateVerify: false,
+		},
+		{
+			ResourceName:      data.ResourceName,
+			ImportState:       true,
+			ImportStateId:     "/applications",
+			ExpectError:       regexp.MustCompile(`Import ID Refers To A Collection`),
+			ImportStateVerify: false,
+		},
+		{
+			ResourceName:      data.ResourceName,
+			ImportState:       true,
+			ImportStateId:     "identity/conditionalAccess/policies/",
+			ExpectError:       regexp.MustCompile(`Import ID Refers To A Collection`),
+			ImportStateVerify: false,
+		},
+	})
+}
+
+func (r MSGraphTestResource) Exists(ctx context.Context, client *clients.Client, state *terraform.InstanceState) (*bool, error) {
+	apiVersion := state.Attributes["api_version"]
+	url := state.Attributes["url"]
+	ctx = clients.WithTenantId(ctx, state.Attributes["tenant_id"])
+
+	if strings.Contains(url, "/$ref") {
+		collectionUrl := strings.TrimSuffix(url, "/$ref")
+		referenceIds, err := client.MSGraphClient.ListRefIDs(ctx, collectionUrl, "beta", clients.DefaultRequestOptions())
+
+		found := false
+		if err != nil {
+			return &found, err
+		}
+		// Check if state.ID exists in the responseBody
+		for _, refId := range referenceIds {
+			if refId == state.ID {
+				found = true
+				break
+			}
+		}
+		return &found, nil
+	}
+
+	checkUrl := fmt.Sprintf("%s/%s", url, state.ID)
+	if state.Attributes["create_method"] == http.MethodPut {
+		checkUrl = url
+	}
+	_, err := client.MSGraphClient.Read(ctx, checkUrl, apiVersion, clients.DefaultRequestOptions())
+	if err == nil {
+		b := true
+		return &b, nil
+	}
+	if utils.ResponseErrorWasNotFound(err) {
+		b := false
+		return &b, nil
+	}
+	return nil, fmt.Errorf("checking for presence of existing %s(api_version=%s) resource: %w", state.ID, apiVersion, err)
+}
+
+func (r MSGraphTestResource) ImportIdFuncWithBetaApiVersion(tfState *terraform.State) (string, error) {
+	state := tfState.RootModule().Resources["msgraph_resource.test"].Primary
+	url := state.Attributes["url"]
+	if !strings.Contains(url, "/$ref") {
+		return fmt.Sprintf("%s/%s?api-version=beta", url, state.ID), nil
+	}
+	return strings.ReplaceAll(url, "/$ref", fmt.Sprintf("/%s/$ref", state.ID)) + "?api-version=beta", nil
+}
+
+// ImportIdFuncFromResourceId imports the resource with its exported `resource_id`.
+func (r MSGraphTestResource) ImportIdFuncFromResourceId(tfState *terraform.State) (string, error) {
+	return tfState.RootModule().Resources["msgraph_resource.test"].Primary.Attributes["resource_id"], nil
+}
+
+func (r MSGraphTestResource) ImportIdFunc(tfState *terraform.State) (string, error) {
+	state := tfState.RootModule().Resources["msgraph_resource.test"].Primary
+	url := state.Attributes["url"]
+	if state.Attributes["create_method"] == http.MethodPut {
+		return fmt.Sprintf("%s?create_method=PUT", url), nil
+	}
+	if tenantId := state.Attributes["tenant_id"]; tenantId != "" {
+		return fmt.Sprintf("%s/%s?tenant_id=%s", url, state.ID, tenantId), nil
+	}
+	if !strings.Contains(url, "/$ref") {
+		return fmt.Sprintf("%s/%s", url, state.ID), nil
+	}
+	return strings.ReplaceAll(url, "/$ref", fmt.Sprintf("/%s/$ref", state.ID)), nil
+}
+
+func (r MSGraphTestResource) basic(data acceptance.TestData) string {
+	return `
+resource "msgraph_resource" "test" {
+  url = "applications"
+  body = {
+    displayName = "Demo App"
+  }
+}
+`
+}
+
+func (r MSGraphTestResource) requestHeaders(displayName string) string {
+	return fmt.Sprintf(`
+resource "msgraph_resource" "test" {
+  url = "applications"
+  body = {
+    displayName = "%s"
+  }
+  request_headers = {
+    ConsistencyLevel = "eventual"
+    Prefer           = ""
+  }
+}
+`, displayName)
+}
+
+// ignoreCasing configures the Microsoft Graph app ID in upper case, the API returns it in lower case.
+func (r MSGraphTestResource) ignoreCasing(displayName string) string {
+	return fmt.Sprintf(`
+resource "msgraph_resource" "test" {
+  url = "applications"
+  body = {
+    displayName = "%s"
+    requiredResourceAccess = [
+      {
+        resourceAppId = "00000003-0000-0000-C000-000000000000"
+        resourceAccess = [
+          {
+            id   = "E1FE6DD8-BA31-4D61-89E7-88639DA4683D"
+            type = "Scope"
+          }
+        ]
+      }
+    ]
+  }
+  ignore_casing = true
+}
+`, displayName)
+}
+
+// groupSettings configures a subset of the values of the Group.Unified settings template,
+// the API returns all the values of the template.
+func (r MSGraphTestResource) groupSettings(allowGuestsToAccessGroups string) string {
+	return fmt.Sprintf(`
+resource "msgraph_resource" "test" {
+  url = "groupSettings"
+  body = {
+    templateId = "62375ab9-6b52-47ed-826b-58e47e0e304b"
+    values = [
+      {
+        name  = "AllowGuestsToAccessGroups"
+        value = "%s"
+      },
+      {
+        name  = "EnableGroupCreation"
+        value = "true"
+      }
+    ]
+  }
+}
+`, allowGuestsToAccessGroups)
+}
+
+func (r MSGraphTestResource) fullBodySync(displayName string) string {
+	return fmt.Sprintf(`
+resource "msgraph_resource" "test" {
+  url = "applications"
+  body = {
+    displayName = "%s"
+  }
+  full_body_sync = true
+}
+`, displayName)
+}
+
+func (r MSGraphTestResource) basicUpdate(data acceptance.TestData) string {
+	return `
+resource "msgraph_resource" "test" {
+  url = "applications"
+  body = {
+    displayName = "Demo App Updated"
+  }
+}
+`
+}
+
+func (r MSGraphTestResource) groupMember() string {
+	return `
+resource "msgraph_resource" "application" {
+  url = "applications"
+  body = {
+    displayName = "My Application"
+  }
+  response_export_values = {
+    appId = "appId"
+  }
+}
+
+resource "msgraph_resource" "servicePrincipal_application" {
+  url = "servicePrincipals"
+  body = {
+    appId = msgraph_resource.application.output.appId
+  }
+}
+
+resource "msgraph_resource" "group" {
+  url = "groups"
+  body = {
+    displayName     = "My Group"
+    mailEnabled     = false
+    mailNickname    = "mygroup"
+    securityEnabled = true
+  }
+}
+
+resource "msgraph_resource" "test" {
+  url         = "groups/${msgraph_resource.group.id}/members/$ref"
+  api_version = "beta"
+  body = {
+    "@odata.id" = "https://graph.microsoft.com/v1.0/directoryObjects/${msgraph_resource.servicePrincipal_application.id}"
+  }
+}
+`
+}
+
+func (r MSGraphTestResource) groupOwnerBind(displayName string) string {
+	return fmt.Sprintf(`
+resource "msgraph_resource" "application" {
+  url = "applications"
+  body = {
+    displayName = "My Application"
+  }
+  response_export_values = {
+    appId = "appId"
+  }
+}
+
+resource "msgraph_resource" "servicePrincipal_application" {
+  url = "servicePrincipals"
+  body = {
+    appId = msgraph_resource.application.output.appId
+  }
+}
+
+resource "msgraph_resource" "test" {
+  url = "groups"
+  body = {
+    displayName     = "%s"
+    mailEnabled     = false
+    mailNickname    = "mygroup-owners-bind"
+    securityEnabled = true
+    "owners@odata.bind" = [
+      "https://graph.microsoft.com/v1.0/directoryObjects/${msgraph_resource.servicePrincipal_application.id}"
+    ]
+  }
+}
+`, displayName)
+}
+
+func (r MSGraphTestResource) groupOwnerBindExpandBodyNavigations(displayName string) string {
+	return fmt.Sprintf(`
+resource "msgraph_resource" "application" {
+  url = "applications"
+  body = {
+    displayName = "My Application"
+  }
+  response_export_values = {
+    appId = "appId"
+  }
+}
+
+resource "msgraph_resource" "servicePrincipal_application" {
+  url = "servicePrincipals"
+  body = {
+    appId = msgraph_resource.application.output.appId
+  }
+}
+
+resource "msgraph_resource" "test" {
+  url = "groups"
+  body = {
+    displayName     = "%s"
+    mailEnabled     = false
+    mailNickname    = "mygroup-owners-bind"
+    securityEnabled = true
+    "owners@odata.bind" = [
+      "https://graph.microsoft.com/v1.0/servicePrincipals/${msgraph_resource.servicePrincipal_application.id}"
+    ]
+  }
+  expand_body_navigations = true
+}
+`, displayName)
+}
+
+func (r MSGraphTestResource) groupOwnerBindGranularReferenceUpdates(owners []string) string {
+	references := make([]string, 0)
+	for _, owner := range owners {
+		references = append(references, fmt.Sprintf(`"https://graph.microsoft.com/v1.0/servicePrincipals/${msgraph_resource.servicePrincipal_%s.id}"`, owner))
+	}
+	return fmt.Sprintf(`
+resource "msgraph_resource" "application_first" {
+  url = "applications"
+  body = {
+    displayName = "My First Application"
+  }
+  response_export_values = {
+    appId = "appId"
+  }
+}
+
+resource "msgraph_resource" "servicePrincipal_first" {
+  url = "servicePrincipals"
+  body = {
+    appId = msgraph_resource.application_first.output.appId
+  }
+}
+
+resource "msgraph_resource" "application_second" {
+  url = "applications"
+  body = {
+    displayName = "My Second Application"
+  }
+  response_export_values = {
+    appId = "appId"
+  }
+}
+
+resource "msgraph_resource" "servicePrincipal_second" {
+  url = "servicePrincipals"
+  body = {
+    appId = msgraph_resource.application_second.output.appId
+  }
+}
+
+resource "msgraph_resource" "test" {
+  url = "groups"
+  body = {
+    displayName         = "My Group Granular Owners"
+    mailEnabled         = false
+    mailNickname        = "mygroup-granular-owners"
+    securityEnabled     = true
+    "owners@odata.bind" = [%s]
+  }
+  granular_reference_updates = true
+}
+`, strings.Join(references, ", "))
+}
+
+func (r MSGraphTestResource) withAcceptableErrorCodes(displayName string) string {
+	return fmt.Sprintf(`
+resource "msgraph_resource" "test" {
+  url = "applications"
+  body = {
+    displayName = "%s"
+  }
+  acceptable_error_codes = [
+    {
+      status_code = 404
+      error_code  = "Request_ResourceNotFound"
+    },
+  ]
+}
+`, displayName)
+}
+
+func (r MSGraphTestResource) outputFormat(format string) string {
+	return fmt.Sprintf(`
+resource "msgraph_resource" "test" {
+  url = "applications"
+  body = {
+    displayName = "Demo App"
+  }
+  output_format = "%s"
+  response_export_values = {
+    appId = "appId"
+  }
+}
+`, format)
+}
+
+func (r MSGraphTestResource) withRetry() string {
+	return `
+resource "msgraph_resource" "test" {
+  url = "applications"
+  body = {
+    displayName = "Demo App Retry"
+  }
+  retry = {
+    error_message_regex = [
+      "temporary error",
+      ".*throttl.*",
+    ]
+  }
+}`
+}
+
+func (r MSGraphTestResource) withRetryInvalidErrorMessageRegex() string {
+	return `
+resource "msgraph_resource" "test" {
+  url = "applications"
+  body = {
+    displayName = "Demo App Retry"
+  }
+  retry = {
+    error_message_regex = [
+      "temporary error",
+      ".*throttl(.*",
+    ]
+  }
+}`
+}
+
+func (r MSGraphTestResource) withRetryStatusCodes() string {
+	return `
+resource "msgraph_resource" "test" {
+  url = "applications"
+  body = {
+    displayName = "Demo App Retry"
+  }
+  retry = {
+    error_message_regex = [
+      ".*throttl.*",
+    ]
+    status_codes = [429, 502, 503, 504]
+  }
+}`
+}
+
+func (r MSGraphTestResource) withRetryIdempotentOnly() string {
+	return `
+resource "msgraph_resource" "test" {
+  url = "applications"
+  body = {
+    displayName = "Demo App Retry"
+  }
+  retry = {
+    status_codes    = [404, 409]
+    idempotent_only = true
+  }
+}
+`
+}
+
+func (r MSGraphTestResource) withRetryBackoff() string {
+	return `
+resource "msgraph_resource" "test" {
+  url = "applications"
+  body = {
+    displayName = "Demo App Retry"
+  }
+  retry = {
+    interval_seconds     = 2
+    max_interval_seconds = 30
+    multiplier           = 1.5
+    randomization_factor = 0.5
+  }
+}`
+}
+
+func (r MSGraphTestResource) withRetryBackoffInvalid() string {
+	return `
+resource "msgraph_resource" "test" {
+  url = "applications"
+  body = {
+    displayName = "Demo App Retry"
+  }
+  retry = {
+    interval_seconds     = 0
+    randomization_factor = 2
+  }
+}`
+}
+
+func (r MSGraphTestResource) withRetryEmpty() string {
+	return `
+resource "msgraph_resource" "test" {
+  url = "applications"
+  body = {
+    displayName = "Demo App Retry"
+  }
+  retry = {}
+}`
+}
+
+func (r MSGraphTestResource) withCreateTimeout() string {
+	return `
+resource "msgraph_resource" "test" {
+  url = "applications"
+  timeouts {
+    create = "1ns"
+  }
+  body = {
+    displayName = "Demo App Timeout Create"
+  }
+}
+`
+}
+
+func (r MSGraphTestResource) withUpdateTimeout() string {
+	return `
+resource "msgraph_resource" "test" {
+  url = "applications"
+  timeouts {
+    update = "1ns"
+  }
+  body = {
+    displayName = "Demo App Updated Timeout Update"
+  }
+}
+`
+}
+
+func (r MSGraphTestResource) namedLocation(displayName string, cidrAddresses []string) string {
+	ipRangesConfig := ""
+	for i, cidr := range cidrAddresses {
+		if i > 0 {
+			ipRangesConfig += ",\n      "
+		}
+		ipRangesConfig += fmt.Sprintf(`{
+        "@odata.type" = "#microsoft.graph.iPv4CidrRange"
+        cidrAddress   = "%s"
+      }`, cidr)
+	}
+
+	return fmt.Sprintf(`
+resource "msgraph_resource" "test" {
+  url = "identity/conditionalAccess/namedLocations"
+  body = {
+    displayName = "%s"
+    ipRanges = [
+      %s
+    ]
+    isTrusted     = false
+    "@odata.type" = "#microsoft.graph.ipNamedLocation"
+  }
+}
+`, displayName, ipRangesConfig)
+}
+
+func (r MSGraphTestResource) namedLocationWithODataType(odataType string) string {
+	return fmt.Sprintf(`
+resource "msgraph_resource" "test" {
+  url = "identity/conditionalAccess/namedLocations"
+  body = {
+    displayName = "Example Named Location"
+    ipRanges = [
+      {
+        "@odata.type" = "#microsoft.graph.iPv4CidrRange"
+        cidrAddress   = "1.2.3.4/32"
+      }
+    ]
+    isTrusted     = false
+    "@odata.type" = "%s"
+  }
+}
+`, odataType)
+}
+
+func (r MSGraphTestResource) countryNamedLocation(displayName string, countries []string, includeUnknown bool) string {
+	return fmt.Sprintf(`
+resource "msgraph_resource" "test" {
+  url = "identity/conditionalAccess/namedLocations"
+  body = {
+    "@odata.type"                     = "#microsoft.graph.countryNamedLocation"
+    displayName                       = "%s"
+    countriesAndRegions               = ["%s"]
+    includeUnknownCountriesAndRegions = %t
+  }
+}
+`, displayName, strings.Join(countries, `", "`), includeUnknown)
+}
+
+func (r MSGraphTestResource) bodyJson(displayName string) string {
+	return fmt.Sprintf(`
+resource "msgraph_resource" "test" {
+  url       = "identity/conditionalAccess/namedLocations"
+  body_json = <<EOT
+{
+  "@odata.type": "#microsoft.graph.countryNamedLocation",
+  "displayName": "%s",
+  "countriesAndRegions": ["US", "GB"],
+  "includeUnknownCountriesAndRegions": false
+}
+EOT
+}
+`, displayName)
+}
+
+func (r MSGraphTestResource) bodyJsonReordered(displayName string) string {
+	return fmt.Sprintf(`
+resource "msgraph_resource" "test" {
+  url       = "identity/conditionalAccess/namedLocations"
+  body_json = "{\"includeUnknownCountriesAndRegions\":false,\"countriesAndRegions\":[\"US\",\"GB\"],\"displayName\":\"%s\",\"@odata.type\":\"#microsoft.graph.countryNamedLocation\"}"
+}
+`, displayName)
+}
+
+func (r MSGraphTestResource) validateOnPlanWithDisplayName(data acceptance.TestData, displayName, mailNickname string) string {
+	return fmt.Sprintf(`
+resource "msgraph_resource" "test" {
+  url              = "groups"
+  validate_on_plan = true
+  body = {
+    displayName     = "%[2]s%[1]s"
+    mailEnabled     = false
+    mailNickname    = "%[3]s%[1]s"
+    securityEnabled = true
+  }
+}
+`, data.RandomString, displayName, mailNickname)
+}
+
+func (r MSGraphTestResource) validateOnPlan(data acceptance.TestData, mailNickname string) string {
+	return fmt.Sprintf(`
+resource "msgraph_resource" "test" {
+  url              = "groups"
+  validate_on_plan = true
+  body = {
+    displayName     = "acctest%[1]s"
+    mailEnabled     = false
+    mailNickname    = "%[2]s%[1]s"
+    securityEnabled = true
+  }
+}
+`, data.RandomString, mailNickname)
+}
+
+func (r MSGraphTestResource) betaApiVersion() string {
+	return `
+resource "msgraph_resource" "test" {
+  url         = "applications"
+  api_version = "beta"
+  body = {
+    displayName = "Demo App"
+  }
+}
+`
+}
+
+func (r MSGraphTestResource) bodyJsonWithBody() string {
+	return `
+resource "msgraph_resource" "test" {
+  url = "applications"
+  body = {
+    displayName = "Demo App"
+  }
+  body_json = jsonencode({
+    displayName = "Demo App"
+  })
+}
+`
+}
+
+func (r MSGraphTestResource) bodyJsonInvalid() string {
+	return `
+resource "msgraph_resource" "test" {
+  url       = "applications"
+  body_json = "{\"displayName\": "
+}
+`
+}
+
+// emptyId calls getByIds, whose response is a collection without an id.
+func (r MSGraphTestResource) emptyId() string {
+	return `
+resource "msgraph_resource" "application" {
+  url = "applications"
+  body = {
+    displayName = "Demo App"
+  }
+}
+
+resource "msgraph_resource" "test" {
+  url = "directoryObjects/getByIds"
+  body = {
+    ids = [msgraph_resource.application.id]
+  }
+}
+`
+}
+
+// team creates a team from a Microsoft 365 group, the team is created asynchronously and the operation is polled.
+func (r MSGraphTestResource) team(data acceptance.TestData) string {
+	return fmt.Sprintf(`
+resource "msgraph_resource" "group" {
+  url = "groups"
+  body = {
+    displayName     = "acctest%[1]s"
+    groupTypes      = ["Unified"]
+    mailEnabled     = true
+    mailNickname    = "acctest%[1]s"
+    securityEnabled = false
+  }
+}
+
+resource "msgraph_resource" "test" {
+  url = "teams"
+  body = {
+    "template@odata.bind" = "https://graph.microsoft.com/v1.0/teamsTemplates('standard')"
+    "group@odata.bind"    = "https://graph.microsoft.com/v1.0/groups('${msgraph_resource.group.id}')"
+  }
+  response_export_values = {
+    displayName = "displayName"
+  }
+}
+`, data.RandomString)
+}
+
+func (r MSGraphTestResource) withUrl(url string) string {
+	return fmt.Sprintf(`
+resource "msgraph_resource" "test" {
+  url = "%s"
+  body = {
+    displayName = "Demo App"
+  }
+}
+`, url)
+}
+
+func (r MSGraphTestResource) providerDefaultApiVersion(apiVersion string) string {
+	apiVersionConfig := ""
+	if apiVersion != "" {
+		apiVersionConfig = fmt.Sprintf("api_version = %q", apiVersion)
+	}
+	return fmt.Sprintf(`
+provider "msgraph" {
+  default_api_version = "beta"
+}
+
+resource "msgraph_resource" "test" {
+  url = "applications"
+  %s
+  body = {
+    displayName = "Demo App"
+  }
+}
+`, apiVersionConfig)
+}
+
+func (r MSGraphTestResource) redactPlanPaths() string {
+	return `
+resource "msgraph_resource" "test" {
+  url = "applications"
+  body = {
+    displayName = "Demo App"
+    web = {
+      redirectUris = ["https://example.com/auth"]
+    }
+  }
+  response_export_values = {
+    web = "web"
+  }
+  redact_plan_paths = ["web.redirectUris"]
+}
+`
+}
+
+func (r MSGraphTestResource) groupMembersInParallel() string {
+	return `
+resource "msgraph_resource" "group" {
+  url = "groups"
+  body = {
+    displayName     = "My Group"
+    mailEnabled     = false
+    mailNickname    = "mygroup"
+    securityEnabled = true
+  }
+}
+
+resource "msgraph_resource" "application" {
+  count = 4
+  url   = "applications"
+  body = {
+    displayName = "My Application ${count.index}"
+  }
+  response_export_values = {
+    appId = "appId"
+  }
+}
+
+resource "msgraph_resource" "servicePrincipal" {
+  count = 4
+  url   = "servicePrincipals"
+  body = {
+    appId = msgraph_resource.application[count.index].output.appId
+  }
+}
+
+resource "msgraph_resource" "member" {
+  count = 3
+  url   = "groups/${msgraph_resource.group.id}/members/$ref"
+  body = {
+    "@odata.id" = "https://graph.microsoft.com/v1.0/directoryObjects/${msgraph_resource.servicePrincipal[count.index].id}"
+  }
+}
+
+resource "msgraph_resource" "test" {
+  url     = "groups/${msgraph_resource.group.id}/members/$ref"
+  lock_id = "groups/${msgraph_resource.group.id}"
+  body = {
+    "@odata.id" = "https://graph.microsoft.com/v1.0/directoryObjects/${msgraph_resource.servicePrincipal[3].id}"
+  }
+}
+`
+}
+
+func (r MSGraphTestResource) withDescription(description string) string {
+	return fmt.Sprintf(`
+resource "msgraph_resource" "test" {
+  url = "applications"
+  body = {
+    displayName = "Demo App"
+    description = %s
+  }
+}
+`, description)
+}
+
+func (r MSGraphTestResource) meTodoList(displayName string) string {
+	return fmt.Sprintf(`
+resource "msgraph_resource" "test" {
+  url = "me/todo/lists"
+  body = {
+    displayName = "%s"
+  }
+}
+`, displayName)
+}
+
+func (r MSGraphTestResource) withConsistency() string {
+	return `
+resource "msgraph_resource" "test" {
+  url = "applications"
+  body = {
+    displayName = "Demo App"
+  }
+  consistency = {
+    poll_interval_seconds = 2
+    max_attempts          = 30
+  }
+}
+`
+}
+
+func (r MSGraphTestResource) withConsistencyMaxAttempts(maxAttempts int) string {
+	return fmt.Sprintf(`
+resource "msgraph_resource" "test" {
+  url = "applications"
+  body = {
+    displayName = "Demo App"
+  }
+  consistency = {
+    max_attempts = %d
+  }
+}
+`, maxAttempts)
+}
+
+func (r MSGraphTestResource) withResponseExportValuesCurrentNode() string {
+	return `
+resource "msgraph_resource" "test" {
+  url = "applications"
+  body = {
+    displayName = "Demo App"
+  }
+  response_export_values = {
+    full   = "@"
+    app_id = "appId"
+  }
+}
+`
+}
+
+func (r MSGraphTestResource) withPrecheckExistsFilter(duplicate bool) string {
+	config := `
+resource "msgraph_resource" "test" {
+  url = "groups"
+  body = {
+    displayName     = "My Group"
+    mailEnabled     = false
+    mailNickname    = "mygroup-precheck"
+    securityEnabled = true
+  }
+  precheck_exists_filter = "mailNickname eq 'mygroup-precheck'"
+}
+`
+	if duplicate {
+		config += `
+resource "msgraph_resource" "duplicate" {
+  url = "groups"
+  body = {
+    displayName     = "My Group"
+    mailEnabled     = false
+    mailNickname    = "mygroup-precheck"
+    securityEnabled = true
+  }
+  precheck_exists_filter = "mailNickname eq 'mygroup-precheck'"
+  depends_on             = [msgraph_resource.test]
+}
+`
+	}
+	return config
+}
+
+func (r MSGraphTestResource) withPrecheckExistsFilterPut() string {
+	return `
+resource "msgraph_resource" "test" {
+  url           = "users/me/authentication/phoneMethods/3179e48a-750b-4051-897c-87b9720928f7"
+  create_method = "PUT"
+  body = {
+    phoneNumber = "+1 2065555555"
+    phoneType   = "mobile"
+  }
+  precheck_exists_filter = "phoneType eq 'mobile'"
+}
+`
+}
+
+func (r MSGraphTestResource) withCreateRetry() string {
+	return `
+resource "msgraph_resource" "application" {
+  url = "applications"
+  body = {
+    displayName = "My Application"
+  }
+  response_export_values = {
+    appId = "appId"
+  }
+}
+
+resource "msgraph_resource" "test" {
+  url = "servicePrincipals"
+  body = {
+    appId = msgraph_resource.application.output.appId
+  }
+  create_retry = {
+    error_codes      = ["Request_MultipleObjectsWithSameKeyValue"]
+    max_attempts     = 5
+    interval_seconds = 5
+  }
+}
+`
+}
+
+func (r MSGraphTestResource) withSensitiveOutputPathPatterns() string {
+	return `
+resource "msgraph_resource" "test" {
+  url = "applications"
+  body = {
+    displayName = "Demo App"
+  }
+  response_export_values = {
+    app_id       = "appId"
+    display_name = "displayName"
+  }
+  sensitive_output_path_patterns = ["^app_id$"]
+}
+`
+}
+
+func (r MSGraphTestResource) withReadExpand() string {
+	return `
+resource "msgraph_resource" "test" {
+  url = "applications"
+  body = {
+    displayName = "Demo App"
+  }
+  read_query_parameters = {
+    "$expand" = ["owners($select=id)"]
+  }
+  full_body_sync = true
+  response_export_values = {
+    owners = "owners[].id"
+  }
+}
+`
+}
+
+func (r MSGraphTestResource) withReadSelect(properties []string) string {
+	readQueryParameters := ""
+	if len(properties) != 0 {
+		readQueryParameters = fmt.Sprintf(`
+  read_query_parameters = {
+    "$select" = ["%s"]
+  }`, strings.Join(properties, `", "`))
+	}
+	return fmt.Sprintf(`
+resource "msgraph_resource" "test" {
+  url = "applications"
+  body = {
+    displayName = "Demo App"
+  }%s
+  response_export_values = {
+    app_id = "appId"
+  }
+}
+`, readQueryParameters)
+}
+
+func (r MSGraphTestResource) withWriteOnlyBody(data acceptance.TestData, password string, version int) string {
+	return fmt.Sprintf(`
+data "msgraph_resource" "domains" {
+  url = "domains"
+  response_export_values = {
+    default = "value[?isDefault].id | [0]"
+  }
+}
+
+resource "msgraph_resource" "test" {
+  url = "users"
+  body = {
+    accountEnabled    = false
+    displayName       = "acctest-%[1]s"
+    mailNickname      = "acctest-%[1]s"
+    userPrincipalName = "acctest-%[1]s@${data.msgraph_resource.domains.output.default}"
+  }
+  write_only_body = {
+    passwordProfile = {
+      forceChangePasswordNextSignIn = true
+      password                      = "%[2]s"
+    }
+  }
+  write_only_body_version = %[3]d
+  response_export_values = {
+    all = "@"
+  }
+}
+`, data.RandomString, password, version)
+}
+
+func (r MSGraphTestResource) federatedIdentityCredentials(name, description string) string {
+	return fmt.Sprintf(`
+resource "msgraph_resource" "application" {
+  url = "applications"
+  body = {
+    displayName = "Demo App"
+  }
+}
+
+resource "msgraph_resource" "test" {
+  url = "applications/${msgraph_resource.application.id}/federatedIdentityCredentials"
+  body = {
+    name        = "%[1]s"
+    description = "%[2]s"
+    audiences   = ["api://AzureADTokenExchange"]
+    issuer      = "https://token.actions.githubusercontent.com"
+    subject     = "repo:contoso/infrastructure:ref:refs/heads/%[1]s"
+  }
+}
+
+resource "msgraph_resource" "other" {
+  url = "applications/${msgraph_resource.application.id}/federatedIdentityCredentials"
+  body = {
+    name      = "other"
+    audiences = ["api://AzureADTokenExchange"]
+    issuer    = "https://token.actions.githubusercontent.com"
+    subject   = "repo:contoso/infrastructure:environment:production"
+  }
+}
+`, name, description)
+}
+
+func (r MSGraphTestResource) extensionProperties(name string) string {
+	return fmt.Sprintf(`
+resource "msgraph_resource" "application" {
+  url = "applications"
+  body = {
+    displayName = "Demo App"
+  }
+}
+
+resource "msgraph_resource" "test" {
+  url = "applications/${msgraph_resource.application.id}/extensionProperties"
+  body = {
+    name          = "%s"
+    dataType      = "String"
+    targetObjects = ["User"]
+  }
+  full_body_sync = true
+  response_export_values = {
+    attribute_name = "name"
+  }
+}
+`, name)
+}
+
+// authenticationMethodUser is a user whose authentication methods are managed by the tests.
+func (r MSGraphTestResource) authenticationMethodUser(data acceptance.TestData) string {
+	return fmt.Sprintf(`
+data "msgraph_resource" "domains" {
+  url = "domains"
+  response_export_values = {
+    default = "value[?isDefault].id | [0]"
+  }
+}
+
+resource "msgraph_resource" "user" {
+  url = "users"
+  body = {
+    accountEnabled    = false
+    displayName       = "acctest-%[1]s"
+    mailNickname      = "acctest-%[1]s"
+    userPrincipalName = "acctest-%[1]s@${data.msgraph_resource.domains.output.default}"
+  }
+  write_only_body = {
+    passwordProfile = {
+      forceChangePasswordNextSignIn = true
+      password                      = "P@ssw0rd-%[1]s-Terraform"
+    }
+  }
+}
+`, data.RandomString)
+}
+
+func (r MSGraphTestResource) phoneMethods(data acceptance.TestData, phoneNumber string, phoneType string) string {
+	return fmt.Sprintf(`
+%s
+
+resource "msgraph_resource" "test" {
+  url = "users/${msgraph_resource.user.id}/authentication/phoneMethods"
+  body = {
+    phoneNumber = "%s"
+    phoneType   = "%s"
+  }
+}
+`, r.authenticationMethodUser(data), phoneNumber, phoneType)
+}
+
+func (r MSGraphTestResource) emailMethods(data acceptance.TestData, emailAddress string) string {
+	return fmt.Sprintf(`
+%s
+
+resource "msgraph_resource" "test" {
+  url = "users/${msgraph_resource.user.id}/authentication/emailMethods"
+  body = {
+    emailAddress = "%s"
+  }
+}
+`, r.authenticationMethodUser(data), emailAddress)
+}
+
+func (r MSGraphTestResource) scopedRoleMembers(data acceptance.TestData, member string) string {
+	return fmt.Sprintf(`
+data "msgraph_resource" "domains" {
+  url = "domains"
+  response_export_values = {
+    default = "value[?isDefault].id | [0]"
+  }
+}
+
+resource "msgraph_resource" "user_a" {
+  url = "users"
+  body = {
+    accountEnabled    = false
+    displayName       = "acctest-a-%[1]s"
+    mailNickname      = "acctest-a-%[1]s"
+    userPrincipalName = "acctest-a-%[1]s@${data.msgraph_resource.domains.output.default}"
+  }
+  write_only_body = {
+    passwordProfile = {
+      forceChangePasswordNextSignIn = true
+      password                      = "P@ssw0rd-%[1]s-Terraform"
+    }
+  }
+}
+
+resource "msgraph_resource" "user_b" {
+  url = "users"
+  body = {
+    accountEnabled    = false
+    displayName       = "acctest-b-%[1]s"
+    mailNickname      = "acctest-b-%[1]s"
+    userPrincipalName = "acctest-b-%[1]s@${data.msgraph_resource.domains.output.default}"
+  }
+  write_only_body = {
+    passwordProfile = {
+      forceChangePasswordNextSignIn = true
+      password                      = "P@ssw0rd-%[1]s-Terraform"
+    }
+  }
+}
+
+resource "msgraph_resource" "administrativeUnit" {
+  url = "directory/administrativeUnits"
+  body = {
+    displayName = "acctest-%[1]s"
+  }
+}
+
+// the User Administrator role, which must be activated in the tenant
+data "msgraph_resource" "userAdministrator" {
+  url = "directoryRoles"
+  query_parameters = {
+    "$filter" = ["roleTemplateId eq 'fe930be7-5e62-47db-91af-98c3a49a38b1'"]
+  }
+  response_export_values = {
+    id = "value[0].id"
+  }
+}
+
+resource "msgraph_resource" "test" {
+  url = "directory/administrativeUnits/${msgraph_resource.administrativeUnit.id}/scopedRoleMembers"
+  body = {
+    roleId = data.msgraph_resource.userAdministrator.output.id
+    roleMemberInfo = {
+      id = msgraph_resource.%[2]s.id
+    }
+  }
+}
+`, data.RandomString, member)
+}
+
+func (r MSGraphTestResource) preferReturnRepresentation(displayName string) string {
+	return fmt.Sprintf(`
+resource "msgraph_resource" "test" {
+  url = "applications"
+  body = {
+    displayName = "%s"
+  }
+  prefer_return_representation = true
+  response_export_values = {
+    displayName = "displayName"
+  }
+}
+`, displayName)
+}
+
+func (r MSGraphTestResource) preferReturnRepresentationWithPreferHeader(displayName string) string {
+	return fmt.Sprintf(`
+resource "msgraph_resource" "test" {
+  url = "applications"
+  body = {
+    displayName = "%s"
+  }
+  prefer_return_representation = true
+  request_headers = {
+    Prefer = "return=minimal"
+  }
+}
+`, displayName)
+}
+
+func (r MSGraphTestResource) returnRepresentation(displayName string) string {
+	return fmt.Sprintf(`
+resource "msgraph_resource" "test" {
+  url = "applications"
+  body = {
+    displayName = "%s"
+  }
+  request_headers = {
+    Prefer = "return=representation"
+  }
+  response_export_values = {
+    displayName = "displayName"
+  }
+}
+`, displayName)
+}
+
+func (r MSGraphTestResource) autoODataType(displayName string, country string) string {
+	return fmt.Sprintf(`
+resource "msgraph_resource" "test" {
+  url             = "identity/conditionalAccess/namedLocations"
+  auto_odata_type = true
+  body = {
+    displayName                       = "%s"
+    countriesAndRegions               = ["%s"]
+    includeUnknownCountriesAndRegions = false
+  }
+  response_export_values = {
+    odata_type = "\"@odata.type\""
+  }
+}
+`, displayName, country)
+}
+
+func (r MSGraphTestResource) writeOnce(mailNickname string, policy string) string {
+	return fmt.Sprintf(`
+resource "msgraph_resource" "test" {
+  url = "groups"
+  body = {
+    displayName     = "My Group"
+    mailEnabled     = false
+    mailNickname    = "%[1]s"
+    securityEnabled = true
+  }
+  write_once_paths  = ["mailNickname"]
+  write_once_policy = "%[2]s"
+  response_export_values = {
+    mailNickname = "mailNickname"
+  }
+}
+`, mailNickname, policy)
+}
+
+func (r MSGraphTestResource) tenantId(tenantId string) string {
+	return fmt.Sprintf(`
+resource "msgraph_resource" "test" {
+  url       = "groups"
+  tenant_id = "%s"
   body = {
-    displayName = "Demo App"
+    displayName     = "My Group"
+    mailEnabled     = false
+    mailNickname    = "mygroup-other-tenant"
+    securityEnabled = true
+  }
+}
+`, tenantId)
+}
+
+func (r MSGraphTestResource) crossTenantAccessPartnerIdPath() string {
+	return strings.Replace(r.crossTenantAccessPartner(true), `id_attribute = "tenantId"`, `id_path      = "tenantId"`, 1)
+}
+
+func (r MSGraphTestResource) idPathWithRef() string {
+	return `
+resource "msgraph_resource" "test" {
+  url     = "groups/00000000-0000-0000-0000-000000000000/members/$ref"
+  id_path = "id"
+  body = {
+    "@odata.id" = "https://graph.microsoft.com/v1.0/directoryObjects/00000000-0000-0000-0000-000000000000"
   }
 }
 `
 }
 
-func (r MSGraphTestResource) basicUpdate(data acceptance.TestData) string {
+func (r MSGraphTestResource) stripBodyPaths(comment string) string {
+	return fmt.Sprintf(`
+resource "msgraph_resource" "test" {
+  url = "groups"
+  body = {
+    _comment        = "%s"
+    displayName     = "My Group"
+    mailEnabled     = false
+    mailNickname    = "mygroup-strip-body-paths"
+    securityEnabled = true
+  }
+  strip_body_paths = ["_comment"]
+}
+`, comment)
+}
+
+func (r MSGraphTestResource) deleteIgnoreStatusCodes() string {
 	return `
 resource "msgraph_resource" "test" {
   url = "applications"
   body = {
-    displayName = "Demo App Updated"
+    displayName = "Demo App"
   }
+  delete_ignore_status_codes = [400, 405]
 }
 `
 }
 
-func (r MSGraphTestResource) groupMember() string {
+func (r MSGraphTestResource) resourceUrlDeepPath() string {
 	return `
 resource "msgraph_resource" "application" {
   url = "applications"
   body = {
-    displayName = "My Application"
+    displayName = "Demo App"
+  }
+}
+
+resource "msgraph_resource" "test" {
+  url = "applications/${msgraph_resource.application.id}/federatedIdentityCredentials"
+  body = {
+    name      = "deep-path"
+    audiences = ["api://AzureADTokenExchange"]
+    issuer    = "https://token.actions.githubusercontent.com"
+    subject   = "repo:contoso/infrastructure:environment:production"
+  }
+}
+
+data "msgraph_resource" "test" {
+  url = msgraph_resource.test.resource_url
+  response_export_values = {
+    name = "name"
+  }
+}
+`
+}
+
+func (r MSGraphTestResource) resourceUrlReference() string {
+	return `
+resource "msgraph_resource" "application" {
+  url = "applications"
+  body = {
+    displayName = "Demo App"
   }
   response_export_values = {
     appId = "appId"
   }
 }
 
-resource "msgraph_resource" "servicePrincipal_application" {
+resource "msgraph_resource" "servicePrincipal" {
   url = "servicePrincipals"
   body = {
     appId = msgraph_resource.application.output.appId
@@ -394,121 +3206,280 @@ resource "msgraph_resource" "group" {
 }
 
 resource "msgraph_resource" "test" {
-  url         = "groups/${msgraph_resource.group.id}/members/$ref"
-  api_version = "beta"
+  url = "groups/${msgraph_resource.group.id}/members/$ref"
   body = {
-    "@odata.id" = "https://graph.microsoft.com/v1.0/directoryObjects/${msgraph_resource.servicePrincipal_application.id}"
+    "@odata.id" = "https://graph.microsoft.com/v1.0/directoryObjects/${msgraph_resource.servicePrincipal.id}"
+  }
+}
+
+data "msgraph_resource" "test" {
+  url = msgraph_resource.test.resource_url
+  response_export_values = {
+    id = "id"
   }
 }
 `
 }
 
-func (r MSGraphTestResource) groupOwnerBind(displayName string) string {
+func (r MSGraphTestResource) withProtectedUrlPatterns(patterns string) string {
 	return fmt.Sprintf(`
-resource "msgraph_resource" "application" {
-  url = "applications"
+provider "msgraph" {
+  protected_url_patterns = [%s]
+}
+`, patterns)
+}
+
+func (r MSGraphTestResource) readAfterCreateDisabled(data acceptance.TestData, description string) string {
+	return fmt.Sprintf(`
+resource "msgraph_resource" "test" {
+  url = "groups"
   body = {
-    displayName = "My Application"
+    displayName     = "acctest-read-after-create-%[1]s"
+    description     = "%[2]s"
+    mailEnabled     = false
+    mailNickname    = "acctest-read-after-create-%[1]s"
+    securityEnabled = true
   }
+  read_after_create = false
   response_export_values = {
-    appId = "appId"
+    displayName = "displayName"
   }
 }
+`, data.RandomString, description)
+}
 
-resource "msgraph_resource" "servicePrincipal_application" {
-  url = "servicePrincipals"
+func (r MSGraphTestResource) referenceReadReferencedObject(data acceptance.TestData) string {
+	return fmt.Sprintf(`
+resource "msgraph_resource" "group" {
+  url = "groups"
   body = {
-    appId = msgraph_resource.application.output.appId
+    displayName     = "acctest-group-%[1]s"
+    mailEnabled     = false
+    mailNickname    = "acctest-group-%[1]s"
+    securityEnabled = true
   }
 }
 
-resource "msgraph_resource" "test" {
+resource "msgraph_resource" "member" {
   url = "groups"
   body = {
-    displayName     = "%s"
+    displayName     = "acctest-member-%[1]s"
     mailEnabled     = false
-    mailNickname    = "mygroup-owners-bind"
+    mailNickname    = "acctest-member-%[1]s"
     securityEnabled = true
-    "owners@odata.bind" = [
-      "https://graph.microsoft.com/v1.0/directoryObjects/${msgraph_resource.servicePrincipal_application.id}"
-    ]
   }
 }
-`, displayName)
-}
 
-func (r MSGraphTestResource) withRetry() string {
-	return `
 resource "msgraph_resource" "test" {
-  url = "applications"
+  url = "groups/${msgraph_resource.group.id}/members/$ref"
   body = {
-    displayName = "Demo App Retry"
+    "@odata.id" = "https://graph.microsoft.com/v1.0/directoryObjects/${msgraph_resource.member.id}"
   }
-  retry = {
-    error_message_regex = [
-      "temporary error",
-      ".*throttl.*",
-    ]
+  read_referenced_object = true
+  response_export_values = {
+    displayName = "displayName"
   }
-}`
+}
+`, data.RandomString)
 }
 
-func (r MSGraphTestResource) withCreateTimeout() string {
-	return `
+// crossTenantAccessPartnerTenantId is the tenant id of the partner organization used in the cross-tenant access tests.
+const crossTenantAccessPartnerTenantId = "72f988bf-86f1-41af-91ab-2d7cd011db47"
+
+func (r MSGraphTestResource) crossTenantAccessPartner(isMfaAccepted bool) string {
+	return fmt.Sprintf(`
 resource "msgraph_resource" "test" {
-  url = "applications"
-  timeouts {
-    create = "1ns"
+  url          = "policies/crossTenantAccessPolicy/partners"
+  id_attribute = "tenantId"
+  body = {
+    tenantId = "%s"
+    inboundTrust = {
+      isMfaAccepted                       = %t
+      isCompliantDeviceAccepted           = false
+      isHybridAzureADJoinedDeviceAccepted = false
+    }
+    b2bCollaborationOutbound = {
+      usersAndGroups = {
+        accessType = "allowed"
+        targets = [
+          {
+            target     = "AllUsers"
+            targetType = "user"
+          }
+        ]
+      }
+    }
   }
+}
+`, crossTenantAccessPartnerTenantId, isMfaAccepted)
+}
+
+func (r MSGraphTestResource) identitySynchronization(displayName string) string {
+	return fmt.Sprintf(`
+resource "msgraph_resource" "partner" {
+  url          = "policies/crossTenantAccessPolicy/partners"
+  id_attribute = "tenantId"
   body = {
-    displayName = "Demo App Timeout Create"
+    tenantId = "%[1]s"
   }
 }
-`
+
+resource "msgraph_resource" "test" {
+  url           = "${msgraph_resource.partner.resource_url}/identitySynchronization"
+  create_method = "PUT"
+  update_method = "PUT"
+  body = {
+    displayName = "%[2]s"
+    userSyncInbound = {
+      isSyncAllowed = true
+    }
+  }
+}
+`, crossTenantAccessPartnerTenantId, displayName)
 }
 
-func (r MSGraphTestResource) withUpdateTimeout() string {
+func (r MSGraphTestResource) createMethodPutWithRef() string {
 	return `
 resource "msgraph_resource" "test" {
-  url = "applications"
-  timeouts {
-    update = "1ns"
-  }
+  url           = "groups/00000000-0000-0000-0000-000000000000/members/$ref"
+  create_method = "PUT"
   body = {
-    displayName = "Demo App Updated Timeout Update"
+    "@odata.id" = "https://graph.microsoft.com/v1.0/directoryObjects/00000000-0000-0000-0000-000000000000"
   }
 }
 `
 }
 
-func (r MSGraphTestResource) namedLocation(displayName string, cidrAddresses []string) string {
-	ipRangesConfig := ""
-	for i, cidr := range cidrAddresses {
-		if i > 0 {
-			ipRangesConfig += ",\n      "
-		}
-		ipRangesConfig += fmt.Sprintf(`{
-        "@odata.type" = "#microsoft.graph.iPv4CidrRange"
-        cidrAddress   = "%s"
-      }`, cidr)
-	}
+func (r MSGraphTestResource) conditionalAccessPolicy(builtInControls []string, signInFrequency int) string {
+	return fmt.Sprintf(`
+resource "msgraph_resource" "test" {
+  url = "identity/conditionalAccess/policies"
+  body = {
+    displayName = "acctest-conditional-access-policy"
+    state       = "disabled"
+    conditions = {
+      clientAppTypes = ["all"]
+      applications = {
+        includeApplications = ["All"]
+      }
+      users = {
+        includeUsers = ["None"]
+      }
+    }
+    grantControls = {
+      operator        = "OR"
+      builtInControls = ["%s"]
+    }
+    sessionControls = {
+      "@odata.type" = "#microsoft.graph.conditionalAccessSessionControls"
+      signInFrequency = {
+        "@odata.type"     = "#microsoft.graph.signInFrequencySessionControl"
+        isEnabled         = true
+        type              = "hours"
+        value             = %d
+        frequencyInterval = "timeBased"
+      }
+    }
+  }
+}
+`, strings.Join(builtInControls, `", "`), signInFrequency)
+}
 
+func (r MSGraphTestResource) updateMethod(displayName string) string {
 	return fmt.Sprintf(`
+
+
+resource "msgraph_resource" "group_example" {
+  url = "groups"
+  body = {
+    displayName     = "group-name"
+    mailEnabled     = false
+    mailNickname    = "group-name"
+    securityEnabled = true
+  }
+}
+
+resource "msgraph_resource" "catalog_example" {
+  url = "identityGovernance/entitlementManagement/catalogs"
+  body = {
+    displayName = "example-catalog"
+    description = "Example catalog"
+  }
+}
+
+resource "msgraph_resource" "access_package_example" {
+  url         = "identityGovernance/entitlementManagement/accessPackages"
+  api_version = "beta"
+  body = {
+    catalogId   = msgraph_resource.catalog_example.id
+    displayName = "access-package"
+    description = "Access Package"
+  }
+}
+
 resource "msgraph_resource" "test" {
-  url = "identity/conditionalAccess/namedLocations"
+  url           = "identityGovernance/entitlementManagement/accessPackageAssignmentPolicies"
+  api_version   = "beta"
+  update_method = "PUT"
   body = {
-    displayName = "%s"
-    ipRanges = [
-      %s
+    accessPackageId = msgraph_resource.access_package_example.id
+    displayName     = "%[1]s"
+    description     = "My assignment %[1]s"
+    expiration = {
+      type     = "afterDuration"
+      duration = "P90D"
+    }
+    requestorSettings = {
+      scopeType = "AllExistingDirectoryMemberUsers"
+    }
+    requestApprovalSettings = {
+      isApprovalRequired = true
+      approvalStages = [
+        {
+          approvalStageTimeOutInDays = 14
+          primaryApprovers = [
+            {
+              "@odata.type" = "#microsoft.graph.groupMembers"
+              groupId       = msgraph_resource.group_example.id
+              description   = "group-name"
+            }
+          ]
+        }
+      ]
+    }
+    reviewSettings = {
+      isEnabled          = true
+      expirationBehavior = "keepAccess"
+      isSelfReview       = true
+      schedule = {
+        startDateTime = "2025-12-12T00:00:00Z"
+        recurrence = {
+          pattern = {
+            type     = "weekly"
+            interval = 1
+          }
+          range = {
+            type      = "noEnd"
+            startDate = "2025-12-12"
+          }
+        }
+      }
+    }
+    questions = [
+      {
+        "@odata.type" = "#microsoft.graph.accessPackageTextInputQuestion"
+        text = {
+          defaultText = "hello, how are you?"
+        }
+        isRequired = false
+      }
     ]
-    isTrusted     = false
-    "@odata.type" = "#microsoft.graph.ipNamedLocation"
   }
 }
-`, displayName, ipRangesConfig)
+`, displayName)
 }
 
-func (r MSGraphTestResource) updateMethod(displayName string) string {
+func (r MSGraphTestResource) updateMethodWithPutMerge(displayName string) string {
 	return fmt.Sprintf(`
 
 
@@ -544,6 +3515,7 @@ resource "msgraph_resource" "test" {
   url           = "identityGovernance/entitlementManagement/accessPackageAssignmentPolicies"
   api_version   = "beta"
   update_method = "PUT"
+  put_merge     = true
   body = {
     accessPackageId = msgraph_resource.access_package_example.id
     displayName     = "%[1]s"
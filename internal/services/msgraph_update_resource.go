@@ -2,18 +2,25 @@ package services
 
 import (
 	"context"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
 	"encoding/json"
 	"fmt"
+	"net/http"
+	"net/url"
+	"strings"
 	"time"
 
 	"github.com/hashicorp/terraform-plugin-framework-timeouts/resource/timeouts"
 	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/attr"
 	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
 	"github.com/hashicorp/terraform-plugin-framework/resource"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/booldefault"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
-	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringdefault"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
 	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
 	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
@@ -22,6 +29,7 @@ import (
 	"github.com/microsoft/terraform-provider-msgraph/internal/clients"
 	"github.com/microsoft/terraform-provider-msgraph/internal/docstrings"
 	"github.com/microsoft/terraform-provider-msgraph/internal/dynamic"
+	"github.com/microsoft/terraform-provider-msgraph/internal/myvalidator"
 	"github.com/microsoft/terraform-provider-msgraph/internal/retry"
 	"github.com/microsoft/terraform-provider-msgraph/internal/utils"
 )
@@ -31,8 +39,13 @@ var (
 	_ resource.Resource                     = &MSGraphUpdateResource{}
 	_ resource.ResourceWithConfigValidators = &MSGraphUpdateResource{}
 	_ resource.ResourceWithModifyPlan       = &MSGraphUpdateResource{}
+	_ resource.ResourceWithImportState      = &MSGraphUpdateResource{}
 )
 
+// FlagOriginalBody is the key of the private state which holds the values of the configured properties before they're
+// updated, they're restored when the resource is deleted with `restore_on_delete`.
+const FlagOriginalBody = "original_body"
+
 func NewMSGraphUpdateResource() resource.Resource {
 	return &MSGraphUpdateResource{}
 }
@@ -53,10 +66,17 @@ type MSGraphUpdateResourceModel struct {
 	ApiVersion            types.String      `tfsdk:"api_version"`
 	Url                   types.String      `tfsdk:"url"`
 	Body                  types.Dynamic     `tfsdk:"body"`
+	RawBodyBase64         types.String      `tfsdk:"raw_body_base64"`
+	ContentType           types.String      `tfsdk:"content_type"`
+	RawBodyHash           types.String      `tfsdk:"raw_body_hash"`
 	IgnoreMissingProperty types.Bool        `tfsdk:"ignore_missing_property"`
+	AutoODataType         types.Bool        `tfsdk:"auto_odata_type"`
+	RestoreOnDelete       types.Bool        `tfsdk:"restore_on_delete"`
 	UpdateQueryParameters types.Map         `tfsdk:"update_query_parameters"`
 	ReadQueryParameters   types.Map         `tfsdk:"read_query_parameters"`
+	RequestHeaders        types.Map         `tfsdk:"request_headers"`
 	ResponseExportValues  map[string]string `tfsdk:"response_export_values"`
+	RedactPlanPaths       types.List        `tfsdk:"redact_plan_paths"`
 	Retry                 retry.Value       `tfsdk:"retry"`
 	Output                types.Dynamic     `tfsdk:"output"`
 	Timeouts              timeouts.Value    `tfsdk:"timeouts"`
@@ -69,7 +89,7 @@ func (r *MSGraphUpdateResource) Metadata(ctx context.Context, req resource.Metad
 func (r *MSGraphUpdateResource) Schema(ctx context.Context, req resource.SchemaRequest, resp *resource.SchemaResponse) {
 	resp.Schema = schema.Schema{
 		MarkdownDescription: "This resource can manage a subset of any existing Microsoft Graph resource's properties.\n\n" +
-			"-> **Note** This resource is used to add or modify properties on an existing resource. When `msgraph_update_resource` is deleted, no operation will be performed, and these properties will stay unchanged. If you want to restore the modified properties to some values, you must apply the restored properties before deleting.",
+			"-> **Note** This resource is used to add or modify properties on an existing resource. When `msgraph_update_resource` is deleted, no operation will be performed, and these properties will stay unchanged, unless `restore_on_delete` is enabled. Otherwise, if you want to restore the modified properties to some values, you must apply the restored properties before deleting.",
 		Description: "This resource can manage a subset of any existing Microsoft Graph resource's properties.",
 
 		Attributes: map[string]schema.Attribute{
@@ -84,6 +104,9 @@ func (r *MSGraphUpdateResource) Schema(ctx context.Context, req resource.SchemaR
 			"url": schema.StringAttribute{
 				MarkdownDescription: docstrings.Url("update_resource"),
 				Required:            true,
+				Validators: []validator.String{
+					myvalidator.RelativeURL(),
+				},
 			},
 
 			"api_version": schema.StringAttribute{
@@ -91,9 +114,14 @@ func (r *MSGraphUpdateResource) Schema(ctx context.Context, req resource.SchemaR
 				Optional:            true,
 				Computed:            true,
 				Validators: []validator.String{
-					stringvalidator.OneOf("v1.0", "beta"),
+					myvalidator.ApiVersion(),
 				},
-				Default: stringdefault.StaticString("v1.0"),
+			},
+
+			"redact_plan_paths": schema.ListAttribute{
+				MarkdownDescription: docstrings.RedactPlanPaths(),
+				ElementType:         types.StringType,
+				Optional:            true,
 			},
 
 			"update_method": schema.StringAttribute{
@@ -109,6 +137,27 @@ func (r *MSGraphUpdateResource) Schema(ctx context.Context, req resource.SchemaR
 				Optional:            true,
 			},
 
+			"raw_body_base64": schema.StringAttribute{
+				MarkdownDescription: "The base64-encoded raw content to be sent as the request body instead of `body`, e.g. the content of a profile photo for `users/{id}/photo/$value`. It's sent with `PUT` unless `update_method` is specified.",
+				Optional:            true,
+				Validators: []validator.String{
+					stringvalidator.ConflictsWith(path.MatchRoot("body")),
+				},
+			},
+
+			"content_type": schema.StringAttribute{
+				MarkdownDescription: "The content type of `raw_body_base64`, e.g. `image/jpeg`. Defaults to `application/octet-stream`.",
+				Optional:            true,
+				Validators: []validator.String{
+					stringvalidator.AlsoRequires(path.MatchRoot("raw_body_base64")),
+				},
+			},
+
+			"raw_body_hash": schema.StringAttribute{
+				MarkdownDescription: "The SHA-256 hash of the raw content returned by the API when `raw_body_base64` is specified. It's used to detect changes made outside of Terraform.",
+				Computed:            true,
+			},
+
 			"ignore_missing_property": schema.BoolAttribute{
 				MarkdownDescription: docstrings.IgnoreMissingProperty(),
 				Optional:            true,
@@ -116,6 +165,20 @@ func (r *MSGraphUpdateResource) Schema(ctx context.Context, req resource.SchemaR
 				Default:             booldefault.StaticBool(true),
 			},
 
+			"auto_odata_type": schema.BoolAttribute{
+				MarkdownDescription: docstrings.AutoODataType(),
+				Optional:            true,
+				Computed:            true,
+				Default:             booldefault.StaticBool(false),
+			},
+
+			"restore_on_delete": schema.BoolAttribute{
+				MarkdownDescription: "Whether to restore the original values of the properties in `body` when the resource is deleted. The values are read before the properties are updated for the first time, and they're sent with `PATCH` on deletion. If the resource has already been deleted, nothing is restored. It's not supported with `raw_body_base64`, and the imported resources have no original values to restore. Defaults to `false`.",
+				Optional:            true,
+				Computed:            true,
+				Default:             booldefault.StaticBool(false),
+			},
+
 			"update_query_parameters": schema.MapAttribute{
 				ElementType: types.ListType{
 					ElemType: types.StringType,
@@ -132,6 +195,12 @@ func (r *MSGraphUpdateResource) Schema(ctx context.Context, req resource.SchemaR
 				MarkdownDescription: "A mapping of query parameters to be sent with the read request.",
 			},
 
+			"request_headers": schema.MapAttribute{
+				ElementType:         types.StringType,
+				Optional:            true,
+				MarkdownDescription: "A mapping of HTTP headers to be sent with every request made for this resource, e.g. `Prefer` or `ConsistencyLevel`. Headers with empty values are not sent. Note that authentication headers are automatically handled.",
+			},
+
 			"response_export_values": schema.MapAttribute{
 				MarkdownDescription: docstrings.ResponseExportValues(),
 				Optional:            true,
@@ -164,6 +233,10 @@ func (r *MSGraphUpdateResource) Configure(ctx context.Context, req resource.Conf
 }
 
 func (r *MSGraphUpdateResource) ModifyPlan(ctx context.Context, request resource.ModifyPlanRequest, response *resource.ModifyPlanResponse) {
+	if planDefaultApiVersion(ctx, r.client, request, response); response.Diagnostics.HasError() {
+		return
+	}
+
 	var plan *MSGraphUpdateResourceModel
 	if response.Diagnostics.Append(request.Plan.Get(ctx, &plan)...); response.Diagnostics.HasError() {
 		return
@@ -173,9 +246,19 @@ func (r *MSGraphUpdateResource) ModifyPlan(ctx context.Context, request resource
 	if response.Diagnostics.Append(request.State.Get(ctx, &state)...); response.Diagnostics.HasError() {
 		return
 	}
+
+	if plan != nil && plan.RestoreOnDelete.ValueBool() && !plan.RawBodyBase64.IsNull() {
+		response.Diagnostics.AddAttributeError(path.Root("restore_on_delete"), "Invalid configuration", "`restore_on_delete` is not supported with `raw_body_base64`.")
+	}
+}
+
+// privateState is the private state of the resource, it's read and written when the original values are captured.
+type privateState interface {
+	privateStateGetter
+	privateStateSetter
 }
 
-func (r *MSGraphUpdateResource) CreateUpdate(ctx context.Context, plan tfsdk.Plan, state *tfsdk.State, diagnostics *diag.Diagnostics, isCreate bool) {
+func (r *MSGraphUpdateResource) CreateUpdate(ctx context.Context, plan tfsdk.Plan, state *tfsdk.State, private privateState, diagnostics *diag.Diagnostics, isCreate bool) {
 	var model MSGraphUpdateResourceModel
 	var stateModel *MSGraphUpdateResourceModel
 	diagnostics.Append(plan.Get(ctx, &model)...)
@@ -194,6 +277,15 @@ func (r *MSGraphUpdateResource) CreateUpdate(ctx context.Context, plan tfsdk.Pla
 	diagnostics.Append(diags...)
 	ctx, cancel := context.WithTimeout(ctx, writeTimeout)
 	defer cancel()
+	ctx = clients.WithOperationMetrics(ctx)
+	defer clients.LogOperationMetrics(ctx, fmt.Sprintf("msgraph_update_resource write of %s", model.Url.ValueString()))
+	ctx = clients.WithRedactedPaths(ctx, AsListOfString(model.RedactPlanPaths))
+	defer redactDiagnostics(diagnostics, updateRedactedPlanValues(&model))
+
+	if !model.RawBodyBase64.IsNull() {
+		r.createUpdateRawBody(ctx, &model, state, diagnostics)
+		return
+	}
 
 	data, err := dynamic.ToJSON(model.Body)
 	if err != nil {
@@ -206,55 +298,131 @@ func (r *MSGraphUpdateResource) CreateUpdate(ctx context.Context, plan tfsdk.Pla
 		return
 	}
 
-	options := clients.RequestOptions{
-		QueryParameters: clients.NewQueryParameters(AsMapOfLists(model.UpdateQueryParameters)),
-		RetryOptions:    clients.NewRetryOptions(model.Retry),
-	}
-
 	updateMethod := "PATCH"
 	if !model.UpdateMethod.IsNull() && model.UpdateMethod.ValueString() != "" {
 		updateMethod = model.UpdateMethod.ValueString()
 	}
+
+	if model.RestoreOnDelete.ValueBool() {
+		if diagnostics.Append(r.captureOriginalBody(ctx, &model, requestBody, private)...); diagnostics.HasError() {
+			return
+		}
+	}
+
+	options := clients.RequestOptions{
+		Headers:         clients.NewHeaders(AsMapOfString(model.RequestHeaders)),
+		QueryParameters: clients.NewQueryParameters(AsMapOfLists(model.UpdateQueryParameters)),
+		RetryOptions:    clients.NewRetryOptions(model.Retry, updateMethod),
+	}
 	if updateMethod == "PUT" {
 		readOptions := clients.RequestOptions{
+			Headers:         clients.NewHeaders(AsMapOfString(model.RequestHeaders)),
 			QueryParameters: clients.NewQueryParameters(AsMapOfLists(model.ReadQueryParameters)),
-			RetryOptions:    clients.NewRetryOptions(model.Retry),
+			RetryOptions:    clients.NewRetryOptions(model.Retry, http.MethodGet),
 		}
 		existingBody, err := r.client.Read(ctx, model.Url.ValueString(), model.ApiVersion.ValueString(), readOptions)
 		if err != nil {
-			diagnostics.AddError("Failed to read existing resource for PUT update", err.Error())
+			diagnostics.AddError("Failed to read existing resource for PUT update", utils.ResponseErrorDetail(err))
 			return
 		}
 
-		requestBody = utils.MergeObject(existingBody, requestBody)
+		// The items removed from the configuration are not merged back from the existing resource.
+		var previousBody interface{}
+		if stateModel != nil && !stateModel.Body.IsNull() {
+			data, err := dynamic.ToJSON(stateModel.Body)
+			if err != nil {
+				diagnostics.AddError("Invalid body in prior state", fmt.Sprintf(`The state "body" is invalid: %s`, err.Error()))
+				return
+			}
+			if err = json.Unmarshal(data, &previousBody); err != nil {
+				diagnostics.AddError("Invalid body in prior state", fmt.Sprintf(`The state "body" is invalid: %s`, err.Error()))
+				return
+			}
+		}
+		requestBody = utils.MergeObjectWithPrevious(existingBody, requestBody, previousBody)
+	}
+	if model.AutoODataType.ValueBool() {
+		requestBody = withInferredODataType(ctx, model.Url.ValueString(), requestBody, requestBody)
 	}
 
 	_, err = r.client.Action(ctx, updateMethod, model.Url.ValueString(), model.ApiVersion.ValueString(), requestBody, options)
 	if err != nil {
-		diagnostics.AddError("Failed to create resource", err.Error())
+		diagnostics.AddError("Failed to create resource", utils.ResponseErrorDetail(err))
 		return
 	}
 
 	options = clients.RequestOptions{
+		Headers:         clients.NewHeaders(AsMapOfString(model.RequestHeaders)),
 		QueryParameters: clients.NewQueryParameters(AsMapOfLists(model.ReadQueryParameters)),
-		RetryOptions:    clients.NewRetryOptions(model.Retry),
+		RetryOptions:    clients.NewRetryOptions(model.Retry, http.MethodGet),
 	}
 	responseBody, err := r.client.Read(ctx, model.Url.ValueString(), model.ApiVersion.ValueString(), options)
 	if err != nil {
-		diagnostics.AddError("Failed to read data source", err.Error())
+		diagnostics.AddError("Failed to read data source", utils.ResponseErrorDetail(err))
 		return
 	}
 	model.Output = types.DynamicValue(buildOutputFromBody(responseBody, model.ResponseExportValues))
+	model.RawBodyHash = types.StringNull()
 	model.Id = types.StringValue(utils.LastSegment(model.Url.ValueString()))
 	diagnostics.Append(state.Set(ctx, &model)...)
 }
 
+// createUpdateRawBody sends the raw content of `raw_body_base64` and stores the hash of the content returned by the API.
+func (r *MSGraphUpdateResource) createUpdateRawBody(ctx context.Context, model *MSGraphUpdateResourceModel, state *tfsdk.State, diagnostics *diag.Diagnostics) {
+	content, err := base64.StdEncoding.DecodeString(model.RawBodyBase64.ValueString())
+	if err != nil {
+		diagnostics.AddError("Invalid raw_body_base64", fmt.Sprintf(`The argument "raw_body_base64" is not a valid base64 string: %s`, err.Error()))
+		return
+	}
+
+	contentType := "application/octet-stream"
+	if !model.ContentType.IsNull() && model.ContentType.ValueString() != "" {
+		contentType = model.ContentType.ValueString()
+	}
+
+	updateMethod := "PUT"
+	if !model.UpdateMethod.IsNull() && model.UpdateMethod.ValueString() != "" {
+		updateMethod = model.UpdateMethod.ValueString()
+	}
+
+	options := clients.RequestOptions{
+		Headers:         clients.NewHeaders(AsMapOfString(model.RequestHeaders)),
+		QueryParameters: clients.NewQueryParameters(AsMapOfLists(model.UpdateQueryParameters)),
+		RetryOptions:    clients.NewRetryOptions(model.Retry, updateMethod),
+	}
+	requestBody := clients.RawBody{
+		Content:     content,
+		ContentType: contentType,
+	}
+	_, err = r.client.Action(ctx, updateMethod, model.Url.ValueString(), model.ApiVersion.ValueString(), requestBody, options)
+	if err != nil {
+		diagnostics.AddError("Failed to create resource", utils.ResponseErrorDetail(err))
+		return
+	}
+
+	options = clients.RequestOptions{
+		Headers:         clients.NewHeaders(AsMapOfString(model.RequestHeaders)),
+		QueryParameters: clients.NewQueryParameters(AsMapOfLists(model.ReadQueryParameters)),
+		RetryOptions:    clients.NewRetryOptions(model.Retry, http.MethodGet),
+	}
+	responseContent, err := r.client.ReadRaw(ctx, model.Url.ValueString(), model.ApiVersion.ValueString(), options)
+	if err != nil {
+		diagnostics.AddError("Failed to read data source", utils.ResponseErrorDetail(err))
+		return
+	}
+
+	model.RawBodyHash = types.StringValue(rawBodyHash(responseContent))
+	model.Output = types.DynamicValue(buildOutputFromBody(nil, nil))
+	model.Id = types.StringValue(utils.LastSegment(model.Url.ValueString()))
+	diagnostics.Append(state.Set(ctx, model)...)
+}
+
 func (r *MSGraphUpdateResource) Create(ctx context.Context, request resource.CreateRequest, response *resource.CreateResponse) {
-	r.CreateUpdate(ctx, request.Plan, &response.State, &response.Diagnostics, true)
+	r.CreateUpdate(ctx, request.Plan, &response.State, response.Private, &response.Diagnostics, true)
 }
 
 func (r *MSGraphUpdateResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
-	r.CreateUpdate(ctx, req.Plan, &resp.State, &resp.Diagnostics, false)
+	r.CreateUpdate(ctx, req.Plan, &resp.State, resp.Private, &resp.Diagnostics, false)
 }
 
 func (r *MSGraphUpdateResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
@@ -263,20 +431,47 @@ func (r *MSGraphUpdateResource) Read(ctx context.Context, req resource.ReadReque
 		return
 	}
 
-	// Apply read timeout (default 5m)
-	readTimeout, diags := model.Timeouts.Read(ctx, 5*time.Minute)
+	// Apply read timeout (defaults to the provider's default_read_timeout)
+	readTimeout, diags := model.Timeouts.Read(ctx, r.client.DefaultReadTimeout())
 	resp.Diagnostics.Append(diags...)
 	ctx, cancel := context.WithTimeout(ctx, readTimeout)
 	defer cancel()
+	ctx = clients.WithOperationMetrics(ctx)
+	defer clients.LogOperationMetrics(ctx, fmt.Sprintf("msgraph_update_resource read of %s", model.Url.ValueString()))
+	ctx = clients.WithRedactedPaths(ctx, AsListOfString(model.RedactPlanPaths))
+	defer redactDiagnostics(&resp.Diagnostics, updateRedactedPlanValues(model))
 
 	if model.ApiVersion.ValueString() == "" {
-		model.ApiVersion = types.StringValue("v1.0")
+		model.ApiVersion = types.StringValue(r.client.DefaultApiVersion())
 	}
 
 	options := clients.RequestOptions{
+		Headers:         clients.NewHeaders(AsMapOfString(model.RequestHeaders)),
 		QueryParameters: clients.NewQueryParameters(AsMapOfLists(model.ReadQueryParameters)),
-		RetryOptions:    clients.NewRetryOptions(model.Retry),
+		RetryOptions:    clients.NewRetryOptions(model.Retry, http.MethodGet),
+	}
+
+	if !model.RawBodyBase64.IsNull() {
+		responseContent, err := r.client.ReadRaw(ctx, model.Url.ValueString(), model.ApiVersion.ValueString(), options)
+		if err != nil {
+			if utils.ResponseErrorWasNotFound(err) {
+				tflog.Info(ctx, fmt.Sprintf("Error reading %q - removing from state", model.Id.ValueString()))
+				resp.State.RemoveResource(ctx)
+				return
+			}
+			resp.Diagnostics.AddError("Failed to read data source", utils.ResponseErrorDetail(err))
+			return
+		}
+
+		// The content is not stored in the state, so a changed hash means the content has been changed outside of Terraform.
+		if hash := rawBodyHash(responseContent); hash != model.RawBodyHash.ValueString() {
+			model.RawBodyHash = types.StringValue(hash)
+			model.RawBodyBase64 = types.StringNull()
+		}
+		resp.Diagnostics.Append(resp.State.Set(ctx, model)...)
+		return
 	}
+
 	responseBody, err := r.client.Read(ctx, model.Url.ValueString(), model.ApiVersion.ValueString(), options)
 	if err != nil {
 		if utils.ResponseErrorWasNotFound(err) {
@@ -284,10 +479,11 @@ func (r *MSGraphUpdateResource) Read(ctx context.Context, req resource.ReadReque
 			resp.State.RemoveResource(ctx)
 			return
 		}
-		resp.Diagnostics.AddError("Failed to read data source", err.Error())
+		resp.Diagnostics.AddError("Failed to read data source", utils.ResponseErrorDetail(err))
 		return
 	}
 
+	responseBody = utils.RewriteODataHosts(responseBody, r.client.GraphBaseUrl())
 	state := model
 	state.Output = types.DynamicValue(buildOutputFromBody(responseBody, model.ResponseExportValues))
 
@@ -326,4 +522,192 @@ func (r *MSGraphUpdateResource) Read(ctx context.Context, req resource.ReadReque
 }
 
 func (r *MSGraphUpdateResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	var model *MSGraphUpdateResourceModel
+	if resp.Diagnostics.Append(req.State.Get(ctx, &model)...); resp.Diagnostics.HasError() {
+		return
+	}
+	if !model.RestoreOnDelete.ValueBool() {
+		return
+	}
+
+	originalBody, diags := originalBodySnapshot(ctx, req.Private)
+	if resp.Diagnostics.Append(diags...); resp.Diagnostics.HasError() {
+		return
+	}
+	if originalBody == nil {
+		tflog.Info(ctx, fmt.Sprintf("No original values of %q are found - skipping the restore", model.Url.ValueString()))
+		return
+	}
+
+	deleteTimeout, diags := model.Timeouts.Delete(ctx, 30*time.Minute)
+	resp.Diagnostics.Append(diags...)
+	ctx, cancel := context.WithTimeout(ctx, deleteTimeout)
+	defer cancel()
+	ctx = clients.WithRedactedPaths(ctx, AsListOfString(model.RedactPlanPaths))
+	defer redactDiagnostics(&resp.Diagnostics, updateRedactedPlanValues(model))
+
+	options := clients.RequestOptions{
+		Headers:         clients.NewHeaders(AsMapOfString(model.RequestHeaders)),
+		QueryParameters: clients.NewQueryParameters(AsMapOfLists(model.UpdateQueryParameters)),
+		RetryOptions:    clients.NewRetryOptions(model.Retry, http.MethodPatch),
+	}
+	_, err := r.client.Action(ctx, http.MethodPatch, model.Url.ValueString(), model.ApiVersion.ValueString(), originalBody, options)
+	if err != nil {
+		if utils.ResponseErrorWasNotFound(err) {
+			tflog.Info(ctx, fmt.Sprintf("%q has already been deleted - skipping the restore", model.Url.ValueString()))
+			return
+		}
+		resp.Diagnostics.AddError("Failed to restore the original values", utils.ResponseErrorDetail(err))
+		return
+	}
+}
+
+// captureOriginalBody stores the current values of the properties in the request body which haven't been captured yet
+// in the private state, so the properties added to `body` later are restored too.
+// updateRedactedPlanValues returns the values of `body` at the paths of `redact_plan_paths`, which are masked in the
+// diagnostics.
+func updateRedactedPlanValues(model *MSGraphUpdateResourceModel) []string {
+	paths := AsListOfString(model.RedactPlanPaths)
+	if len(paths) == 0 {
+		return nil
+	}
+	var body interface{}
+	if err := unmarshalBody(model.Body, &body); err != nil {
+		return nil
+	}
+	return utils.StringValuesAtPaths(body, paths)
+}
+
+func (r *MSGraphUpdateResource) captureOriginalBody(ctx context.Context, model *MSGraphUpdateResourceModel, requestBody interface{}, private privateState) diag.Diagnostics {
+	originalBody, diags := originalBodySnapshot(ctx, private)
+	if diags.HasError() {
+		return diags
+	}
+	captured, _ := originalBody.(map[string]interface{})
+	requestMap, ok := requestBody.(map[string]interface{})
+	if !ok {
+		return diags
+	}
+	missing := false
+	for key := range requestMap {
+		if _, ok := captured[key]; !ok && !strings.HasSuffix(key, "@odata.bind") {
+			missing = true
+			break
+		}
+	}
+	if !missing {
+		return diags
+	}
+
+	options := clients.RequestOptions{
+		Headers:         clients.NewHeaders(AsMapOfString(model.RequestHeaders)),
+		QueryParameters: clients.NewQueryParameters(AsMapOfLists(model.ReadQueryParameters)),
+		RetryOptions:    clients.NewRetryOptions(model.Retry, http.MethodGet),
+	}
+	existingBody, err := r.client.Read(ctx, model.Url.ValueString(), model.ApiVersion.ValueString(), options)
+	if err != nil {
+		diags.AddError("Failed to read the original values", utils.ResponseErrorDetail(err))
+		return diags
+	}
+
+	data, err := json.Marshal(withOriginalValues(captured, requestMap, existingBody))
+	if err != nil {
+		diags.AddError("Invalid original values", err.Error())
+		return diags
+	}
+	return append(diags, private.SetKey(ctx, FlagOriginalBody, data)...)
+}
+
+// withOriginalValues returns a copy of the captured values with the values of the existing body for the properties of
+// the request body which haven't been captured yet. The properties which don't exist in the existing body are captured
+// as null, so they're cleared on restore. The OData annotations are kept as configured, because they're required as
+// discriminators in the request.
+func withOriginalValues(captured map[string]interface{}, requestBody map[string]interface{}, existingBody interface{}) map[string]interface{} {
+	existingMap, _ := existingBody.(map[string]interface{})
+	res := make(map[string]interface{}, len(requestBody))
+	for key, value := range captured {
+		res[key] = value
+	}
+	for key, value := range requestBody {
+		if _, ok := res[key]; ok {
+			continue
+		}
+		if strings.HasPrefix(key, "@odata.") {
+			res[key] = value
+			continue
+		}
+		if strings.HasSuffix(key, "@odata.bind") {
+			// The bound references are not returned in the response, so they can't be restored.
+			continue
+		}
+		res[key] = existingMap[key]
+	}
+	return res
+}
+
+// originalBodySnapshot returns the original values stored in the private state, or nil if there's none.
+func originalBodySnapshot(ctx context.Context, private privateStateGetter) (interface{}, diag.Diagnostics) {
+	data, diags := private.GetKey(ctx, FlagOriginalBody)
+	if diags.HasError() || len(data) == 0 {
+		return nil, diags
+	}
+	var snapshot interface{}
+	if err := json.Unmarshal(data, &snapshot); err != nil {
+		diags.AddError("Invalid private state", fmt.Sprintf("The original values are invalid: %s", err.Error()))
+		return nil, diags
+	}
+	return snapshot, diags
+}
+
+func (r *MSGraphUpdateResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
+	parsedUrl, err := url.Parse(req.ID)
+	if err != nil {
+		resp.Diagnostics.AddError("Failed to parse URL", err.Error())
+		return
+	}
+
+	apiVersion := r.client.DefaultApiVersion()
+	if parsedUrl.Query().Get("api-version") != "" {
+		apiVersion = parsedUrl.Query().Get("api-version")
+	}
+
+	urlValue := strings.Trim(parsedUrl.Path, "/")
+	if urlValue == "" {
+		resp.Diagnostics.AddError(
+			"Invalid Import ID",
+			fmt.Sprintf("The import ID must be the URL of the resource to update. For example: 'applications/{application-id}'. Got: %s", req.ID),
+		)
+		return
+	}
+
+	// The body is left empty, it's reconciled with the configuration in the next plan.
+	model := &MSGraphUpdateResourceModel{
+		Id:                    types.StringValue(utils.LastSegment(urlValue)),
+		Url:                   types.StringValue(urlValue),
+		ApiVersion:            types.StringValue(apiVersion),
+		Body:                  types.DynamicNull(),
+		IgnoreMissingProperty: types.BoolValue(true),
+		AutoODataType:         types.BoolValue(false),
+		RestoreOnDelete:       types.BoolValue(false),
+		UpdateQueryParameters: types.MapNull(types.ListType{ElemType: types.StringType}),
+		ReadQueryParameters:   types.MapNull(types.ListType{ElemType: types.StringType}),
+		RequestHeaders:        types.MapNull(types.StringType),
+		RedactPlanPaths:       types.ListNull(types.StringType),
+		Retry:                 retry.NewValueNull(),
+		Timeouts: timeouts.Value{
+			Object: types.ObjectNull(map[string]attr.Type{
+				"create": types.StringType,
+				"update": types.StringType,
+				"read":   types.StringType,
+				"delete": types.StringType,
+			}),
+		},
+	}
+	resp.Diagnostics.Append(resp.State.Set(ctx, model)...)
+}
+
+// rawBodyHash returns the hex-encoded SHA-256 hash of the raw content.
+func rawBodyHash(content []byte) string {
+	sum := sha256.Sum256(content)
+	return hex.EncodeToString(sum[:])
 }
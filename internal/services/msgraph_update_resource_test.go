@@ -1,8 +1,14 @@
 package services_test
 
 import (
+	"bytes"
 	"context"
+	"encoding/base64"
 	"fmt"
+	"image"
+	"image/color"
+	"image/draw"
+	"image/jpeg"
 	"regexp"
 	"testing"
 
@@ -148,11 +154,103 @@ func TestAcc_UpdateResourceWithPutUpdateMethod(t *testing.T) {
 	})
 }
 
+func TestAcc_UpdateResourceRawBody(t *testing.T) {
+	data := acceptance.BuildTestData(t, "msgraph_update_resource", "test")
+
+	r := MSGraphTestUpdateResource{}
+
+	data.ResourceTest(t, r, []resource.TestStep{
+		{
+			Config: r.groupPhoto(color.RGBA{R: 255, A: 255}),
+			Check: resource.ComposeTestCheckFunc(
+				check.That(data.ResourceName).Exists(r),
+				check.That(data.ResourceName).Key("raw_body_hash").MatchesRegex(regexp.MustCompile(`^[a-f0-9]{64}$`)),
+			),
+		},
+		{
+			Config: r.groupPhoto(color.RGBA{B: 255, A: 255}),
+			Check: resource.ComposeTestCheckFunc(
+				check.That(data.ResourceName).Exists(r),
+				check.That(data.ResourceName).Key("raw_body_hash").MatchesRegex(regexp.MustCompile(`^[a-f0-9]{64}$`)),
+			),
+		},
+	})
+}
+
+func TestAcc_UpdateResourceImport(t *testing.T) {
+	data := acceptance.BuildTestData(t, "msgraph_update_resource", "test")
+
+	r := MSGraphTestUpdateResource{}
+
+	data.ResourceTest(t, r, []resource.TestStep{
+		{
+			Config: r.basic("Demo App Updated"),
+			Check: resource.ComposeTestCheckFunc(
+				check.That(data.ResourceName).Exists(r),
+			),
+		},
+		data.ImportStepWithImportStateIdFunc(r.ImportIdFunc, "body", "output", "retry"),
+		data.ImportStepWithImportStateIdFunc(r.ImportIdFuncWithBetaApiVersion, "body", "output", "retry", "api_version"),
+		{
+			Config: r.basic("Demo App Updated Again"),
+			Check: resource.ComposeTestCheckFunc(
+				check.That(data.ResourceName).Exists(r),
+			),
+		},
+	})
+}
+
+func TestAcc_UpdateResourceRestoreOnDelete(t *testing.T) {
+	data := acceptance.BuildTestData(t, "msgraph_update_resource", "test")
+
+	r := MSGraphTestUpdateResource{}
+
+	data.ResourceTest(t, r, []resource.TestStep{
+		{
+			Config: r.restoreOnDelete("Demo App Updated"),
+			Check: resource.ComposeTestCheckFunc(
+				check.That(data.ResourceName).Exists(r),
+				check.That(data.ResourceName).Key("restore_on_delete").HasValue("true"),
+			),
+		},
+		{
+			Config: r.restoreOnDelete("Demo App Updated Again"),
+			Check: resource.ComposeTestCheckFunc(
+				check.That(data.ResourceName).Exists(r),
+			),
+		},
+		{
+			Config: r.applicationOnlyWithDataSource(),
+		},
+		{
+			Config: r.applicationOnlyWithDataSource(),
+			Check: resource.ComposeTestCheckFunc(
+				check.That("data.msgraph_resource.test").Key("output.displayName").HasValue("Demo App"),
+			),
+		},
+	})
+}
+
+func (r MSGraphTestUpdateResource) ImportIdFunc(tfState *terraform.State) (string, error) {
+	state := tfState.RootModule().Resources["msgraph_update_resource.test"].Primary
+	return state.Attributes["url"], nil
+}
+
+func (r MSGraphTestUpdateResource) ImportIdFuncWithBetaApiVersion(tfState *terraform.State) (string, error) {
+	state := tfState.RootModule().Resources["msgraph_update_resource.test"].Primary
+	return fmt.Sprintf("%s?api-version=beta", state.Attributes["url"]), nil
+}
+
 func (r MSGraphTestUpdateResource) Exists(ctx context.Context, client *clients.Client, state *terraform.InstanceState) (*bool, error) {
 	apiVersion := state.Attributes["api_version"]
 	url := state.Attributes["url"]
 
-	_, err := client.MSGraphClient.Read(ctx, url, apiVersion, clients.DefaultRequestOptions())
+	var err error
+	if state.Attributes["raw_body_base64"] != "" {
+		_, err = client.MSGraphClient.ReadRaw(ctx, url, apiVersion, clients.DefaultRequestOptions())
+	} else {
+		_, err = client.MSGraphClient.Read(ctx, url, apiVersion, clients.DefaultRequestOptions())
+	}
 	if err == nil {
 		b := true
 		return &b, nil
@@ -279,6 +377,31 @@ resource "msgraph_update_resource" "test" {
 `
 }
 
+func (r MSGraphTestUpdateResource) groupPhoto(fill color.RGBA) string {
+	img := image.NewRGBA(image.Rect(0, 0, 64, 64))
+	draw.Draw(img, img.Bounds(), &image.Uniform{C: fill}, image.Point{}, draw.Src)
+	var buf bytes.Buffer
+	_ = jpeg.Encode(&buf, img, nil)
+
+	return fmt.Sprintf(`
+resource "msgraph_resource" "group" {
+  url = "groups"
+  body = {
+    displayName     = "acctest-group-photo"
+    mailEnabled     = false
+    mailNickname    = "acctest-group-photo"
+    securityEnabled = true
+  }
+}
+
+resource "msgraph_update_resource" "test" {
+  url             = "groups/${msgraph_resource.group.id}/photo/$value"
+  raw_body_base64 = "%s"
+  content_type    = "image/jpeg"
+}
+`, base64.StdEncoding.EncodeToString(buf.Bytes()))
+}
+
 func (r MSGraphTestUpdateResource) groupWithOwnerBase() string {
 	return `
 resource "msgraph_resource" "application" {
@@ -434,3 +557,31 @@ resource "msgraph_update_resource" "test" {
 }
 `, displayName)
 }
+
+func (r MSGraphTestUpdateResource) restoreOnDelete(displayName string) string {
+	return fmt.Sprintf(`
+%s
+
+resource "msgraph_update_resource" "test" {
+  url               = "applications/${msgraph_resource.application.id}"
+  restore_on_delete = true
+  body = {
+    displayName = "%s"
+    description = "Managed by msgraph_update_resource"
+  }
+}
+`, MSGraphTestUpdateResource{}.applicationOnly(), displayName)
+}
+
+func (r MSGraphTestUpdateResource) applicationOnlyWithDataSource() string {
+	return fmt.Sprintf(`
+%s
+
+data "msgraph_resource" "test" {
+  url = "applications/${msgraph_resource.application.id}"
+  response_export_values = {
+    displayName = "displayName"
+  }
+}
+`, MSGraphTestUpdateResource{}.applicationOnly())
+}
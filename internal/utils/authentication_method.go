@@ -0,0 +1,37 @@
+package utils
+
+import (
+	"regexp"
+	"strings"
+)
+
+// authenticationMethodUrlRegex matches the URLs of the authentication methods of a user, either the collection, e.g.
+// `users/{id}/authentication/phoneMethods`, or an item of it, e.g. `me/authentication/emailMethods/{id}`.
+var authenticationMethodUrlRegex = regexp.MustCompile(`(?i)^(?:users/[^/]+|me)/authentication/([^/]+Methods)(?:/[^/]+)?$`)
+
+// AuthenticationMethodCollection returns the name of the collection of the authentication methods of a user in the
+// URL, e.g. `phoneMethods` for `users/{id}/authentication/phoneMethods/{id}`, or an empty string if the URL is not one
+// of an authentication method.
+func AuthenticationMethodCollection(url string) string {
+	if matches := authenticationMethodUrlRegex.FindStringSubmatch(strings.Trim(url, "/")); len(matches) == 2 {
+		return matches[1]
+	}
+	return ""
+}
+
+// PhoneNumbersEqual returns whether the phone numbers are the same number. The phone numbers are compared without the
+// formatting characters, because Microsoft Graph returns them in the `+{country code} {subscriber number}` format,
+// e.g. `+1 2065555555` for `+1 (206) 555-5555`. The extensions, e.g. `x1234`, are compared too.
+func PhoneNumbersEqual(a string, b string) bool {
+	return normalizePhoneNumber(a) == normalizePhoneNumber(b)
+}
+
+func normalizePhoneNumber(input string) string {
+	var sb strings.Builder
+	for _, c := range strings.ToLower(input) {
+		if c == '+' || c == 'x' || (c >= '0' && c <= '9') {
+			sb.WriteRune(c)
+		}
+	}
+	return sb.String()
+}
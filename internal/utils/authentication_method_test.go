@@ -0,0 +1,87 @@
+package utils
+
+import (
+	"testing"
+)
+
+func TestAuthenticationMethodCollection(t *testing.T) {
+	testcases := []struct {
+		url      string
+		expected string
+	}{
+		{
+			url:      "users/00000000-0000-0000-0000-000000000000/authentication/phoneMethods",
+			expected: "phoneMethods",
+		},
+		{
+			url:      "users/user@example.com/authentication/emailMethods/3ddfcfc8-9383-446f-83cc-3ab9be4be18f",
+			expected: "emailMethods",
+		},
+		{
+			url:      "/me/authentication/phoneMethods/3179e48a-750b-4051-897c-87b9720928f7",
+			expected: "phoneMethods",
+		},
+		{
+			url:      "users/00000000-0000-0000-0000-000000000000/authentication/methods",
+			expected: "",
+		},
+		{
+			url:      "users/00000000-0000-0000-0000-000000000000/authentication/phoneMethods/3179e48a-750b-4051-897c-87b9720928f7/enableSmsSignIn",
+			expected: "",
+		},
+		{
+			url:      "policies/authenticationMethodsPolicy/authenticationMethodConfigurations",
+			expected: "",
+		},
+	}
+
+	for _, tc := range testcases {
+		t.Run(tc.url, func(t *testing.T) {
+			if actual := AuthenticationMethodCollection(tc.url); actual != tc.expected {
+				t.Fatalf("expected %q, got %q", tc.expected, actual)
+			}
+		})
+	}
+}
+
+func TestPhoneNumbersEqual(t *testing.T) {
+	testcases := []struct {
+		a        string
+		b        string
+		expected bool
+	}{
+		{
+			a:        "+1 2065555555",
+			b:        "+1 2065555555",
+			expected: true,
+		},
+		{
+			a:        "+1 (206) 555-5555",
+			b:        "+1 2065555555",
+			expected: true,
+		},
+		{
+			a:        "+1 206.555.5555 X1234",
+			b:        "+1 2065555555x1234",
+			expected: true,
+		},
+		{
+			a:        "+1 2065555555",
+			b:        "+1 2065555556",
+			expected: false,
+		},
+		{
+			a:        "+1 2065555555",
+			b:        "+1 2065555555x1234",
+			expected: false,
+		},
+	}
+
+	for _, tc := range testcases {
+		t.Run(tc.a+" "+tc.b, func(t *testing.T) {
+			if actual := PhoneNumbersEqual(tc.a, tc.b); actual != tc.expected {
+				t.Fatalf("expected %v, got %v", tc.expected, actual)
+			}
+		})
+	}
+}
@@ -14,19 +14,65 @@ import (
 
 type ChangeFunc func(ctx context.Context) (*bool, error)
 
+// DefaultPollInterval is the minimum delay between two checks of a change.
+const DefaultPollInterval = 5 * time.Second
+
+// continuousTargetOccurence is the number of consecutive checks which must observe a change before it's considered
+// consistent.
+const continuousTargetOccurence = 3
+
+// PollOptions configures how the consistency of a change is checked.
+type PollOptions struct {
+	// PollInterval is the minimum delay between two checks, the delay grows exponentially up to 10 seconds unless
+	// it's longer. Defaults to DefaultPollInterval.
+	PollInterval time.Duration
+
+	// MaxAttempts is the maximum number of checks, including the consecutive checks which confirm the change.
+	// Defaults to 0, the checks are only bounded by the deadline of the context.
+	MaxAttempts int
+}
+
+func (o PollOptions) minTimeout() time.Duration {
+	if o.PollInterval <= 0 {
+		return DefaultPollInterval
+	}
+	return o.PollInterval
+}
+
+// limit returns a ChangeFunc which fails once the change has been checked MaxAttempts times.
+func (o PollOptions) limit(f ChangeFunc) ChangeFunc {
+	if o.MaxAttempts <= 0 {
+		return f
+	}
+	attempts := 0
+	return func(ctx context.Context) (*bool, error) {
+		if attempts >= o.MaxAttempts {
+			return nil, fmt.Errorf("the change is not consistent after %d attempts", o.MaxAttempts)
+		}
+		attempts++
+		return f(ctx)
+	}
+}
+
 func WaitForDeletion(ctx context.Context, f ChangeFunc) error {
+	return WaitForDeletionWithOptions(ctx, PollOptions{}, f)
+}
+
+// WaitForDeletionWithOptions waits until the resource doesn't exist, checking it as configured by the options.
+func WaitForDeletionWithOptions(ctx context.Context, options PollOptions, f ChangeFunc) error {
 	deadline, ok := ctx.Deadline()
 	if !ok {
 		return errors.New("context has no deadline")
 	}
 
+	f = options.limit(f)
 	timeout := time.Until(deadline)
 	_, err := (&retry.StateChangeConf{ //nolint:staticcheck
 		Pending:                   []string{"Waiting"},
 		Target:                    []string{"Deleted"},
 		Timeout:                   timeout,
-		MinTimeout:                5 * time.Second,
-		ContinuousTargetOccurence: 3,
+		MinTimeout:                options.minTimeout(),
+		ContinuousTargetOccurence: continuousTargetOccurence,
 		Refresh: func() (interface{}, string, error) {
 			exists, err := f(ctx)
 			if err != nil {
@@ -46,22 +92,32 @@ func WaitForDeletion(ctx context.Context, f ChangeFunc) error {
 }
 
 func WaitForUpdate(ctx context.Context, f ChangeFunc) error {
+	return WaitForUpdateWithOptions(ctx, PollOptions{}, f)
+}
+
+// WaitForUpdateWithOptions waits until the change is observed, checking it as configured by the options.
+func WaitForUpdateWithOptions(ctx context.Context, options PollOptions, f ChangeFunc) error {
 	deadline, ok := ctx.Deadline()
 	if !ok {
 		return errors.New("context has no deadline")
 	}
 
-	_, err := WaitForUpdateWithTimeout(ctx, time.Until(deadline), f)
+	_, err := waitForUpdate(ctx, time.Until(deadline), options, f)
 	return err
 }
 
 func WaitForUpdateWithTimeout(ctx context.Context, timeout time.Duration, f ChangeFunc) (bool, error) {
+	return waitForUpdate(ctx, timeout, PollOptions{}, f)
+}
+
+func waitForUpdate(ctx context.Context, timeout time.Duration, options PollOptions, f ChangeFunc) (bool, error) {
+	f = options.limit(f)
 	res, err := (&retry.StateChangeConf{ //nolint:staticcheck
 		Pending:                   []string{"Waiting"},
 		Target:                    []string{"Done"},
 		Timeout:                   timeout,
-		MinTimeout:                5 * time.Second,
-		ContinuousTargetOccurence: 3,
+		MinTimeout:                options.minTimeout(),
+		ContinuousTargetOccurence: continuousTargetOccurence,
 		Refresh: func() (interface{}, string, error) {
 			updated, err := f(ctx)
 			if err != nil {
@@ -121,3 +177,26 @@ func WaitForUpdateWithTimeoutDelayStart(ctx context.Context, timeout, delay time
 	}
 	return res.(bool), err
 }
+
+// WaitForVisible checks the existence of a resource up to the number of attempts, waiting the interval between the
+// checks, until it's observed. This gives a resource which was just created, e.g. the member of a group, the time to
+// be replicated before it's reported as missing. It returns false if none of the checks observed the resource.
+func WaitForVisible(ctx context.Context, attempts int, interval time.Duration, f ChangeFunc) (bool, error) {
+	for attempt := 1; ; attempt++ {
+		exists, err := f(ctx)
+		if err != nil {
+			return false, err
+		}
+		if exists == nil {
+			return false, fmt.Errorf("retrieving resource: exists was nil")
+		}
+		if *exists || attempt >= attempts {
+			return *exists, nil
+		}
+		select {
+		case <-ctx.Done():
+			return false, ctx.Err()
+		case <-time.After(interval):
+		}
+	}
+}
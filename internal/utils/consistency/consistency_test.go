@@ -0,0 +1,157 @@
+package consistency
+
+import (
+	"context"
+	"regexp"
+	"testing"
+	"time"
+)
+
+func TestWaitForUpdateWithOptions(t *testing.T) {
+	testcases := []struct {
+		name       string
+		exists     bool
+		options    PollOptions
+		timeout    time.Duration
+		wantErr    string
+		wantChecks int
+		minChecks  int
+	}{
+		{
+			name:       "observed",
+			exists:     true,
+			options:    PollOptions{PollInterval: 10 * time.Millisecond, MaxAttempts: 5},
+			timeout:    time.Minute,
+			wantChecks: 3,
+		},
+		{
+			name:       "max attempts",
+			exists:     false,
+			options:    PollOptions{PollInterval: 10 * time.Millisecond, MaxAttempts: 4},
+			timeout:    time.Minute,
+			wantErr:    "not consistent after 4 attempts",
+			wantChecks: 4,
+		},
+		{
+			name:      "deadline",
+			exists:    false,
+			options:   PollOptions{PollInterval: 10 * time.Millisecond},
+			timeout:   500 * time.Millisecond,
+			wantErr:   "timeout|deadline exceeded",
+			minChecks: 1,
+		},
+	}
+
+	for _, tc := range testcases {
+		t.Run(tc.name, func(t *testing.T) {
+			ctx, cancel := context.WithTimeout(context.Background(), tc.timeout)
+			defer cancel()
+
+			checks := 0
+			err := WaitForUpdateWithOptions(ctx, tc.options, func(ctx context.Context) (*bool, error) {
+				checks++
+				return &tc.exists, nil
+			})
+			if tc.wantErr == "" && err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if tc.wantErr != "" && (err == nil || !regexp.MustCompile(tc.wantErr).MatchString(err.Error())) {
+				t.Fatalf("expected error containing %q, got %v", tc.wantErr, err)
+			}
+			if tc.wantChecks != 0 && checks != tc.wantChecks {
+				t.Fatalf("expected %d checks, got %d", tc.wantChecks, checks)
+			}
+			if checks < tc.minChecks {
+				t.Fatalf("expected at least %d checks, got %d", tc.minChecks, checks)
+			}
+		})
+	}
+}
+
+func TestWaitForDeletionWithOptions_MaxAttempts(t *testing.T) {
+	ctx, cancel := context.WithTimeout(context.Background(), time.Minute)
+	defer cancel()
+
+	exists := true
+	checks := 0
+	err := WaitForDeletionWithOptions(ctx, PollOptions{PollInterval: 10 * time.Millisecond, MaxAttempts: 3}, func(ctx context.Context) (*bool, error) {
+		checks++
+		return &exists, nil
+	})
+	if err == nil || !regexp.MustCompile("not consistent after 3 attempts").MatchString(err.Error()) {
+		t.Fatalf("expected max attempts error, got %v", err)
+	}
+	if checks != 3 {
+		t.Fatalf("expected 3 checks, got %d", checks)
+	}
+}
+
+func TestPollOptions_minTimeout(t *testing.T) {
+	if got := (PollOptions{}).minTimeout(); got != DefaultPollInterval {
+		t.Fatalf("expected %v, got %v", DefaultPollInterval, got)
+	}
+	if got := (PollOptions{PollInterval: 2 * time.Second}).minTimeout(); got != 2*time.Second {
+		t.Fatalf("expected 2s, got %v", got)
+	}
+}
+
+func TestWaitForVisible(t *testing.T) {
+	testcases := []struct {
+		name       string
+		visibleAt  int
+		attempts   int
+		want       bool
+		wantChecks int
+	}{
+		{
+			name:       "visible immediately",
+			visibleAt:  1,
+			attempts:   3,
+			want:       true,
+			wantChecks: 1,
+		},
+		{
+			name:       "delayed visibility",
+			visibleAt:  3,
+			attempts:   3,
+			want:       true,
+			wantChecks: 3,
+		},
+		{
+			name:       "not visible",
+			visibleAt:  5,
+			attempts:   3,
+			want:       false,
+			wantChecks: 3,
+		},
+	}
+
+	for _, tc := range testcases {
+		t.Run(tc.name, func(t *testing.T) {
+			checks := 0
+			got, err := WaitForVisible(context.Background(), tc.attempts, time.Millisecond, func(ctx context.Context) (*bool, error) {
+				checks++
+				exists := checks >= tc.visibleAt
+				return &exists, nil
+			})
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if got != tc.want || checks != tc.wantChecks {
+				t.Fatalf("expected %t after %d checks, got %t after %d checks", tc.want, tc.wantChecks, got, checks)
+			}
+		})
+	}
+}
+
+func TestWaitForVisible_Canceled(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	_, err := WaitForVisible(ctx, 3, time.Minute, func(ctx context.Context) (*bool, error) {
+		exists := false
+		return &exists, nil
+	})
+	if err == nil {
+		t.Fatal("expected an error, got nil")
+	}
+}
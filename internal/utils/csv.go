@@ -0,0 +1,40 @@
+package utils
+
+import (
+	"bytes"
+	"encoding/csv"
+	"fmt"
+	"io"
+)
+
+var utf8BOM = []byte{0xEF, 0xBB, 0xBF}
+
+// ParseCSV parses CSV content with a header row, e.g. the usage reports of the Microsoft Graph API,
+// into a list of objects keyed by the column names. The values are kept as strings.
+func ParseCSV(data []byte) ([]interface{}, error) {
+	reader := csv.NewReader(bytes.NewReader(bytes.TrimPrefix(data, utf8BOM)))
+	headers, err := reader.Read()
+	if err == io.EOF {
+		return []interface{}{}, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("reading the CSV header: %w", err)
+	}
+
+	res := make([]interface{}, 0)
+	for {
+		record, err := reader.Read()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return nil, fmt.Errorf("reading the CSV records: %w", err)
+		}
+		item := make(map[string]interface{}, len(headers))
+		for i, header := range headers {
+			item[header] = record[i]
+		}
+		res = append(res, item)
+	}
+	return res, nil
+}
@@ -0,0 +1,65 @@
+package utils
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestParseCSV(t *testing.T) {
+	testcases := []struct {
+		name      string
+		input     string
+		want      []interface{}
+		wantError bool
+	}{
+		{
+			name:  "empty content",
+			input: "",
+			want:  []interface{}{},
+		},
+		{
+			name:  "header only",
+			input: "Report Refresh Date,User Principal Name\n",
+			want:  []interface{}{},
+		},
+		{
+			name:  "records with byte order mark and quoted values",
+			input: "\xEF\xBB\xBFReport Refresh Date,User Principal Name,Display Name\n2024-01-01,user1@contoso.com,\"Doe, John\"\n2024-01-01,user2@contoso.com,Jane\n",
+			want: []interface{}{
+				map[string]interface{}{
+					"Report Refresh Date": "2024-01-01",
+					"User Principal Name": "user1@contoso.com",
+					"Display Name":        "Doe, John",
+				},
+				map[string]interface{}{
+					"Report Refresh Date": "2024-01-01",
+					"User Principal Name": "user2@contoso.com",
+					"Display Name":        "Jane",
+				},
+			},
+		},
+		{
+			name:      "record with a wrong number of fields",
+			input:     "a,b\n1,2,3\n",
+			wantError: true,
+		},
+	}
+
+	for _, tc := range testcases {
+		t.Run(tc.name, func(t *testing.T) {
+			got, err := ParseCSV([]byte(tc.input))
+			if tc.wantError {
+				if err == nil {
+					t.Fatalf("expected an error, got %v", got)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if !reflect.DeepEqual(got, tc.want) {
+				t.Fatalf("expected %v, got %v", tc.want, got)
+			}
+		})
+	}
+}
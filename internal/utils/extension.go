@@ -0,0 +1,25 @@
+package utils
+
+import (
+	"regexp"
+)
+
+// extensionAttributeNameRegex matches the names of the directory extension attributes, which are the names of the
+// extension properties prefixed with the app ID of the application they're registered on without dashes, e.g.
+// `extension_b7d8e648520f41d3b9d0fdeb0c4ba8e3_jobGroup`.
+var extensionAttributeNameRegex = regexp.MustCompile(`^extension_[0-9a-fA-F]{32}_(.+)$`)
+
+// IsExtensionAttributeName returns whether the property name is the name of a directory extension attribute.
+func IsExtensionAttributeName(name string) bool {
+	return extensionAttributeNameRegex.MatchString(name)
+}
+
+// ExtensionPropertyName returns the name of the extension property of a directory extension attribute, e.g. `jobGroup`
+// for `extension_b7d8e648520f41d3b9d0fdeb0c4ba8e3_jobGroup`. The input is returned as is if it isn't an extension
+// attribute name.
+func ExtensionPropertyName(name string) string {
+	if matches := extensionAttributeNameRegex.FindStringSubmatch(name); len(matches) == 2 {
+		return matches[1]
+	}
+	return name
+}
@@ -0,0 +1,50 @@
+package utils
+
+import (
+	"testing"
+)
+
+func TestExtensionPropertyName(t *testing.T) {
+	testcases := []struct {
+		name              string
+		input             string
+		expectedExtension bool
+		expected          string
+	}{
+		{
+			name:              "extension attribute",
+			input:             "extension_b7d8e648520f41d3b9d0fdeb0c4ba8e3_jobGroup",
+			expectedExtension: true,
+			expected:          "jobGroup",
+		},
+		{
+			name:              "extension attribute with underscores",
+			input:             "extension_B7D8E648520F41D3B9D0FDEB0C4BA8E3_cost_center",
+			expectedExtension: true,
+			expected:          "cost_center",
+		},
+		{
+			name:              "app ID with dashes",
+			input:             "extension_b7d8e648-520f-41d3-b9d0-fdeb0c4ba8e3_jobGroup",
+			expectedExtension: false,
+			expected:          "extension_b7d8e648-520f-41d3-b9d0-fdeb0c4ba8e3_jobGroup",
+		},
+		{
+			name:              "regular property",
+			input:             "jobTitle",
+			expectedExtension: false,
+			expected:          "jobTitle",
+		},
+	}
+
+	for _, tc := range testcases {
+		t.Run(tc.name, func(t *testing.T) {
+			if actual := IsExtensionAttributeName(tc.input); actual != tc.expectedExtension {
+				t.Fatalf("expected IsExtensionAttributeName %v, got %v", tc.expectedExtension, actual)
+			}
+			if actual := ExtensionPropertyName(tc.input); actual != tc.expected {
+				t.Fatalf("expected %q, got %q", tc.expected, actual)
+			}
+		})
+	}
+}
@@ -6,3 +6,23 @@ func LastSegment(input string) string {
 	index := strings.LastIndex(input, "/")
 	return input[index+1:]
 }
+
+// ItemUrl returns the canonical URL of an item of a collection, which can be used as the `url` of other resources. The
+// `$ref` segment of reference collections and the redundant slashes are removed, e.g. the collection
+// `/groups/{group-id}/members/$ref` and the ID `{member-id}` result in `groups/{group-id}/members/{member-id}`.
+// The canonical URL of the collection is returned if the ID is empty, e.g. for the objects created with PUT.
+func ItemUrl(collectionUrl string, id string) string {
+	segments := make([]string, 0)
+	for _, segment := range strings.Split(collectionUrl, "/") {
+		if segment != "" {
+			segments = append(segments, segment)
+		}
+	}
+	if len(segments) != 0 && segments[len(segments)-1] == "$ref" {
+		segments = segments[:len(segments)-1]
+	}
+	if id != "" {
+		segments = append(segments, id)
+	}
+	return strings.Join(segments, "/")
+}
@@ -0,0 +1,51 @@
+package utils
+
+import "testing"
+
+func TestItemUrl(t *testing.T) {
+	testcases := []struct {
+		name          string
+		collectionUrl string
+		id            string
+		expected      string
+	}{
+		{
+			name:          "collection",
+			collectionUrl: "groups",
+			id:            "00000000-0000-0000-0000-000000000001",
+			expected:      "groups/00000000-0000-0000-0000-000000000001",
+		},
+		{
+			name:          "deep path",
+			collectionUrl: "applications/00000000-0000-0000-0000-000000000001/federatedIdentityCredentials",
+			id:            "00000000-0000-0000-0000-000000000002",
+			expected:      "applications/00000000-0000-0000-0000-000000000001/federatedIdentityCredentials/00000000-0000-0000-0000-000000000002",
+		},
+		{
+			name:          "reference collection",
+			collectionUrl: "groups/00000000-0000-0000-0000-000000000001/members/$ref",
+			id:            "00000000-0000-0000-0000-000000000002",
+			expected:      "groups/00000000-0000-0000-0000-000000000001/members/00000000-0000-0000-0000-000000000002",
+		},
+		{
+			name:          "reference collection with slashes",
+			collectionUrl: "/groups/00000000-0000-0000-0000-000000000001//owners/$ref/",
+			id:            "00000000-0000-0000-0000-000000000002",
+			expected:      "groups/00000000-0000-0000-0000-000000000001/owners/00000000-0000-0000-0000-000000000002",
+		},
+		{
+			name:          "object created with PUT",
+			collectionUrl: "/policies/crossTenantAccessPolicy/default/",
+			id:            "",
+			expected:      "policies/crossTenantAccessPolicy/default",
+		},
+	}
+
+	for _, tc := range testcases {
+		t.Run(tc.name, func(t *testing.T) {
+			if actual := ItemUrl(tc.collectionUrl, tc.id); actual != tc.expected {
+				t.Fatalf("expected %q, got %q", tc.expected, actual)
+			}
+		})
+	}
+}
@@ -1,6 +1,9 @@
 package utils
 
 import (
+	"fmt"
+	"strconv"
+
 	jmes "github.com/jmespath/go-jmespath"
 )
 
@@ -14,3 +17,20 @@ func ExtractObjectJMES(old interface{}, pathKey, path string) interface{} {
 	result[pathKey] = value
 	return result
 }
+
+// ExtractStringJMES is used to extract a string from the object using JMES path, numbers are formatted as strings.
+func ExtractStringJMES(input interface{}, path string) (string, error) {
+	value, err := jmes.Search(path, input)
+	if err != nil {
+		return "", fmt.Errorf("failed to search %q: %w", path, err)
+	}
+	switch v := value.(type) {
+	case string:
+		if v != "" {
+			return v, nil
+		}
+	case float64:
+		return strconv.FormatFloat(v, 'f', -1, 64), nil
+	}
+	return "", fmt.Errorf("the path %q doesn't refer to a non-empty string, got %v", path, value)
+}
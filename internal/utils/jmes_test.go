@@ -0,0 +1,196 @@
+package utils
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestExtractStringJMES(t *testing.T) {
+	response := map[string]interface{}{
+		"application": map[string]interface{}{
+			"id":    "00000000-0000-0000-0000-000000000001",
+			"appId": "00000000-0000-0000-0000-000000000002",
+		},
+		"servicePrincipal": map[string]interface{}{
+			"id": "00000000-0000-0000-0000-000000000003",
+		},
+		"keyId":   "",
+		"version": float64(2),
+	}
+	testcases := []struct {
+		name        string
+		path        string
+		want        string
+		expectError bool
+	}{
+		{
+			name: "nested id",
+			path: "servicePrincipal.id",
+			want: "00000000-0000-0000-0000-000000000003",
+		},
+		{
+			name: "number",
+			path: "version",
+			want: "2",
+		},
+		{
+			name:        "missing property",
+			path:        "servicePrincipal.appId",
+			expectError: true,
+		},
+		{
+			name:        "empty string",
+			path:        "keyId",
+			expectError: true,
+		},
+		{
+			name:        "object",
+			path:        "application",
+			expectError: true,
+		},
+		{
+			name:        "invalid path",
+			path:        "servicePrincipal.[",
+			expectError: true,
+		},
+	}
+	for _, tc := range testcases {
+		t.Run(tc.name, func(t *testing.T) {
+			got, err := ExtractStringJMES(response, tc.path)
+			if tc.expectError {
+				if err == nil {
+					t.Fatalf("expected an error, got %q", got)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if got != tc.want {
+				t.Fatalf("ExtractStringJMES() = %q, want %q", got, tc.want)
+			}
+		})
+	}
+}
+
+func TestExtractObjectJMES(t *testing.T) {
+	// the body of a paged collection after the items of all pages are aggregated into value
+	collection := map[string]interface{}{
+		"@odata.context": "https://graph.microsoft.com/v1.0/$metadata#users",
+		"value": []interface{}{
+			map[string]interface{}{"id": "1", "displayName": "Alice", "accountEnabled": true, "tags": []interface{}{"a", "b"}},
+			map[string]interface{}{"id": "2", "displayName": "Bob", "accountEnabled": false, "tags": []interface{}{"c"}},
+			map[string]interface{}{"id": "3", "displayName": "Carol", "accountEnabled": true, "tags": []interface{}{}},
+		},
+	}
+	testcases := []struct {
+		name string
+		path string
+		want interface{}
+	}{
+		{
+			name: "list projection",
+			path: "value[].displayName",
+			want: []interface{}{"Alice", "Bob", "Carol"},
+		},
+		{
+			name: "filter projection",
+			path: "value[?accountEnabled].id",
+			want: []interface{}{"1", "3"},
+		},
+		{
+			name: "filter with comparison",
+			path: "value[?displayName == 'Bob'].id | [0]",
+			want: "2",
+		},
+		{
+			name: "flatten",
+			path: "value[].tags[]",
+			want: []interface{}{"a", "b", "c"},
+		},
+		{
+			name: "function",
+			path: "length(value)",
+			want: float64(3),
+		},
+		{
+			name: "multiselect hash projection",
+			path: "value[?!accountEnabled].{id: id, name: displayName}",
+			want: []interface{}{map[string]interface{}{"id": "2", "name": "Bob"}},
+		},
+		{
+			name: "empty projection",
+			path: "value[?id == '4'].id",
+			want: []interface{}{},
+		},
+		{
+			name: "missing property",
+			path: "nextLink",
+			want: nil,
+		},
+		{
+			name: "current node",
+			path: "@",
+			want: collection,
+		},
+	}
+	for _, tc := range testcases {
+		t.Run(tc.name, func(t *testing.T) {
+			got := ExtractObjectJMES(collection, "result", tc.path)
+			want := map[string]interface{}{"result": tc.want}
+			if !reflect.DeepEqual(got, want) {
+				t.Fatalf("ExtractObjectJMES() = %#v, want %#v", got, want)
+			}
+		})
+	}
+
+	if got := ExtractObjectJMES(collection, "result", "value[?"); got != nil {
+		t.Fatalf("expected nil for an invalid path, got %#v", got)
+	}
+}
+
+func TestExtractObjectJMES_MergeLists(t *testing.T) {
+	collection := map[string]interface{}{
+		"value": []interface{}{
+			map[string]interface{}{"id": "1", "displayName": "Alice"},
+			map[string]interface{}{"id": "2", "displayName": "Bob"},
+		},
+	}
+	var output interface{} = make(map[string]interface{})
+	output = MergeObject(output, ExtractObjectJMES(collection, "ids", "value[].id"))
+	output = MergeObject(output, ExtractObjectJMES(collection, "count", "length(value)"))
+	output = MergeObject(output, ExtractObjectJMES(collection, "first", "value[0]"))
+
+	want := map[string]interface{}{
+		"ids":   []interface{}{"1", "2"},
+		"count": float64(2),
+		"first": map[string]interface{}{"id": "1", "displayName": "Alice"},
+	}
+	if !reflect.DeepEqual(output, want) {
+		t.Fatalf("merged output = %#v, want %#v", output, want)
+	}
+}
+
+func TestExtractObjectJMES_CurrentNode(t *testing.T) {
+	body := map[string]interface{}{
+		"@odata.context": "https://graph.microsoft.com/v1.0/$metadata#applications/$entity",
+		"id":             "1",
+		"displayName":    "Demo App",
+		"description":    nil,
+		"tags":           []interface{}{},
+		"api": map[string]interface{}{
+			"oauth2PermissionScopes": []interface{}{map[string]interface{}{"value": "read"}},
+		},
+	}
+	var output interface{} = make(map[string]interface{})
+	output = MergeObject(output, ExtractObjectJMES(body, "full", "@"))
+	output = MergeObject(output, ExtractObjectJMES(body, "id", "id"))
+
+	want := map[string]interface{}{
+		"full": body,
+		"id":   "1",
+	}
+	if !reflect.DeepEqual(output, want) {
+		t.Fatalf("merged output = %#v, want %#v", output, want)
+	}
+}
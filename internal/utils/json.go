@@ -29,16 +29,24 @@ func NormalizeJson(input interface{}) string {
 
 // MergeObject is used to merge object old and new, if overlaps, use new value
 func MergeObject(old interface{}, new interface{}) interface{} {
+	return MergeObjectWithPrevious(old, new, nil)
+}
+
+// MergeObjectWithPrevious merges object old and new like MergeObject, previous is the value which new replaces.
+// The items of the arrays which are identified by their keys, e.g. `id`, are only kept from old when they're not in
+// previous, so that the items removed from new are also removed from the result.
+func MergeObjectWithPrevious(old interface{}, new interface{}, previous interface{}) interface{} {
 	if new == nil {
 		return new
 	}
 	switch oldValue := old.(type) {
 	case map[string]interface{}:
 		if newMap, ok := new.(map[string]interface{}); ok {
+			previousMap, _ := previous.(map[string]interface{})
 			res := make(map[string]interface{})
 			for key, value := range oldValue {
 				if _, ok := newMap[key]; ok {
-					res[key] = MergeObject(value, newMap[key])
+					res[key] = MergeObjectWithPrevious(value, newMap[key], previousMap[key])
 				} else {
 					res[key] = value
 				}
@@ -52,6 +60,10 @@ func MergeObject(old interface{}, new interface{}) interface{} {
 		}
 	case []interface{}:
 		if newArr, ok := new.([]interface{}); ok {
+			if len(oldValue) != 0 && allHaveIdentifiers(oldValue) && allHaveIdentifiers(newArr) {
+				previousArr, _ := previous.([]interface{})
+				return mergeArrayItemsByIdentifier(oldValue, newArr, previousArr)
+			}
 			if len(oldValue) != len(newArr) {
 				return newArr
 			}
@@ -65,6 +77,53 @@ func MergeObject(old interface{}, new interface{}) interface{} {
 	return new
 }
 
+// mergeArrayItemsByIdentifier merges the items with the same identifier regardless of their positions. The new items
+// are returned in their order, followed by the old items whose identifiers are neither in new nor in previous,
+// like the keys of a map which are missing in new are kept. The items in previous but not in new have been removed.
+func mergeArrayItemsByIdentifier(oldArr []interface{}, newArr []interface{}, previousArr []interface{}) []interface{} {
+	res := make([]interface{}, 0)
+	merged := make([]bool, len(oldArr))
+	for _, newItem := range newArr {
+		found := false
+		for index, oldItem := range oldArr {
+			if !merged[index] && areSameArrayItems(oldItem, newItem) {
+				res = append(res, MergeObjectWithPrevious(oldItem, newItem, sameArrayItem(previousArr, newItem)))
+				merged[index] = true
+				found = true
+				break
+			}
+		}
+		if !found {
+			res = append(res, newItem)
+		}
+	}
+	for index, oldItem := range oldArr {
+		if !merged[index] && sameArrayItem(previousArr, oldItem) == nil {
+			res = append(res, oldItem)
+		}
+	}
+	return res
+}
+
+// sameArrayItem returns the item of the array which has the same identifier as item, or nil if there's none.
+func sameArrayItem(arr []interface{}, item interface{}) interface{} {
+	for _, value := range arr {
+		if areSameArrayItems(value, item) {
+			return value
+		}
+	}
+	return nil
+}
+
+func allHaveIdentifiers(arr []interface{}) bool {
+	for _, item := range arr {
+		if identifierOfArrayItem(item) == "" {
+			return false
+		}
+	}
+	return true
+}
+
 type UpdateJsonOption struct {
 	IgnoreCasing          bool
 	IgnoreMissingProperty bool
@@ -73,6 +132,11 @@ type UpdateJsonOption struct {
 
 // UpdateObject is used to get an updated object which has same schema as old, but with new value
 func UpdateObject(old interface{}, new interface{}, option UpdateJsonOption) interface{} {
+	return updateObject(old, new, option, "")
+}
+
+// updateObject updates old like UpdateObject, path is the property path of old in the body, e.g. `grantControls.builtInControls`.
+func updateObject(old interface{}, new interface{}, option UpdateJsonOption, path string) interface{} {
 	if reflect.DeepEqual(old, new) {
 		return old
 	}
@@ -84,9 +148,14 @@ func UpdateObject(old interface{}, new interface{}, option UpdateJsonOption) int
 				switch {
 				case value == nil && option.IgnoreNullProperty:
 					res[key] = nil
+				case key == odataTypeKey && isSameODataType(value, newMap[key]):
+					// The type is kept as configured, e.g. without the leading `#` the API adds when it echoes it.
+					res[key] = value
 				case newMap[key] != nil:
-					res[key] = UpdateObject(value, newMap[key], option)
-				case option.IgnoreMissingProperty || isZeroValue(value):
+					res[key] = updateObject(value, newMap[key], option, propertyPath(path, key))
+				case option.IgnoreMissingProperty || isZeroValue(value) || key == odataTypeKey:
+					// The @odata.type is not always returned in the response, but it's required as a discriminator
+					// in the request, so it's kept as configured. The other OData metadata fields are compared.
 					res[key] = value
 				}
 			}
@@ -103,9 +172,16 @@ func UpdateObject(old interface{}, new interface{}, option UpdateJsonOption) int
 				if len(oldValue) != len(newArr) {
 					return newArr
 				}
+				// Some arrays are returned in a different order than they're configured, e.g. the grant controls
+				// of conditional access policies, so they're compared as sets first.
+				if setLikeArrayPaths[path] {
+					if res, ok := matchArrayItemsUnordered(oldValue, newArr, option, path); ok {
+						return res
+					}
+				}
 				res := make([]interface{}, 0)
 				for index := range oldValue {
-					res = append(res, UpdateObject(oldValue[index], newArr[index], option))
+					res = append(res, updateObject(oldValue[index], newArr[index], option, path))
 				}
 				return res
 			}
@@ -117,7 +193,7 @@ func UpdateObject(old interface{}, new interface{}, option UpdateJsonOption) int
 				found := false
 				for index, newItem := range newArr {
 					if reflect.DeepEqual(oldItem, newItem) && !used[index] {
-						res = append(res, UpdateObject(oldItem, newItem, option))
+						res = append(res, updateObject(oldItem, newItem, option, path))
 						used[index] = true
 						found = true
 						break
@@ -128,7 +204,7 @@ func UpdateObject(old interface{}, new interface{}, option UpdateJsonOption) int
 				}
 				for index, newItem := range newArr {
 					if areSameArrayItems(oldItem, newItem) && !used[index] {
-						res = append(res, UpdateObject(oldItem, newItem, option))
+						res = append(res, updateObject(oldItem, newItem, option, path))
 						used[index] = true
 						break
 					}
@@ -136,9 +212,15 @@ func UpdateObject(old interface{}, new interface{}, option UpdateJsonOption) int
 			}
 
 			for index, newItem := range newArr {
-				if !used[index] {
-					res = append(res, newItem)
+				if used[index] {
+					continue
 				}
+				// The name-value pairs of a template are all returned, e.g. the values of group settings, so the
+				// pairs which are not configured are ignored like missing properties.
+				if option.IgnoreMissingProperty && nameValuePairArrayPaths[path] && isNameValuePair(newItem) {
+					continue
+				}
+				res = append(res, newItem)
 			}
 			return res
 		}
@@ -155,29 +237,140 @@ func UpdateObject(old interface{}, new interface{}, option UpdateJsonOption) int
 	return new
 }
 
+// matchArrayItemsUnordered matches each old item with an equivalent new item regardless of their positions.
+// It returns the updated items in the order of old, and false if any old item has no equivalent new item.
+func matchArrayItemsUnordered(oldArr []interface{}, newArr []interface{}, option UpdateJsonOption, path string) ([]interface{}, bool) {
+	res := make([]interface{}, 0)
+	used := make([]bool, len(newArr))
+	for _, oldItem := range oldArr {
+		found := false
+		for index, newItem := range newArr {
+			if used[index] {
+				continue
+			}
+			if updated := updateObject(oldItem, newItem, option, path); reflect.DeepEqual(updated, oldItem) {
+				res = append(res, updated)
+				used[index] = true
+				found = true
+				break
+			}
+		}
+		if !found {
+			return nil, false
+		}
+	}
+	return res, true
+}
+
+// areSameArrayItems compares the items by the first identifier property which both of them have, e.g. by `name` if
+// the `id` is only returned by the API.
 func areSameArrayItems(a, b interface{}) bool {
-	aId := identifierOfArrayItem(a)
-	bId := identifierOfArrayItem(b)
-	if aId == "" || bId == "" {
-		return false
+	for _, key := range arrayItemIdentifierKeys {
+		aId := identifierValue(a, key)
+		bId := identifierValue(b, key)
+		if aId != "" && bId != "" {
+			return aId == bId
+		}
 	}
-	return aId == bId
+	return false
 }
 
+// arrayItemIdentifierKeys are the properties which identify the items of an array, in the order of their priority.
+var arrayItemIdentifierKeys = []string{"id", "name", "key", "@odata.id"}
+
+// identifierOfArrayItem returns the value of the first identifier property of the item, e.g. the `id` of an app role.
 func identifierOfArrayItem(input interface{}) string {
+	for _, key := range arrayItemIdentifierKeys {
+		if value := identifierValue(input, key); value != "" {
+			return value
+		}
+	}
+	return ""
+}
+
+func identifierValue(input interface{}, key string) string {
 	inputMap, ok := input.(map[string]interface{})
 	if !ok {
 		return ""
 	}
-	name := inputMap["name"]
-	if name == nil {
+	value, ok := inputMap[key].(string)
+	if !ok {
 		return ""
 	}
-	nameValue, ok := name.(string)
+	return value
+}
+
+// nameValuePairArrayPaths are the property paths of the arrays of name-value pairs whose pairs are all returned by the
+// API, e.g. the `values` of group settings created from a settings template.
+var nameValuePairArrayPaths = map[string]bool{
+	"values": true,
+}
+
+// isNameValuePair returns whether the array item only consists of a `name` and a `value`, like the setting values
+// created from a template.
+func isNameValuePair(input interface{}) bool {
+	inputMap, ok := input.(map[string]interface{})
+	if !ok || len(inputMap) != 2 {
+		return false
+	}
+	_, hasValue := inputMap["value"]
+	return hasValue && identifierValue(input, "name") != ""
+}
+
+// isSameODataType returns whether the values of `@odata.type` refer to the same type, which is compared without the
+// leading `#` and case-insensitively.
+func isSameODataType(a, b interface{}) bool {
+	aValue, ok := a.(string)
 	if !ok {
-		return ""
+		return false
 	}
-	return nameValue
+	bValue, ok := b.(string)
+	if !ok {
+		return false
+	}
+	return strings.EqualFold(strings.TrimPrefix(aValue, "#"), strings.TrimPrefix(bValue, "#"))
+}
+
+// setLikeArrayPaths are the property paths of the arrays whose items are compared regardless of their order, because
+// the API returns them in a different order than they're configured.
+var setLikeArrayPaths = map[string]bool{
+	"countriesAndRegions":                         true,
+	"grantControls.builtInControls":               true,
+	"grantControls.customAuthenticationFactors":   true,
+	"grantControls.termsOfUse":                    true,
+	"conditions.clientAppTypes":                   true,
+	"conditions.signInRiskLevels":                 true,
+	"conditions.userRiskLevels":                   true,
+	"conditions.applications.includeApplications": true,
+	"conditions.applications.excludeApplications": true,
+	"conditions.users.includeUsers":               true,
+	"conditions.users.excludeUsers":               true,
+	"conditions.users.includeGroups":              true,
+	"conditions.users.excludeGroups":              true,
+	"conditions.users.includeRoles":               true,
+	"conditions.users.excludeRoles":               true,
+	"conditions.locations.includeLocations":       true,
+	"conditions.locations.excludeLocations":       true,
+	"conditions.platforms.includePlatforms":       true,
+	"conditions.platforms.excludePlatforms":       true,
+}
+
+// propertyPath returns the path of the property key of the object at path.
+func propertyPath(path string, key string) string {
+	if path == "" {
+		return key
+	}
+	return path + "." + key
+}
+
+func isArrayOfPrimitives(input []interface{}) bool {
+	for _, item := range input {
+		switch item.(type) {
+		case map[string]interface{}, []interface{}:
+			return false
+		}
+	}
+	return true
 }
 
 func isZeroValue(value interface{}) bool {
@@ -211,6 +404,11 @@ func isZeroValue(value interface{}) bool {
 // This is required for Microsoft Graph API endpoints that use polymorphic types and
 // need the discriminator field (@odata.type) to be present in PATCH requests.
 func DiffObject(old interface{}, new interface{}, option UpdateJsonOption) interface{} {
+	return diffObject(old, new, option, "")
+}
+
+// diffObject computes the patch like DiffObject, path is the property path of old in the body, e.g. `grantControls.builtInControls`.
+func diffObject(old interface{}, new interface{}, option UpdateJsonOption, path string) interface{} {
 	if reflect.DeepEqual(old, new) {
 		return nil
 	}
@@ -220,8 +418,16 @@ func DiffObject(old interface{}, new interface{}, option UpdateJsonOption) inter
 			res := make(map[string]interface{})
 			// include keys present in new
 			for key, newVal := range newMap {
+				if newVal == nil {
+					// A property explicitly set to null is deleted by PATCH, so it's sent unless it was already null,
+					// unlike an absent property which is left as is.
+					if oldVal, ok := oldValue[key]; !ok || oldVal != nil {
+						res[key] = nil
+					}
+					continue
+				}
 				if oldVal, ok := oldValue[key]; ok {
-					if d := DiffObject(oldVal, newVal, option); d != nil {
+					if d := diffObject(oldVal, newVal, option, propertyPath(path, key)); d != nil {
 						res[key] = d
 					}
 				} else {
@@ -253,6 +459,13 @@ func DiffObject(old interface{}, new interface{}, option UpdateJsonOption) inter
 			if reflect.DeepEqual(oldValue, newArr) {
 				return nil
 			}
+			// Some arrays of primitives like the countriesAndRegions of country named locations are sets,
+			// so reordering their items is not a change. The order of the other arrays is sent as configured.
+			if setLikeArrayPaths[path] && isArrayOfPrimitives(oldValue) && isArrayOfPrimitives(newArr) && len(oldValue) == len(newArr) {
+				if _, ok := matchArrayItemsUnordered(oldValue, newArr, option, path); ok {
+					return nil
+				}
+			}
 			// For arrays, send the full new array when changed
 			return newArr
 		}
@@ -269,3 +482,43 @@ func DiffObject(old interface{}, new interface{}, option UpdateJsonOption) inter
 	// primitives or differing types -> return new
 	return new
 }
+
+// ChangedUnmanagedProperties returns the top-level properties which are not configured in the body, but whose values
+// in the response are different from the ones in the snapshot of a previous response. Annotations, the directory
+// extension attributes, which are added by the applications registering them, and the navigation properties bound in
+// the body are not compared. A property which is not returned anymore is only reported when
+// option.IgnoreMissingProperty is false, in which case its value is nil.
+func ChangedUnmanagedProperties(body interface{}, snapshot interface{}, response interface{}, option UpdateJsonOption) map[string]interface{} {
+	res := make(map[string]interface{})
+	bodyMap, _ := body.(map[string]interface{})
+	snapshotMap, ok := snapshot.(map[string]interface{})
+	if !ok {
+		return res
+	}
+	responseMap, ok := response.(map[string]interface{})
+	if !ok {
+		return res
+	}
+	for key, oldValue := range snapshotMap {
+		if strings.Contains(key, "@") || IsExtensionAttributeName(key) {
+			continue
+		}
+		if _, ok := bodyMap[key]; ok {
+			continue
+		}
+		if _, ok := bodyMap[key+"@odata.bind"]; ok {
+			continue
+		}
+		newValue, ok := responseMap[key]
+		if !ok {
+			if !option.IgnoreMissingProperty {
+				res[key] = nil
+			}
+			continue
+		}
+		if !reflect.DeepEqual(updateObject(oldValue, newValue, option, key), oldValue) {
+			res[key] = newValue
+		}
+	}
+	return res
+}
@@ -62,6 +62,64 @@ func TestMergeObject(t *testing.T) {
 			newV: []interface{}{2, map[string]interface{}{"x": 9}},
 			want: []interface{}{2, map[string]interface{}{"x": 9}},
 		},
+		{
+			name: "keyed arrays merged by identifier when an item is added",
+			old: []interface{}{
+				map[string]interface{}{"name": "a", "value": "1", "unmanaged": true},
+			},
+			newV: []interface{}{
+				map[string]interface{}{"name": "a", "value": "2"},
+				map[string]interface{}{"name": "b", "value": "3"},
+			},
+			want: []interface{}{
+				map[string]interface{}{"name": "a", "value": "2", "unmanaged": true},
+				map[string]interface{}{"name": "b", "value": "3"},
+			},
+		},
+		{
+			name: "keyed arrays keep the old items which are not in new",
+			old: []interface{}{
+				map[string]interface{}{"name": "a", "value": "1"},
+				map[string]interface{}{"name": "b", "value": "2"},
+				map[string]interface{}{"name": "c", "value": "3"},
+			},
+			newV: []interface{}{
+				map[string]interface{}{"name": "b", "value": "9"},
+			},
+			want: []interface{}{
+				map[string]interface{}{"name": "b", "value": "9"},
+				map[string]interface{}{"name": "a", "value": "1"},
+				map[string]interface{}{"name": "c", "value": "3"},
+			},
+		},
+		{
+			name: "keyed arrays merged by identifier when reordered",
+			old: []interface{}{
+				map[string]interface{}{"name": "a", "value": "1", "unmanaged": "x"},
+				map[string]interface{}{"name": "b", "value": "2", "unmanaged": "y"},
+			},
+			newV: []interface{}{
+				map[string]interface{}{"name": "b", "value": "2"},
+				map[string]interface{}{"name": "a", "value": "1"},
+			},
+			want: []interface{}{
+				map[string]interface{}{"name": "b", "value": "2", "unmanaged": "y"},
+				map[string]interface{}{"name": "a", "value": "1", "unmanaged": "x"},
+			},
+		},
+		{
+			name: "arrays with items without identifier replaced when lengths differ",
+			old: []interface{}{
+				map[string]interface{}{"name": "a", "value": "1"},
+				map[string]interface{}{"value": "2"},
+			},
+			newV: []interface{}{
+				map[string]interface{}{"name": "a", "value": "3"},
+			},
+			want: []interface{}{
+				map[string]interface{}{"name": "a", "value": "3"},
+			},
+		},
 	}
 	for _, tc := range testcases {
 		t.Run(tc.name, func(t *testing.T) {
@@ -73,6 +131,84 @@ func TestMergeObject(t *testing.T) {
 	}
 }
 
+func TestMergeObjectWithPrevious(t *testing.T) {
+	testcases := []struct {
+		name     string
+		old      interface{}
+		newV     interface{}
+		previous interface{}
+		want     interface{}
+	}{
+		{
+			name: "keyed item removed from the previous value is not kept",
+			old: map[string]interface{}{
+				"appRoles": []interface{}{
+					map[string]interface{}{"id": "1", "value": "Read", "isEnabled": true},
+					map[string]interface{}{"id": "2", "value": "Write", "isEnabled": true},
+				},
+			},
+			newV: map[string]interface{}{
+				"appRoles": []interface{}{
+					map[string]interface{}{"id": "1", "value": "Read"},
+				},
+			},
+			previous: map[string]interface{}{
+				"appRoles": []interface{}{
+					map[string]interface{}{"id": "1", "value": "Read"},
+					map[string]interface{}{"id": "2", "value": "Write"},
+				},
+			},
+			want: map[string]interface{}{
+				"appRoles": []interface{}{
+					map[string]interface{}{"id": "1", "value": "Read", "isEnabled": true},
+				},
+			},
+		},
+		{
+			name: "unmanaged keyed item is kept",
+			old: []interface{}{
+				map[string]interface{}{"id": "1", "value": "Read"},
+				map[string]interface{}{"id": "2", "value": "Write"},
+				map[string]interface{}{"id": "3", "value": "Admin"},
+			},
+			newV: []interface{}{
+				map[string]interface{}{"id": "1", "value": "Read"},
+			},
+			previous: []interface{}{
+				map[string]interface{}{"id": "1", "value": "Read"},
+				map[string]interface{}{"id": "2", "value": "Write"},
+			},
+			want: []interface{}{
+				map[string]interface{}{"id": "1", "value": "Read"},
+				map[string]interface{}{"id": "3", "value": "Admin"},
+			},
+		},
+		{
+			name: "no previous value keeps all the old keyed items",
+			old: []interface{}{
+				map[string]interface{}{"id": "1", "value": "Read"},
+				map[string]interface{}{"id": "2", "value": "Write"},
+			},
+			newV: []interface{}{
+				map[string]interface{}{"id": "1", "value": "Read"},
+			},
+			previous: nil,
+			want: []interface{}{
+				map[string]interface{}{"id": "1", "value": "Read"},
+				map[string]interface{}{"id": "2", "value": "Write"},
+			},
+		},
+	}
+	for _, tc := range testcases {
+		t.Run(tc.name, func(t *testing.T) {
+			got := MergeObjectWithPrevious(tc.old, tc.newV, tc.previous)
+			if !reflect.DeepEqual(got, tc.want) {
+				t.Fatalf("MergeObjectWithPrevious() = %#v, want %#v", got, tc.want)
+			}
+		})
+	}
+}
+
 func TestUpdateObject(t *testing.T) {
 	testcases := []struct {
 		name string
@@ -102,6 +238,294 @@ func TestUpdateObject(t *testing.T) {
 			opt:  UpdateJsonOption{IgnoreCasing: true},
 			want: "Hello",
 		},
+		{
+			name: "reordered set-like array keeps old order",
+			old: map[string]interface{}{
+				"grantControls": map[string]interface{}{"builtInControls": []interface{}{"mfa", "compliantDevice"}},
+			},
+			newV: map[string]interface{}{
+				"grantControls": map[string]interface{}{"builtInControls": []interface{}{"compliantDevice", "mfa"}},
+			},
+			want: map[string]interface{}{
+				"grantControls": map[string]interface{}{"builtInControls": []interface{}{"mfa", "compliantDevice"}},
+			},
+		},
+		{
+			name: "changed set-like array returns new",
+			old: map[string]interface{}{
+				"grantControls": map[string]interface{}{"builtInControls": []interface{}{"mfa", "compliantDevice"}},
+			},
+			newV: map[string]interface{}{
+				"grantControls": map[string]interface{}{"builtInControls": []interface{}{"domainJoinedDevice", "mfa"}},
+			},
+			want: map[string]interface{}{
+				"grantControls": map[string]interface{}{"builtInControls": []interface{}{"domainJoinedDevice", "mfa"}},
+			},
+		},
+		{
+			name: "reordered array which is not a set returns new order",
+			old:  map[string]interface{}{"identifierUris": []interface{}{"api://a", "api://b"}},
+			newV: map[string]interface{}{"identifierUris": []interface{}{"api://b", "api://a"}},
+			want: map[string]interface{}{"identifierUris": []interface{}{"api://b", "api://a"}},
+		},
+		{
+			name: "reordered object array which is not a set is compared by position",
+			old: []interface{}{
+				map[string]interface{}{"isEnabled": true, "type": "hours"},
+				map[string]interface{}{"isEnabled": false},
+			},
+			newV: []interface{}{
+				map[string]interface{}{"isEnabled": false, "mode": "always"},
+				map[string]interface{}{"isEnabled": true, "type": "hours", "value": 4},
+			},
+			opt: UpdateJsonOption{IgnoreMissingProperty: true},
+			want: []interface{}{
+				map[string]interface{}{"isEnabled": false, "type": "hours"},
+				map[string]interface{}{"isEnabled": true},
+			},
+		},
+		{
+			name: "named array items not configured are ignored with ignore missing",
+			old: map[string]interface{}{"values": []interface{}{
+				map[string]interface{}{"name": "EnableMIPLabels", "value": "true"},
+			}},
+			newV: map[string]interface{}{"values": []interface{}{
+				map[string]interface{}{"name": "CustomBlockedWordsList", "value": ""},
+				map[string]interface{}{"name": "EnableMIPLabels", "value": "true"},
+				map[string]interface{}{"name": "AllowGuestsToAccessGroups", "value": "true"},
+			}},
+			opt: UpdateJsonOption{IgnoreMissingProperty: true},
+			want: map[string]interface{}{"values": []interface{}{
+				map[string]interface{}{"name": "EnableMIPLabels", "value": "true"},
+			}},
+		},
+		{
+			name: "named array items changed value is detected with ignore missing",
+			old: map[string]interface{}{"values": []interface{}{
+				map[string]interface{}{"name": "EnableMIPLabels", "value": "true"},
+			}},
+			newV: map[string]interface{}{"values": []interface{}{
+				map[string]interface{}{"name": "CustomBlockedWordsList", "value": ""},
+				map[string]interface{}{"name": "EnableMIPLabels", "value": "false"},
+			}},
+			opt: UpdateJsonOption{IgnoreMissingProperty: true},
+			want: map[string]interface{}{"values": []interface{}{
+				map[string]interface{}{"name": "EnableMIPLabels", "value": "false"},
+			}},
+		},
+		{
+			name: "id-keyed array items not configured are reported with ignore missing",
+			old: []interface{}{
+				map[string]interface{}{"id": "1", "value": "Read"},
+			},
+			newV: []interface{}{
+				map[string]interface{}{"id": "1", "value": "Read", "isEnabled": true},
+				map[string]interface{}{"id": "2", "value": "Write", "isEnabled": true},
+			},
+			opt: UpdateJsonOption{IgnoreMissingProperty: true},
+			want: []interface{}{
+				map[string]interface{}{"id": "1", "value": "Read"},
+				map[string]interface{}{"id": "2", "value": "Write", "isEnabled": true},
+			},
+		},
+		{
+			name: "named array items not configured are kept without ignore missing",
+			old: map[string]interface{}{"values": []interface{}{
+				map[string]interface{}{"name": "EnableMIPLabels", "value": "true"},
+			}},
+			newV: map[string]interface{}{"values": []interface{}{
+				map[string]interface{}{"name": "EnableMIPLabels", "value": "true"},
+				map[string]interface{}{"name": "AllowGuestsToAccessGroups", "value": "true"},
+			}},
+			opt: UpdateJsonOption{IgnoreMissingProperty: false},
+			want: map[string]interface{}{"values": []interface{}{
+				map[string]interface{}{"name": "EnableMIPLabels", "value": "true"},
+				map[string]interface{}{"name": "AllowGuestsToAccessGroups", "value": "true"},
+			}},
+		},
+		{
+			name: "name-value pairs not configured in other arrays are reported with ignore missing",
+			old: map[string]interface{}{"attributes": []interface{}{
+				map[string]interface{}{"name": "department", "value": "Sales"},
+			}},
+			newV: map[string]interface{}{"attributes": []interface{}{
+				map[string]interface{}{"name": "department", "value": "Sales"},
+				map[string]interface{}{"name": "costCenter", "value": "42"},
+			}},
+			opt: UpdateJsonOption{IgnoreMissingProperty: true},
+			want: map[string]interface{}{"attributes": []interface{}{
+				map[string]interface{}{"name": "department", "value": "Sales"},
+				map[string]interface{}{"name": "costCenter", "value": "42"},
+			}},
+		},
+		{
+			name: "odata.type missing in response is preserved",
+			old: map[string]interface{}{
+				"@odata.type":       "#microsoft.graph.conditionalAccessSessionControls",
+				"signInFrequency":   map[string]interface{}{"value": 4},
+				"persistentBrowser": nil,
+			},
+			newV: map[string]interface{}{
+				"signInFrequency": map[string]interface{}{"value": 4},
+			},
+			opt: UpdateJsonOption{IgnoreMissingProperty: false},
+			want: map[string]interface{}{
+				"@odata.type":       "#microsoft.graph.conditionalAccessSessionControls",
+				"signInFrequency":   map[string]interface{}{"value": 4},
+				"persistentBrowser": nil,
+			},
+		},
+		{
+			name: "odata.id missing in response is reported",
+			old: map[string]interface{}{
+				"@odata.id":   "https://graph.microsoft.com/v1.0/directoryObjects/1",
+				"displayName": "example",
+			},
+			newV: map[string]interface{}{
+				"displayName": "example",
+			},
+			opt: UpdateJsonOption{IgnoreMissingProperty: false},
+			want: map[string]interface{}{
+				"displayName": "example",
+			},
+		},
+		{
+			name: "odata.type echoed with a different format is preserved",
+			old: map[string]interface{}{
+				"@odata.type": "microsoft.graph.ipNamedLocation",
+				"displayName": "example",
+			},
+			newV: map[string]interface{}{
+				"@odata.type": "#Microsoft.Graph.ipNamedLocation",
+				"displayName": "example",
+			},
+			opt: UpdateJsonOption{IgnoreMissingProperty: false},
+			want: map[string]interface{}{
+				"@odata.type": "microsoft.graph.ipNamedLocation",
+				"displayName": "example",
+			},
+		},
+		{
+			name: "odata.type of another type is returned",
+			old: map[string]interface{}{
+				"@odata.type": "#microsoft.graph.ipNamedLocation",
+			},
+			newV: map[string]interface{}{
+				"@odata.type": "#microsoft.graph.countryNamedLocation",
+			},
+			opt: UpdateJsonOption{IgnoreMissingProperty: false},
+			want: map[string]interface{}{
+				"@odata.type": "#microsoft.graph.countryNamedLocation",
+			},
+		},
+		{
+			name: "null property not returned -> null is kept",
+			old:  map[string]interface{}{"displayName": "example", "description": nil},
+			newV: map[string]interface{}{"displayName": "example"},
+			opt:  UpdateJsonOption{IgnoreMissingProperty: true},
+			want: map[string]interface{}{"displayName": "example", "description": nil},
+		},
+		{
+			name: "null property not returned without ignore missing -> null is kept",
+			old:  map[string]interface{}{"displayName": "example", "description": nil},
+			newV: map[string]interface{}{"displayName": "example"},
+			opt:  UpdateJsonOption{IgnoreMissingProperty: false},
+			want: map[string]interface{}{"displayName": "example", "description": nil},
+		},
+		{
+			name: "null property still returned with a value -> value is returned",
+			old:  map[string]interface{}{"displayName": "example", "description": nil},
+			newV: map[string]interface{}{"displayName": "example", "description": "old"},
+			opt:  UpdateJsonOption{IgnoreMissingProperty: true},
+			want: map[string]interface{}{"displayName": "example", "description": "old"},
+		},
+		{
+			name: "absent property returned with a value -> property is ignored",
+			old:  map[string]interface{}{"displayName": "example"},
+			newV: map[string]interface{}{"displayName": "example", "description": "old"},
+			opt:  UpdateJsonOption{IgnoreMissingProperty: true},
+			want: map[string]interface{}{"displayName": "example"},
+		},
+		{
+			name: "app roles reordered are matched by id",
+			old: map[string]interface{}{"appRoles": []interface{}{
+				map[string]interface{}{"id": "00000000-0000-0000-0000-000000000001", "value": "Reader"},
+				map[string]interface{}{"id": "00000000-0000-0000-0000-000000000002", "value": "Writer"},
+			}},
+			newV: map[string]interface{}{"appRoles": []interface{}{
+				map[string]interface{}{"id": "00000000-0000-0000-0000-000000000002", "value": "Writer", "origin": "Application"},
+				map[string]interface{}{"id": "00000000-0000-0000-0000-000000000001", "value": "Reader", "origin": "Application"},
+			}},
+			opt: UpdateJsonOption{IgnoreMissingProperty: true},
+			want: map[string]interface{}{"appRoles": []interface{}{
+				map[string]interface{}{"id": "00000000-0000-0000-0000-000000000001", "value": "Reader"},
+				map[string]interface{}{"id": "00000000-0000-0000-0000-000000000002", "value": "Writer"},
+			}},
+		},
+		{
+			name: "app roles reordered with a changed value",
+			old: map[string]interface{}{"appRoles": []interface{}{
+				map[string]interface{}{"id": "00000000-0000-0000-0000-000000000001", "value": "Reader"},
+				map[string]interface{}{"id": "00000000-0000-0000-0000-000000000002", "value": "Writer"},
+			}},
+			newV: map[string]interface{}{"appRoles": []interface{}{
+				map[string]interface{}{"id": "00000000-0000-0000-0000-000000000002", "value": "Writer"},
+				map[string]interface{}{"id": "00000000-0000-0000-0000-000000000001", "value": "Read"},
+			}},
+			opt: UpdateJsonOption{IgnoreMissingProperty: true},
+			want: map[string]interface{}{"appRoles": []interface{}{
+				map[string]interface{}{"id": "00000000-0000-0000-0000-000000000001", "value": "Read"},
+				map[string]interface{}{"id": "00000000-0000-0000-0000-000000000002", "value": "Writer"},
+			}},
+		},
+		{
+			name: "items matched by name when the id is only returned",
+			old: []interface{}{
+				map[string]interface{}{"name": "a", "value": "1"},
+				map[string]interface{}{"name": "b", "value": "2"},
+			},
+			newV: []interface{}{
+				map[string]interface{}{"id": "2", "name": "b", "value": "2"},
+				map[string]interface{}{"id": "1", "name": "a", "value": "1"},
+			},
+			opt: UpdateJsonOption{IgnoreMissingProperty: true},
+			want: []interface{}{
+				map[string]interface{}{"name": "a", "value": "1"},
+				map[string]interface{}{"name": "b", "value": "2"},
+			},
+		},
+		{
+			name: "references reordered are matched by @odata.id",
+			old: []interface{}{
+				map[string]interface{}{"@odata.id": "https://graph.microsoft.com/v1.0/directoryObjects/1"},
+				map[string]interface{}{"@odata.id": "https://graph.microsoft.com/v1.0/directoryObjects/2"},
+			},
+			newV: []interface{}{
+				map[string]interface{}{"@odata.id": "https://graph.microsoft.com/v1.0/directoryObjects/2"},
+				map[string]interface{}{"@odata.id": "https://graph.microsoft.com/v1.0/directoryObjects/1"},
+			},
+			opt: UpdateJsonOption{IgnoreMissingProperty: false},
+			want: []interface{}{
+				map[string]interface{}{"@odata.id": "https://graph.microsoft.com/v1.0/directoryObjects/1"},
+				map[string]interface{}{"@odata.id": "https://graph.microsoft.com/v1.0/directoryObjects/2"},
+			},
+		},
+		{
+			name: "items matched by key",
+			old: []interface{}{
+				map[string]interface{}{"key": "a", "value": "1"},
+				map[string]interface{}{"key": "b", "value": "2"},
+			},
+			newV: []interface{}{
+				map[string]interface{}{"key": "b", "value": "3"},
+				map[string]interface{}{"key": "a", "value": "1"},
+			},
+			opt: UpdateJsonOption{IgnoreMissingProperty: false},
+			want: []interface{}{
+				map[string]interface{}{"key": "a", "value": "1"},
+				map[string]interface{}{"key": "b", "value": "3"},
+			},
+		},
 	}
 	for _, tc := range testcases {
 		t.Run(tc.name, func(t *testing.T) {
@@ -195,6 +619,66 @@ func TestDiffObject(t *testing.T) {
 				"displayName": "Updated Named Location",
 			},
 		},
+		{
+			name: "reordered countries of country named location -> nil",
+			old: map[string]interface{}{
+				"@odata.type":         "#microsoft.graph.countryNamedLocation",
+				"displayName":         "Example Country Location",
+				"countriesAndRegions": []interface{}{"US", "GB", "DE"},
+			},
+			newV: map[string]interface{}{
+				"@odata.type":         "#microsoft.graph.countryNamedLocation",
+				"displayName":         "Example Country Location",
+				"countriesAndRegions": []interface{}{"DE", "US", "GB"},
+			},
+			opt:  UpdateJsonOption{},
+			want: nil,
+		},
+		{
+			name: "changed countries of country named location - should include odata.type",
+			old: map[string]interface{}{
+				"@odata.type":                       "#microsoft.graph.countryNamedLocation",
+				"displayName":                       "Example Country Location",
+				"countriesAndRegions":               []interface{}{"US", "GB"},
+				"includeUnknownCountriesAndRegions": false,
+			},
+			newV: map[string]interface{}{
+				"@odata.type":                       "#microsoft.graph.countryNamedLocation",
+				"displayName":                       "Example Country Location",
+				"countriesAndRegions":               []interface{}{"GB", "FR"},
+				"includeUnknownCountriesAndRegions": false,
+			},
+			opt: UpdateJsonOption{},
+			want: map[string]interface{}{
+				"@odata.type":         "#microsoft.graph.countryNamedLocation",
+				"countriesAndRegions": []interface{}{"GB", "FR"},
+			},
+		},
+		{
+			name: "reordered primitive array which is not a set -> full array returned",
+			old:  map[string]interface{}{"identifierUris": []interface{}{"api://a", "api://b"}},
+			newV: map[string]interface{}{"identifierUris": []interface{}{"api://b", "api://a"}},
+			opt:  UpdateJsonOption{},
+			want: map[string]interface{}{"identifierUris": []interface{}{"api://b", "api://a"}},
+		},
+		{
+			name: "reordered built-in controls of conditional access policy -> nil",
+			old: map[string]interface{}{
+				"grantControls": map[string]interface{}{"builtInControls": []interface{}{"mfa", "compliantDevice"}},
+			},
+			newV: map[string]interface{}{
+				"grantControls": map[string]interface{}{"builtInControls": []interface{}{"compliantDevice", "mfa"}},
+			},
+			opt:  UpdateJsonOption{},
+			want: nil,
+		},
+		{
+			name: "reordered array of objects -> full array returned",
+			old:  []interface{}{map[string]interface{}{"a": 1}, map[string]interface{}{"b": 2}},
+			newV: []interface{}{map[string]interface{}{"b": 2}, map[string]interface{}{"a": 1}},
+			opt:  UpdateJsonOption{},
+			want: []interface{}{map[string]interface{}{"b": 2}, map[string]interface{}{"a": 1}},
+		},
 		{
 			name: "odata.type in nested objects should be preserved",
 			old: map[string]interface{}{
@@ -309,6 +793,48 @@ func TestDiffObject(t *testing.T) {
 			opt:  UpdateJsonOption{},
 			want: map[string]interface{}{"a": []interface{}{}},
 		},
+		{
+			name: "property changed to null -> null is sent",
+			old:  map[string]interface{}{"displayName": "example", "description": "old"},
+			newV: map[string]interface{}{"displayName": "example", "description": nil},
+			opt:  UpdateJsonOption{},
+			want: map[string]interface{}{"description": nil},
+		},
+		{
+			name: "property removed from body -> nothing is sent",
+			old:  map[string]interface{}{"displayName": "example", "description": "old"},
+			newV: map[string]interface{}{"displayName": "example"},
+			opt:  UpdateJsonOption{},
+			want: nil,
+		},
+		{
+			name: "property added as null -> null is sent",
+			old:  map[string]interface{}{"displayName": "example"},
+			newV: map[string]interface{}{"displayName": "example", "description": nil},
+			opt:  UpdateJsonOption{},
+			want: map[string]interface{}{"description": nil},
+		},
+		{
+			name: "null property unchanged but other field changed -> null is not sent",
+			old:  map[string]interface{}{"displayName": "example", "description": nil},
+			newV: map[string]interface{}{"displayName": "changed", "description": nil},
+			opt:  UpdateJsonOption{},
+			want: map[string]interface{}{"displayName": "changed"},
+		},
+		{
+			name: "null property unchanged -> nil",
+			old:  map[string]interface{}{"displayName": "example", "description": nil},
+			newV: map[string]interface{}{"displayName": "example", "description": nil},
+			opt:  UpdateJsonOption{IgnoreMissingProperty: true},
+			want: nil,
+		},
+		{
+			name: "nested property changed to null -> null is sent",
+			old:  map[string]interface{}{"web": map[string]interface{}{"homePageUrl": "https://example.com", "logoutUrl": "https://example.com/logout"}},
+			newV: map[string]interface{}{"web": map[string]interface{}{"homePageUrl": "https://example.com", "logoutUrl": nil}},
+			opt:  UpdateJsonOption{IgnoreMissingProperty: true},
+			want: map[string]interface{}{"web": map[string]interface{}{"logoutUrl": nil}},
+		},
 	}
 	for _, tc := range testcases {
 		t.Run(tc.name, func(t *testing.T) {
@@ -319,3 +845,113 @@ func TestDiffObject(t *testing.T) {
 		})
 	}
 }
+
+func TestChangedUnmanagedProperties(t *testing.T) {
+	snapshot := map[string]interface{}{
+		"@odata.context": "https://graph.microsoft.com/v1.0/$metadata#groups/$entity",
+		"displayName":    "group",
+		"description":    "original",
+		"visibility":     "Private",
+		"members":        []interface{}{},
+
+		"extension_b7d8e648520f41d3b9d0fdeb0c4ba8e3_jobGroup": "A",
+	}
+	testcases := []struct {
+		name     string
+		body     interface{}
+		response interface{}
+		opt      UpdateJsonOption
+		want     map[string]interface{}
+	}{
+		{
+			name: "no changes",
+			body: map[string]interface{}{"displayName": "group"},
+			response: map[string]interface{}{
+				"@odata.context": "https://graph.microsoft.com/v1.0/$metadata#groups/$entity",
+				"displayName":    "group",
+				"description":    "original",
+				"visibility":     "Private",
+				"members":        []interface{}{},
+			},
+			want: map[string]interface{}{},
+		},
+		{
+			name: "changed unmanaged property is reported",
+			body: map[string]interface{}{"displayName": "group"},
+			response: map[string]interface{}{
+				"displayName": "changed",
+				"description": "changed",
+				"visibility":  "Private",
+				"members":     []interface{}{},
+			},
+			opt:  UpdateJsonOption{IgnoreMissingProperty: true},
+			want: map[string]interface{}{"description": "changed"},
+		},
+		{
+			name: "bound navigation property is not compared",
+			body: map[string]interface{}{"displayName": "group", "members@odata.bind": []interface{}{"1"}},
+			response: map[string]interface{}{
+				"displayName": "group",
+				"description": "original",
+				"visibility":  "Private",
+				"members":     []interface{}{map[string]interface{}{"id": "1"}},
+			},
+			want: map[string]interface{}{},
+		},
+		{
+			name: "missing property is ignored with ignore missing",
+			body: map[string]interface{}{"displayName": "group"},
+			response: map[string]interface{}{
+				"displayName": "group",
+				"description": "original",
+				"members":     []interface{}{},
+			},
+			opt:  UpdateJsonOption{IgnoreMissingProperty: true},
+			want: map[string]interface{}{},
+		},
+		{
+			name: "missing property is reported without ignore missing",
+			body: map[string]interface{}{"displayName": "group"},
+			response: map[string]interface{}{
+				"displayName": "group",
+				"description": "original",
+				"members":     []interface{}{},
+			},
+			opt:  UpdateJsonOption{IgnoreMissingProperty: false},
+			want: map[string]interface{}{"visibility": nil},
+		},
+		{
+			name: "changed extension attribute is not compared",
+			body: map[string]interface{}{"displayName": "group"},
+			response: map[string]interface{}{
+				"displayName": "group",
+				"description": "original",
+				"visibility":  "Private",
+				"members":     []interface{}{},
+
+				"extension_b7d8e648520f41d3b9d0fdeb0c4ba8e3_jobGroup": "B",
+			},
+			want: map[string]interface{}{},
+		},
+		{
+			name: "casing is ignored",
+			body: map[string]interface{}{"displayName": "group"},
+			response: map[string]interface{}{
+				"displayName": "group",
+				"description": "original",
+				"visibility":  "private",
+				"members":     []interface{}{},
+			},
+			opt:  UpdateJsonOption{IgnoreCasing: true},
+			want: map[string]interface{}{},
+		},
+	}
+	for _, tc := range testcases {
+		t.Run(tc.name, func(t *testing.T) {
+			got := ChangedUnmanagedProperties(tc.body, snapshot, tc.response, tc.opt)
+			if !reflect.DeepEqual(got, tc.want) {
+				t.Fatalf("ChangedUnmanagedProperties() = %#v, want %#v", got, tc.want)
+			}
+		})
+	}
+}
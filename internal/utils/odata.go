@@ -0,0 +1,360 @@
+package utils
+
+import (
+	"fmt"
+	"net/url"
+	"slices"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+const (
+	odataBindSuffix = "@odata.bind"
+	removedKey      = "@removed"
+	odataIdKey      = "@odata.id"
+	odataContextKey = "@odata.context"
+)
+
+// NavigationPropertiesOfBody returns the sorted names of the navigation properties which are bound in the body
+// with the `<navigation property>@odata.bind` annotation, e.g. `owners` for `owners@odata.bind`.
+func NavigationPropertiesOfBody(body interface{}) []string {
+	bodyMap, ok := body.(map[string]interface{})
+	if !ok {
+		return nil
+	}
+	res := make([]string, 0)
+	for key := range bodyMap {
+		if name := strings.TrimSuffix(key, odataBindSuffix); name != key && name != "" {
+			res = append(res, name)
+		}
+	}
+	sort.Strings(res)
+	return res
+}
+
+// ExpandedProperties returns the names of the navigation properties expanded by the values of the `$expand` query
+// parameter, e.g. `owners` and `memberOf` for `owners($select=id,displayName),memberOf`. The wildcard `*` is skipped,
+// as the names of the properties it expands aren't known.
+func ExpandedProperties(expand []string) []string {
+	res := make([]string, 0)
+	for _, value := range expand {
+		depth, start := 0, 0
+		for i := 0; i <= len(value); i++ {
+			if i < len(value) {
+				switch value[i] {
+				case '(':
+					depth++
+					continue
+				case ')':
+					depth--
+					continue
+				case ',':
+					if depth != 0 {
+						continue
+					}
+				default:
+					continue
+				}
+			}
+			name := strings.TrimSpace(value[start:i])
+			if index := strings.IndexAny(name, "(/"); index != -1 {
+				name = strings.TrimSpace(name[:index])
+			}
+			if name != "" && name != "*" && !slices.Contains(res, name) {
+				res = append(res, name)
+			}
+			start = i + 1
+		}
+	}
+	return res
+}
+
+// WithoutProperties returns a copy of the body without the properties.
+func WithoutProperties(body interface{}, names []string) interface{} {
+	bodyMap, ok := body.(map[string]interface{})
+	if !ok || len(names) == 0 {
+		return body
+	}
+	res := make(map[string]interface{}, len(bodyMap))
+	for key, value := range bodyMap {
+		if !slices.Contains(names, key) {
+			res[key] = value
+		}
+	}
+	return res
+}
+
+// UpdateNavigationBindings returns a copy of the response whose `<navigation property>@odata.bind` annotations are
+// built from the expanded navigation properties, so they can be compared with the annotations in the body.
+// A reference in the body is kept as is when the response contains the item it refers to, and references to the other
+// items are built as `{baseUrl}/directoryObjects/{id}`.
+func UpdateNavigationBindings(body interface{}, response interface{}, baseUrl string) interface{} {
+	bodyMap, ok := body.(map[string]interface{})
+	if !ok {
+		return response
+	}
+	responseMap, ok := response.(map[string]interface{})
+	if !ok {
+		return response
+	}
+
+	res := make(map[string]interface{}, len(responseMap))
+	for key, value := range responseMap {
+		res[key] = value
+	}
+	for _, name := range NavigationPropertiesOfBody(bodyMap) {
+		expanded, ok := responseMap[name]
+		if !ok {
+			continue
+		}
+		bindKey := name + odataBindSuffix
+		switch references := bodyMap[bindKey].(type) {
+		case []interface{}:
+			res[bindKey] = navigationBindingsOfCollection(references, expanded, baseUrl)
+		case string:
+			if id := idOfNavigationItem(expanded); id != "" {
+				if LastSegment(references) == id {
+					res[bindKey] = references
+				} else {
+					res[bindKey] = fmt.Sprintf("%s/directoryObjects/%s", baseUrl, id)
+				}
+			}
+		}
+	}
+	return res
+}
+
+func navigationBindingsOfCollection(references []interface{}, expanded interface{}, baseUrl string) []interface{} {
+	items, _ := expanded.([]interface{})
+	ids := make([]string, 0)
+	idSet := make(map[string]bool)
+	for _, item := range items {
+		if id := idOfNavigationItem(item); id != "" {
+			ids = append(ids, id)
+			idSet[id] = true
+		}
+	}
+
+	res := make([]interface{}, 0)
+	used := make(map[string]bool)
+	for _, reference := range references {
+		referenceValue, ok := reference.(string)
+		if !ok {
+			continue
+		}
+		if id := LastSegment(referenceValue); idSet[id] && !used[id] {
+			res = append(res, referenceValue)
+			used[id] = true
+		}
+	}
+	for _, id := range ids {
+		if !used[id] {
+			res = append(res, fmt.Sprintf("%s/directoryObjects/%s", baseUrl, id))
+		}
+	}
+	return res
+}
+
+func idOfNavigationItem(input interface{}) string {
+	inputMap, ok := input.(map[string]interface{})
+	if !ok {
+		return ""
+	}
+	id, _ := inputMap["id"].(string)
+	return id
+}
+
+// SeparateRemovedItems returns a copy of the response of a delta query whose items marked with the `@removed`
+// annotation are moved from `value` to `removed`, which is the list of the IDs of the removed items.
+// The response is returned as is if none of its items is marked as removed.
+func SeparateRemovedItems(response interface{}) interface{} {
+	responseMap, ok := response.(map[string]interface{})
+	if !ok {
+		return response
+	}
+	items, ok := responseMap["value"].([]interface{})
+	if !ok {
+		return response
+	}
+
+	value := make([]interface{}, 0)
+	removed := make([]interface{}, 0)
+	for _, item := range items {
+		itemMap, ok := item.(map[string]interface{})
+		if !ok || itemMap[removedKey] == nil {
+			value = append(value, item)
+			continue
+		}
+		removed = append(removed, itemMap["id"])
+	}
+	if len(removed) == 0 {
+		return response
+	}
+
+	res := make(map[string]interface{})
+	for key, val := range responseMap {
+		res[key] = val
+	}
+	res["value"] = value
+	res["removed"] = removed
+	return res
+}
+
+// DiffDeltaItems classifies the items of a delta query response by the IDs of the objects which are already known.
+// The items marked as removed are returned by their IDs, and the other items are added if their IDs are not known,
+// otherwise they're updated. When the response is a full synchronization, i.e. the delta query restarted without a
+// delta token, the known objects which are not in the response are returned as removed too.
+func DiffDeltaItems(response interface{}, knownIds map[string]bool, fullSync bool) ([]interface{}, []interface{}, []string) {
+	added := make([]interface{}, 0)
+	updated := make([]interface{}, 0)
+	removed := make([]string, 0)
+	seen := make(map[string]bool)
+
+	var items []interface{}
+	if responseMap, ok := response.(map[string]interface{}); ok {
+		items, _ = responseMap["value"].([]interface{})
+	}
+	for _, item := range items {
+		itemMap, ok := item.(map[string]interface{})
+		if !ok {
+			continue
+		}
+		id, _ := itemMap["id"].(string)
+		if itemMap[removedKey] != nil {
+			if id != "" && !seen[id] {
+				removed = append(removed, id)
+			}
+			seen[id] = true
+			continue
+		}
+		seen[id] = true
+		if knownIds[id] {
+			updated = append(updated, item)
+		} else {
+			added = append(added, item)
+		}
+	}
+
+	if fullSync {
+		missing := make([]string, 0)
+		for id := range knownIds {
+			if !seen[id] {
+				missing = append(missing, id)
+			}
+		}
+		sort.Strings(missing)
+		removed = append(removed, missing...)
+	}
+	return added, updated, removed
+}
+
+// DiffNavigationBindings compares two collections of `@odata.bind` references by the IDs of the objects they refer to.
+// It returns the references which are only in new, and the IDs of the objects which are only referred to in old.
+func DiffNavigationBindings(old []interface{}, new []interface{}) ([]string, []string) {
+	oldIds := make(map[string]bool)
+	for _, reference := range old {
+		if referenceValue, ok := reference.(string); ok {
+			oldIds[LastSegment(referenceValue)] = true
+		}
+	}
+	newIds := make(map[string]bool)
+	added := make([]string, 0)
+	for _, reference := range new {
+		referenceValue, ok := reference.(string)
+		if !ok {
+			continue
+		}
+		id := LastSegment(referenceValue)
+		if !oldIds[id] && !newIds[id] {
+			added = append(added, referenceValue)
+		}
+		newIds[id] = true
+	}
+	removed := make([]string, 0)
+	for _, reference := range old {
+		referenceValue, ok := reference.(string)
+		if !ok {
+			continue
+		}
+		if id := LastSegment(referenceValue); !newIds[id] {
+			removed = append(removed, id)
+			newIds[id] = true
+		}
+	}
+	return added, removed
+}
+
+// RewriteODataHosts returns a copy of the input whose `@odata.id` and `@odata.context` URLs, at any depth, have the
+// scheme and host of baseUrl. The API may return these annotations with a host which differs from the configured one,
+// e.g. in national clouds, which would otherwise be reported as changes of the annotations configured in the body.
+func RewriteODataHosts(input interface{}, baseUrl string) interface{} {
+	base, err := url.Parse(baseUrl)
+	if err != nil || base.Host == "" {
+		return input
+	}
+	return rewriteODataHosts(input, base)
+}
+
+func rewriteODataHosts(input interface{}, base *url.URL) interface{} {
+	switch v := input.(type) {
+	case map[string]interface{}:
+		res := make(map[string]interface{}, len(v))
+		for key, value := range v {
+			if str, ok := value.(string); ok && (key == odataIdKey || key == odataContextKey) {
+				res[key] = rewriteHost(str, base)
+				continue
+			}
+			res[key] = rewriteODataHosts(value, base)
+		}
+		return res
+	case []interface{}:
+		res := make([]interface{}, 0, len(v))
+		for _, item := range v {
+			res = append(res, rewriteODataHosts(item, base))
+		}
+		return res
+	}
+	return input
+}
+
+// rewriteHost replaces the scheme and host of an absolute URL, relative URLs are returned as is.
+func rewriteHost(input string, base *url.URL) string {
+	parsed, err := url.Parse(input)
+	if err != nil || parsed.Host == "" || (strings.EqualFold(parsed.Host, base.Host) && parsed.Scheme == base.Scheme) {
+		return input
+	}
+	parsed.Scheme = base.Scheme
+	parsed.Host = base.Host
+	return parsed.String()
+}
+
+// FunctionCall returns the path segment calling the OData function with the parameters inlined, e.g.
+// `reminderView(EndDateTime='2024-01-07T00:00:00',StartDateTime='2024-01-01T00:00:00')`. The parameters are sorted by
+// name, the strings are quoted with their single quotes doubled, and the numbers, booleans and nulls are written as is.
+func FunctionCall(name string, parameters map[string]interface{}) (string, error) {
+	names := make([]string, 0, len(parameters))
+	for key := range parameters {
+		names = append(names, key)
+	}
+	sort.Strings(names)
+
+	items := make([]string, 0, len(names))
+	for _, key := range names {
+		var literal string
+		switch v := parameters[key].(type) {
+		case nil:
+			literal = "null"
+		case string:
+			literal = fmt.Sprintf("'%s'", strings.ReplaceAll(v, "'", "''"))
+		case bool:
+			literal = strconv.FormatBool(v)
+		case float64:
+			literal = strconv.FormatFloat(v, 'f', -1, 64)
+		default:
+			return "", fmt.Errorf("the parameter %q of the function %q must be a string, a number, a boolean or null, got %T", key, name, v)
+		}
+		items = append(items, fmt.Sprintf("%s=%s", key, literal))
+	}
+	return fmt.Sprintf("%s(%s)", name, strings.Join(items, ",")), nil
+}
@@ -0,0 +1,488 @@
+package utils
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestNavigationPropertiesOfBody(t *testing.T) {
+	testcases := []struct {
+		name string
+		body interface{}
+		want []string
+	}{
+		{
+			name: "no navigation properties",
+			body: map[string]interface{}{"displayName": "group"},
+			want: []string{},
+		},
+		{
+			name: "bound navigation properties are sorted",
+			body: map[string]interface{}{
+				"displayName":        "group",
+				"owners@odata.bind":  []interface{}{"https://graph.microsoft.com/v1.0/directoryObjects/1"},
+				"members@odata.bind": []interface{}{"https://graph.microsoft.com/v1.0/directoryObjects/2"},
+			},
+			want: []string{"members", "owners"},
+		},
+		{
+			name: "not an object",
+			body: []interface{}{"a"},
+			want: nil,
+		},
+	}
+	for _, tc := range testcases {
+		t.Run(tc.name, func(t *testing.T) {
+			got := NavigationPropertiesOfBody(tc.body)
+			if !reflect.DeepEqual(got, tc.want) {
+				t.Fatalf("NavigationPropertiesOfBody() = %#v, want %#v", got, tc.want)
+			}
+		})
+	}
+}
+
+func TestExpandedProperties(t *testing.T) {
+	testcases := []struct {
+		name   string
+		expand []string
+		want   []string
+	}{
+		{
+			name:   "no expand",
+			expand: nil,
+			want:   []string{},
+		},
+		{
+			name:   "single property",
+			expand: []string{"owners"},
+			want:   []string{"owners"},
+		},
+		{
+			name:   "comma separated properties with options",
+			expand: []string{"owners($select=id,displayName), memberOf($top=5)"},
+			want:   []string{"owners", "memberOf"},
+		},
+		{
+			name:   "multiple values",
+			expand: []string{"owners", "memberOf", "owners"},
+			want:   []string{"owners", "memberOf"},
+		},
+		{
+			name:   "type cast and wildcard",
+			expand: []string{"members/microsoft.graph.user,*"},
+			want:   []string{"members"},
+		},
+	}
+	for _, tc := range testcases {
+		t.Run(tc.name, func(t *testing.T) {
+			got := ExpandedProperties(tc.expand)
+			if !reflect.DeepEqual(got, tc.want) {
+				t.Fatalf("ExpandedProperties() = %#v, want %#v", got, tc.want)
+			}
+		})
+	}
+}
+
+func TestWithoutProperties(t *testing.T) {
+	body := map[string]interface{}{
+		"displayName": "app",
+		"owners":      []interface{}{map[string]interface{}{"id": "1"}},
+	}
+	got := WithoutProperties(body, []string{"owners", "memberOf"})
+	want := map[string]interface{}{"displayName": "app"}
+	if !reflect.DeepEqual(got, want) {
+		t.Fatalf("WithoutProperties() = %#v, want %#v", got, want)
+	}
+	if _, ok := body["owners"]; !ok {
+		t.Fatalf("WithoutProperties() must not change the body")
+	}
+	if got := WithoutProperties("value", []string{"owners"}); got != "value" {
+		t.Fatalf("WithoutProperties() = %#v, want the input", got)
+	}
+}
+
+func TestUpdateNavigationBindings(t *testing.T) {
+	baseUrl := "https://graph.microsoft.com/v1.0"
+	testcases := []struct {
+		name     string
+		body     interface{}
+		response interface{}
+		want     interface{}
+	}{
+		{
+			name: "configured references are kept when the items are expanded",
+			body: map[string]interface{}{
+				"owners@odata.bind": []interface{}{
+					"https://graph.microsoft.com/v1.0/users/1",
+					"https://graph.microsoft.com/v1.0/directoryObjects/2",
+				},
+			},
+			response: map[string]interface{}{
+				"id":     "group",
+				"owners": []interface{}{map[string]interface{}{"id": "2"}, map[string]interface{}{"id": "1"}},
+			},
+			want: map[string]interface{}{
+				"id":     "group",
+				"owners": []interface{}{map[string]interface{}{"id": "2"}, map[string]interface{}{"id": "1"}},
+				"owners@odata.bind": []interface{}{
+					"https://graph.microsoft.com/v1.0/users/1",
+					"https://graph.microsoft.com/v1.0/directoryObjects/2",
+				},
+			},
+		},
+		{
+			name: "removed items are dropped and added items are appended",
+			body: map[string]interface{}{
+				"owners@odata.bind": []interface{}{
+					"https://graph.microsoft.com/v1.0/directoryObjects/1",
+					"https://graph.microsoft.com/v1.0/directoryObjects/2",
+				},
+			},
+			response: map[string]interface{}{
+				"owners": []interface{}{map[string]interface{}{"id": "2"}, map[string]interface{}{"id": "3"}},
+			},
+			want: map[string]interface{}{
+				"owners": []interface{}{map[string]interface{}{"id": "2"}, map[string]interface{}{"id": "3"}},
+				"owners@odata.bind": []interface{}{
+					"https://graph.microsoft.com/v1.0/directoryObjects/2",
+					"https://graph.microsoft.com/v1.0/directoryObjects/3",
+				},
+			},
+		},
+		{
+			name: "single-valued navigation property",
+			body: map[string]interface{}{
+				"manager@odata.bind": "https://graph.microsoft.com/v1.0/users/1",
+			},
+			response: map[string]interface{}{
+				"manager": map[string]interface{}{"id": "2"},
+			},
+			want: map[string]interface{}{
+				"manager":            map[string]interface{}{"id": "2"},
+				"manager@odata.bind": "https://graph.microsoft.com/v1.0/directoryObjects/2",
+			},
+		},
+		{
+			name: "navigation property not expanded",
+			body: map[string]interface{}{
+				"owners@odata.bind": []interface{}{"https://graph.microsoft.com/v1.0/directoryObjects/1"},
+			},
+			response: map[string]interface{}{"id": "group"},
+			want:     map[string]interface{}{"id": "group"},
+		},
+	}
+	for _, tc := range testcases {
+		t.Run(tc.name, func(t *testing.T) {
+			got := UpdateNavigationBindings(tc.body, tc.response, baseUrl)
+			if !reflect.DeepEqual(got, tc.want) {
+				t.Fatalf("UpdateNavigationBindings() = %#v, want %#v", got, tc.want)
+			}
+		})
+	}
+}
+
+func TestSeparateRemovedItems(t *testing.T) {
+	testcases := []struct {
+		name     string
+		response interface{}
+		want     interface{}
+	}{
+		{
+			name: "no removed items",
+			response: map[string]interface{}{
+				"value": []interface{}{map[string]interface{}{"id": "1"}},
+			},
+			want: map[string]interface{}{
+				"value": []interface{}{map[string]interface{}{"id": "1"}},
+			},
+		},
+		{
+			name: "removed items are separated",
+			response: map[string]interface{}{
+				"@odata.deltaLink": "https://graph.microsoft.com/v1.0/users/delta?$deltatoken=token",
+				"value": []interface{}{
+					map[string]interface{}{"id": "1", "displayName": "user"},
+					map[string]interface{}{"id": "2", "@removed": map[string]interface{}{"reason": "deleted"}},
+					map[string]interface{}{"id": "3", "@removed": map[string]interface{}{"reason": "changed"}},
+				},
+			},
+			want: map[string]interface{}{
+				"@odata.deltaLink": "https://graph.microsoft.com/v1.0/users/delta?$deltatoken=token",
+				"value": []interface{}{
+					map[string]interface{}{"id": "1", "displayName": "user"},
+				},
+				"removed": []interface{}{"2", "3"},
+			},
+		},
+		{
+			name:     "not a collection",
+			response: map[string]interface{}{"id": "1"},
+			want:     map[string]interface{}{"id": "1"},
+		},
+	}
+
+	for _, tc := range testcases {
+		t.Run(tc.name, func(t *testing.T) {
+			got := SeparateRemovedItems(tc.response)
+			if !reflect.DeepEqual(got, tc.want) {
+				t.Fatalf("SeparateRemovedItems() = %#v, want %#v", got, tc.want)
+			}
+		})
+	}
+}
+
+func TestDiffDeltaItems(t *testing.T) {
+	response := map[string]interface{}{
+		"@odata.deltaLink": "https://graph.microsoft.com/v1.0/groups/delta?$deltatoken=token",
+		"value": []interface{}{
+			map[string]interface{}{"id": "1", "displayName": "updated"},
+			map[string]interface{}{"id": "4", "displayName": "added"},
+			map[string]interface{}{"id": "2", "@removed": map[string]interface{}{"reason": "deleted"}},
+		},
+	}
+
+	testcases := []struct {
+		name        string
+		response    interface{}
+		knownIds    map[string]bool
+		fullSync    bool
+		wantAdded   []interface{}
+		wantUpdated []interface{}
+		wantRemoved []string
+	}{
+		{
+			name:     "incremental changes",
+			response: response,
+			knownIds: map[string]bool{"1": true, "2": true, "3": true},
+			wantAdded: []interface{}{
+				map[string]interface{}{"id": "4", "displayName": "added"},
+			},
+			wantUpdated: []interface{}{
+				map[string]interface{}{"id": "1", "displayName": "updated"},
+			},
+			wantRemoved: []string{"2"},
+		},
+		{
+			name:     "full synchronization",
+			response: response,
+			knownIds: map[string]bool{"1": true, "2": true, "3": true},
+			fullSync: true,
+			wantAdded: []interface{}{
+				map[string]interface{}{"id": "4", "displayName": "added"},
+			},
+			wantUpdated: []interface{}{
+				map[string]interface{}{"id": "1", "displayName": "updated"},
+			},
+			wantRemoved: []string{"2", "3"},
+		},
+		{
+			name:     "initial synchronization",
+			response: response,
+			knownIds: map[string]bool{},
+			fullSync: true,
+			wantAdded: []interface{}{
+				map[string]interface{}{"id": "1", "displayName": "updated"},
+				map[string]interface{}{"id": "4", "displayName": "added"},
+			},
+			wantUpdated: []interface{}{},
+			wantRemoved: []string{"2"},
+		},
+		{
+			name:        "not a collection",
+			response:    map[string]interface{}{"id": "1"},
+			knownIds:    map[string]bool{"1": true},
+			wantAdded:   []interface{}{},
+			wantUpdated: []interface{}{},
+			wantRemoved: []string{},
+		},
+	}
+
+	for _, tc := range testcases {
+		t.Run(tc.name, func(t *testing.T) {
+			added, updated, removed := DiffDeltaItems(tc.response, tc.knownIds, tc.fullSync)
+			if !reflect.DeepEqual(added, tc.wantAdded) {
+				t.Fatalf("expected added %#v, got %#v", tc.wantAdded, added)
+			}
+			if !reflect.DeepEqual(updated, tc.wantUpdated) {
+				t.Fatalf("expected updated %#v, got %#v", tc.wantUpdated, updated)
+			}
+			if !reflect.DeepEqual(removed, tc.wantRemoved) {
+				t.Fatalf("expected removed %#v, got %#v", tc.wantRemoved, removed)
+			}
+		})
+	}
+}
+
+func TestDiffNavigationBindings(t *testing.T) {
+	testcases := []struct {
+		name        string
+		old         []interface{}
+		new         []interface{}
+		wantAdded   []string
+		wantRemoved []string
+	}{
+		{
+			name:        "no changes",
+			old:         []interface{}{"https://graph.microsoft.com/v1.0/directoryObjects/1"},
+			new:         []interface{}{"https://graph.microsoft.com/v1.0/directoryObjects/1"},
+			wantAdded:   []string{},
+			wantRemoved: []string{},
+		},
+		{
+			name:        "same object referred with a different url",
+			old:         []interface{}{"https://graph.microsoft.com/v1.0/directoryObjects/1"},
+			new:         []interface{}{"https://graph.microsoft.com/v1.0/users/1"},
+			wantAdded:   []string{},
+			wantRemoved: []string{},
+		},
+		{
+			name: "added and removed references",
+			old: []interface{}{
+				"https://graph.microsoft.com/v1.0/directoryObjects/1",
+				"https://graph.microsoft.com/v1.0/directoryObjects/2",
+			},
+			new: []interface{}{
+				"https://graph.microsoft.com/v1.0/directoryObjects/2",
+				"https://graph.microsoft.com/v1.0/directoryObjects/3",
+				"https://graph.microsoft.com/v1.0/directoryObjects/3",
+			},
+			wantAdded:   []string{"https://graph.microsoft.com/v1.0/directoryObjects/3"},
+			wantRemoved: []string{"1"},
+		},
+	}
+
+	for _, tc := range testcases {
+		t.Run(tc.name, func(t *testing.T) {
+			added, removed := DiffNavigationBindings(tc.old, tc.new)
+			if !reflect.DeepEqual(added, tc.wantAdded) {
+				t.Fatalf("added = %#v, want %#v", added, tc.wantAdded)
+			}
+			if !reflect.DeepEqual(removed, tc.wantRemoved) {
+				t.Fatalf("removed = %#v, want %#v", removed, tc.wantRemoved)
+			}
+		})
+	}
+}
+
+func TestRewriteODataHosts(t *testing.T) {
+	testcases := []struct {
+		name    string
+		input   interface{}
+		baseUrl string
+		want    interface{}
+	}{
+		{
+			name: "hosts of annotations are rewritten",
+			input: map[string]interface{}{
+				"@odata.context": "https://graph.microsoft.us/v1.0/$metadata#groups/$entity",
+				"@odata.id":      "https://graph.microsoft.us/v2/00000000-0000-0000-0000-000000000000/directoryObjects/1/Microsoft.DirectoryServices.Group",
+				"id":             "1",
+				"description":    "https://graph.microsoft.us/v1.0/groups",
+			},
+			baseUrl: "https://graph.microsoft.com",
+			want: map[string]interface{}{
+				"@odata.context": "https://graph.microsoft.com/v1.0/$metadata#groups/$entity",
+				"@odata.id":      "https://graph.microsoft.com/v2/00000000-0000-0000-0000-000000000000/directoryObjects/1/Microsoft.DirectoryServices.Group",
+				"id":             "1",
+				"description":    "https://graph.microsoft.us/v1.0/groups",
+			},
+		},
+		{
+			name: "nested annotations are rewritten",
+			input: map[string]interface{}{
+				"value": []interface{}{
+					map[string]interface{}{"@odata.id": "http://graph.microsoft.us/v1.0/directoryObjects/1", "id": "1"},
+					map[string]interface{}{"@odata.id": "directoryObjects/2", "id": "2"},
+				},
+			},
+			baseUrl: "https://graph.microsoft.com",
+			want: map[string]interface{}{
+				"value": []interface{}{
+					map[string]interface{}{"@odata.id": "https://graph.microsoft.com/v1.0/directoryObjects/1", "id": "1"},
+					map[string]interface{}{"@odata.id": "directoryObjects/2", "id": "2"},
+				},
+			},
+		},
+		{
+			name:    "invalid base url",
+			input:   map[string]interface{}{"@odata.id": "https://graph.microsoft.us/v1.0/directoryObjects/1"},
+			baseUrl: "graph",
+			want:    map[string]interface{}{"@odata.id": "https://graph.microsoft.us/v1.0/directoryObjects/1"},
+		},
+	}
+
+	for _, tc := range testcases {
+		t.Run(tc.name, func(t *testing.T) {
+			got := RewriteODataHosts(tc.input, tc.baseUrl)
+			if !reflect.DeepEqual(got, tc.want) {
+				t.Fatalf("RewriteODataHosts() = %#v, want %#v", got, tc.want)
+			}
+		})
+	}
+}
+
+func TestFunctionCall(t *testing.T) {
+	testcases := []struct {
+		name       string
+		function   string
+		parameters map[string]interface{}
+		want       string
+		wantErr    bool
+	}{
+		{
+			name:     "sorted parameters",
+			function: "reminderView",
+			parameters: map[string]interface{}{
+				"StartDateTime": "2024-01-01T00:00:00",
+				"EndDateTime":   "2024-01-07T00:00:00",
+			},
+			want: "reminderView(EndDateTime='2024-01-07T00:00:00',StartDateTime='2024-01-01T00:00:00')",
+		},
+		{
+			name:     "literals",
+			function: "getSomething",
+			parameters: map[string]interface{}{
+				"count":   float64(10),
+				"ratio":   0.5,
+				"enabled": true,
+				"filter":  nil,
+			},
+			want: "getSomething(count=10,enabled=true,filter=null,ratio=0.5)",
+		},
+		{
+			name:       "quotes are escaped",
+			function:   "search",
+			parameters: map[string]interface{}{"q": "Contoso's plan"},
+			want:       "search(q='Contoso''s plan')",
+		},
+		{
+			name:     "no parameters",
+			function: "getAllMessages",
+			want:     "getAllMessages()",
+		},
+		{
+			name:       "object parameter",
+			function:   "search",
+			parameters: map[string]interface{}{"q": map[string]interface{}{"value": "x"}},
+			wantErr:    true,
+		},
+	}
+
+	for _, tc := range testcases {
+		t.Run(tc.name, func(t *testing.T) {
+			got, err := FunctionCall(tc.function, tc.parameters)
+			if tc.wantErr {
+				if err == nil {
+					t.Fatalf("FunctionCall() expected an error, got %q", got)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("FunctionCall() unexpected error: %v", err)
+			}
+			if got != tc.want {
+				t.Fatalf("FunctionCall() = %q, want %q", got, tc.want)
+			}
+		})
+	}
+}
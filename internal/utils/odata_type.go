@@ -0,0 +1,108 @@
+package utils
+
+import (
+	"regexp"
+	"strings"
+)
+
+const odataTypeKey = "@odata.type"
+
+// odataTypeRule infers the `@odata.type` of the body sent to the URLs matching the pattern, it returns an empty string
+// when the type is ambiguous.
+type odataTypeRule struct {
+	pattern *regexp.Regexp
+	infer   func(match []string, body map[string]interface{}) string
+}
+
+// odataTypeRules are the curated polymorphic endpoints whose `@odata.type` is required and can be inferred.
+var odataTypeRules = []odataTypeRule{
+	{
+		// The named locations are either IP ranges or countries, which is inferred from their properties.
+		pattern: regexp.MustCompile(`(?i)^identity/conditionalAccess/namedLocations(/[^/]+)?$`),
+		infer: func(_ []string, body map[string]interface{}) string {
+			isIp := hasAnyProperty(body, "ipRanges", "isTrusted")
+			isCountry := hasAnyProperty(body, "countriesAndRegions", "countryLookupMethod", "includeUnknownCountriesAndRegions")
+			switch {
+			case isIp && !isCountry:
+				return "#microsoft.graph.ipNamedLocation"
+			case isCountry && !isIp:
+				return "#microsoft.graph.countryNamedLocation"
+			}
+			return ""
+		},
+	},
+	{
+		// The authentication method configurations are identified by the name of the method.
+		pattern: regexp.MustCompile(`(?i)^policies/authenticationMethodsPolicy/authenticationMethodConfigurations/([^/]+)$`),
+		infer: func(match []string, _ map[string]interface{}) string {
+			return authenticationMethodConfigurationTypes[strings.ToLower(match[1])]
+		},
+	},
+	{
+		pattern: regexp.MustCompile(`(?i)^identity/authenticationEventsFlows(/[^/]+)?$`),
+		infer: func(_ []string, _ map[string]interface{}) string {
+			return "#microsoft.graph.externalUsersSelfServiceSignUpEventsFlow"
+		},
+	},
+}
+
+var authenticationMethodConfigurationTypes = map[string]string{
+	"email":                  "#microsoft.graph.emailAuthenticationMethodConfiguration",
+	"fido2":                  "#microsoft.graph.fido2AuthenticationMethodConfiguration",
+	"hardwareoath":           "#microsoft.graph.hardwareOathAuthenticationMethodConfiguration",
+	"microsoftauthenticator": "#microsoft.graph.microsoftAuthenticatorAuthenticationMethodConfiguration",
+	"sms":                    "#microsoft.graph.smsAuthenticationMethodConfiguration",
+	"softwareoath":           "#microsoft.graph.softwareOathAuthenticationMethodConfiguration",
+	"temporaryaccesspass":    "#microsoft.graph.temporaryAccessPassAuthenticationMethodConfiguration",
+	"voice":                  "#microsoft.graph.voiceAuthenticationMethodConfiguration",
+	"x509certificate":        "#microsoft.graph.x509CertificateAuthenticationMethodConfiguration",
+}
+
+// InferODataType returns the `@odata.type` of the body sent to the URL, e.g. `#microsoft.graph.ipNamedLocation` for
+// the named locations with `ipRanges`. It returns an empty string when the URL isn't one of the curated polymorphic
+// endpoints, or when the type is ambiguous.
+func InferODataType(url string, body interface{}) string {
+	bodyMap, ok := body.(map[string]interface{})
+	if !ok {
+		return ""
+	}
+	url = strings.Trim(strings.SplitN(url, "?", 2)[0], "/")
+	for _, rule := range odataTypeRules {
+		if match := rule.pattern.FindStringSubmatch(url); match != nil {
+			return rule.infer(match, bodyMap)
+		}
+	}
+	return ""
+}
+
+// WithODataType returns a copy of the body with the `@odata.type` inferred from the URL and the full body, the body is
+// returned as is when it already has an `@odata.type` or the type can't be inferred. The full body is the one the
+// type is inferred from, e.g. when the body is the patch of the changed properties.
+func WithODataType(url string, body interface{}, fullBody interface{}) (interface{}, string) {
+	bodyMap, ok := body.(map[string]interface{})
+	if !ok {
+		return body, ""
+	}
+	if _, ok := bodyMap[odataTypeKey]; ok {
+		return body, ""
+	}
+	odataType := InferODataType(url, fullBody)
+	if odataType == "" {
+		return body, ""
+	}
+	res := make(map[string]interface{}, len(bodyMap)+1)
+	for key, value := range bodyMap {
+		res[key] = value
+	}
+	res[odataTypeKey] = odataType
+	return res, odataType
+}
+
+func hasAnyProperty(body map[string]interface{}, names ...string) bool {
+	for _, name := range names {
+		if _, ok := body[name]; ok {
+			return true
+		}
+	}
+	return false
+}
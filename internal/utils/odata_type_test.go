@@ -0,0 +1,82 @@
+package utils
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestInferODataType(t *testing.T) {
+	testcases := []struct {
+		name string
+		url  string
+		body interface{}
+		want string
+	}{
+		{
+			name: "ip named location",
+			url:  "identity/conditionalAccess/namedLocations",
+			body: map[string]interface{}{"displayName": "office", "ipRanges": []interface{}{}},
+			want: "#microsoft.graph.ipNamedLocation",
+		},
+		{
+			name: "country named location item",
+			url:  "/identity/conditionalAccess/namedLocations/00000000-0000-0000-0000-000000000000",
+			body: map[string]interface{}{"displayName": "countries", "countriesAndRegions": []interface{}{"US"}},
+			want: "#microsoft.graph.countryNamedLocation",
+		},
+		{
+			name: "ambiguous named location",
+			url:  "identity/conditionalAccess/namedLocations",
+			body: map[string]interface{}{"displayName": "location"},
+			want: "",
+		},
+		{
+			name: "authentication method configuration",
+			url:  "policies/authenticationMethodsPolicy/authenticationMethodConfigurations/Fido2?$select=id",
+			body: map[string]interface{}{"state": "enabled"},
+			want: "#microsoft.graph.fido2AuthenticationMethodConfiguration",
+		},
+		{
+			name: "unknown authentication method configuration",
+			url:  "policies/authenticationMethodsPolicy/authenticationMethodConfigurations/unknown",
+			body: map[string]interface{}{"state": "enabled"},
+			want: "",
+		},
+		{
+			name: "other endpoint",
+			url:  "groups",
+			body: map[string]interface{}{"displayName": "group"},
+			want: "",
+		},
+		{
+			name: "not an object",
+			url:  "identity/authenticationEventsFlows",
+			body: "flow",
+			want: "",
+		},
+	}
+
+	for _, tc := range testcases {
+		t.Run(tc.name, func(t *testing.T) {
+			if got := InferODataType(tc.url, tc.body); got != tc.want {
+				t.Fatalf("InferODataType() = %q, want %q", got, tc.want)
+			}
+		})
+	}
+}
+
+func TestWithODataType(t *testing.T) {
+	fullBody := map[string]interface{}{"displayName": "office", "ipRanges": []interface{}{}}
+
+	got, odataType := WithODataType("identity/conditionalAccess/namedLocations/1", map[string]interface{}{"displayName": "office"}, fullBody)
+	want := map[string]interface{}{"displayName": "office", "@odata.type": "#microsoft.graph.ipNamedLocation"}
+	if !reflect.DeepEqual(got, want) || odataType != "#microsoft.graph.ipNamedLocation" {
+		t.Fatalf("WithODataType() = %v, %q, want %v", got, odataType, want)
+	}
+
+	configured := map[string]interface{}{"@odata.type": "#microsoft.graph.countryNamedLocation", "ipRanges": []interface{}{}}
+	got, odataType = WithODataType("identity/conditionalAccess/namedLocations", configured, configured)
+	if !reflect.DeepEqual(got, configured) || odataType != "" {
+		t.Fatalf("expected the configured @odata.type to be kept, got %v", got)
+	}
+}
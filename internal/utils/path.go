@@ -0,0 +1,73 @@
+package utils
+
+import (
+	"strings"
+)
+
+// ValueAtPath returns the value at the path of the input, and whether it exists. A path is a dot separated list of
+// property names, e.g. `mailNickname` or `web.homePageUrl`. The arrays along the path are not traversed.
+func ValueAtPath(input interface{}, path string) (interface{}, bool) {
+	if path == "" {
+		return nil, false
+	}
+	current := input
+	for _, segment := range strings.Split(path, ".") {
+		v, ok := current.(map[string]interface{})
+		if !ok {
+			return nil, false
+		}
+		if current, ok = v[segment]; !ok {
+			return nil, false
+		}
+	}
+	return current, true
+}
+
+// ReplacePath returns a copy of the target whose value at the path is replaced with the value at the same path of the
+// source. The value is removed from the target if it doesn't exist in the source. The target is returned as is if the
+// objects along the path don't exist in it.
+func ReplacePath(target interface{}, source interface{}, path string) interface{} {
+	if path == "" {
+		return target
+	}
+	value, ok := ValueAtPath(source, path)
+	return replacePath(target, strings.Split(path, "."), value, ok)
+}
+
+func replacePath(target interface{}, segments []string, value interface{}, ok bool) interface{} {
+	v, isMap := target.(map[string]interface{})
+	if !isMap {
+		return target
+	}
+	res := make(map[string]interface{}, len(v))
+	for key, item := range v {
+		res[key] = item
+	}
+	if len(segments) == 1 {
+		if ok {
+			res[segments[0]] = value
+		} else {
+			delete(res, segments[0])
+		}
+		return res
+	}
+	child, exists := v[segments[0]]
+	if !exists {
+		return target
+	}
+	res[segments[0]] = replacePath(child, segments[1:], value, ok)
+	return res
+}
+
+// WithoutPaths returns a copy of the input without the values at the paths. The paths which don't exist in the input
+// are ignored.
+func WithoutPaths(input interface{}, paths []string) interface{} {
+	output := input
+	for _, path := range paths {
+		if path == "" {
+			continue
+		}
+		output = replacePath(output, strings.Split(path, "."), nil, false)
+	}
+	return output
+}
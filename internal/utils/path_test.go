@@ -0,0 +1,190 @@
+package utils
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestValueAtPath(t *testing.T) {
+	input := map[string]interface{}{
+		"mailNickname": "example",
+		"web": map[string]interface{}{
+			"homePageUrl": "https://example.com",
+		},
+		"tags": []interface{}{"a", "b"},
+	}
+
+	testcases := []struct {
+		name          string
+		path          string
+		expected      interface{}
+		expectedFound bool
+	}{
+		{
+			name:          "top level property",
+			path:          "mailNickname",
+			expected:      "example",
+			expectedFound: true,
+		},
+		{
+			name:          "nested property",
+			path:          "web.homePageUrl",
+			expected:      "https://example.com",
+			expectedFound: true,
+		},
+		{
+			name:          "array",
+			path:          "tags",
+			expected:      []interface{}{"a", "b"},
+			expectedFound: true,
+		},
+		{
+			name:          "missing property",
+			path:          "web.logoutUrl",
+			expectedFound: false,
+		},
+		{
+			name:          "property of primitive",
+			path:          "mailNickname.value",
+			expectedFound: false,
+		},
+		{
+			name:          "empty path",
+			path:          "",
+			expectedFound: false,
+		},
+	}
+
+	for _, tc := range testcases {
+		t.Run(tc.name, func(t *testing.T) {
+			actual, found := ValueAtPath(input, tc.path)
+			if found != tc.expectedFound {
+				t.Fatalf("expected found %v, got %v", tc.expectedFound, found)
+			}
+			if !reflect.DeepEqual(actual, tc.expected) {
+				t.Fatalf("expected %v, got %v", tc.expected, actual)
+			}
+		})
+	}
+}
+
+func TestReplacePath(t *testing.T) {
+	testcases := []struct {
+		name     string
+		target   interface{}
+		source   interface{}
+		path     string
+		expected interface{}
+	}{
+		{
+			name: "top level property",
+			target: map[string]interface{}{
+				"displayName":  "new",
+				"mailNickname": "new",
+			},
+			source: map[string]interface{}{
+				"displayName":  "old",
+				"mailNickname": "old",
+			},
+			path: "mailNickname",
+			expected: map[string]interface{}{
+				"displayName":  "new",
+				"mailNickname": "old",
+			},
+		},
+		{
+			name: "nested property",
+			target: map[string]interface{}{
+				"web": map[string]interface{}{"homePageUrl": "https://new.example.com", "logoutUrl": "https://new.example.com/logout"},
+			},
+			source: map[string]interface{}{
+				"web": map[string]interface{}{"homePageUrl": "https://old.example.com"},
+			},
+			path: "web.homePageUrl",
+			expected: map[string]interface{}{
+				"web": map[string]interface{}{"homePageUrl": "https://old.example.com", "logoutUrl": "https://new.example.com/logout"},
+			},
+		},
+		{
+			name: "missing in source",
+			target: map[string]interface{}{
+				"displayName":  "new",
+				"mailNickname": "new",
+			},
+			source: map[string]interface{}{
+				"displayName": "old",
+			},
+			path: "mailNickname",
+			expected: map[string]interface{}{
+				"displayName": "new",
+			},
+		},
+		{
+			name: "missing in target",
+			target: map[string]interface{}{
+				"displayName": "new",
+			},
+			source: map[string]interface{}{
+				"web": map[string]interface{}{"homePageUrl": "https://old.example.com"},
+			},
+			path: "web.homePageUrl",
+			expected: map[string]interface{}{
+				"displayName": "new",
+			},
+		},
+	}
+
+	for _, tc := range testcases {
+		t.Run(tc.name, func(t *testing.T) {
+			actual := ReplacePath(tc.target, tc.source, tc.path)
+			if !reflect.DeepEqual(actual, tc.expected) {
+				t.Fatalf("expected %v, got %v", tc.expected, actual)
+			}
+		})
+	}
+}
+
+func TestWithoutPaths(t *testing.T) {
+	input := map[string]interface{}{
+		"_comment":    "the group of the on-call engineers",
+		"displayName": "example",
+		"web": map[string]interface{}{
+			"_comment":    "the portal",
+			"homePageUrl": "https://example.com",
+		},
+	}
+
+	testcases := []struct {
+		name     string
+		paths    []string
+		expected interface{}
+	}{
+		{
+			name:  "top level and nested properties",
+			paths: []string{"_comment", "web._comment"},
+			expected: map[string]interface{}{
+				"displayName": "example",
+				"web": map[string]interface{}{
+					"homePageUrl": "https://example.com",
+				},
+			},
+		},
+		{
+			name:     "missing paths",
+			paths:    []string{"description", "owners._comment", ""},
+			expected: input,
+		},
+	}
+
+	for _, tc := range testcases {
+		t.Run(tc.name, func(t *testing.T) {
+			actual := WithoutPaths(input, tc.paths)
+			if !reflect.DeepEqual(actual, tc.expected) {
+				t.Fatalf("expected %v, got %v", tc.expected, actual)
+			}
+		})
+	}
+	if _, ok := input["_comment"]; !ok {
+		t.Fatalf("expected the input not to be changed")
+	}
+}
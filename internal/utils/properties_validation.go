@@ -0,0 +1,68 @@
+package utils
+
+import (
+	"fmt"
+	"net/url"
+	"reflect"
+	"regexp"
+	"strings"
+)
+
+// propertiesValidationRule is an endpoint which validates the properties of the objects of the collections matching
+// the pattern against the policies of the directory, without creating or updating them.
+type propertiesValidationRule struct {
+	pattern *regexp.Regexp
+	// createUrl validates the properties of a new object of the entity type.
+	createUrl  string
+	entityType string
+	// updateUrl validates the properties of an existing object, it's formatted with its ID.
+	updateUrl  string
+	properties []string
+}
+
+// propertiesValidationRules are the collections whose objects can be validated, the URL of the collection must match
+// exactly, e.g. `groups` but not `administrativeUnits/{id}/groups`, whose objects are references.
+var propertiesValidationRules = []propertiesValidationRule{
+	{
+		// the display name and mail nickname of groups are validated against the group naming policy
+		pattern:    regexp.MustCompile(`(?i)^groups$`),
+		createUrl:  "directoryObjects/validateProperties",
+		entityType: "Group",
+		updateUrl:  "groups/%s/validateProperties",
+		properties: []string{"displayName", "mailNickname"},
+	},
+}
+
+// PropertiesValidation returns the URL and the body of the request which validates the properties of an object of the
+// collection without creating or updating it. When the ID is empty, the properties of a new object are validated.
+// Otherwise, only the properties changed from the previous body are validated against the existing object, as the
+// unchanged ones would be reported as conflicting with the object itself. It returns false when the collection has no
+// validation endpoint or there's no property to validate.
+func PropertiesValidation(collectionUrl string, id string, body map[string]interface{}, previousBody map[string]interface{}) (string, map[string]interface{}, bool) {
+	collectionUrl = strings.Trim(strings.SplitN(collectionUrl, "?", 2)[0], "/")
+	for _, rule := range propertiesValidationRules {
+		if !rule.pattern.MatchString(collectionUrl) {
+			continue
+		}
+		requestBody := make(map[string]interface{})
+		for _, property := range rule.properties {
+			value, ok := body[property]
+			if !ok {
+				continue
+			}
+			if id != "" && reflect.DeepEqual(value, previousBody[property]) {
+				continue
+			}
+			requestBody[property] = value
+		}
+		if len(requestBody) == 0 {
+			return "", nil, false
+		}
+		if id != "" {
+			return fmt.Sprintf(rule.updateUrl, url.PathEscape(id)), requestBody, true
+		}
+		requestBody["entityType"] = rule.entityType
+		return rule.createUrl, requestBody, true
+	}
+	return "", nil, false
+}
@@ -0,0 +1,93 @@
+package utils
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestPropertiesValidation(t *testing.T) {
+	testcases := []struct {
+		name          string
+		collectionUrl string
+		id            string
+		body          map[string]interface{}
+		previousBody  map[string]interface{}
+		wantUrl       string
+		wantBody      map[string]interface{}
+		wantOk        bool
+	}{
+		{
+			name:          "new group",
+			collectionUrl: "groups",
+			body:          map[string]interface{}{"displayName": "Sales", "mailNickname": "sales", "securityEnabled": true},
+			wantUrl:       "directoryObjects/validateProperties",
+			wantBody:      map[string]interface{}{"entityType": "Group", "displayName": "Sales", "mailNickname": "sales"},
+			wantOk:        true,
+		},
+		{
+			name:          "new group with a leading slash",
+			collectionUrl: "/groups",
+			body:          map[string]interface{}{"displayName": "Sales"},
+			wantUrl:       "directoryObjects/validateProperties",
+			wantBody:      map[string]interface{}{"entityType": "Group", "displayName": "Sales"},
+			wantOk:        true,
+		},
+		{
+			name:          "updated group only validates the changed properties",
+			collectionUrl: "groups",
+			id:            "00000000-0000-0000-0000-000000000001",
+			body:          map[string]interface{}{"displayName": "Sales EMEA", "mailNickname": "sales"},
+			previousBody:  map[string]interface{}{"displayName": "Sales", "mailNickname": "sales"},
+			wantUrl:       "groups/00000000-0000-0000-0000-000000000001/validateProperties",
+			wantBody:      map[string]interface{}{"displayName": "Sales EMEA"},
+			wantOk:        true,
+		},
+		{
+			name:          "updated group without changed properties",
+			collectionUrl: "groups",
+			id:            "00000000-0000-0000-0000-000000000001",
+			body:          map[string]interface{}{"displayName": "Sales", "mailNickname": "sales", "description": "updated"},
+			previousBody:  map[string]interface{}{"displayName": "Sales", "mailNickname": "sales"},
+			wantOk:        false,
+		},
+		{
+			name:          "group without validated properties",
+			collectionUrl: "groups",
+			body:          map[string]interface{}{"description": "Sales"},
+			wantOk:        false,
+		},
+		{
+			name:          "groups of an administrative unit",
+			collectionUrl: "administrativeUnits/00000000-0000-0000-0000-000000000001/groups",
+			body:          map[string]interface{}{"displayName": "Sales"},
+			wantOk:        false,
+		},
+		{
+			name:          "groups of a team",
+			collectionUrl: "teams/00000000-0000-0000-0000-000000000001/groups",
+			body:          map[string]interface{}{"displayName": "Sales"},
+			wantOk:        false,
+		},
+		{
+			name:          "other collection",
+			collectionUrl: "applications",
+			body:          map[string]interface{}{"displayName": "Sales"},
+			wantOk:        false,
+		},
+	}
+
+	for _, tc := range testcases {
+		t.Run(tc.name, func(t *testing.T) {
+			gotUrl, gotBody, gotOk := PropertiesValidation(tc.collectionUrl, tc.id, tc.body, tc.previousBody)
+			if gotOk != tc.wantOk {
+				t.Fatalf("expected ok %v, got %v", tc.wantOk, gotOk)
+			}
+			if gotUrl != tc.wantUrl {
+				t.Fatalf("expected URL %q, got %q", tc.wantUrl, gotUrl)
+			}
+			if tc.wantOk && !reflect.DeepEqual(gotBody, tc.wantBody) {
+				t.Fatalf("expected body %v, got %v", tc.wantBody, gotBody)
+			}
+		})
+	}
+}
@@ -0,0 +1,198 @@
+package utils
+
+import (
+	"sort"
+	"strings"
+)
+
+// RedactedPlaceholder replaces the values which are masked by RedactPaths.
+const RedactedPlaceholder = "(redacted)"
+
+// RedactPaths returns a copy of the input whose values at the paths are replaced with RedactedPlaceholder.
+// A path is a dot separated list of property names, e.g. `keyCredentials.key`, and the items of the arrays
+// along the path are all redacted. The paths which don't exist in the input are ignored.
+func RedactPaths(input interface{}, paths []string) interface{} {
+	output := input
+	for _, path := range paths {
+		if path == "" {
+			continue
+		}
+		output = redactPath(output, strings.Split(path, "."))
+	}
+	return output
+}
+
+func redactPath(input interface{}, segments []string) interface{} {
+	switch v := input.(type) {
+	case map[string]interface{}:
+		value, ok := v[segments[0]]
+		if !ok {
+			return input
+		}
+		res := make(map[string]interface{}, len(v))
+		for key, item := range v {
+			res[key] = item
+		}
+		if len(segments) == 1 {
+			res[segments[0]] = RedactedPlaceholder
+		} else {
+			res[segments[0]] = redactPath(value, segments[1:])
+		}
+		return res
+	case []interface{}:
+		res := make([]interface{}, len(v))
+		for i, item := range v {
+			res[i] = redactPath(item, segments)
+		}
+		return res
+	}
+	return input
+}
+
+// sensitivePropertyNames are the lower-cased names, or parts of the names, of the properties whose values are
+// credentials, e.g. `secretText` of the password credentials or `password` of the password profile of a user.
+var sensitivePropertyNames = []string{"password", "secret", "token"}
+
+// RedactSensitiveProperties returns a copy of the input whose values of the properties named like credentials,
+// e.g. `password`, `secretText` or `accessToken`, and of the `key` properties are replaced with RedactedPlaceholder
+// at any depth.
+func RedactSensitiveProperties(input interface{}) interface{} {
+	switch v := input.(type) {
+	case map[string]interface{}:
+		res := make(map[string]interface{}, len(v))
+		for key, item := range v {
+			if isSensitivePropertyName(key) {
+				res[key] = RedactedPlaceholder
+			} else {
+				res[key] = RedactSensitiveProperties(item)
+			}
+		}
+		return res
+	case []interface{}:
+		res := make([]interface{}, len(v))
+		for i, item := range v {
+			res[i] = RedactSensitiveProperties(item)
+		}
+		return res
+	}
+	return input
+}
+
+func isSensitivePropertyName(name string) bool {
+	name = strings.ToLower(name)
+	if name == "key" {
+		return true
+	}
+	for _, sensitive := range sensitivePropertyNames {
+		if strings.Contains(name, sensitive) {
+			return true
+		}
+	}
+	return false
+}
+
+// RedactKeys returns a copy of the input whose values of the properties named like one of the keys, compared
+// case-insensitively, are replaced with RedactedPlaceholder at any depth.
+func RedactKeys(input interface{}, keys []string) interface{} {
+	if len(keys) == 0 {
+		return input
+	}
+	switch v := input.(type) {
+	case map[string]interface{}:
+		res := make(map[string]interface{}, len(v))
+		for key, item := range v {
+			if containsFold(keys, key) {
+				res[key] = RedactedPlaceholder
+			} else {
+				res[key] = RedactKeys(item, keys)
+			}
+		}
+		return res
+	case []interface{}:
+		res := make([]interface{}, len(v))
+		for i, item := range v {
+			res[i] = RedactKeys(item, keys)
+		}
+		return res
+	}
+	return input
+}
+
+func containsFold(values []string, value string) bool {
+	for _, v := range values {
+		if strings.EqualFold(v, value) {
+			return true
+		}
+	}
+	return false
+}
+
+// StringValuesAtPaths returns the non-empty strings of the input at the paths, including the strings nested in the
+// objects and arrays found at the paths. The paths are the same as the paths of RedactPaths.
+func StringValuesAtPaths(input interface{}, paths []string) []string {
+	values := make([]string, 0)
+	for _, path := range paths {
+		if path == "" {
+			continue
+		}
+		values = appendValuesAtPath(values, input, strings.Split(path, "."))
+	}
+	return values
+}
+
+func appendValuesAtPath(values []string, input interface{}, segments []string) []string {
+	switch v := input.(type) {
+	case map[string]interface{}:
+		value, ok := v[segments[0]]
+		if !ok {
+			return values
+		}
+		if len(segments) == 1 {
+			return appendStringValues(values, value)
+		}
+		return appendValuesAtPath(values, value, segments[1:])
+	case []interface{}:
+		for _, item := range v {
+			values = appendValuesAtPath(values, item, segments)
+		}
+	}
+	return values
+}
+
+func appendStringValues(values []string, input interface{}) []string {
+	switch v := input.(type) {
+	case string:
+		if v != "" {
+			values = append(values, v)
+		}
+	case map[string]interface{}:
+		for _, item := range v {
+			values = appendStringValues(values, item)
+		}
+	case []interface{}:
+		for _, item := range v {
+			values = appendStringValues(values, item)
+		}
+	}
+	return values
+}
+
+// RedactValues returns the text whose occurrences of the values are replaced with RedactedPlaceholder. The longer
+// values are replaced first, so a value containing another one is fully masked.
+func RedactValues(text string, values []string) string {
+	if len(values) == 0 {
+		return text
+	}
+	sorted := make([]string, len(values))
+	copy(sorted, values)
+	sort.SliceStable(sorted, func(i, j int) bool {
+		return len(sorted[i]) > len(sorted[j])
+	})
+	for _, value := range sorted {
+		if value == "" {
+			continue
+		}
+		text = strings.ReplaceAll(text, value, RedactedPlaceholder)
+	}
+	return text
+}
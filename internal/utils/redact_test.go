@@ -0,0 +1,262 @@
+package utils
+
+import (
+	"reflect"
+	"sort"
+	"testing"
+)
+
+func TestRedactPaths(t *testing.T) {
+	testcases := []struct {
+		name     string
+		input    interface{}
+		paths    []string
+		expected interface{}
+	}{
+		{
+			name: "top level property",
+			input: map[string]interface{}{
+				"displayName": "example",
+				"logo":        "aGVsbG8=",
+			},
+			paths: []string{"logo"},
+			expected: map[string]interface{}{
+				"displayName": "example",
+				"logo":        RedactedPlaceholder,
+			},
+		},
+		{
+			name: "nested property in array",
+			input: map[string]interface{}{
+				"keyCredentials": []interface{}{
+					map[string]interface{}{"type": "AsymmetricX509Cert", "key": "MIIC"},
+					map[string]interface{}{"type": "AsymmetricX509Cert", "key": "MIID"},
+				},
+			},
+			paths: []string{"keyCredentials.key"},
+			expected: map[string]interface{}{
+				"keyCredentials": []interface{}{
+					map[string]interface{}{"type": "AsymmetricX509Cert", "key": RedactedPlaceholder},
+					map[string]interface{}{"type": "AsymmetricX509Cert", "key": RedactedPlaceholder},
+				},
+			},
+		},
+		{
+			name: "missing path",
+			input: map[string]interface{}{
+				"displayName": "example",
+			},
+			paths: []string{"logo", "web.redirectUris", ""},
+			expected: map[string]interface{}{
+				"displayName": "example",
+			},
+		},
+		{
+			name:     "not an object",
+			input:    "example",
+			paths:    []string{"logo"},
+			expected: "example",
+		},
+	}
+
+	for _, tc := range testcases {
+		t.Run(tc.name, func(t *testing.T) {
+			actual := RedactPaths(tc.input, tc.paths)
+			if !reflect.DeepEqual(actual, tc.expected) {
+				t.Fatalf("expected %v, got %v", tc.expected, actual)
+			}
+		})
+	}
+}
+
+func TestStringValuesAtPaths(t *testing.T) {
+	testcases := []struct {
+		name     string
+		input    interface{}
+		paths    []string
+		expected []string
+	}{
+		{
+			name: "top level property",
+			input: map[string]interface{}{
+				"displayName": "example",
+				"logo":        "aGVsbG8=",
+			},
+			paths:    []string{"logo"},
+			expected: []string{"aGVsbG8="},
+		},
+		{
+			name: "nested values in array",
+			input: map[string]interface{}{
+				"web": map[string]interface{}{
+					"redirectUris": []interface{}{"https://example.com/a", "https://example.com/b"},
+				},
+			},
+			paths:    []string{"web"},
+			expected: []string{"https://example.com/a", "https://example.com/b"},
+		},
+		{
+			name: "property of array items",
+			input: map[string]interface{}{
+				"keyCredentials": []interface{}{
+					map[string]interface{}{"type": "AsymmetricX509Cert", "key": "MIIC"},
+					map[string]interface{}{"type": "AsymmetricX509Cert", "key": ""},
+				},
+			},
+			paths:    []string{"keyCredentials.key"},
+			expected: []string{"MIIC"},
+		},
+		{
+			name: "missing path and non-string values",
+			input: map[string]interface{}{
+				"displayName": "example",
+				"isEnabled":   true,
+			},
+			paths:    []string{"logo", "isEnabled", ""},
+			expected: []string{},
+		},
+	}
+
+	for _, tc := range testcases {
+		t.Run(tc.name, func(t *testing.T) {
+			actual := StringValuesAtPaths(tc.input, tc.paths)
+			sort.Strings(actual)
+			if !reflect.DeepEqual(actual, tc.expected) {
+				t.Fatalf("expected %v, got %v", tc.expected, actual)
+			}
+		})
+	}
+}
+
+func TestRedactValues(t *testing.T) {
+	testcases := []struct {
+		name     string
+		text     string
+		values   []string
+		expected string
+	}{
+		{
+			name:     "no values",
+			text:     "The value 'aGVsbG8=' is invalid.",
+			values:   nil,
+			expected: "The value 'aGVsbG8=' is invalid.",
+		},
+		{
+			name:     "every occurrence",
+			text:     "The value 'aGVsbG8=' of logo is invalid: aGVsbG8=",
+			values:   []string{"aGVsbG8="},
+			expected: "The value '(redacted)' of logo is invalid: (redacted)",
+		},
+		{
+			name:     "longer values first",
+			text:     "https://example.com/auth is not allowed",
+			values:   []string{"example", "https://example.com/auth"},
+			expected: "(redacted) is not allowed",
+		},
+	}
+
+	for _, tc := range testcases {
+		t.Run(tc.name, func(t *testing.T) {
+			actual := RedactValues(tc.text, tc.values)
+			if actual != tc.expected {
+				t.Fatalf("expected %q, got %q", tc.expected, actual)
+			}
+		})
+	}
+}
+
+func TestRedactPaths_DoesNotModifyInput(t *testing.T) {
+	input := map[string]interface{}{
+		"web": map[string]interface{}{"secret": "value"},
+	}
+	RedactPaths(input, []string{"web.secret"})
+	if input["web"].(map[string]interface{})["secret"] != "value" {
+		t.Fatalf("expected the input to be unchanged, got %v", input)
+	}
+}
+
+func TestRedactSensitiveProperties(t *testing.T) {
+	testcases := []struct {
+		name     string
+		input    interface{}
+		expected interface{}
+	}{
+		{
+			name: "nested credentials",
+			input: map[string]interface{}{
+				"displayName": "example",
+				"passwordProfile": map[string]interface{}{
+					"password": "P@ssw0rd",
+				},
+				"keyCredentials": []interface{}{
+					map[string]interface{}{"type": "AsymmetricX509Cert", "key": "MIIC"},
+				},
+			},
+			expected: map[string]interface{}{
+				"displayName":     "example",
+				"passwordProfile": RedactedPlaceholder,
+				"keyCredentials": []interface{}{
+					map[string]interface{}{"type": "AsymmetricX509Cert", "key": RedactedPlaceholder},
+				},
+			},
+		},
+		{
+			name: "case insensitive",
+			input: map[string]interface{}{
+				"secretText":     "value",
+				"AccessToken":    "value",
+				"keyId":          "00000000-0000-0000-0000-000000000000",
+				"signInAudience": "AzureADMyOrg",
+			},
+			expected: map[string]interface{}{
+				"secretText":     RedactedPlaceholder,
+				"AccessToken":    RedactedPlaceholder,
+				"keyId":          "00000000-0000-0000-0000-000000000000",
+				"signInAudience": "AzureADMyOrg",
+			},
+		},
+		{
+			name:     "not an object",
+			input:    "password",
+			expected: "password",
+		},
+	}
+
+	for _, tc := range testcases {
+		t.Run(tc.name, func(t *testing.T) {
+			actual := RedactSensitiveProperties(tc.input)
+			if !reflect.DeepEqual(actual, tc.expected) {
+				t.Fatalf("expected %v, got %v", tc.expected, actual)
+			}
+		})
+	}
+}
+
+func TestRedactKeys(t *testing.T) {
+	input := map[string]interface{}{
+		"displayName": "example",
+		"passwordProfile": map[string]interface{}{
+			"Password":                      "P@ssw0rd",
+			"forceChangePasswordNextSignIn": true,
+		},
+		"keyCredentials": []interface{}{
+			map[string]interface{}{"keyId": "1", "key": "MIIC"},
+		},
+	}
+	expected := map[string]interface{}{
+		"displayName": "example",
+		"passwordProfile": map[string]interface{}{
+			"Password":                      RedactedPlaceholder,
+			"forceChangePasswordNextSignIn": true,
+		},
+		"keyCredentials": []interface{}{
+			map[string]interface{}{"keyId": "1", "key": RedactedPlaceholder},
+		},
+	}
+	if actual := RedactKeys(input, []string{"password", "key"}); !reflect.DeepEqual(actual, expected) {
+		t.Fatalf("expected %v, got %v", expected, actual)
+	}
+	if actual := RedactKeys(input, nil); !reflect.DeepEqual(actual, input) {
+		t.Fatalf("expected the input to be returned as is, got %v", actual)
+	}
+}
@@ -1,10 +1,14 @@
 package utils
 
 import (
+	"encoding/json"
 	"errors"
+	"fmt"
 	"net/http"
+	"strings"
 
 	"github.com/Azure/azure-sdk-for-go/sdk/azcore"
+	"github.com/Azure/azure-sdk-for-go/sdk/azcore/runtime"
 )
 
 func ResponseErrorWasNotFound(err error) bool {
@@ -27,3 +31,103 @@ func ResponseErrorWasStatusCode(err error, statusCode int) bool {
 	var responseErr *azcore.ResponseError
 	return errors.As(err, &responseErr) && responseErr.StatusCode == statusCode
 }
+
+// ResponseErrorMatches returns true if the error is a response error with the status code, and the Graph error code
+// if it's not empty. The Graph error code is compared case-insensitively.
+func ResponseErrorMatches(err error, statusCode int, errorCode string) bool {
+	var responseErr *azcore.ResponseError
+	if !errors.As(err, &responseErr) || responseErr.StatusCode != statusCode {
+		return false
+	}
+	return errorCode == "" || strings.EqualFold(responseErr.ErrorCode, errorCode)
+}
+
+// ResponseErrorHasCode returns true if the error is a response error with any of the Graph error codes. The Graph
+// error codes are compared case-insensitively.
+func ResponseErrorHasCode(err error, errorCodes []string) bool {
+	var responseErr *azcore.ResponseError
+	if !errors.As(err, &responseErr) || responseErr.ErrorCode == "" {
+		return false
+	}
+	for _, errorCode := range errorCodes {
+		if strings.EqualFold(responseErr.ErrorCode, errorCode) {
+			return true
+		}
+	}
+	return false
+}
+
+// GraphError is the error envelope returned by Microsoft Graph, e.g.
+// `{"error": {"code": "...", "message": "...", "innerError": {"request-id": "...", "date": "..."}}}`.
+type GraphError struct {
+	StatusCode int
+	Code       string
+	Message    string
+	RequestId  string
+	Date       string
+}
+
+// ParseGraphError parses the body of a response error into the Graph error envelope.
+// It returns nil if the error isn't a response error, or its body isn't a Graph error.
+func ParseGraphError(err error) *GraphError {
+	var responseErr *azcore.ResponseError
+	if !errors.As(err, &responseErr) || responseErr.RawResponse == nil {
+		return nil
+	}
+	payload, payloadErr := runtime.Payload(responseErr.RawResponse)
+	if payloadErr != nil {
+		return nil
+	}
+	var envelope struct {
+		Error *struct {
+			Code       string `json:"code"`
+			Message    string `json:"message"`
+			InnerError *struct {
+				RequestId string `json:"request-id"`
+				Date      string `json:"date"`
+			} `json:"innerError"`
+		} `json:"error"`
+	}
+	if json.Unmarshal(payload, &envelope) != nil || envelope.Error == nil {
+		return nil
+	}
+	graphErr := &GraphError{
+		StatusCode: responseErr.StatusCode,
+		Code:       envelope.Error.Code,
+		Message:    envelope.Error.Message,
+	}
+	if inner := envelope.Error.InnerError; inner != nil {
+		graphErr.RequestId = inner.RequestId
+		graphErr.Date = inner.Date
+	}
+	if graphErr.RequestId == "" {
+		graphErr.RequestId = responseErr.RawResponse.Header.Get("request-id")
+	}
+	return graphErr
+}
+
+// ResponseErrorDetail returns the detail of a diagnostic for the error. The Graph errors are formatted with their code,
+// message, and the request ID and date which are needed to open a support ticket, the other errors are returned as is.
+func ResponseErrorDetail(err error) string {
+	graphErr := ParseGraphError(err)
+	if graphErr == nil {
+		return err.Error()
+	}
+	var responseErr *azcore.ResponseError
+	errors.As(err, &responseErr)
+
+	var sb strings.Builder
+	if request := responseErr.RawResponse.Request; request != nil && request.URL != nil {
+		fmt.Fprintf(&sb, "%s %s\n", request.Method, request.URL.String())
+	}
+	fmt.Fprintf(&sb, "Status: %d %s\n", graphErr.StatusCode, http.StatusText(graphErr.StatusCode))
+	fmt.Fprintf(&sb, "Code: %s\n", graphErr.Code)
+	fmt.Fprintf(&sb, "Message: %s", graphErr.Message)
+	if graphErr.RequestId != "" {
+		fmt.Fprintf(&sb, "\nRequest ID: %s", graphErr.RequestId)
+	}
+	if graphErr.Date != "" {
+		fmt.Fprintf(&sb, "\nDate: %s", graphErr.Date)
+	}
+	return sb.String()
+}
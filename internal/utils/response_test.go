@@ -188,3 +188,258 @@ func TestResponseErrorWasStatusCode(t *testing.T) {
 		})
 	}
 }
+
+func TestResponseErrorMatches(t *testing.T) {
+	newResponseError := func(statusCode int, errorCode string) error {
+		return &azcore.ResponseError{
+			StatusCode: statusCode,
+			ErrorCode:  errorCode,
+			RawResponse: &http.Response{
+				StatusCode: statusCode,
+				Status:     http.StatusText(statusCode),
+				Body:       io.NopCloser(bytes.NewReader([]byte("{}"))),
+				Request: &http.Request{
+					Method: "POST",
+					URL:    &url.URL{Scheme: "https", Host: "graph.microsoft.com", Path: "/v1.0/groups/test/members/$ref"},
+				},
+			},
+		}
+	}
+
+	tests := []struct {
+		name       string
+		err        error
+		statusCode int
+		errorCode  string
+		expected   bool
+	}{
+		{
+			name:       "nil error",
+			err:        nil,
+			statusCode: http.StatusBadRequest,
+			expected:   false,
+		},
+		{
+			name:       "non-ResponseError",
+			err:        errors.New("some error"),
+			statusCode: http.StatusBadRequest,
+			expected:   false,
+		},
+		{
+			name:       "matching status code without error code",
+			err:        newResponseError(http.StatusNotFound, "Request_ResourceNotFound"),
+			statusCode: http.StatusNotFound,
+			expected:   true,
+		},
+		{
+			name:       "matching status code and error code",
+			err:        newResponseError(http.StatusBadRequest, "Request_BadRequest"),
+			statusCode: http.StatusBadRequest,
+			errorCode:  "request_badrequest",
+			expected:   true,
+		},
+		{
+			name:       "matching status code and different error code",
+			err:        newResponseError(http.StatusBadRequest, "Request_BadRequest"),
+			statusCode: http.StatusBadRequest,
+			errorCode:  "Authorization_RequestDenied",
+			expected:   false,
+		},
+		{
+			name:       "different status code",
+			err:        newResponseError(http.StatusForbidden, "Request_BadRequest"),
+			statusCode: http.StatusBadRequest,
+			errorCode:  "Request_BadRequest",
+			expected:   false,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result := ResponseErrorMatches(tt.err, tt.statusCode, tt.errorCode)
+			if result != tt.expected {
+				t.Errorf("ResponseErrorMatches() = %v, expected %v", result, tt.expected)
+			}
+		})
+	}
+}
+
+func TestResponseErrorHasCode(t *testing.T) {
+	newResponseError := func(errorCode string) error {
+		return &azcore.ResponseError{
+			StatusCode: http.StatusBadRequest,
+			ErrorCode:  errorCode,
+			RawResponse: &http.Response{
+				StatusCode: http.StatusBadRequest,
+				Status:     http.StatusText(http.StatusBadRequest),
+				Body:       io.NopCloser(bytes.NewReader([]byte("{}"))),
+				Request: &http.Request{
+					Method: "POST",
+					URL:    &url.URL{Scheme: "https", Host: "graph.microsoft.com", Path: "/v1.0/servicePrincipals"},
+				},
+			},
+		}
+	}
+	errorCodes := []string{"Request_MultipleObjectsWithSameKeyValue", "Request_ResourceNotFound"}
+
+	tests := []struct {
+		name     string
+		err      error
+		expected bool
+	}{
+		{
+			name:     "nil error",
+			err:      nil,
+			expected: false,
+		},
+		{
+			name:     "non-ResponseError",
+			err:      errors.New("Request_MultipleObjectsWithSameKeyValue"),
+			expected: false,
+		},
+		{
+			name:     "matching error code",
+			err:      newResponseError("Request_MultipleObjectsWithSameKeyValue"),
+			expected: true,
+		},
+		{
+			name:     "matching error code with different casing",
+			err:      newResponseError("request_resourcenotfound"),
+			expected: true,
+		},
+		{
+			name:     "different error code",
+			err:      newResponseError("Request_BadRequest"),
+			expected: false,
+		},
+		{
+			name:     "empty error code",
+			err:      newResponseError(""),
+			expected: false,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result := ResponseErrorHasCode(tt.err, errorCodes)
+			if result != tt.expected {
+				t.Errorf("ResponseErrorHasCode() = %v, expected %v", result, tt.expected)
+			}
+		})
+	}
+}
+
+func TestParseGraphError(t *testing.T) {
+	newResponseError := func(statusCode int, body string, header http.Header) error {
+		return &azcore.ResponseError{
+			StatusCode: statusCode,
+			RawResponse: &http.Response{
+				StatusCode: statusCode,
+				Status:     http.StatusText(statusCode),
+				Header:     header,
+				Body:       io.NopCloser(bytes.NewReader([]byte(body))),
+				Request: &http.Request{
+					Method: "POST",
+					URL:    &url.URL{Scheme: "https", Host: "graph.microsoft.com", Path: "/v1.0/applications"},
+				},
+			},
+		}
+	}
+
+	tests := []struct {
+		name     string
+		err      error
+		expected *GraphError
+	}{
+		{
+			name:     "non-ResponseError",
+			err:      errors.New("some error"),
+			expected: nil,
+		},
+		{
+			name: "graph error with inner error",
+			err: newResponseError(http.StatusBadRequest, `{
+				"error": {
+					"code": "Request_BadRequest",
+					"message": "Invalid value specified for property 'displayName' of resource 'Application'.",
+					"innerError": {
+						"date": "2024-01-01T00:00:00",
+						"request-id": "00000000-0000-0000-0000-000000000001",
+						"client-request-id": "00000000-0000-0000-0000-000000000002"
+					}
+				}
+			}`, nil),
+			expected: &GraphError{
+				StatusCode: http.StatusBadRequest,
+				Code:       "Request_BadRequest",
+				Message:    "Invalid value specified for property 'displayName' of resource 'Application'.",
+				RequestId:  "00000000-0000-0000-0000-000000000001",
+				Date:       "2024-01-01T00:00:00",
+			},
+		},
+		{
+			name: "graph error without inner error uses the request-id header",
+			err: newResponseError(http.StatusForbidden, `{"error": {"code": "Authorization_RequestDenied", "message": "Insufficient privileges to complete the operation."}}`,
+				http.Header{"Request-Id": []string{"00000000-0000-0000-0000-000000000003"}}),
+			expected: &GraphError{
+				StatusCode: http.StatusForbidden,
+				Code:       "Authorization_RequestDenied",
+				Message:    "Insufficient privileges to complete the operation.",
+				RequestId:  "00000000-0000-0000-0000-000000000003",
+			},
+		},
+		{
+			name:     "body which isn't a graph error",
+			err:      newResponseError(http.StatusBadGateway, `<html>Bad Gateway</html>`, nil),
+			expected: nil,
+		},
+		{
+			name:     "json body without error",
+			err:      newResponseError(http.StatusBadRequest, `{"value": []}`, nil),
+			expected: nil,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result := ParseGraphError(tt.err)
+			if tt.expected == nil || result == nil {
+				if tt.expected != result {
+					t.Fatalf("ParseGraphError() = %+v, want %+v", result, tt.expected)
+				}
+				return
+			}
+			if *result != *tt.expected {
+				t.Fatalf("ParseGraphError() = %+v, want %+v", *result, *tt.expected)
+			}
+		})
+	}
+}
+
+func TestResponseErrorDetail(t *testing.T) {
+	err := &azcore.ResponseError{
+		StatusCode: http.StatusBadRequest,
+		RawResponse: &http.Response{
+			StatusCode: http.StatusBadRequest,
+			Status:     http.StatusText(http.StatusBadRequest),
+			Body:       io.NopCloser(bytes.NewReader([]byte(`{"error": {"code": "Request_BadRequest", "message": "Invalid value.", "innerError": {"date": "2024-01-01T00:00:00", "request-id": "00000000-0000-0000-0000-000000000001"}}}`))),
+			Request: &http.Request{
+				Method: "POST",
+				URL:    &url.URL{Scheme: "https", Host: "graph.microsoft.com", Path: "/v1.0/applications"},
+			},
+		},
+	}
+	expected := "POST https://graph.microsoft.com/v1.0/applications\n" +
+		"Status: 400 Bad Request\n" +
+		"Code: Request_BadRequest\n" +
+		"Message: Invalid value.\n" +
+		"Request ID: 00000000-0000-0000-0000-000000000001\n" +
+		"Date: 2024-01-01T00:00:00"
+	if actual := ResponseErrorDetail(err); actual != expected {
+		t.Fatalf("ResponseErrorDetail() = %q, want %q", actual, expected)
+	}
+
+	if actual := ResponseErrorDetail(errors.New("some error")); actual != "some error" {
+		t.Fatalf("ResponseErrorDetail() = %q, want %q", actual, "some error")
+	}
+}
@@ -0,0 +1,68 @@
+package utils
+
+import (
+	"regexp"
+)
+
+// SplitSensitivePaths splits the input into the values whose paths don't match any of the patterns, and the values
+// whose paths match. The path of a value is the dot separated list of property names leading to it, e.g.
+// `app.passwordCredentials.secretText`, the items of arrays share the path of the array. A matched value is moved
+// with all its nested values, and the arrays containing matched values are kept in both parts with the same length.
+// The second part is nil if no value matches.
+func SplitSensitivePaths(input interface{}, patterns []*regexp.Regexp) (interface{}, interface{}) {
+	if len(patterns) == 0 {
+		return input, nil
+	}
+	return splitSensitivePaths(input, "", patterns)
+}
+
+func splitSensitivePaths(input interface{}, path string, patterns []*regexp.Regexp) (interface{}, interface{}) {
+	switch v := input.(type) {
+	case map[string]interface{}:
+		public := make(map[string]interface{}, len(v))
+		sensitive := make(map[string]interface{})
+		for key, item := range v {
+			itemPath := key
+			if path != "" {
+				itemPath = path + "." + key
+			}
+			if matchesAnyPattern(itemPath, patterns) {
+				sensitive[key] = item
+				continue
+			}
+			itemPublic, itemSensitive := splitSensitivePaths(item, itemPath, patterns)
+			public[key] = itemPublic
+			if itemSensitive != nil {
+				sensitive[key] = itemSensitive
+			}
+		}
+		if len(sensitive) == 0 {
+			return public, nil
+		}
+		return public, sensitive
+	case []interface{}:
+		public := make([]interface{}, len(v))
+		sensitive := make([]interface{}, len(v))
+		found := false
+		for i, item := range v {
+			public[i], sensitive[i] = splitSensitivePaths(item, path, patterns)
+			if sensitive[i] != nil {
+				found = true
+			}
+		}
+		if !found {
+			return public, nil
+		}
+		return public, sensitive
+	}
+	return input, nil
+}
+
+func matchesAnyPattern(path string, patterns []*regexp.Regexp) bool {
+	for _, pattern := range patterns {
+		if pattern.MatchString(path) {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,96 @@
+package utils
+
+import (
+	"reflect"
+	"regexp"
+	"testing"
+)
+
+func TestSplitSensitivePaths(t *testing.T) {
+	input := map[string]interface{}{
+		"appId": "00000000-0000-0000-0000-000000000001",
+		"app": map[string]interface{}{
+			"displayName": "Demo App",
+			"passwordCredentials": []interface{}{
+				map[string]interface{}{"keyId": "1", "secretText": "secret1"},
+				map[string]interface{}{"keyId": "2"},
+			},
+		},
+		"accessToken": "token",
+		"tags":        []interface{}{"a", "b"},
+	}
+
+	testcases := []struct {
+		name          string
+		patterns      []string
+		wantPublic    interface{}
+		wantSensitive interface{}
+	}{
+		{
+			name:       "no patterns",
+			wantPublic: input,
+		},
+		{
+			name:     "leaves",
+			patterns: []string{`(?i)(secret\w*|password|token)$`},
+			wantPublic: map[string]interface{}{
+				"appId": "00000000-0000-0000-0000-000000000001",
+				"app": map[string]interface{}{
+					"displayName": "Demo App",
+					"passwordCredentials": []interface{}{
+						map[string]interface{}{"keyId": "1"},
+						map[string]interface{}{"keyId": "2"},
+					},
+				},
+				"tags": []interface{}{"a", "b"},
+			},
+			wantSensitive: map[string]interface{}{
+				"app": map[string]interface{}{
+					"passwordCredentials": []interface{}{
+						map[string]interface{}{"secretText": "secret1"},
+						nil,
+					},
+				},
+				"accessToken": "token",
+			},
+		},
+		{
+			name:     "subtree",
+			patterns: []string{`^app\.passwordCredentials$`},
+			wantPublic: map[string]interface{}{
+				"appId": "00000000-0000-0000-0000-000000000001",
+				"app": map[string]interface{}{
+					"displayName": "Demo App",
+				},
+				"accessToken": "token",
+				"tags":        []interface{}{"a", "b"},
+			},
+			wantSensitive: map[string]interface{}{
+				"app": map[string]interface{}{
+					"passwordCredentials": input["app"].(map[string]interface{})["passwordCredentials"],
+				},
+			},
+		},
+		{
+			name:       "no match",
+			patterns:   []string{`^certificate$`},
+			wantPublic: input,
+		},
+	}
+
+	for _, tc := range testcases {
+		t.Run(tc.name, func(t *testing.T) {
+			patterns := make([]*regexp.Regexp, 0, len(tc.patterns))
+			for _, pattern := range tc.patterns {
+				patterns = append(patterns, regexp.MustCompile(pattern))
+			}
+			public, sensitive := SplitSensitivePaths(input, patterns)
+			if !reflect.DeepEqual(public, tc.wantPublic) {
+				t.Fatalf("public = %#v, want %#v", public, tc.wantPublic)
+			}
+			if !reflect.DeepEqual(sensitive, tc.wantSensitive) {
+				t.Fatalf("sensitive = %#v, want %#v", sensitive, tc.wantSensitive)
+			}
+		})
+	}
+}